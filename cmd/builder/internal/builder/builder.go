@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"go.uber.org/zap"
+)
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+var templates = template.Must(template.New("").Option("missingkey=error").ParseFS(templatesFS, "templates/*.tmpl"))
+
+// sources lists the files generated for a distribution, with their template, and whether
+// they are Go sources to format.
+var sources = []struct {
+	file     string
+	template string
+	goSource bool
+}{
+	{"main.go", "main.go.tmpl", true},
+	{"main_others.go", "main_others.go.tmpl", true},
+	{"main_windows.go", "main_windows.go.tmpl", true},
+	{"components.go", "components.go.tmpl", true},
+	{"go.mod", "go.mod.tmpl", false},
+}
+
+// templateContext is the data the templates are executed with.
+type templateContext struct {
+	*Config
+	// Imports are the packages of the components, each one once.
+	Imports []importSpec
+	// Requires are the module requirements of the distribution.
+	Requires []string
+	// Replaces are the replace directives of the distribution.
+	Replaces []string
+}
+
+// GenerateAndCompile generates the sources of the distribution, downloads its dependencies
+// and builds it, unless the compilation is skipped.
+func GenerateAndCompile(cfg *Config) error {
+	if err := Generate(cfg); err != nil {
+		return err
+	}
+	if cfg.SkipCompilation {
+		cfg.Logger.Info("Generated the sources, skipping the compilation", zap.String("path", cfg.Distribution.OutputPath))
+		return nil
+	}
+	if err := GetModules(cfg); err != nil {
+		return err
+	}
+	return Compile(cfg)
+}
+
+// Generate writes the sources of the distribution to the output path, creating it if needed.
+// The configuration must have been validated.
+func Generate(cfg *Config) error {
+	if cfg.Distribution.OutputPath == "" {
+		outputPath, err := ioutil.TempDir("", "otelcol-distribution")
+		if err != nil {
+			return fmt.Errorf("failed to create the output path: %w", err)
+		}
+		cfg.Distribution.OutputPath = outputPath
+	}
+	if err := os.MkdirAll(cfg.Distribution.OutputPath, 0750); err != nil {
+		return fmt.Errorf("failed to create the output path %q: %w", cfg.Distribution.OutputPath, err)
+	}
+
+	requires, err := cfg.requires()
+	if err != nil {
+		return err
+	}
+	ctx := templateContext{
+		Config:   cfg,
+		Imports:  imports(cfg.modules()),
+		Requires: requires,
+		Replaces: cfg.replaces(),
+	}
+	for _, src := range sources {
+		if err := generateSource(ctx, cfg.Distribution.OutputPath, src.file, src.template, src.goSource); err != nil {
+			return err
+		}
+	}
+	cfg.Logger.Info("Generated the sources", zap.String("path", cfg.Distribution.OutputPath))
+	return nil
+}
+
+func generateSource(ctx templateContext, outputPath, file, tmpl string, goSource bool) error {
+	buf := bytes.Buffer{}
+	if err := templates.ExecuteTemplate(&buf, tmpl, ctx); err != nil {
+		return fmt.Errorf("failed executing template %q: %w", tmpl, err)
+	}
+	content := buf.Bytes()
+	if goSource {
+		formatted, err := format.Source(content)
+		if err != nil {
+			return fmt.Errorf("failed formatting %q: %w", file, err)
+		}
+		content = formatted
+	}
+	outputFile := filepath.Join(outputPath, file)
+	if err := ioutil.WriteFile(outputFile, content, 0600); err != nil {
+		return fmt.Errorf("failed writing %q: %w", outputFile, err)
+	}
+	return nil
+}
+
+// importSpec is an import of the generated components, Name is empty when the package
+// is imported with its default name.
+type importSpec struct {
+	Name string
+	Path string
+}
+
+// imports returns the imports of the packages of the given modules, each package once.
+func imports(mods []Module) []importSpec {
+	var imps []importSpec
+	seen := make(map[importSpec]bool)
+	for _, mod := range mods {
+		imp := importSpec{Name: mod.Name, Path: mod.Import}
+		if seen[imp] {
+			continue
+		}
+		seen[imp] = true
+		if imp.Name == path.Base(imp.Path) {
+			imp.Name = ""
+		}
+		imps = append(imps, imp)
+	}
+	return imps
+}
+
+// GetModules resolves and downloads the dependencies of the generated distribution,
+// completing its go.mod and writing its go.sum.
+func GetModules(cfg *Config) error {
+	cfg.Logger.Info("Getting the dependencies of the distribution")
+	return runGo(cfg, "mod", "tidy")
+}
+
+// Compile builds the binary of the generated distribution in the output path.
+func Compile(cfg *Config) error {
+	cfg.Logger.Info("Compiling the distribution", zap.String("name", cfg.Distribution.Name))
+	if err := runGo(cfg, "build", "-trimpath", "-o", cfg.Distribution.Name); err != nil {
+		return err
+	}
+	cfg.Logger.Info("Compiled the distribution",
+		zap.String("binary", filepath.Join(cfg.Distribution.OutputPath, cfg.Distribution.Name)))
+	return nil
+}
+
+// runGo runs the go command with the given arguments in the output path.
+func runGo(cfg *Config, args ...string) error {
+	// #nosec G204
+	cmd := exec.Command(cfg.Distribution.Go, args...)
+	cmd.Dir = cfg.Distribution.OutputPath
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to run \"go %s\": %w\n%s", strings.Join(args, " "), err, out)
+	}
+	return nil
+}
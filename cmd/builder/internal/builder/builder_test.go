@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	cfg := DefaultConfig()
+	require.NoError(t, LoadConfig(&cfg, filepath.Join("testdata", "manifest.yaml")))
+	cfg.SkipCompilation = true
+	cfg.Distribution.OutputPath = t.TempDir()
+	require.NoError(t, cfg.Validate())
+	require.NoError(t, GenerateAndCompile(&cfg))
+
+	fset := token.NewFileSet()
+	for _, file := range []string{"main.go", "main_others.go", "main_windows.go"} {
+		_, err := parser.ParseFile(fset, filepath.Join(cfg.Distribution.OutputPath, file), nil, 0)
+		assert.NoError(t, err, file)
+	}
+
+	components, err := parser.ParseFile(fset, filepath.Join(cfg.Distribution.OutputPath, "components.go"), nil, 0)
+	require.NoError(t, err)
+	imports := make(map[string]string)
+	for _, imp := range components.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		require.NoError(t, err)
+		name := ""
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[path] = name
+	}
+	assert.Equal(t, map[string]string{
+		"go.opentelemetry.io/collector/component":                 "",
+		"go.opentelemetry.io/collector/consumer/consumererror":    "",
+		"go.opentelemetry.io/collector/extension/zpagesextension": "",
+		"go.opentelemetry.io/collector/receiver/otlpreceiver":     "",
+		"github.com/org/customreceiver":                           "",
+		"go.opentelemetry.io/collector/processor/batchprocessor":  "",
+		"go.opentelemetry.io/collector/exporter/otlpexporter":     "",
+		"github.com/org/exporters/otlp":                           "orgotlp",
+	}, imports)
+
+	main, err := ioutil.ReadFile(filepath.Join(cfg.Distribution.OutputPath, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(main), `Command:     "otelcol-custom",`)
+	assert.Contains(t, string(main), `Version:     "1.2.3",`)
+
+	goMod, err := ioutil.ReadFile(filepath.Join(cfg.Distribution.OutputPath, "go.mod"))
+	require.NoError(t, err)
+	assert.Equal(t, `module example.com/otelcol-custom
+
+go 1.16
+
+require (
+	go.opentelemetry.io/collector v0.33.0
+	github.com/org/customreceiver v1.0.0
+	github.com/org/exporters v0.1.0
+)
+
+replace github.com/org/customreceiver => ../customreceiver
+
+replace github.com/org/common => ../common
+`, string(goMod))
+}
+
+func TestGenerateDefaultOutputPath(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SkipCompilation = true
+	require.NoError(t, cfg.Validate())
+	require.NoError(t, Generate(&cfg))
+	t.Cleanup(func() { assert.NoError(t, os.RemoveAll(cfg.Distribution.OutputPath)) })
+	assert.NotEmpty(t, cfg.Distribution.OutputPath)
+	assert.FileExists(t, filepath.Join(cfg.Distribution.OutputPath, "components.go"))
+}
+
+func TestCompileError(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Distribution.OutputPath = t.TempDir()
+	cfg.Distribution.Go = filepath.Join(t.TempDir(), "missing-go")
+	require.NoError(t, cfg.Validate())
+	assert.Error(t, GenerateAndCompile(&cfg))
+}
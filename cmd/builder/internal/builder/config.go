@@ -0,0 +1,255 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// DefaultOtelColVersion is the version of the collector core required by default
+	// by the generated distributions.
+	DefaultOtelColVersion = "0.33.0"
+
+	defaultModule      = "otelcol-custom"
+	defaultName        = "otelcol-custom"
+	defaultDescription = "Custom OpenTelemetry Collector distribution"
+	defaultVersion     = "1.0.0"
+)
+
+// goModVersion matches the versions accepted in a module requirement, e.g. "v1.2.3",
+// "v0.0.0-20210101000000-abcdef123456" or "v1.2.3-rc.1+build".
+var goModVersion = regexp.MustCompile(`^v[0-9]+\.[0-9]+\.[0-9]+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+// Config holds the settings of a distribution build, loaded from the manifest file.
+type Config struct {
+	Logger *zap.Logger `yaml:"-"`
+
+	// SkipCompilation only generates the sources of the distribution, without building them.
+	SkipCompilation bool `yaml:"-"`
+
+	Distribution Distribution `yaml:"dist"`
+	Extensions   []Module     `yaml:"extensions"`
+	Receivers    []Module     `yaml:"receivers"`
+	Processors   []Module     `yaml:"processors"`
+	Exporters    []Module     `yaml:"exporters"`
+	// Replaces are added as is as replace directives of the generated go.mod,
+	// e.g. "github.com/org/module => ../module".
+	Replaces []string `yaml:"replaces"`
+}
+
+// Distribution holds the settings of the generated distribution.
+type Distribution struct {
+	// Module is the Go module path of the generated distribution.
+	Module string `yaml:"module"`
+	// Name is the name of the binary and of the command of the distribution.
+	Name string `yaml:"name"`
+	// Description is the description shown by the command of the distribution.
+	Description string `yaml:"description"`
+	// Version is the version of the distribution.
+	Version string `yaml:"version"`
+	// OtelColVersion is the version of the collector core to build the distribution with.
+	OtelColVersion string `yaml:"otelcol_version"`
+	// OutputPath is the directory the sources and the binary are written to.
+	OutputPath string `yaml:"output_path"`
+	// Go is the path to the go binary used to build the distribution.
+	Go string `yaml:"go"`
+}
+
+// Module is a component to include in the distribution.
+type Module struct {
+	// GoMod is the module requirement providing the component, as "<module path> <version>".
+	GoMod string `yaml:"gomod"`
+	// Import is the path of the package holding the component's NewFactory function,
+	// defaults to the module path.
+	Import string `yaml:"import"`
+	// Name is the name the package is imported as, defaults to the last element of the import path.
+	Name string `yaml:"name"`
+	// Path is a local directory holding the module, replacing the module requirement.
+	Path string `yaml:"path"`
+}
+
+// DefaultConfig returns the configuration used for the settings missing from the manifest.
+func DefaultConfig() Config {
+	return Config{
+		Logger: zap.NewNop(),
+		Distribution: Distribution{
+			Module:         defaultModule,
+			Name:           defaultName,
+			Description:    defaultDescription,
+			Version:        defaultVersion,
+			OtelColVersion: DefaultOtelColVersion,
+		},
+	}
+}
+
+// LoadConfig loads the manifest at the given path on top of the given configuration.
+// Unknown keys in the manifest are reported as errors.
+func LoadConfig(cfg *Config, manifestPath string) error {
+	content, err := ioutil.ReadFile(filepath.Clean(manifestPath))
+	if err != nil {
+		return fmt.Errorf("failed to read the manifest %q: %w", manifestPath, err)
+	}
+	if err = yaml.UnmarshalStrict(content, cfg); err != nil {
+		return fmt.Errorf("failed to parse the manifest %q: %w", manifestPath, err)
+	}
+	return nil
+}
+
+// Validate checks the configuration and completes the settings derived from the others:
+// the version of the collector core, the path to the go binary and the imports and names
+// of the modules.
+func (c *Config) Validate() error {
+	if c.Distribution.Name == "" {
+		return errors.New("the distribution name must not be empty")
+	}
+	if c.Distribution.Module == "" {
+		return errors.New("the distribution module must not be empty")
+	}
+	c.Distribution.OtelColVersion = strings.TrimPrefix(c.Distribution.OtelColVersion, "v")
+	if !goModVersion.MatchString("v" + c.Distribution.OtelColVersion) {
+		return fmt.Errorf("invalid otelcol_version %q", c.Distribution.OtelColVersion)
+	}
+
+	if !c.SkipCompilation && c.Distribution.Go == "" {
+		goPath, err := exec.LookPath("go")
+		if err != nil {
+			return fmt.Errorf("cannot find the go binary, set dist::go to its path: %w", err)
+		}
+		c.Distribution.Go = goPath
+	}
+
+	names := make(map[string]string)
+	kinds := []struct {
+		name    string
+		modules []Module
+	}{
+		{"extensions", c.Extensions},
+		{"receivers", c.Receivers},
+		{"processors", c.Processors},
+		{"exporters", c.Exporters},
+	}
+	for _, kind := range kinds {
+		for i := range kind.modules {
+			mod := &kind.modules[i]
+			if err := mod.complete(); err != nil {
+				return fmt.Errorf("invalid module #%d of %s: %w", i, kind.name, err)
+			}
+			if imp, ok := names[mod.Name]; ok && imp != mod.Import {
+				return fmt.Errorf("the packages %q and %q are both imported as %q, set a unique name to one of them",
+					imp, mod.Import, mod.Name)
+			}
+			names[mod.Name] = mod.Import
+		}
+	}
+
+	if _, err := c.requires(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// complete validates the module and fills in its default import path and name.
+func (m *Module) complete() error {
+	modPath, version, err := m.splitGoMod()
+	if err != nil {
+		return err
+	}
+	if !goModVersion.MatchString(version) {
+		return fmt.Errorf("invalid version %q in gomod %q", version, m.GoMod)
+	}
+	if m.Import == "" {
+		m.Import = modPath
+	}
+	if m.Name == "" {
+		m.Name = path.Base(m.Import)
+	}
+	if !token.IsIdentifier(m.Name) {
+		return fmt.Errorf("%q is not a valid package name for %q, set a name to import it as", m.Name, m.Import)
+	}
+	return nil
+}
+
+// splitGoMod returns the module path and the version of the module requirement.
+func (m *Module) splitGoMod() (string, string, error) {
+	fields := strings.Fields(m.GoMod)
+	if len(fields) != 2 {
+		return "", "", fmt.Errorf("gomod %q must be a module path followed by a version", m.GoMod)
+	}
+	return fields[0], fields[1], nil
+}
+
+// modules returns all the modules of the distribution.
+func (c *Config) modules() []Module {
+	var mods []Module
+	mods = append(mods, c.Extensions...)
+	mods = append(mods, c.Receivers...)
+	mods = append(mods, c.Processors...)
+	mods = append(mods, c.Exporters...)
+	return mods
+}
+
+// requires returns the module requirements of the distribution, each module once, after
+// the collector core. Requiring the same module in different versions is an error.
+func (c *Config) requires() ([]string, error) {
+	coreRequire := "go.opentelemetry.io/collector v" + c.Distribution.OtelColVersion
+	versions := map[string]string{"go.opentelemetry.io/collector": "v" + c.Distribution.OtelColVersion}
+	requires := []string{coreRequire}
+	for _, mod := range c.modules() {
+		modPath, version, err := mod.splitGoMod()
+		if err != nil {
+			return nil, err
+		}
+		if v, ok := versions[modPath]; ok {
+			if v != version {
+				return nil, fmt.Errorf("the module %q is required in both versions %s and %s", modPath, v, version)
+			}
+			continue
+		}
+		versions[modPath] = version
+		requires = append(requires, modPath+" "+version)
+	}
+	return requires, nil
+}
+
+// replaces returns the replace directives of the distribution: the ones of the modules
+// with a local path, then the configured ones.
+func (c *Config) replaces() []string {
+	var replaces []string
+	seen := make(map[string]bool)
+	for _, mod := range c.modules() {
+		if mod.Path == "" {
+			continue
+		}
+		modPath, _, _ := mod.splitGoMod()
+		if seen[modPath] {
+			continue
+		}
+		seen[modPath] = true
+		replaces = append(replaces, modPath+" => "+mod.Path)
+	}
+	return append(replaces, c.Replaces...)
+}
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	require.NoError(t, LoadConfig(&cfg, filepath.Join("testdata", "manifest.yaml")))
+	cfg.SkipCompilation = true
+	require.NoError(t, cfg.Validate())
+
+	assert.Equal(t, Distribution{
+		Module:         "example.com/otelcol-custom",
+		Name:           "otelcol-custom",
+		Description:    "Custom OpenTelemetry Collector distribution",
+		Version:        "1.2.3",
+		OtelColVersion: "0.33.0",
+		OutputPath:     "/tmp/otelcol-custom",
+	}, cfg.Distribution)
+	assert.Equal(t, []Module{
+		{GoMod: "go.opentelemetry.io/collector v0.33.0", Import: "go.opentelemetry.io/collector/receiver/otlpreceiver", Name: "otlpreceiver"},
+		{GoMod: "github.com/org/customreceiver v1.0.0", Import: "github.com/org/customreceiver", Name: "customreceiver", Path: "../customreceiver"},
+	}, cfg.Receivers)
+	assert.Equal(t, "orgotlp", cfg.Exporters[1].Name)
+
+	requires, err := cfg.requires()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"go.opentelemetry.io/collector v0.33.0",
+		"github.com/org/customreceiver v1.0.0",
+		"github.com/org/exporters v0.1.0",
+	}, requires)
+	assert.Equal(t, []string{
+		"github.com/org/customreceiver => ../customreceiver",
+		"github.com/org/common => ../common",
+	}, cfg.replaces())
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.Error(t, LoadConfig(&cfg, filepath.Join("testdata", "missing.yaml")))
+	assert.Error(t, LoadConfig(&cfg, filepath.Join("testdata", "unknown_key.yaml")))
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		errMsg string
+	}{
+		{
+			name:   "default",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:   "empty name",
+			modify: func(cfg *Config) { cfg.Distribution.Name = "" },
+			errMsg: "the distribution name must not be empty",
+		},
+		{
+			name:   "empty module",
+			modify: func(cfg *Config) { cfg.Distribution.Module = "" },
+			errMsg: "the distribution module must not be empty",
+		},
+		{
+			name:   "invalid otelcol version",
+			modify: func(cfg *Config) { cfg.Distribution.OtelColVersion = "latest" },
+			errMsg: `invalid otelcol_version "latest"`,
+		},
+		{
+			name: "missing version",
+			modify: func(cfg *Config) {
+				cfg.Receivers = []Module{{GoMod: "github.com/org/receiver"}}
+			},
+			errMsg: `invalid module #0 of receivers: gomod "github.com/org/receiver" must be a module path followed by a version`,
+		},
+		{
+			name: "invalid version",
+			modify: func(cfg *Config) {
+				cfg.Receivers = []Module{{GoMod: "github.com/org/receiver 1.0"}}
+			},
+			errMsg: `invalid module #0 of receivers: invalid version "1.0" in gomod "github.com/org/receiver 1.0"`,
+		},
+		{
+			name: "invalid name",
+			modify: func(cfg *Config) {
+				cfg.Exporters = []Module{{GoMod: "github.com/org/my-exporter v1.0.0"}}
+			},
+			errMsg: `invalid module #0 of exporters: "my-exporter" is not a valid package name for "github.com/org/my-exporter", set a name to import it as`,
+		},
+		{
+			name: "duplicate name",
+			modify: func(cfg *Config) {
+				cfg.Receivers = []Module{{GoMod: "github.com/org/a/otlp v1.0.0"}}
+				cfg.Exporters = []Module{{GoMod: "github.com/org/b/otlp v1.0.0"}}
+			},
+			errMsg: `the packages "github.com/org/a/otlp" and "github.com/org/b/otlp" are both imported as "otlp", set a unique name to one of them`,
+		},
+		{
+			name: "same package",
+			modify: func(cfg *Config) {
+				cfg.Receivers = []Module{{GoMod: "github.com/org/kafka v1.0.0"}}
+				cfg.Exporters = []Module{{GoMod: "github.com/org/kafka v1.0.0"}}
+			},
+		},
+		{
+			name: "conflicting versions",
+			modify: func(cfg *Config) {
+				cfg.Receivers = []Module{{GoMod: "github.com/org/components v1.0.0", Import: "github.com/org/components/receiver"}}
+				cfg.Exporters = []Module{{GoMod: "github.com/org/components v1.1.0", Import: "github.com/org/components/exporter"}}
+			},
+			errMsg: `the module "github.com/org/components" is required in both versions v1.0.0 and v1.1.0`,
+		},
+		{
+			name: "conflicting core version",
+			modify: func(cfg *Config) {
+				cfg.Receivers = []Module{{GoMod: "go.opentelemetry.io/collector v0.32.0", Import: "go.opentelemetry.io/collector/receiver/otlpreceiver"}}
+			},
+			errMsg: `the module "go.opentelemetry.io/collector" is required in both versions v0.33.0 and v0.32.0`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.SkipCompilation = true
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
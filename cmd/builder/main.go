@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Program builder generates and builds custom OpenTelemetry Collector distributions
+// from a manifest listing their components.
+package main
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/cmd/builder/internal/builder"
+)
+
+func main() {
+	if err := newCommand().Execute(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func newCommand() *cobra.Command {
+	cfg := builder.DefaultConfig()
+	var (
+		manifestPath string
+		outputPath   string
+		name         string
+		version      string
+		goPath       string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "builder",
+		Short: "Builds a custom OpenTelemetry Collector distribution",
+		Long: "Generates the main package, the components and the go.mod of an OpenTelemetry Collector distribution" +
+			" from a manifest listing its extensions, receivers, processors and exporters, then builds its binary.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger, err := zap.NewDevelopment()
+			if err != nil {
+				return err
+			}
+			cfg.Logger = logger
+
+			if manifestPath != "" {
+				if err = builder.LoadConfig(&cfg, manifestPath); err != nil {
+					return err
+				}
+			}
+			// The flags take precedence over the manifest.
+			flags := cmd.Flags()
+			if flags.Changed("output-path") {
+				cfg.Distribution.OutputPath = outputPath
+			}
+			if flags.Changed("name") {
+				cfg.Distribution.Name = name
+			}
+			if flags.Changed("version") {
+				cfg.Distribution.Version = version
+			}
+			if flags.Changed("go") {
+				cfg.Distribution.Go = goPath
+			}
+
+			if err = cfg.Validate(); err != nil {
+				return err
+			}
+			return builder.GenerateAndCompile(&cfg)
+		},
+	}
+
+	cmd.Flags().StringVar(&manifestPath, "config", "", "Path to the manifest listing the components of the distribution")
+	cmd.Flags().StringVar(&outputPath, "output-path", "", "Directory the sources and the binary are written to, a temporary directory by default")
+	cmd.Flags().StringVar(&name, "name", "", "Name of the distribution binary and command")
+	cmd.Flags().StringVar(&version, "version", "", "Version of the distribution")
+	cmd.Flags().StringVar(&goPath, "go", "", "Path to the go binary, the one found in the PATH by default")
+	cmd.Flags().BoolVar(&cfg.SkipCompilation, "skip-compilation", false, "Only generate the sources, without building them")
+	return cmd
+}
@@ -21,16 +21,21 @@ import (
 	"time"
 
 	"github.com/fatih/structtag"
+
+	"go.opentelemetry.io/collector/config/configopaque"
 )
 
+var opaqueType = reflect.TypeOf(configopaque.String(""))
+
 // Field holds attributes and subfields of a config struct.
 type Field struct {
-	Name    string      `yaml:",omitempty"`
-	Type    string      `yaml:",omitempty"`
-	Kind    string      `yaml:",omitempty"`
-	Default interface{} `yaml:",omitempty"`
-	Doc     string      `yaml:",omitempty"`
-	Fields  []*Field    `yaml:",omitempty"`
+	Name      string      `yaml:",omitempty"`
+	Type      string      `yaml:",omitempty"`
+	Kind      string      `yaml:",omitempty"`
+	Default   interface{} `yaml:",omitempty"`
+	Doc       string      `yaml:",omitempty"`
+	Sensitive bool        `yaml:",omitempty"`
+	Fields    []*Field    `yaml:",omitempty"`
 }
 
 // ReadFields accepts both a config struct's Value, as well as a DirResolver,
@@ -84,10 +89,11 @@ func refl(f *Field, v reflect.Value, dr DirResolver) {
 				typeStr = "" // omit if redundant
 			}
 			next = &Field{
-				Name: name,
-				Type: typeStr,
-				Kind: kindStr,
-				Doc:  comments[structField.Name],
+				Name:      name,
+				Type:      typeStr,
+				Kind:      kindStr,
+				Doc:       comments[structField.Name],
+				Sensitive: isSensitive(fv.Type()),
 			}
 			f.Fields = append(f.Fields, next)
 		}
@@ -113,7 +119,7 @@ func handleKind(v reflect.Value, f *Field, dr DirResolver) {
 			refl(f, reflect.New(e.Elem()), dr)
 		}
 	case reflect.String:
-		if v.String() != "" {
+		if v.String() != "" && !f.Sensitive {
 			f.Default = v.String()
 		}
 	case reflect.Bool:
@@ -135,6 +141,16 @@ func handleKind(v reflect.Value, f *Field, dr DirResolver) {
 	}
 }
 
+// isSensitive returns true if the type holds configopaque.String values, whose
+// contents must not show up in the generated documentation.
+func isSensitive(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return isSensitive(t.Elem())
+	}
+	return t == opaqueType
+}
+
 func mapstructure(st reflect.StructTag) (string, []string, error) {
 	tag := string(st)
 	if tag == "" {
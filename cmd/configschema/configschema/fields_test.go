@@ -19,6 +19,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/config/configopaque"
 )
 
 func TestReadFieldsWithDefaults(t *testing.T) {
@@ -53,6 +55,29 @@ func TestReadFieldsWithoutDefaults(t *testing.T) {
 	testReadFields(t, testStruct{}, map[string]interface{}{})
 }
 
+type testSecrets struct {
+	Token   configopaque.String            `mapstructure:"token"`
+	Headers map[string]configopaque.String `mapstructure:"headers"`
+	User    string                         `mapstructure:"user"`
+}
+
+func TestReadFieldsSensitive(t *testing.T) {
+	root := ReadFields(
+		reflect.ValueOf(testSecrets{Token: "s3cr3t", User: "me"}),
+		testDR(),
+	)
+	assert.Equal(t, &Field{
+		Name:      "token",
+		Type:      "configopaque.String",
+		Kind:      "string",
+		Sensitive: true,
+	}, getField(root.Fields, "token"))
+	assert.True(t, getField(root.Fields, "headers").Sensitive)
+	user := getField(root.Fields, "user")
+	assert.False(t, user.Sensitive)
+	assert.Equal(t, "me", user.Default)
+}
+
 func getField(fields []*Field, name string) *Field {
 	for _, f := range fields {
 		if f.Name == name {
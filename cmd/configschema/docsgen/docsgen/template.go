@@ -65,6 +65,7 @@ const tableTemplateStr = `### {{ cleanType .Type }}
 {{- else -}}
     {{ .Kind }}
 {{- end -}}
+{{- if .Sensitive }} (sensitive){{ end -}}
 | {{ .Default }} | {{ join .Doc }} |
 {{ end }}
 `
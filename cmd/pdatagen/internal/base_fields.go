@@ -21,7 +21,7 @@ import (
 
 const accessorSliceTemplate = `// ${fieldName} returns the ${originFieldName} associated with this ${structName}.
 func (ms ${structName}) ${fieldName}() ${returnType} {
-	return new${returnType}(&(*ms.orig).${originFieldName})
+	return new${returnType}(&(*ms.orig).${originFieldName}, ms.state)
 }`
 
 const accessorsSliceTestTemplate = `func Test${structName}_${fieldName}(t *testing.T) {
@@ -34,7 +34,7 @@ const accessorsSliceTestTemplate = `func Test${structName}_${fieldName}(t *testi
 
 const accessorsMessageValueTemplate = `// ${fieldName} returns the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) ${fieldName}() ${returnType} {
-	return new${returnType}(&(*ms.orig).${originFieldName})
+	return new${returnType}(&(*ms.orig).${originFieldName}, ms.state)
 }`
 
 const accessorsMessageValueTestTemplate = `func Test${structName}_${fieldName}(t *testing.T) {
@@ -50,6 +50,7 @@ func (ms ${structName}) ${fieldName}() ${returnType} {
 
 // Set${fieldName} replaces the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) Set${fieldName}(v ${returnType}) {
+	ms.state.assertMutable()
 	(*ms.orig).${originFieldName} = v
 }`
 
@@ -60,6 +61,7 @@ func (ms ${structName}) ${fieldName}() ${returnType} {
 
 // Set${fieldName} replaces the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) Set${fieldName}(v ${returnType}) {
+	ms.state.assertMutable()
 	(*ms.orig).${originFieldName} = &${originFullName}_As${fieldType}{
 		As${fieldType}: v,
 	}
@@ -80,6 +82,7 @@ func (ms ${structName}) ${fieldName}() ${returnType} {
 
 // Set${fieldName} replaces the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) Set${fieldName}(v ${returnType}) {
+	ms.state.assertMutable()
 	(*ms.orig).${originFieldName} = ${rawType}(v)
 }`
 
@@ -95,6 +98,7 @@ func (ms ${structName}) ${fieldName}() ${returnType} {
 
 // Set${fieldName} replaces the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) Set${fieldName}(v ${returnType}) {
+	ms.state.assertMutable()
 	(*ms.orig).${originFieldName} = v.orig
 }`
 
@@ -106,6 +110,8 @@ type baseField interface {
 	generateSetWithTestValue(sb *strings.Builder)
 
 	generateCopyToValue(sb *strings.Builder)
+
+	generateDiff(sb *strings.Builder)
 }
 
 type sliceField struct {
@@ -154,6 +160,10 @@ func (sf *sliceField) generateCopyToValue(sb *strings.Builder) {
 	sb.WriteString("\tms." + sf.fieldName + "().CopyTo(dest." + sf.fieldName + "())")
 }
 
+func (sf *sliceField) generateDiff(sb *strings.Builder) {
+	sb.WriteString("\tdiff" + sf.returnSlice.getName() + "(d, path+\"." + sf.fieldName + "\", expected." + sf.fieldName + "(), actual." + sf.fieldName + "())")
+}
+
 var _ baseField = (*sliceField)(nil)
 
 type messageValueField struct {
@@ -206,6 +216,10 @@ func (mf *messageValueField) generateCopyToValue(sb *strings.Builder) {
 	sb.WriteString("\tms." + mf.fieldName + "().CopyTo(dest." + mf.fieldName + "())")
 }
 
+func (mf *messageValueField) generateDiff(sb *strings.Builder) {
+	sb.WriteString("\tdiff" + mf.returnMessage.structName + "(d, path+\"." + mf.fieldName + "\", expected." + mf.fieldName + "(), actual." + mf.fieldName + "())")
+}
+
 var _ baseField = (*messageValueField)(nil)
 
 type primitiveField struct {
@@ -260,6 +274,10 @@ func (pf *primitiveField) generateCopyToValue(sb *strings.Builder) {
 	sb.WriteString("\tdest.Set" + pf.fieldName + "(ms." + pf.fieldName + "())")
 }
 
+func (pf *primitiveField) generateDiff(sb *strings.Builder) {
+	sb.WriteString("\td.compare(path+\"." + pf.fieldName + "\", expected." + pf.fieldName + "(), actual." + pf.fieldName + "())")
+}
+
 var _ baseField = (*primitiveField)(nil)
 
 // Types that has defined a custom type (e.g. "type Timestamp uint64")
@@ -325,6 +343,14 @@ func (ptf *primitiveTypedField) generateCopyToValue(sb *strings.Builder) {
 	sb.WriteString("\tdest.Set" + ptf.fieldName + "(ms." + ptf.fieldName + "())")
 }
 
+func (ptf *primitiveTypedField) generateDiff(sb *strings.Builder) {
+	compare := "compare"
+	if ptf.returnType == "Timestamp" {
+		compare = "compareTimestamp"
+	}
+	sb.WriteString("\td." + compare + "(path+\"." + ptf.fieldName + "\", expected." + ptf.fieldName + "(), actual." + ptf.fieldName + "())")
+}
+
 var _ baseField = (*primitiveTypedField)(nil)
 
 // Types that has defined a custom type (e.g. "type TraceID struct {}")
@@ -381,11 +407,16 @@ func (ptf *primitiveStructField) generateCopyToValue(sb *strings.Builder) {
 	sb.WriteString("\tdest.Set" + ptf.fieldName + "(ms." + ptf.fieldName + "())")
 }
 
+func (ptf *primitiveStructField) generateDiff(sb *strings.Builder) {
+	sb.WriteString("\td.compare(path+\"." + ptf.fieldName + "\", expected." + ptf.fieldName + "().HexString(), actual." + ptf.fieldName + "().HexString())")
+}
+
 var _ baseField = (*primitiveStructField)(nil)
 
 // oneofField is used in case where the proto defines an "oneof".
 type oneofField struct {
 	copyFuncName    string
+	diffFuncName    string
 	originFieldName string
 	testVal         string
 	fillTestName    string
@@ -401,7 +432,11 @@ func (one oneofField) generateSetWithTestValue(sb *strings.Builder) {
 }
 
 func (one oneofField) generateCopyToValue(sb *strings.Builder) {
-	sb.WriteString("\t" + one.copyFuncName + "(ms.orig, dest.orig)")
+	sb.WriteString("\t" + one.copyFuncName + "(ms, dest)")
+}
+
+func (one oneofField) generateDiff(sb *strings.Builder) {
+	sb.WriteString("\t" + one.diffFuncName + "(d, path, expected, actual)")
 }
 
 var _ baseField = (*oneofField)(nil)
@@ -467,6 +502,11 @@ func (opv *oneOfPrimitiveValue) generateCopyToValue(sb *strings.Builder) {
 	sb.WriteString("\t dest.Set" + opv.name + "(ms." + opv.name + "())\n")
 }
 
+func (opv *oneOfPrimitiveValue) generateDiff(sb *strings.Builder) {
+	sb.WriteString("\t\tcase pdata.MetricValueType" + opv.fieldType + ":\n")
+	sb.WriteString("\t\t\td.compare(path+\"." + opv.name + "\", expected." + opv.name + "(), actual." + opv.name + "())\n")
+}
+
 var _ baseField = (*oneOfPrimitiveValue)(nil)
 
 type numberField struct {
@@ -502,4 +542,14 @@ func (nf *numberField) generateCopyToValue(sb *strings.Builder) {
 	sb.WriteString("\t}\n")
 }
 
+func (nf *numberField) generateDiff(sb *strings.Builder) {
+	sb.WriteString("\tif d.compare(path+\".Type\", expected.Type(), actual.Type()) {\n")
+	sb.WriteString("\t\tswitch expected.Type() {\n")
+	for _, field := range nf.fields {
+		field.generateDiff(sb)
+	}
+	sb.WriteString("\t\t}\n")
+	sb.WriteString("\t}")
+}
+
 var _ baseField = (*numberField)(nil)
@@ -23,6 +23,8 @@ const commonSliceTemplate = `
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ${structName}) MoveAndAppendTo(dest ${structName}) {
+	es.state.assertMutable()
+	dest.state.assertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -35,6 +37,7 @@ func (es ${structName}) MoveAndAppendTo(dest ${structName}) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ${structName}) RemoveIf(f func(${elementName}) bool) {
+	es.state.assertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -121,18 +124,19 @@ const slicePtrTemplate = `// ${structName} logically represents a slice of ${ele
 type ${structName} struct {
 	// orig points to the slice ${originName} field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*${originName}
+	orig  *[]*${originName}
+	state *sharedState
 }
 
-func new${structName}(orig *[]*${originName}) ${structName} {
-	return ${structName}{orig}
+func new${structName}(orig *[]*${originName}, state *sharedState) ${structName} {
+	return ${structName}{orig: orig, state: state}
 }
 
 // New${structName} creates a ${structName} with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func New${structName}() ${structName} {
 	orig := []*${originName}(nil)
-	return ${structName}{&orig}
+	return new${structName}(&orig, newSharedState())
 }
 
 // Len returns the number of elements in the slice.
@@ -150,17 +154,18 @@ func (es ${structName}) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ${structName}) At(ix int) ${elementName} {
-	return new${elementName}((*es.orig)[ix])
+	return new${elementName}((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ${structName}) CopyTo(dest ${structName}) {
+	dest.state.assertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			new${elementName}((*es.orig)[i]).CopyTo(new${elementName}((*dest.orig)[i]))
+			new${elementName}((*es.orig)[i], es.state).CopyTo(new${elementName}((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -168,7 +173,7 @@ func (es ${structName}) CopyTo(dest ${structName}) {
 	wrappers := make([]*${originName}, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		new${elementName}((*es.orig)[i]).CopyTo(new${elementName}(wrappers[i]))
+		new${elementName}((*es.orig)[i], es.state).CopyTo(new${elementName}(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -185,6 +190,7 @@ func (es ${structName}) CopyTo(dest ${structName}) {
 //       // Here should set all the values for e.
 //   }
 func (es ${structName}) EnsureCapacity(newCap int) {
+	es.state.assertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -198,6 +204,7 @@ func (es ${structName}) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty ${elementName}.
 // It returns the newly added ${elementName}.
 func (es ${structName}) AppendEmpty() ${elementName} {
+	es.state.assertMutable()
 	*es.orig = append(*es.orig, &${originName}{})
 	return es.At(es.Len() - 1)
 }
@@ -212,6 +219,7 @@ func (es ${structName}) AppendEmpty() ${elementName} {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es ${structName}) Sort(less func(a, b ${elementName}) bool) ${structName} {
+	es.state.assertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -220,11 +228,11 @@ func (es ${structName}) Sort(less func(a, b ${elementName}) bool) ${structName}
 const slicePtrTestTemplate = `func Test${structName}(t *testing.T) {
 	es := New${structName}()
 	assert.EqualValues(t, 0, es.Len())
-	es = new${structName}(&[]*${originName}{})
+	es = new${structName}(&[]*${originName}{}, newSharedState())
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := new${elementName}(&${originName}{})
+	emptyVal := new${elementName}(&${originName}{}, newSharedState())
 	testVal := generateTest${elementName}()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -294,18 +302,19 @@ const sliceValueTemplate = `// ${structName} logically represents a slice of ${e
 type ${structName} struct {
 	// orig points to the slice ${originName} field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]${originName}
+	orig  *[]${originName}
+	state *sharedState
 }
 
-func new${structName}(orig *[]${originName}) ${structName} {
-	return ${structName}{orig}
+func new${structName}(orig *[]${originName}, state *sharedState) ${structName} {
+	return ${structName}{orig: orig, state: state}
 }
 
 // New${structName} creates a ${structName} with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func New${structName}() ${structName} {
 	orig := []${originName}(nil)
-	return ${structName}{&orig}
+	return new${structName}(&orig, newSharedState())
 }
 
 // Len returns the number of elements in the slice.
@@ -323,11 +332,12 @@ func (es ${structName}) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ${structName}) At(ix int) ${elementName} {
-	return new${elementName}(&(*es.orig)[ix])
+	return new${elementName}(&(*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ${structName}) CopyTo(dest ${structName}) {
+	dest.state.assertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
@@ -337,7 +347,7 @@ func (es ${structName}) CopyTo(dest ${structName}) {
 	}
 
 	for i := range *es.orig {
-		new${elementName}(&(*es.orig)[i]).CopyTo(new${elementName}(&(*dest.orig)[i]))
+		new${elementName}(&(*es.orig)[i], es.state).CopyTo(new${elementName}(&(*dest.orig)[i], dest.state))
 	}
 }
 
@@ -353,6 +363,7 @@ func (es ${structName}) CopyTo(dest ${structName}) {
 //       // Here should set all the values for e.
 //   }
 func (es ${structName}) EnsureCapacity(newCap int) {
+	es.state.assertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -366,6 +377,7 @@ func (es ${structName}) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty ${elementName}.
 // It returns the newly added ${elementName}.
 func (es ${structName}) AppendEmpty() ${elementName} {
+	es.state.assertMutable()
 	*es.orig = append(*es.orig, ${originName}{})
 	return es.At(es.Len() - 1)
 }`
@@ -373,11 +385,11 @@ func (es ${structName}) AppendEmpty() ${elementName} {
 const sliceValueTestTemplate = `func Test${structName}(t *testing.T) {
 	es := New${structName}()
 	assert.EqualValues(t, 0, es.Len())
-	es = new${structName}(&[]${originName}{})
+	es = new${structName}(&[]${originName}{}, newSharedState())
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := new${elementName}(&${originName}{})
+	emptyVal := new${elementName}(&${originName}{}, newSharedState())
 	testVal := generateTest${elementName}()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -428,6 +440,39 @@ func Test${structName}_EnsureCapacity(t *testing.T) {
 	assert.Equal(t, ensureLargeLen, cap(*es.orig))
 }`
 
+const sliceDiffTemplate = `func diff${structName}(d *differ, path string, expected, actual pdata.${structName}) {
+	d.diffSlice(path, expected.Len(), actual.Len(), ${ignoreOrder}, func(d *differ, path string, i, j int) {
+		diff${elementName}(d, path, expected.At(i), actual.At(j))
+	})
+}`
+
+// sliceDiffOrders maps the slices whose order can be ignored when diffing to their
+// order kind in the pdatatest package. The order of the other slices is always compared.
+var sliceDiffOrders = map[string]string{
+	"ResourceSpansSlice":                 "resourceOrder",
+	"ResourceMetricsSlice":               "resourceOrder",
+	"ResourceLogsSlice":                  "resourceOrder",
+	"InstrumentationLibrarySpansSlice":   "scopeOrder",
+	"InstrumentationLibraryMetricsSlice": "scopeOrder",
+	"InstrumentationLibraryLogsSlice":    "scopeOrder",
+	"SpanSlice":                          "spanOrder",
+	"SpanEventSlice":                     "spanOrder",
+	"SpanLinkSlice":                      "spanOrder",
+	"MetricSlice":                        "metricOrder",
+	"LogSlice":                           "logRecordOrder",
+	"NumberDataPointSlice":               "dataPointOrder",
+	"HistogramDataPointSlice":            "dataPointOrder",
+	"SummaryDataPointSlice":              "dataPointOrder",
+	"ExemplarSlice":                      "dataPointOrder",
+}
+
+func sliceIgnoreOrder(structName string) string {
+	if order, ok := sliceDiffOrders[structName]; ok {
+		return "d.ignoreOrder(" + order + ")"
+	}
+	return "false"
+}
+
 type baseSlice interface {
 	getName() string
 }
@@ -456,11 +501,17 @@ func (ss *sliceOfPtrs) generateTestValueHelpers(sb *strings.Builder) {
 	sb.WriteString(os.Expand(commonSliceGenerateTest, ss.templateFields()))
 }
 
+func (ss *sliceOfPtrs) generateDiff(sb *strings.Builder) {
+	sb.WriteString(os.Expand(sliceDiffTemplate, ss.templateFields()))
+}
+
 func (ss *sliceOfPtrs) templateFields() func(name string) string {
 	return func(name string) string {
 		switch name {
 		case "structName":
 			return ss.structName
+		case "ignoreOrder":
+			return sliceIgnoreOrder(ss.structName)
 		case "elementName":
 			return ss.element.structName
 		case "originName":
@@ -497,11 +548,17 @@ func (ss *sliceOfValues) generateTestValueHelpers(sb *strings.Builder) {
 	sb.WriteString(os.Expand(commonSliceGenerateTest, ss.templateFields()))
 }
 
+func (ss *sliceOfValues) generateDiff(sb *strings.Builder) {
+	sb.WriteString(os.Expand(sliceDiffTemplate, ss.templateFields()))
+}
+
 func (ss *sliceOfValues) templateFields() func(name string) string {
 	return func(name string) string {
 		switch name {
 		case "structName":
 			return ss.structName
+		case "ignoreOrder":
+			return sliceIgnoreOrder(ss.structName)
 		case "elementName":
 			return ss.element.structName
 		case "originName":
@@ -28,22 +28,24 @@ const messageValueTemplate = `${description}
 // Important: zero-initialized instance is not valid for use.
 // ${deprecated}
 type ${structName} struct {
-	orig *${originName}
+	orig  *${originName}
+	state *sharedState
 }
 
-func new${structName}(orig *${originName}) ${structName} {
-	return ${structName}{orig: orig}
+func new${structName}(orig *${originName}, state *sharedState) ${structName} {
+	return ${structName}{orig: orig, state: state}
 }
 
 // New${structName} creates a new empty ${structName}.
 //
 // This must be used only in testing code since no "Set" method available.
 func New${structName}() ${structName} {
-	return new${structName}(&${originName}{})
+	return new${structName}(&${originName}{}, newSharedState())
 }`
 
 const messageValueCopyToHeaderTemplate = `// CopyTo copies all properties from the current struct to the dest.
-func (ms ${structName}) CopyTo(dest ${structName}) {`
+func (ms ${structName}) CopyTo(dest ${structName}) {
+	dest.state.assertMutable()`
 
 const messageValueCopyToFooterTemplate = `}`
 
@@ -63,6 +65,9 @@ const messageValueGenerateTestTemplate = `func generateTest${structName}() ${str
 const messageValueFillTestHeaderTemplate = `func fillTest${structName}(tv ${structName}) {`
 const messageValueFillTestFooterTemplate = `}`
 
+const messageValueDiffHeaderTemplate = `func diff${structName}(d *differ, path string, expected, actual pdata.${structName}) {`
+const messageValueDiffFooterTemplate = `}`
+
 const newLine = "\n"
 
 type baseStruct interface {
@@ -73,6 +78,8 @@ type baseStruct interface {
 	generateTests(sb *strings.Builder)
 
 	generateTestValueHelpers(sb *strings.Builder)
+
+	generateDiff(sb *strings.Builder)
 }
 
 type messageValueStruct struct {
@@ -174,4 +181,24 @@ func (ms *messageValueStruct) generateTestValueHelpers(sb *strings.Builder) {
 	}))
 }
 
+func (ms *messageValueStruct) generateDiff(sb *strings.Builder) {
+	sb.WriteString(os.Expand(messageValueDiffHeaderTemplate, func(name string) string {
+		switch name {
+		case "structName":
+			return ms.structName
+		default:
+			panic(name)
+		}
+	}))
+	// Write fields diff for the struct
+	for _, f := range ms.fields {
+		sb.WriteString(newLine)
+		f.generateDiff(sb)
+	}
+	sb.WriteString(newLine)
+	sb.WriteString(os.Expand(messageValueDiffFooterTemplate, func(name string) string {
+		panic(name)
+	}))
+}
+
 var _ baseStruct = (*messageValueStruct)(nil)
@@ -31,9 +31,7 @@ const header = `// Copyright The OpenTelemetry Authors
 // limitations under the License.
 
 // Code generated by "cmd/pdatagen/main.go". DO NOT EDIT.
-// To regenerate this file run "go run cmd/pdatagen/main.go".
-
-package pdata`
+// To regenerate this file run "go run cmd/pdatagen/main.go".`
 
 // AllFiles is a list of all files that needs to be generated.
 var AllFiles = []*File{
@@ -60,6 +58,8 @@ func (f *File) GenerateFile() string {
 	// Write headers
 	sb.WriteString(header)
 	sb.WriteString(newLine + newLine)
+	sb.WriteString("package pdata")
+	sb.WriteString(newLine + newLine)
 	// Add imports
 	sb.WriteString("import (" + newLine)
 	for _, imp := range f.imports {
@@ -86,6 +86,8 @@ func (f *File) GenerateTestFile() string {
 	// Write headers
 	sb.WriteString(header)
 	sb.WriteString(newLine + newLine)
+	sb.WriteString("package pdata")
+	sb.WriteString(newLine + newLine)
 	// Add imports
 	sb.WriteString("import (" + newLine)
 	for _, imp := range f.testImports {
@@ -109,3 +111,26 @@ func (f *File) GenerateTestFile() string {
 	sb.WriteString(newLine)
 	return sb.String()
 }
+
+// GenerateDiffFile generates the diff functions of the pdatatest package for the
+// configured data structures for this File.
+func (f *File) GenerateDiffFile() string {
+	var sb strings.Builder
+
+	// Write headers
+	sb.WriteString(header)
+	sb.WriteString(newLine + newLine)
+	sb.WriteString("package pdatatest")
+	sb.WriteString(newLine + newLine)
+	// Add imports
+	sb.WriteString("import (" + newLine)
+	sb.WriteString("\t\"go.opentelemetry.io/collector/model/pdata\"" + newLine)
+	sb.WriteString(")")
+	// Write all diff functions
+	for _, s := range f.structs {
+		sb.WriteString(newLine + newLine)
+		s.generateDiff(&sb)
+	}
+	sb.WriteString(newLine)
+	return sb.String()
+}
@@ -316,6 +316,8 @@ var exemplar = &messageValueStruct{
 			originFieldName: "FilteredAttributes",
 			returnSlice:     attributeMap,
 		},
+		traceIDField,
+		spanIDField,
 	},
 }
 
@@ -392,6 +394,7 @@ var aggregationTemporalityField = &primitiveTypedField{
 
 var oneofDataField = &oneofField{
 	copyFuncName:    "copyData",
+	diffFuncName:    "diffData",
 	originFieldName: "Data",
 	testVal:         "&otlpmetrics.Metric_Gauge{Gauge: &otlpmetrics.Gauge{}}",
 	fillTestName:    "Gauge",
@@ -38,5 +38,10 @@ func main() {
 		_, err = f.WriteString(fp.GenerateTestFile())
 		check(err)
 		check(f.Close())
+		f, err = os.Create("./model/pdata/pdatatest/generated_" + fp.Name + ".go")
+		check(err)
+		_, err = f.WriteString(fp.GenerateDiffFile())
+		check(err)
+		check(f.Close())
 	}
 }
@@ -0,0 +1,460 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package componenttest
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+// leakTimeout is how long the goroutines started by a component have to exit after
+// the component is shut down.
+const leakTimeout = 5 * time.Second
+
+// roundTripTimeout is how long the data sent in a round-trip has to reach the consumer,
+// long enough for an exporter to retry once.
+const roundTripTimeout = 15 * time.Second
+
+// lifecycleCase is the creation of a component for a data type, and the smoke test
+// sending data through it.
+type lifecycleCase struct {
+	dataType config.DataType
+	// create returns a new component for the data type.
+	create func() (component.Component, error)
+	// setUp, if set, is called before the component is started for the round-trip, and
+	// returns the function tearing down what it set up.
+	setUp func(t *testing.T) func()
+	// roundTrip, if set, sends data to the started component.
+	roundTrip func(t *testing.T, c component.Component)
+}
+
+// VerifyReceiverConformance runs the lifecycle conformance suite against the receivers
+// created by the factory with the given config, for every supported data type:
+//   - the default config passes configcheck.ValidateConfig;
+//   - a receiver can be shut down without being started;
+//   - a receiver can be started, shut down, and shut down again;
+//   - the data sent to a started receiver reaches the next consumer;
+//   - the goroutines started by the receiver exit and its endpoints are released
+//     after the shutdown.
+//
+// The config usually is the default config with endpoints on free ports. The data is
+// sent by the exporters created by the sender factory with the sender config, which
+// point at the endpoints of the receiver config. The data round-trip is skipped if the
+// sender factory is nil or does not support the data type.
+func VerifyReceiverConformance(t *testing.T, factory component.ReceiverFactory, cfg config.Receiver, sender component.ExporterFactory, senderCfg config.Exporter) {
+	verifyDefaultConfig(t, factory)
+	set := NewNopReceiverCreateSettings()
+	senderSet := NewNopExporterCreateSettings()
+	tracesSink := new(consumertest.TracesSink)
+	metricsSink := new(consumertest.MetricsSink)
+	logsSink := new(consumertest.LogsSink)
+	verifyLifecycle(t, cfg, true, []lifecycleCase{
+		{
+			dataType: config.TracesDataType,
+			create: func() (component.Component, error) {
+				tracesSink.Reset()
+				return factory.CreateTracesReceiver(context.Background(), set, cfg, tracesSink)
+			},
+			roundTrip: func(t *testing.T, c component.Component) {
+				if sender != nil {
+					sendThroughExporter(t, func() (component.Exporter, error) {
+						return sender.CreateTracesExporter(context.Background(), senderSet, senderCfg)
+					}, func(ctx context.Context, exp component.Exporter) error {
+						return exp.(consumer.Traces).ConsumeTraces(ctx, testdata.GenerateTracesTwoSpansSameResource())
+					}, tracesSink.SpanCount, 2)
+				}
+				require.NoError(t, c.Shutdown(context.Background()))
+			},
+		},
+		{
+			dataType: config.MetricsDataType,
+			create: func() (component.Component, error) {
+				metricsSink.Reset()
+				return factory.CreateMetricsReceiver(context.Background(), set, cfg, metricsSink)
+			},
+			roundTrip: func(t *testing.T, c component.Component) {
+				if sender != nil {
+					sendThroughExporter(t, func() (component.Exporter, error) {
+						return sender.CreateMetricsExporter(context.Background(), senderSet, senderCfg)
+					}, func(ctx context.Context, exp component.Exporter) error {
+						return exp.(consumer.Metrics).ConsumeMetrics(ctx, testdata.GenerateMetricsTwoMetrics())
+					}, metricsSink.DataPointCount, testdata.GenerateMetricsTwoMetrics().DataPointCount())
+				}
+				require.NoError(t, c.Shutdown(context.Background()))
+			},
+		},
+		{
+			dataType: config.LogsDataType,
+			create: func() (component.Component, error) {
+				logsSink.Reset()
+				return factory.CreateLogsReceiver(context.Background(), set, cfg, logsSink)
+			},
+			roundTrip: func(t *testing.T, c component.Component) {
+				if sender != nil {
+					sendThroughExporter(t, func() (component.Exporter, error) {
+						return sender.CreateLogsExporter(context.Background(), senderSet, senderCfg)
+					}, func(ctx context.Context, exp component.Exporter) error {
+						return exp.(consumer.Logs).ConsumeLogs(ctx, testdata.GenerateLogsTwoLogRecordsSameResource())
+					}, logsSink.LogRecordCount, 2)
+				}
+				require.NoError(t, c.Shutdown(context.Background()))
+			},
+		},
+	})
+}
+
+// VerifyProcessorConformance runs the lifecycle conformance suite of
+// VerifyReceiverConformance against the processors created by the factory with the
+// given config, and verifies that the data sent to a started processor reaches the
+// next consumer once the processor is shut down. The config must not drop data.
+func VerifyProcessorConformance(t *testing.T, factory component.ProcessorFactory, cfg config.Processor) {
+	verifyDefaultConfig(t, factory)
+	set := NewNopProcessorCreateSettings()
+	tracesSink := new(consumertest.TracesSink)
+	metricsSink := new(consumertest.MetricsSink)
+	logsSink := new(consumertest.LogsSink)
+	verifyLifecycle(t, cfg, true, []lifecycleCase{
+		{
+			dataType: config.TracesDataType,
+			create: func() (component.Component, error) {
+				tracesSink.Reset()
+				return factory.CreateTracesProcessor(context.Background(), set, cfg, tracesSink)
+			},
+			roundTrip: func(t *testing.T, c component.Component) {
+				require.NoError(t, c.(consumer.Traces).ConsumeTraces(context.Background(), testdata.GenerateTracesTwoSpansSameResource()))
+				require.NoError(t, c.Shutdown(context.Background()))
+				assert.Equal(t, 2, tracesSink.SpanCount())
+			},
+		},
+		{
+			dataType: config.MetricsDataType,
+			create: func() (component.Component, error) {
+				metricsSink.Reset()
+				return factory.CreateMetricsProcessor(context.Background(), set, cfg, metricsSink)
+			},
+			roundTrip: func(t *testing.T, c component.Component) {
+				md := testdata.GenerateMetricsTwoMetrics()
+				require.NoError(t, c.(consumer.Metrics).ConsumeMetrics(context.Background(), md))
+				require.NoError(t, c.Shutdown(context.Background()))
+				assert.Equal(t, testdata.GenerateMetricsTwoMetrics().DataPointCount(), metricsSink.DataPointCount())
+			},
+		},
+		{
+			dataType: config.LogsDataType,
+			create: func() (component.Component, error) {
+				logsSink.Reset()
+				return factory.CreateLogsProcessor(context.Background(), set, cfg, logsSink)
+			},
+			roundTrip: func(t *testing.T, c component.Component) {
+				require.NoError(t, c.(consumer.Logs).ConsumeLogs(context.Background(), testdata.GenerateLogsTwoLogRecordsSameResource()))
+				require.NoError(t, c.Shutdown(context.Background()))
+				assert.Equal(t, 2, logsSink.LogRecordCount())
+			},
+		},
+	})
+}
+
+// VerifyExporterConformance runs the lifecycle conformance suite of
+// VerifyReceiverConformance against the exporters created by the factory with the
+// given config, except for the endpoints which are the ones of the destination, and
+// verifies that the data sent to a started exporter reaches the destination. The
+// destination is the receiver created by the destination factory with the destination
+// config, which must listen on the endpoints of the exporter config. If the destination
+// factory is nil or does not support the data type, the suite only verifies that a
+// started exporter accepts data without panicking, and the exporter may fail to send it.
+func VerifyExporterConformance(t *testing.T, factory component.ExporterFactory, cfg config.Exporter, destination component.ReceiverFactory, destinationCfg config.Receiver) {
+	verifyDefaultConfig(t, factory)
+	set := NewNopExporterCreateSettings()
+	destinationSet := NewNopReceiverCreateSettings()
+	tracesSink := new(consumertest.TracesSink)
+	metricsSink := new(consumertest.MetricsSink)
+	logsSink := new(consumertest.LogsSink)
+	// received reports whether a destination was started for the round-trip.
+	received := false
+	verifyLifecycle(t, cfg, false, []lifecycleCase{
+		{
+			dataType: config.TracesDataType,
+			create: func() (component.Component, error) {
+				return factory.CreateTracesExporter(context.Background(), set, cfg)
+			},
+			setUp: func(t *testing.T) func() {
+				tracesSink.Reset()
+				return startDestination(t, destination != nil, &received, func() (component.Receiver, error) {
+					return destination.CreateTracesReceiver(context.Background(), destinationSet, destinationCfg, tracesSink)
+				})
+			},
+			roundTrip: func(t *testing.T, c component.Component) {
+				sendToExporter(t, received, func(ctx context.Context) error {
+					return c.(consumer.Traces).ConsumeTraces(ctx, testdata.GenerateTracesTwoSpansSameResource())
+				}, tracesSink.SpanCount, 2)
+				require.NoError(t, c.Shutdown(context.Background()))
+			},
+		},
+		{
+			dataType: config.MetricsDataType,
+			create: func() (component.Component, error) {
+				return factory.CreateMetricsExporter(context.Background(), set, cfg)
+			},
+			setUp: func(t *testing.T) func() {
+				metricsSink.Reset()
+				return startDestination(t, destination != nil, &received, func() (component.Receiver, error) {
+					return destination.CreateMetricsReceiver(context.Background(), destinationSet, destinationCfg, metricsSink)
+				})
+			},
+			roundTrip: func(t *testing.T, c component.Component) {
+				sendToExporter(t, received, func(ctx context.Context) error {
+					return c.(consumer.Metrics).ConsumeMetrics(ctx, testdata.GenerateMetricsTwoMetrics())
+				}, metricsSink.DataPointCount, testdata.GenerateMetricsTwoMetrics().DataPointCount())
+				require.NoError(t, c.Shutdown(context.Background()))
+			},
+		},
+		{
+			dataType: config.LogsDataType,
+			create: func() (component.Component, error) {
+				return factory.CreateLogsExporter(context.Background(), set, cfg)
+			},
+			setUp: func(t *testing.T) func() {
+				logsSink.Reset()
+				return startDestination(t, destination != nil, &received, func() (component.Receiver, error) {
+					return destination.CreateLogsReceiver(context.Background(), destinationSet, destinationCfg, logsSink)
+				})
+			},
+			roundTrip: func(t *testing.T, c component.Component) {
+				sendToExporter(t, received, func(ctx context.Context) error {
+					return c.(consumer.Logs).ConsumeLogs(ctx, testdata.GenerateLogsTwoLogRecordsSameResource())
+				}, logsSink.LogRecordCount, 2)
+				require.NoError(t, c.Shutdown(context.Background()))
+			},
+		},
+	})
+}
+
+// VerifyExtensionConformance runs the lifecycle conformance suite of
+// VerifyReceiverConformance against the extensions created by the factory with the
+// given config.
+func VerifyExtensionConformance(t *testing.T, factory component.ExtensionFactory, cfg config.Extension) {
+	verifyDefaultConfig(t, factory)
+	verifyLifecycle(t, cfg, true, []lifecycleCase{
+		{
+			dataType: "extension",
+			create: func() (component.Component, error) {
+				return factory.CreateExtension(context.Background(), NewNopExtensionCreateSettings(), cfg)
+			},
+		},
+	})
+}
+
+func verifyDefaultConfig(t *testing.T, factory component.Factory) {
+	t.Run("default_config", func(t *testing.T) {
+		var cfg interface{}
+		switch f := factory.(type) {
+		case component.ReceiverFactory:
+			cfg = f.CreateDefaultConfig()
+		case component.ProcessorFactory:
+			cfg = f.CreateDefaultConfig()
+		case component.ExporterFactory:
+			cfg = f.CreateDefaultConfig()
+		case component.ExtensionFactory:
+			cfg = f.CreateDefaultConfig()
+		}
+		require.NotNil(t, cfg, "factory %q returned no default config", factory.Type())
+		assert.NoError(t, configcheck.ValidateConfig(cfg))
+	})
+}
+
+func verifyLifecycle(t *testing.T, cfg interface{}, checkEndpoints bool, cases []lifecycleCase) {
+	var endpoints []string
+	if checkEndpoints {
+		endpoints = listenEndpoints(reflect.ValueOf(cfg))
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(string(tc.dataType), func(t *testing.T) {
+			goroutines := runtime.NumGoroutine()
+
+			c, err := tc.create()
+			if err == componenterror.ErrDataTypeIsNotSupported {
+				t.Skipf("data type %q is not supported", tc.dataType)
+			}
+			require.NoError(t, err)
+			require.NotNil(t, c)
+
+			// Shutdown without Start.
+			assert.NotPanics(t, func() {
+				assert.NoError(t, c.Shutdown(context.Background()))
+			}, "Shutdown without Start")
+
+			// Start, Shutdown and Shutdown again.
+			tearDown := func() {}
+			if tc.setUp != nil {
+				tearDown = tc.setUp(t)
+			}
+			c, err = tc.create()
+			require.NoError(t, err)
+			require.NoError(t, c.Start(context.Background(), NewNopHost()))
+			if tc.roundTrip != nil {
+				assert.NotPanics(t, func() { tc.roundTrip(t, c) }, "data round-trip")
+			} else {
+				assert.NoError(t, c.Shutdown(context.Background()))
+			}
+			assert.NotPanics(t, func() {
+				assert.NoError(t, c.Shutdown(context.Background()))
+			}, "second Shutdown")
+			tearDown()
+
+			verifyNoGoroutineLeak(t, goroutines)
+			for _, endpoint := range endpoints {
+				verifyEndpointReleased(t, endpoint)
+			}
+		})
+	}
+}
+
+// sendThroughExporter starts the exporter created by create, sends data through it, and
+// waits for count to return want.
+func sendThroughExporter(t *testing.T, create func() (component.Exporter, error), send func(ctx context.Context, exp component.Exporter) error, count func() int, want int) {
+	exp, err := create()
+	if err == componenterror.ErrDataTypeIsNotSupported {
+		t.Log("the sender does not support the data type, skipping the data round-trip")
+		return
+	}
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), NewNopHost()))
+	defer func() { assert.NoError(t, exp.Shutdown(context.Background())) }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), roundTripTimeout)
+	defer cancel()
+	require.NoError(t, send(ctx, exp))
+	assert.Eventually(t, func() bool { return count() == want }, roundTripTimeout, 10*time.Millisecond,
+		"the data sent did not reach the next consumer")
+}
+
+// startDestination starts the receiver created by create if enabled, and returns the
+// function shutting it down. received is set to whether the receiver was started.
+func startDestination(t *testing.T, enabled bool, received *bool, create func() (component.Receiver, error)) func() {
+	*received = false
+	if !enabled {
+		return func() {}
+	}
+	rcv, err := create()
+	if err == componenterror.ErrDataTypeIsNotSupported {
+		t.Log("the destination does not support the data type, skipping the data round-trip")
+		return func() {}
+	}
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), NewNopHost()))
+	*received = true
+	return func() { assert.NoError(t, rcv.Shutdown(context.Background())) }
+}
+
+// sendToExporter sends data to a started exporter and, if a destination was started,
+// waits for count to return want. Without a destination, the exporter may fail to send
+// the data.
+func sendToExporter(t *testing.T, received bool, send func(ctx context.Context) error, count func() int, want int) {
+	if !received {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := send(ctx); err != nil {
+			t.Logf("sending data failed: %v", err)
+		}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), roundTripTimeout)
+	defer cancel()
+	require.NoError(t, send(ctx))
+	assert.Eventually(t, func() bool { return count() == want }, roundTripTimeout, 10*time.Millisecond,
+		"the data sent did not reach the destination")
+}
+
+// verifyNoGoroutineLeak waits for the number of goroutines to go back to the given
+// number, and reports the running goroutines otherwise.
+func verifyNoGoroutineLeak(t *testing.T, goroutines int) {
+	deadline := time.Now().Add(leakTimeout)
+	for runtime.NumGoroutine() > goroutines && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > goroutines {
+		buf := make([]byte, 1<<20)
+		buf = buf[:runtime.Stack(buf, true)]
+		t.Errorf("%d goroutine(s) leaked after Shutdown:\n%s", n-goroutines, buf)
+	}
+}
+
+// verifyEndpointReleased verifies that the host:port endpoint can be listened on.
+func verifyEndpointReleased(t *testing.T, endpoint string) {
+	ln, err := net.Listen("tcp", endpoint)
+	if !assert.NoError(t, err, "endpoint %q was not released after Shutdown", endpoint) {
+		return
+	}
+	assert.NoError(t, ln.Close())
+}
+
+// listenEndpoints returns the host:port values, with a fixed port, of the string fields
+// named Endpoint of the config.
+func listenEndpoints(v reflect.Value) []string {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return listenEndpoints(v.Elem())
+	case reflect.Struct:
+		var endpoints []string
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.PkgPath != "" {
+				// Unexported field.
+				continue
+			}
+			fv := v.Field(i)
+			if field.Name == "Endpoint" && fv.Kind() == reflect.String {
+				if isListenEndpoint(fv.String()) {
+					endpoints = append(endpoints, fv.String())
+				}
+				continue
+			}
+			endpoints = append(endpoints, listenEndpoints(fv)...)
+		}
+		return endpoints
+	}
+	return nil
+}
+
+func isListenEndpoint(endpoint string) bool {
+	_, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return false
+	}
+	p, err := strconv.Atoi(port)
+	return err == nil && p > 0
+}
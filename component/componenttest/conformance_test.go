@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package componenttest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+)
+
+func TestVerifyReceiverConformance(t *testing.T) {
+	factory := NewNopReceiverFactory()
+	VerifyReceiverConformance(t, factory, factory.CreateDefaultConfig(), nil, nil)
+}
+
+func TestVerifyExporterConformance(t *testing.T) {
+	factory := NewNopExporterFactory()
+	VerifyExporterConformance(t, factory, factory.CreateDefaultConfig(), nil, nil)
+}
+
+func TestVerifyExtensionConformance(t *testing.T) {
+	factory := NewNopExtensionFactory()
+	VerifyExtensionConformance(t, factory, factory.CreateDefaultConfig())
+}
+
+func TestListenEndpoints(t *testing.T) {
+	type grpc struct {
+		NetAddr confignet.NetAddr
+	}
+	type cfg struct {
+		config.ReceiverSettings
+		GRPC     *grpc
+		HTTP     *confignet.TCPAddr
+		None     *confignet.TCPAddr
+		URL      struct{ Endpoint string }
+		Any      struct{ Endpoint string }
+		endpoint string
+	}
+	c := &cfg{
+		GRPC:     &grpc{NetAddr: confignet.NetAddr{Endpoint: "localhost:4317", Transport: "tcp"}},
+		HTTP:     &confignet.TCPAddr{Endpoint: "0.0.0.0:4318"},
+		URL:      struct{ Endpoint string }{Endpoint: "http://localhost:4318/v1/traces"},
+		Any:      struct{ Endpoint string }{Endpoint: "localhost:0"},
+		endpoint: "localhost:1234",
+	}
+	assert.Equal(t, []string{"localhost:4317", "0.0.0.0:4318"}, listenEndpoints(reflect.ValueOf(c)))
+}
@@ -17,6 +17,10 @@ package config
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/config/configparser"
 )
@@ -44,86 +48,102 @@ var _ validatable = (*Config)(nil)
 // invalid cases that we currently don't check for but which we may want to add in
 // the future (e.g. disallowing receiving and exporting on the same endpoint).
 func (cfg *Config) Validate() error {
+	if errs := cfg.ValidateAll(); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// ValidateAll validates the configuration like Validate but instead of stopping at the
+// first error it returns all the errors found.
+func (cfg *Config) ValidateAll() []error {
+	var errs []error
+
 	// Currently there is no default receiver enabled.
 	// The configuration must specify at least one receiver to be valid.
 	if len(cfg.Receivers) == 0 {
-		return errMissingReceivers
+		errs = append(errs, errMissingReceivers)
 	}
 
 	// Validate the receiver configuration.
 	for recv, recvCfg := range cfg.Receivers {
 		if err := recvCfg.Validate(); err != nil {
-			return fmt.Errorf("receiver \"%s\" has invalid configuration: %w", recv, err)
+			errs = append(errs, fmt.Errorf("receiver \"%s\" has invalid configuration: %w", recv, err))
 		}
 	}
 
 	// Currently there is no default exporter enabled.
 	// The configuration must specify at least one exporter to be valid.
 	if len(cfg.Exporters) == 0 {
-		return errMissingExporters
+		errs = append(errs, errMissingExporters)
 	}
 
 	// Validate the exporter configuration.
 	for exp, expCfg := range cfg.Exporters {
 		if err := expCfg.Validate(); err != nil {
-			return fmt.Errorf("exporter \"%s\" has invalid configuration: %w", exp, err)
+			errs = append(errs, fmt.Errorf("exporter \"%s\" has invalid configuration: %w", exp, err))
 		}
 	}
 
 	// Validate the processor configuration.
 	for proc, procCfg := range cfg.Processors {
 		if err := procCfg.Validate(); err != nil {
-			return fmt.Errorf("processor \"%s\" has invalid configuration: %w", proc, err)
+			errs = append(errs, fmt.Errorf("processor \"%s\" has invalid configuration: %w", proc, err))
 		}
 	}
 
 	// Validate the extension configuration.
 	for ext, extCfg := range cfg.Extensions {
 		if err := extCfg.Validate(); err != nil {
-			return fmt.Errorf("extension \"%s\" has invalid configuration: %w", ext, err)
+			errs = append(errs, fmt.Errorf("extension \"%s\" has invalid configuration: %w", ext, err))
 		}
 	}
 
-	// Check that all enabled extensions in the service are configured.
-	if err := cfg.validateServiceExtensions(); err != nil {
-		return err
+	if err := cfg.Service.Telemetry.Logs.validate(); err != nil {
+		errs = append(errs, err)
 	}
+	errs = append(errs, cfg.validateServiceTelemetry()...)
+
+	// Check that all enabled extensions in the service are configured.
+	errs = append(errs, cfg.validateServiceExtensions()...)
 
 	// Check that all pipelines have at least one receiver and one exporter, and they reference
 	// only configured components.
-	return cfg.validateServicePipelines()
+	return append(errs, cfg.validateServicePipelines()...)
 }
 
-func (cfg *Config) validateServiceExtensions() error {
+func (cfg *Config) validateServiceExtensions() []error {
+	var errs []error
 	// Validate extensions.
 	for _, ref := range cfg.Service.Extensions {
 		// Check that the name referenced in the Service extensions exists in the top-level extensions.
 		if cfg.Extensions[ref] == nil {
-			return fmt.Errorf("service references extension %q which does not exist", ref)
+			errs = append(errs, fmt.Errorf("service references extension %q which does not exist", ref))
 		}
 	}
 
-	return nil
+	return errs
 }
 
-func (cfg *Config) validateServicePipelines() error {
+func (cfg *Config) validateServicePipelines() []error {
 	// Must have at least one pipeline.
 	if len(cfg.Service.Pipelines) == 0 {
-		return errMissingServicePipelines
+		return []error{errMissingServicePipelines}
 	}
 
+	var errs []error
 	// Validate pipelines.
 	for _, pipeline := range cfg.Service.Pipelines {
 		// Validate pipeline has at least one receiver.
 		if len(pipeline.Receivers) == 0 {
-			return fmt.Errorf("pipeline %q must have at least one receiver", pipeline.Name)
+			errs = append(errs, fmt.Errorf("pipeline %q must have at least one receiver", pipeline.Name))
 		}
 
 		// Validate pipeline receiver name references.
 		for _, ref := range pipeline.Receivers {
 			// Check that the name referenced in the pipeline's receivers exists in the top-level receivers.
 			if cfg.Receivers[ref] == nil {
-				return fmt.Errorf("pipeline %q references receiver %q which does not exist", pipeline.Name, ref)
+				errs = append(errs, fmt.Errorf("pipeline %q references receiver %q which does not exist", pipeline.Name, ref))
 			}
 		}
 
@@ -131,28 +151,129 @@ func (cfg *Config) validateServicePipelines() error {
 		for _, ref := range pipeline.Processors {
 			// Check that the name referenced in the pipeline's processors exists in the top-level processors.
 			if cfg.Processors[ref] == nil {
-				return fmt.Errorf("pipeline %q references processor %q which does not exist", pipeline.Name, ref)
+				errs = append(errs, fmt.Errorf("pipeline %q references processor %q which does not exist", pipeline.Name, ref))
 			}
 		}
 
 		// Validate pipeline has at least one exporter.
 		if len(pipeline.Exporters) == 0 {
-			return fmt.Errorf("pipeline %q must have at least one exporter", pipeline.Name)
+			errs = append(errs, fmt.Errorf("pipeline %q must have at least one exporter", pipeline.Name))
 		}
 
 		// Validate pipeline exporter name references.
 		for _, ref := range pipeline.Exporters {
 			// Check that the name referenced in the pipeline's Exporters exists in the top-level Exporters.
 			if cfg.Exporters[ref] == nil {
-				return fmt.Errorf("pipeline %q references exporter %q which does not exist", pipeline.Name, ref)
+				errs = append(errs, fmt.Errorf("pipeline %q references exporter %q which does not exist", pipeline.Name, ref))
+			}
+		}
+
+		if err := pipeline.validateFanout(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func (cfg *Config) validateServiceTelemetry() []error {
+	var errs []error
+	// Check that the exporters referenced by the telemetry exist in the top-level exporters.
+	for _, ref := range cfg.Service.Telemetry.Metrics.Exporters {
+		if cfg.Exporters[ref] == nil {
+			errs = append(errs, fmt.Errorf("service telemetry metrics references exporter %q which does not exist", ref))
+		} else if name, ok := cfg.pipelineUsingExporter(ref); ok {
+			errs = append(errs, fmt.Errorf("service telemetry metrics references exporter %q which is also used by pipeline %q", ref, name))
+		}
+	}
+	for _, ref := range cfg.Service.Telemetry.Traces.Exporters {
+		if cfg.Exporters[ref] == nil {
+			errs = append(errs, fmt.Errorf("service telemetry traces references exporter %q which does not exist", ref))
+		} else if name, ok := cfg.pipelineUsingExporter(ref); ok {
+			errs = append(errs, fmt.Errorf("service telemetry traces references exporter %q which is also used by pipeline %q", ref, name))
+		}
+	}
+
+	if interval := cfg.Service.Telemetry.Metrics.Interval; interval != 0 && interval < time.Second {
+		errs = append(errs, errors.New("service telemetry metrics interval must be at least 1s"))
+	}
+	return errs
+}
+
+// pipelineUsingExporter returns the name of the first pipeline, in name order, that uses the exporter.
+// The telemetry exporters are separate instances, so sharing one with a pipeline would, for example,
+// make both of them listen on the same port.
+func (cfg *Config) pipelineUsingExporter(id ComponentID) (string, bool) {
+	names := make([]string, 0, len(cfg.Service.Pipelines))
+	for name := range cfg.Service.Pipelines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, ref := range cfg.Service.Pipelines[name].Exporters {
+			if ref == id {
+				return name, true
 			}
 		}
 	}
+	return "", false
+}
+
+func (l *ServiceTelemetryLogs) validate() error {
+	if l.Level != "" {
+		var lvl zapcore.Level
+		if err := lvl.UnmarshalText([]byte(l.Level)); err != nil {
+			return fmt.Errorf("service telemetry logs has invalid level %q", l.Level)
+		}
+	}
+
+	switch l.Encoding {
+	case "", "json", "console":
+	default:
+		return fmt.Errorf("service telemetry logs has unknown encoding %q", l.Encoding)
+	}
+
+	if l.Rotation != nil && (l.Rotation.MaxSizeMiB <= 0 || l.Rotation.MaxBackups < 0) {
+		return errors.New("service telemetry logs rotation must have a positive max_size_mib and a non-negative max_backups")
+	}
+
+	if l.Sampling != nil && (l.Sampling.Initial < 0 || l.Sampling.Thereafter < 0) {
+		return errors.New("service telemetry logs sampling must have non-negative initial and thereafter")
+	}
+	return nil
+}
+
+func (p *Pipeline) validateFanout() error {
+	switch p.Fanout.Mode {
+	case "", FanoutModeSync, FanoutModeAsync:
+	default:
+		return fmt.Errorf("pipeline %q has unknown fanout mode %q", p.Name, p.Fanout.Mode)
+	}
+
+	if p.Fanout.QueueSize < 0 {
+		return fmt.Errorf("pipeline %q must have a non-negative fanout queue size", p.Name)
+	}
+
+	// Validate that the best effort exporters are exporters of the pipeline.
+	for _, ref := range p.Fanout.BestEffort {
+		found := false
+		for _, exp := range p.Exporters {
+			if exp == ref {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("pipeline %q references best effort exporter %q which is not in its exporters", p.Name, ref)
+		}
+	}
 	return nil
 }
 
 // Service defines the configurable components of the service.
 type Service struct {
+	// Telemetry is the configuration of the collector's own telemetry.
+	Telemetry ServiceTelemetry
+
 	// Extensions are the ordered list of extensions configured for the service.
 	Extensions []ComponentID
 
@@ -160,6 +281,97 @@ type Service struct {
 	Pipelines Pipelines
 }
 
+// ServiceTelemetry defines the configurable settings of the collector's own telemetry.
+type ServiceTelemetry struct {
+	// Logs is the configuration of the collector's own logs.
+	Logs ServiceTelemetryLogs
+
+	// Metrics is the configuration of the collector's own metrics.
+	Metrics ServiceTelemetryMetrics
+
+	// Traces is the configuration of the collector's own traces.
+	Traces ServiceTelemetryTraces
+
+	// Resource are the attributes added to the resource of the collector's own metrics
+	// and traces, in addition to the service.name, service.version and service.instance.id.
+	Resource map[string]string
+}
+
+// ServiceTelemetryMetrics defines the configurable settings of the collector's own metrics.
+type ServiceTelemetryMetrics struct {
+	// Address is the address the metrics are served on in the Prometheus format, nil
+	// means the address given on the command line and empty disables the endpoint.
+	Address *string
+
+	// Exporters are the metrics exporters the metrics are pushed to, they must be
+	// configured in the exporters section but do not have to be used by a pipeline.
+	Exporters []ComponentID
+
+	// Interval is the interval the metrics are pushed at, at least 1 second, zero means
+	// 10 seconds.
+	Interval time.Duration
+}
+
+// ServiceTelemetryTraces defines the configurable settings of the collector's own traces.
+type ServiceTelemetryTraces struct {
+	// Exporters are the traces exporters the spans recorded by the collector are pushed
+	// to, they must be configured in the exporters section but do not have to be used
+	// by a pipeline.
+	Exporters []ComponentID
+}
+
+// ServiceTelemetryLogs defines the configurable settings of the collector's own logs.
+// The settings left to their zero value keep the values given on the command line
+// or the defaults of the logging profile.
+type ServiceTelemetryLogs struct {
+	// Level is the minimum enabled logging level, e.g. "debug" or "info".
+	Level string
+
+	// Development puts the logger in development mode, which takes stack traces more
+	// liberally and makes DPanic level logs panic.
+	Development bool
+
+	// Encoding is the encoding of the logs, "json" or "console".
+	Encoding string
+
+	// OutputPaths are the URLs or file paths the logs are written to, "stdout" and "stderr"
+	// are the standard streams. Empty means "stderr".
+	OutputPaths []string
+
+	// ErrorOutputPaths are the URLs or file paths the internal errors of the logger are
+	// written to. Empty means "stderr".
+	ErrorOutputPaths []string
+
+	// Rotation, if set, rotates the files of the OutputPaths once they reach a size.
+	Rotation *ServiceTelemetryLogsRotation
+
+	// Sampling, if set, overrides the sampling of the logging profile.
+	Sampling *ServiceTelemetryLogsSampling
+
+	// InitialFields are the fields added to every log entry.
+	InitialFields map[string]interface{}
+}
+
+// ServiceTelemetryLogsRotation defines the rotation of the log files.
+type ServiceTelemetryLogsRotation struct {
+	// MaxSizeMiB is the size in MiB a log file reaches before it is rotated.
+	MaxSizeMiB int
+
+	// MaxBackups is the number of rotated files kept, zero keeps all of them.
+	MaxBackups int
+}
+
+// ServiceTelemetryLogsSampling defines the sampling of the logs, applied every second
+// to the entries with the same level and message.
+type ServiceTelemetryLogsSampling struct {
+	// Initial is the number of entries logged every second before sampling.
+	Initial int
+
+	// Thereafter is the sampling rate after the Initial entries, one entry out of
+	// Thereafter is logged.
+	Thereafter int
+}
+
 // Type is the component type as it is used in the config.
 type Type string
 
@@ -201,6 +413,44 @@ type Pipeline struct {
 	Receivers  []ComponentID
 	Processors []ComponentID
 	Exporters  []ComponentID
+	Fanout     FanoutSettings
+}
+
+// IsBestEffort returns true if the exporter is a best effort exporter of the pipeline.
+func (p *Pipeline) IsBestEffort(id ComponentID) bool {
+	for _, ref := range p.Fanout.BestEffort {
+		if ref == id {
+			return true
+		}
+	}
+	return false
+}
+
+// FanoutMode defines how the data is sent to the exporters of a pipeline.
+type FanoutMode string
+
+const (
+	// FanoutModeSync sends the data to one exporter after the other in the caller's goroutine.
+	// This is the default.
+	FanoutModeSync FanoutMode = "sync"
+
+	// FanoutModeAsync sends the data to every required exporter in its own goroutine, and
+	// queues the data for every best effort exporter so that they never block the pipeline.
+	FanoutModeAsync FanoutMode = "async"
+)
+
+// FanoutSettings defines how the data is sent to the exporters of a pipeline.
+type FanoutSettings struct {
+	// Mode is the FanoutMode, empty means FanoutModeSync.
+	Mode FanoutMode
+
+	// BestEffort are the exporters whose failures are not reported to the receivers, all the
+	// other exporters of the pipeline are required.
+	BestEffort []ComponentID
+
+	// QueueSize is the number of requests queued for every best effort exporter in FanoutModeAsync,
+	// the requests are dropped when the queue is full. Zero means the default size.
+	QueueSize int
 }
 
 // Pipelines is a map of names to Pipelines.
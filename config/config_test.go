@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -159,6 +160,36 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errors.New(`pipeline "traces" must have at least one exporter`),
 		},
+		{
+			name: "invalid-fanout-mode",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				pipe := cfg.Service.Pipelines["traces"]
+				pipe.Fanout.Mode = "parallel"
+				return cfg
+			},
+			expected: errors.New(`pipeline "traces" has unknown fanout mode "parallel"`),
+		},
+		{
+			name: "invalid-fanout-queue-size",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				pipe := cfg.Service.Pipelines["traces"]
+				pipe.Fanout.QueueSize = -1
+				return cfg
+			},
+			expected: errors.New(`pipeline "traces" must have a non-negative fanout queue size`),
+		},
+		{
+			name: "invalid-best-effort-exporter-reference",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				pipe := cfg.Service.Pipelines["traces"]
+				pipe.Fanout.BestEffort = append(pipe.Fanout.BestEffort, NewIDWithName("nop", "2"))
+				return cfg
+			},
+			expected: errors.New(`pipeline "traces" references best effort exporter "nop/2" which is not in its exporters`),
+		},
 		{
 			name: "missing-pipelines",
 			cfgFn: func() *Config {
@@ -212,6 +243,78 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: fmt.Errorf(`extension "nop" has invalid configuration: %w`, errInvalidExtConfig),
 		},
+		{
+			name: "invalid-telemetry-logs-level",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Logs.Level = "verbose"
+				return cfg
+			},
+			expected: errors.New(`service telemetry logs has invalid level "verbose"`),
+		},
+		{
+			name: "invalid-telemetry-logs-encoding",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Logs.Encoding = "xml"
+				return cfg
+			},
+			expected: errors.New(`service telemetry logs has unknown encoding "xml"`),
+		},
+		{
+			name: "invalid-telemetry-logs-rotation",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Logs.Rotation = &ServiceTelemetryLogsRotation{}
+				return cfg
+			},
+			expected: errors.New("service telemetry logs rotation must have a positive max_size_mib and a non-negative max_backups"),
+		},
+		{
+			name: "invalid-telemetry-metrics-exporter-reference",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Metrics.Exporters = []ComponentID{NewIDWithName("nop", "2")}
+				return cfg
+			},
+			expected: errors.New(`service telemetry metrics references exporter "nop/2" which does not exist`),
+		},
+		{
+			name: "invalid-telemetry-traces-exporter-reference",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Traces.Exporters = []ComponentID{NewIDWithName("nop", "2")}
+				return cfg
+			},
+			expected: errors.New(`service telemetry traces references exporter "nop/2" which does not exist`),
+		},
+		{
+			name: "telemetry-metrics-exporter-used-by-pipeline",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Metrics.Exporters = []ComponentID{NewID("nop")}
+				return cfg
+			},
+			expected: errors.New(`service telemetry metrics references exporter "nop" which is also used by pipeline "traces"`),
+		},
+		{
+			name: "telemetry-traces-exporter-used-by-pipeline",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Traces.Exporters = []ComponentID{NewID("nop")}
+				return cfg
+			},
+			expected: errors.New(`service telemetry traces references exporter "nop" which is also used by pipeline "traces"`),
+		},
+		{
+			name: "invalid-telemetry-metrics-interval",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Metrics.Interval = time.Millisecond
+				return cfg
+			},
+			expected: errors.New("service telemetry metrics interval must be at least 1s"),
+		},
 	}
 
 	for _, test := range testCases {
@@ -222,6 +325,24 @@ func TestConfigValidate(t *testing.T) {
 	}
 }
 
+func TestConfigValidateAll(t *testing.T) {
+	cfg := generateConfig()
+	assert.Empty(t, cfg.ValidateAll())
+
+	cfg.Exporters = nil
+	cfg.Service.Extensions = append(cfg.Service.Extensions, NewIDWithName("nop", "2"))
+	pipe := cfg.Service.Pipelines["traces"]
+	pipe.Processors = append(pipe.Processors, NewIDWithName("nop", "2"))
+
+	assert.Equal(t, []error{
+		errMissingExporters,
+		errors.New(`service references extension "nop/2" which does not exist`),
+		errors.New(`pipeline "traces" references processor "nop/2" which does not exist`),
+		errors.New(`pipeline "traces" references exporter "nop" which does not exist`),
+	}, cfg.ValidateAll())
+	assert.Equal(t, errMissingExporters, cfg.Validate())
+}
+
 func generateConfig() *Config {
 	return &Config{
 		Receivers: map[ComponentID]Receiver{
@@ -28,11 +28,13 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
 )
 
@@ -92,7 +94,8 @@ type GRPCClientSettings struct {
 	WaitForReady bool `mapstructure:"wait_for_ready"`
 
 	// The headers associated with gRPC requests.
-	Headers map[string]string `mapstructure:"headers"`
+	// The values are opaque, they are redacted when the configuration is printed or logged.
+	Headers map[string]configopaque.String `mapstructure:"headers"`
 
 	// Sets the balancer in grpclb_policy to discover the servers. Default is pick_first.
 	// https://github.com/grpc/grpc-go/blob/master/examples/features/load_balancing/README.md
@@ -170,6 +173,15 @@ func (gcs *GRPCClientSettings) SanitizedEndpoint() string {
 	}
 }
 
+// HeadersMetadata returns the Headers as the gRPC metadata to attach to the requests.
+func (gcs *GRPCClientSettings) HeadersMetadata() metadata.MD {
+	headers := make(map[string]string, len(gcs.Headers))
+	for k, v := range gcs.Headers {
+		headers[k] = string(v)
+	}
+	return metadata.New(headers)
+}
+
 func (gcs *GRPCClientSettings) isSchemeHTTP() bool {
 	return strings.HasPrefix(gcs.Endpoint, "http://")
 }
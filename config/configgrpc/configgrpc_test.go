@@ -31,6 +31,7 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/model/otlpgrpc"
 	"go.opentelemetry.io/collector/model/pdata"
@@ -49,7 +50,7 @@ func TestDefaultGrpcClientSettings(t *testing.T) {
 
 func TestAllGrpcClientSettings(t *testing.T) {
 	gcs := &GRPCClientSettings{
-		Headers: map[string]string{
+		Headers: map[string]configopaque.String{
 			"test": "test",
 		},
 		Endpoint:    "localhost:1234",
@@ -185,7 +186,7 @@ func TestGRPCClientSettingsError(t *testing.T) {
 		{
 			err: "invalid balancer_name: test",
 			settings: GRPCClientSettings{
-				Headers: map[string]string{
+				Headers: map[string]configopaque.String{
 					"test": "test",
 				},
 				Endpoint:    "localhost:1234",
@@ -28,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/internal/middleware"
 )
@@ -51,7 +52,8 @@ type HTTPClientSettings struct {
 
 	// Additional headers attached to each HTTP request sent by the client.
 	// Existing header values are overwritten if collision happens.
-	Headers map[string]string `mapstructure:"headers,omitempty"`
+	// The values are opaque, they are redacted when the configuration is printed or logged.
+	Headers map[string]configopaque.String `mapstructure:"headers,omitempty"`
 
 	// Custom Round Tripper to allow for individual components to intercept HTTP requests
 	CustomRoundTripper func(next http.RoundTripper) (http.RoundTripper, error)
@@ -122,13 +124,13 @@ func (hcs *HTTPClientSettings) ToClient(ext map[config.ComponentID]component.Ext
 // Custom RoundTripper that adds headers.
 type headerRoundTripper struct {
 	transport http.RoundTripper
-	headers   map[string]string
+	headers   map[string]configopaque.String
 }
 
 // RoundTrip is a custom RoundTripper that adds headers to the request.
 func (interceptor *headerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 	for k, v := range interceptor.headers {
-		req.Header.Set(k, v)
+		req.Header.Set(k, string(v))
 	}
 	// Send the request to next transport.
 	return interceptor.transport.RoundTrip(req)
@@ -153,21 +155,28 @@ type HTTPServerSettings struct {
 	// CORS needs to be enabled first by providing a non-empty list in CorsOrigins
 	// A wildcard (*) can be used to match any header.
 	CorsHeaders []string `mapstructure:"cors_allowed_headers"`
+
+	// Auth for this server.
+	Auth *configauth.Authentication `mapstructure:"auth,omitempty"`
 }
 
 // ToListener creates a net.Listener.
 func (hss *HTTPServerSettings) ToListener() (net.Listener, error) {
-	listener, err := net.Listen("tcp", hss.Endpoint)
-	if err != nil {
-		return nil, err
-	}
-
+	var tlsCfg *tls.Config
 	if hss.TLSSetting != nil {
-		var tlsCfg *tls.Config
+		var err error
 		tlsCfg, err = hss.TLSSetting.LoadTLSConfig()
 		if err != nil {
 			return nil, err
 		}
+	}
+
+	listener, err := net.Listen("tcp", hss.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if tlsCfg != nil {
 		listener = tls.NewListener(listener, tlsCfg)
 	}
 	return listener, nil
@@ -176,7 +185,8 @@ func (hss *HTTPServerSettings) ToListener() (net.Listener, error) {
 // toServerOptions has options that change the behavior of the HTTP server
 // returned by HTTPServerSettings.ToServer().
 type toServerOptions struct {
-	errorHandler middleware.ErrorHandler
+	errorHandler  middleware.ErrorHandler
+	authenticator configauth.ServerAuthenticator
 }
 
 // ToServerOption is an option to change the behavior of the HTTP server
@@ -191,6 +201,31 @@ func WithErrorHandler(e middleware.ErrorHandler) ToServerOption {
 	}
 }
 
+// WithAuthenticator requires every request to be authenticated by the given
+// authenticator, see HTTPServerSettings.ServerAuthenticator. Requests failing
+// the authentication are rejected with http.StatusUnauthorized. A nil authenticator
+// lets every request through.
+func WithAuthenticator(authenticator configauth.ServerAuthenticator) ToServerOption {
+	return func(opts *toServerOptions) {
+		opts.authenticator = authenticator
+	}
+}
+
+// ServerAuthenticator resolves the authenticator configured in Auth from the given extensions.
+// It returns nil if no authentication is configured.
+func (hss *HTTPServerSettings) ServerAuthenticator(ext map[config.ComponentID]component.Extension) (configauth.ServerAuthenticator, error) {
+	if hss.Auth == nil {
+		return nil, nil
+	}
+
+	componentID, err := config.NewIDFromString(hss.Auth.AuthenticatorName)
+	if err != nil {
+		return nil, err
+	}
+
+	return configauth.GetServerAuthenticator(ext, componentID)
+}
+
 // ToServer creates an http.Server from settings object.
 func (hss *HTTPServerSettings) ToServer(handler http.Handler, opts ...ToServerOption) *http.Server {
 	serverOpts := &toServerOptions{}
@@ -203,6 +238,10 @@ func (hss *HTTPServerSettings) ToServer(handler http.Handler, opts ...ToServerOp
 		middleware.WithErrorHandler(serverOpts.errorHandler),
 	)
 
+	if serverOpts.authenticator != nil {
+		handler = authHandler(handler, serverOpts.authenticator)
+	}
+
 	if len(hss.CorsOrigins) > 0 {
 		co := cors.Options{
 			AllowedOrigins:   hss.CorsOrigins,
@@ -229,3 +268,15 @@ func (hss *HTTPServerSettings) ToServer(handler http.Handler, opts ...ToServerOp
 		Handler: handler,
 	}
 }
+
+func authHandler(handler http.Handler, authenticator configauth.ServerAuthenticator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := authenticator.Authenticate(r.Context(), r.Header)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -15,6 +15,7 @@
 package confighttp
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -31,6 +32,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
 )
 
@@ -510,6 +512,61 @@ func TestHttpCorsInvalidSettings(t *testing.T) {
 	require.NoError(t, s.Close())
 }
 
+func TestHTTPServerSettingsServerAuthenticator(t *testing.T) {
+	authenticator := &configauth.MockAuthenticator{}
+	ext := map[config.ComponentID]component.Extension{
+		config.NewID("mock"): authenticator,
+	}
+
+	hss := &HTTPServerSettings{}
+	got, err := hss.ServerAuthenticator(ext)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	hss.Auth = &configauth.Authentication{AuthenticatorName: "mock"}
+	got, err = hss.ServerAuthenticator(ext)
+	require.NoError(t, err)
+	assert.Equal(t, authenticator, got)
+
+	hss.Auth = &configauth.Authentication{AuthenticatorName: "missing"}
+	_, err = hss.ServerAuthenticator(ext)
+	assert.Error(t, err)
+
+	hss.Auth = &configauth.Authentication{AuthenticatorName: "/invalid"}
+	_, err = hss.ServerAuthenticator(ext)
+	assert.Error(t, err)
+}
+
+func TestHTTPServerAuth(t *testing.T) {
+	authenticator := &configauth.MockAuthenticator{
+		AuthenticateFunc: func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+			if len(headers["Authorization"]) == 0 || headers["Authorization"][0] != "Bearer token" {
+				return ctx, errors.New("invalid token")
+			}
+			return ctx, nil
+		},
+	}
+
+	hss := &HTTPServerSettings{Endpoint: "localhost:0"}
+	handlerCalled := false
+	s := hss.ToServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerCalled = true
+	}), WithAuthenticator(authenticator))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	s.Handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.False(t, handlerCalled)
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rec = httptest.NewRecorder()
+	s.Handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, handlerCalled)
+}
+
 func verifyCorsResp(t *testing.T, url string, origin string, extraHeader bool, wantStatus int, wantAllowed bool) {
 	req, err := http.NewRequest("OPTIONS", url, nil)
 	require.NoError(t, err, "Error creating trace OPTIONS request: %v", err)
@@ -585,7 +642,7 @@ func TestHttpHeaders(t *testing.T) {
 				ReadBufferSize:  0,
 				WriteBufferSize: 0,
 				Timeout:         0,
-				Headers: map[string]string{
+				Headers: map[string]configopaque.String{
 					"header1": "value1",
 				},
 			}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package configopaque
+
+import (
+	"encoding"
+	"fmt"
+)
+
+// maskedString is the value used in place of the opaque values.
+const maskedString = "[REDACTED]"
+
+// String is a configuration value holding a secret. It is unmarshalled as a regular string
+// but it is marshalled, formatted and logged as "[REDACTED]", so the secret doesn't leak to
+// logs, zPages or configuration dumps. Use string(value) to get the actual secret.
+type String string
+
+var _ encoding.TextMarshaler = String("")
+var _ fmt.Stringer = String("")
+var _ fmt.GoStringer = String("")
+
+// MarshalText marshals the String as "[REDACTED]", it is used by the JSON and YAML encoders.
+func (s String) MarshalText() ([]byte, error) {
+	return []byte(maskedString), nil
+}
+
+// String formats the String as "[REDACTED]".
+func (s String) String() string {
+	return maskedString
+}
+
+// GoString formats the String as "[REDACTED]" when printed with the %#v verb.
+func (s String) GoString() string {
+	return fmt.Sprintf("%q", maskedString)
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package configopaque
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gopkg.in/yaml.v2"
+
+	"go.opentelemetry.io/collector/config/configparser"
+)
+
+type testConfig struct {
+	Password String            `mapstructure:"password" json:"password" yaml:"password"`
+	Headers  map[string]String `mapstructure:"headers" json:"headers" yaml:"headers"`
+}
+
+func TestString(t *testing.T) {
+	cp := configparser.NewParserFromStringMap(map[string]interface{}{
+		"password": "s3cr3t",
+		"headers":  map[string]interface{}{"authorization": "Bearer s3cr3t"},
+	})
+	cfg := &testConfig{}
+	require.NoError(t, cp.UnmarshalExact(cfg))
+	assert.Equal(t, "s3cr3t", string(cfg.Password))
+	assert.Equal(t, "Bearer s3cr3t", string(cfg.Headers["authorization"]))
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		assert.NotContains(t, fmt.Sprintf(format, cfg), "s3cr3t", format)
+	}
+
+	out, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"password":"[REDACTED]","headers":{"authorization":"[REDACTED]"}}`, string(out))
+
+	out, err = yaml.Marshal(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "password: '[REDACTED]'\nheaders:\n  authorization: '[REDACTED]'\n", string(out))
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	zap.New(core).Info("config", zap.Any("config", cfg))
+	require.Equal(t, 1, logs.Len())
+	encoded, err := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()).EncodeEntry(logs.All()[0].Entry, logs.All()[0].Context)
+	require.NoError(t, err)
+	assert.NotContains(t, encoded.String(), "s3cr3t")
+	assert.Contains(t, encoded.String(), maskedString)
+}
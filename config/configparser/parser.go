@@ -132,6 +132,24 @@ func (l *Parser) MergeStringMap(cfg map[string]interface{}) error {
 	return l.k.Merge(toMerge)
 }
 
+// Merge deep-merges the configuration from the given Parser into this one. Maps are merged
+// recursively, any other value, including lists, present in the given Parser replaces the
+// existing one. Keys with a nil value in the given Parser don't change the existing value.
+// It returns an error if a key holds values of different kinds (map, list or scalar) in
+// both parsers, in which case this Parser is not modified.
+func (l *Parser) Merge(other *Parser) error {
+	merged, err := mergeMaps(l.ToStringMap(), other.ToStringMap(), "")
+	if err != nil {
+		return err
+	}
+
+	k := koanf.New(KeyDelimiter)
+	// Cannot return error because the koanf instance is empty.
+	_ = k.Load(confmap.Provider(merged, KeyDelimiter), nil)
+	l.k = k
+	return nil
+}
+
 // Sub returns new Parser instance representing a sub-config of this instance.
 // It returns an error is the sub-config is not a map (use Get()) and an empty Parser if
 // none exists.
@@ -156,6 +174,53 @@ func (l *Parser) ToStringMap() map[string]interface{} {
 	return maps.Unflatten(l.k.All(), KeyDelimiter)
 }
 
+// mergeMaps returns a new map with the values of src deep-merged into dst.
+func mergeMaps(dst, src map[string]interface{}, prefix string) (map[string]interface{}, error) {
+	merged := make(map[string]interface{}, len(dst))
+	for k, v := range dst {
+		merged[k] = v
+	}
+
+	for k, srcVal := range src {
+		key := k
+		if prefix != "" {
+			key = prefix + KeyDelimiter + k
+		}
+
+		dstVal, ok := merged[k]
+		switch {
+		case !ok || dstVal == nil:
+			merged[k] = srcVal
+		case srcVal == nil:
+			// Nothing to merge, keep the existing value.
+		case valueKind(dstVal) != valueKind(srcVal):
+			return nil, fmt.Errorf("cannot merge key %q: %s conflicts with %s", key, valueKind(dstVal), valueKind(srcVal))
+		case valueKind(dstVal) == "a map":
+			value, err := mergeMaps(cast.ToStringMap(dstVal), cast.ToStringMap(srcVal), key)
+			if err != nil {
+				return nil, err
+			}
+			merged[k] = value
+		default:
+			merged[k] = srcVal
+		}
+	}
+
+	return merged, nil
+}
+
+// valueKind describes the kind of a configuration value for merge purposes.
+func valueKind(v interface{}) string {
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Map:
+		return "a map"
+	case reflect.Slice, reflect.Array:
+		return "a list"
+	default:
+		return "a scalar value"
+	}
+}
+
 // decoderConfig returns a default mapstructure.DecoderConfig capable of parsing time.Duration
 // and weakly converting config field values to primitive types.  It also ensures that maps
 // whose values are nil pointer structs resolved to the zero value of the target struct (see
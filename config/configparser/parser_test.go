@@ -104,3 +104,98 @@ func TestToStringMap(t *testing.T) {
 		})
 	}
 }
+
+func TestMerge(t *testing.T) {
+	base := NewParserFromStringMap(map[string]interface{}{
+		"receivers": map[string]interface{}{
+			"otlp": map[string]interface{}{
+				"protocols": map[string]interface{}{
+					"grpc": nil,
+				},
+			},
+		},
+		"processors": map[string]interface{}{
+			"attributes": map[string]interface{}{
+				"actions": []interface{}{"a", "b"},
+			},
+			"batch": map[string]interface{}{
+				"timeout": "1s",
+			},
+		},
+	})
+	overlay := NewParserFromStringMap(map[string]interface{}{
+		"receivers": map[string]interface{}{
+			"otlp": map[string]interface{}{
+				"protocols": map[string]interface{}{
+					"grpc": map[string]interface{}{"endpoint": "localhost:4317"},
+					"http": nil,
+				},
+			},
+		},
+		"processors": map[string]interface{}{
+			"attributes": map[string]interface{}{
+				"actions": []interface{}{"c"},
+			},
+			"batch": nil,
+		},
+	})
+
+	require.NoError(t, base.Merge(overlay))
+	assert.Equal(t, map[string]interface{}{
+		"receivers": map[string]interface{}{
+			"otlp": map[string]interface{}{
+				"protocols": map[string]interface{}{
+					"grpc": map[string]interface{}{"endpoint": "localhost:4317"},
+					"http": nil,
+				},
+			},
+		},
+		"processors": map[string]interface{}{
+			"attributes": map[string]interface{}{
+				"actions": []interface{}{"c"},
+			},
+			"batch": map[string]interface{}{
+				"timeout": "1s",
+			},
+		},
+	}, base.ToStringMap())
+}
+
+func TestMerge_Conflict(t *testing.T) {
+	tests := []struct {
+		name    string
+		overlay map[string]interface{}
+		errMsg  string
+	}{
+		{
+			name:    "map_with_scalar",
+			overlay: map[string]interface{}{"receivers": map[string]interface{}{"otlp": "enabled"}},
+			errMsg:  `cannot merge key "receivers::otlp": a map conflicts with a scalar value`,
+		},
+		{
+			name:    "list_with_map",
+			overlay: map[string]interface{}{"list": map[string]interface{}{"key": "value"}},
+			errMsg:  `cannot merge key "list": a list conflicts with a map`,
+		},
+		{
+			name:    "scalar_with_list",
+			overlay: map[string]interface{}{"scalar": []interface{}{1}},
+			errMsg:  `cannot merge key "scalar": a scalar value conflicts with a list`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := map[string]interface{}{
+				"receivers": map[string]interface{}{
+					"otlp": map[string]interface{}{"endpoint": "localhost:4317"},
+				},
+				"list":   []interface{}{1, 2},
+				"scalar": 1,
+			}
+			base := NewParserFromStringMap(original)
+			err := base.Merge(NewParserFromStringMap(tt.overlay))
+			assert.EqualError(t, err, tt.errMsg)
+			assert.Equal(t, original, base.ToStringMap())
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -77,14 +78,60 @@ type configSettings struct {
 }
 
 type serviceSettings struct {
+	Telemetry  telemetrySettings           `mapstructure:"telemetry"`
 	Extensions []string                    `mapstructure:"extensions"`
 	Pipelines  map[string]pipelineSettings `mapstructure:"pipelines"`
 }
 
+type telemetrySettings struct {
+	Logs     logsSettings      `mapstructure:"logs"`
+	Metrics  metricsSettings   `mapstructure:"metrics"`
+	Traces   tracesSettings    `mapstructure:"traces"`
+	Resource map[string]string `mapstructure:"resource"`
+}
+
+type metricsSettings struct {
+	Address   *string       `mapstructure:"address"`
+	Exporters []string      `mapstructure:"exporters"`
+	Interval  time.Duration `mapstructure:"interval"`
+}
+
+type tracesSettings struct {
+	Exporters []string `mapstructure:"exporters"`
+}
+
+type logsSettings struct {
+	Level            string                 `mapstructure:"level"`
+	Development      bool                   `mapstructure:"development"`
+	Encoding         string                 `mapstructure:"encoding"`
+	OutputPaths      []string               `mapstructure:"output_paths"`
+	ErrorOutputPaths []string               `mapstructure:"error_output_paths"`
+	Rotation         *logsRotationSettings  `mapstructure:"rotation"`
+	Sampling         *logsSamplingSettings  `mapstructure:"sampling"`
+	InitialFields    map[string]interface{} `mapstructure:"initial_fields"`
+}
+
+type logsRotationSettings struct {
+	MaxSizeMiB int `mapstructure:"max_size_mib"`
+	MaxBackups int `mapstructure:"max_backups"`
+}
+
+type logsSamplingSettings struct {
+	Initial    int `mapstructure:"initial"`
+	Thereafter int `mapstructure:"thereafter"`
+}
+
 type pipelineSettings struct {
-	Receivers  []string `mapstructure:"receivers"`
-	Processors []string `mapstructure:"processors"`
-	Exporters  []string `mapstructure:"exporters"`
+	Receivers  []string       `mapstructure:"receivers"`
+	Processors []string       `mapstructure:"processors"`
+	Exporters  []string       `mapstructure:"exporters"`
+	Fanout     fanoutSettings `mapstructure:"fanout"`
+}
+
+type fanoutSettings struct {
+	Mode       string   `mapstructure:"mode"`
+	BestEffort []string `mapstructure:"best_effort"`
+	QueueSize  int      `mapstructure:"queue_size"`
 }
 
 type defaultUnmarshaler struct{}
@@ -219,8 +266,72 @@ func unmarshalExtensions(exts map[string]map[string]interface{}, factories map[c
 	return extensions, nil
 }
 
+func unmarshalTelemetry(rawTelemetry telemetrySettings) (config.ServiceTelemetry, error) {
+	rawLogs := rawTelemetry.Logs
+	logs := config.ServiceTelemetryLogs{
+		Level:            rawLogs.Level,
+		Development:      rawLogs.Development,
+		Encoding:         rawLogs.Encoding,
+		OutputPaths:      rawLogs.OutputPaths,
+		ErrorOutputPaths: rawLogs.ErrorOutputPaths,
+		InitialFields:    rawLogs.InitialFields,
+	}
+	if rawLogs.Rotation != nil {
+		logs.Rotation = &config.ServiceTelemetryLogsRotation{
+			MaxSizeMiB: rawLogs.Rotation.MaxSizeMiB,
+			MaxBackups: rawLogs.Rotation.MaxBackups,
+		}
+	}
+	if rawLogs.Sampling != nil {
+		logs.Sampling = &config.ServiceTelemetryLogsSampling{
+			Initial:    rawLogs.Sampling.Initial,
+			Thereafter: rawLogs.Sampling.Thereafter,
+		}
+	}
+
+	metricsExporters, err := parseTelemetryExporters(rawTelemetry.Metrics.Exporters)
+	if err != nil {
+		return config.ServiceTelemetry{}, err
+	}
+	tracesExporters, err := parseTelemetryExporters(rawTelemetry.Traces.Exporters)
+	if err != nil {
+		return config.ServiceTelemetry{}, err
+	}
+
+	return config.ServiceTelemetry{
+		Logs: logs,
+		Metrics: config.ServiceTelemetryMetrics{
+			Address:   rawTelemetry.Metrics.Address,
+			Exporters: metricsExporters,
+			Interval:  rawTelemetry.Metrics.Interval,
+		},
+		Traces: config.ServiceTelemetryTraces{
+			Exporters: tracesExporters,
+		},
+		Resource: rawTelemetry.Resource,
+	}, nil
+}
+
+func parseTelemetryExporters(names []string) ([]config.ComponentID, error) {
+	var ret []config.ComponentID
+	for _, name := range names {
+		id, err := config.NewIDFromString(name)
+		if err != nil {
+			return nil, fmt.Errorf("service telemetry: invalid exporter name %s : %w", name, err)
+		}
+		ret = append(ret, id)
+	}
+	return ret, nil
+}
+
 func unmarshalService(rawService serviceSettings) (config.Service, error) {
 	var ret config.Service
+	telemetry, err := unmarshalTelemetry(rawService.Telemetry)
+	if err != nil {
+		return ret, err
+	}
+	ret.Telemetry = telemetry
+
 	ret.Extensions = make([]config.ComponentID, 0, len(rawService.Extensions))
 	for _, extIDStr := range rawService.Extensions {
 		id, err := config.NewIDFromString(extIDStr)
@@ -411,6 +522,11 @@ func unmarshalPipelines(pipelinesConfig map[string]pipelineSettings) (config.Pip
 		if pipelineCfg.Exporters, err = parseIDNames(id, exportersKeyName, rawPipeline.Exporters); err != nil {
 			return nil, err
 		}
+		pipelineCfg.Fanout.Mode = config.FanoutMode(rawPipeline.Fanout.Mode)
+		if pipelineCfg.Fanout.BestEffort, err = parseIDNames(id, exportersKeyName, rawPipeline.Fanout.BestEffort); err != nil {
+			return nil, err
+		}
+		pipelineCfg.Fanout.QueueSize = rawPipeline.Fanout.QueueSize
 
 		if pipelines[fullName] != nil {
 			return nil, errorDuplicateName(pipelinesKeyName, id)
@@ -18,6 +18,7 @@ import (
 	"os"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -45,6 +46,35 @@ func TestDecodeConfig(t *testing.T) {
 	assert.Equal(t, 2, len(cfg.Service.Extensions))
 	assert.Equal(t, config.NewIDWithName("exampleextension", "0"), cfg.Service.Extensions[0])
 	assert.Equal(t, config.NewIDWithName("exampleextension", "1"), cfg.Service.Extensions[1])
+	emptyAddress := ""
+	assert.Equal(t,
+		config.ServiceTelemetry{
+			Logs: config.ServiceTelemetryLogs{
+				Level:       "debug",
+				Development: true,
+				Encoding:    "json",
+				OutputPaths: []string{"stdout", "/var/log/otelcol.log"},
+				Rotation: &config.ServiceTelemetryLogsRotation{
+					MaxSizeMiB: 10,
+					MaxBackups: 3,
+				},
+				Sampling: &config.ServiceTelemetryLogsSampling{
+					Initial:    5,
+					Thereafter: 50,
+				},
+				InitialFields: map[string]interface{}{"service": "otelcol"},
+			},
+			Metrics: config.ServiceTelemetryMetrics{
+				Address:   &emptyAddress,
+				Exporters: []config.ComponentID{config.NewID("exampleexporter")},
+				Interval:  30 * time.Second,
+			},
+			Traces: config.ServiceTelemetryTraces{
+				Exporters: []config.ComponentID{config.NewIDWithName("exampleexporter", "myexporter")},
+			},
+			Resource: map[string]string{"deployment.environment": "test"},
+		},
+		cfg.Service.Telemetry)
 
 	// Verify receivers
 	assert.Equal(t, 2, len(cfg.Receivers), "Incorrect receivers count")
@@ -110,7 +140,12 @@ func TestDecodeConfig(t *testing.T) {
 			InputType:  config.TracesDataType,
 			Receivers:  []config.ComponentID{config.NewID("examplereceiver")},
 			Processors: []config.ComponentID{config.NewID("exampleprocessor")},
-			Exporters:  []config.ComponentID{config.NewID("exampleexporter")},
+			Exporters:  []config.ComponentID{config.NewID("exampleexporter"), config.NewIDWithName("exampleexporter", "myexporter")},
+			Fanout: config.FanoutSettings{
+				Mode:       config.FanoutModeAsync,
+				BestEffort: []config.ComponentID{config.NewIDWithName("exampleexporter", "myexporter")},
+				QueueSize:  10,
+			},
 		},
 		cfg.Service.Pipelines["traces"],
 		"Did not load pipeline config correctly")
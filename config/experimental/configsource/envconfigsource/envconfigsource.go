@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Package envconfigsource implements a config source that injects the values of
+// environment variables into the configuration:
+//
+//    config_sources:
+//      env:
+//        defaults:
+//          LOG_LEVEL: info
+//
+//    service:
+//      telemetry:
+//        logs:
+//          level: $env:LOG_LEVEL
+//
+// The selector is the name of the environment variable. Retrieving a variable that is
+// not defined and doesn't have a default value is an error.
+package envconfigsource
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/collector/config/experimental/configsource"
+)
+
+type envConfigSource struct {
+	defaults map[string]interface{}
+}
+
+func newConfigSource(cfg *Config) configsource.ConfigSource {
+	return &envConfigSource{defaults: cfg.Defaults}
+}
+
+func (e *envConfigSource) NewSession(context.Context) (configsource.Session, error) {
+	return e, nil
+}
+
+func (e *envConfigSource) Retrieve(_ context.Context, selector string, _ interface{}) (configsource.Retrieved, error) {
+	if value, ok := os.LookupEnv(selector); ok {
+		return &retrieved{value: value}, nil
+	}
+	if value, ok := e.defaults[selector]; ok {
+		return &retrieved{value: value}, nil
+	}
+	return nil, fmt.Errorf("environment variable %q is not defined and has no default value", selector)
+}
+
+func (e *envConfigSource) RetrieveEnd(context.Context) error {
+	return nil
+}
+
+func (e *envConfigSource) Close(context.Context) error {
+	return nil
+}
+
+type retrieved struct {
+	value interface{}
+}
+
+func (r *retrieved) Value() interface{} {
+	return r.value
+}
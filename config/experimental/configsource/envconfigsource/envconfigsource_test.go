@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package envconfigsource
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/experimental/configsource"
+)
+
+func TestFactory(t *testing.T) {
+	f := NewFactory()
+	assert.Equal(t, config.Type("env"), f.Type())
+	cfg := f.CreateDefaultConfig()
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+	assert.Equal(t, config.NewID("env"), cfg.ID())
+
+	src, err := f.CreateConfigSource(context.Background(), cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, src)
+}
+
+func TestEnvConfigSource(t *testing.T) {
+	const envVar = "ENV_CONFIG_SOURCE_TEST_VAR"
+	require.NoError(t, os.Setenv(envVar, "env_value"))
+	defer os.Unsetenv(envVar)
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Defaults = map[string]interface{}{
+		envVar:        "ignored",
+		"NOT_DEFINED": 42,
+	}
+	src, err := NewFactory().CreateConfigSource(context.Background(), cfg)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	session, err := src.NewSession(ctx)
+	require.NoError(t, err)
+
+	r, err := session.Retrieve(ctx, envVar, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "env_value", r.Value())
+	_, watchable := r.(configsource.Watchable)
+	assert.False(t, watchable)
+
+	r, err = session.Retrieve(ctx, "NOT_DEFINED", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 42, r.Value())
+
+	_, err = session.Retrieve(ctx, "NOT_DEFINED_NO_DEFAULT", nil)
+	assert.Error(t, err)
+
+	assert.NoError(t, session.RetrieveEnd(ctx))
+	assert.NoError(t, session.Close(ctx))
+}
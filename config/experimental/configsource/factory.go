@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configsource
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// ConfigSettings is the configuration of a ConfigSource. Specific config sources must
+// implement this interface and must embed SourceSettings struct or a struct that extends it.
+type ConfigSettings interface {
+	// ID returns the ID of the ConfigSource, as declared in the config_sources section.
+	ID() config.ComponentID
+	// SetIDName updates the name part of the ID of the ConfigSource.
+	SetIDName(idName string)
+}
+
+// SourceSettings defines common settings of a ConfigSource configuration.
+// Specific config sources can embed this struct and extend it with more fields if needed.
+//
+// When embedded in the config source config, it must be with `mapstructure:",squash"` tag.
+type SourceSettings struct {
+	id config.ComponentID `mapstructure:"-"`
+}
+
+// NewSourceSettings return a new SourceSettings with the given ComponentID.
+func NewSourceSettings(id config.ComponentID) SourceSettings {
+	return SourceSettings{id: id}
+}
+
+var _ ConfigSettings = (*SourceSettings)(nil)
+
+// ID returns the config source ComponentID.
+func (ss *SourceSettings) ID() config.ComponentID {
+	return ss.id
+}
+
+// SetIDName sets the config source name.
+func (ss *SourceSettings) SetIDName(idName string) {
+	ss.id = config.NewIDWithName(ss.id.Type(), idName)
+}
+
+// Factory is a factory interface for config sources.
+type Factory interface {
+	// Type gets the type of the ConfigSource created by this factory.
+	Type() config.Type
+
+	// CreateDefaultConfig creates the default configuration for the ConfigSource.
+	// This method can be called multiple times depending on the pipeline
+	// configuration and should not cause side-effects that prevent the creation
+	// of multiple instances of the ConfigSource.
+	// The object returned by this method needs to pass the checks implemented by
+	// 'configcheck.ValidateConfig'. It is recommended to have such check in the
+	// tests of any implementation of the Factory interface.
+	CreateDefaultConfig() ConfigSettings
+
+	// CreateConfigSource creates a ConfigSource based on the given config.
+	CreateConfigSource(ctx context.Context, cfg ConfigSettings) (ConfigSource, error)
+}
+
+// Factories maps the type of a ConfigSource to the respective factory object.
+type Factories map[config.Type]Factory
+
+// MakeFactoryMap takes a list of config source factories and returns a map
+// with factory type as keys. It returns a non-nil error when more than one factories
+// have the same type.
+func MakeFactoryMap(factories ...Factory) (Factories, error) {
+	fMap := Factories{}
+	for _, f := range factories {
+		if _, ok := fMap[f.Type()]; ok {
+			return fMap, fmt.Errorf("duplicate config source factory %q", f.Type())
+		}
+		fMap[f.Type()] = f
+	}
+	return fMap, nil
+}
@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package fileconfigsource
+
+import (
+	"go.opentelemetry.io/collector/config/experimental/configsource"
+)
+
+// Config has the configuration for the file config source.
+type Config struct {
+	configsource.SourceSettings `mapstructure:",squash"`
+
+	// WatchFiles controls if the files referenced in the configuration are watched
+	// for updates, triggering a reload of the configuration when they change.
+	WatchFiles bool `mapstructure:"watch_files"`
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package fileconfigsource
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/experimental/configsource"
+)
+
+const (
+	// The value of config source "type" in configuration.
+	typeStr = "file"
+)
+
+type factory struct{}
+
+// NewFactory creates a factory for the file config source.
+func NewFactory() configsource.Factory {
+	return &factory{}
+}
+
+func (f *factory) Type() config.Type {
+	return typeStr
+}
+
+func (f *factory) CreateDefaultConfig() configsource.ConfigSettings {
+	return &Config{
+		SourceSettings: configsource.NewSourceSettings(config.NewID(typeStr)),
+		WatchFiles:     true,
+	}
+}
+
+func (f *factory) CreateConfigSource(_ context.Context, cfg configsource.ConfigSettings) (configsource.ConfigSource, error) {
+	return newConfigSource(cfg.(*Config)), nil
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Package fileconfigsource implements a config source that injects the contents of
+// files into the configuration, typically used for secrets:
+//
+//    config_sources:
+//      file:
+//
+//    exporters:
+//      otlp:
+//        headers:
+//          api-key: $file:/etc/otelcol/api-key
+//
+// The selector is the path of the file, its contents are injected as a string, or
+// as a []byte if the parameter "binary" is true, e.g.: "$file:/etc/cert.der?binary=true".
+// Unless "watch_files" is false, the file is watched and any update on it triggers a
+// reload of the configuration.
+package fileconfigsource
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+
+	"go.opentelemetry.io/collector/config/experimental/configsource"
+	"go.opentelemetry.io/collector/config/experimental/configsource/internal/filewatcher"
+)
+
+type fileConfigSource struct {
+	watchFiles bool
+}
+
+func newConfigSource(cfg *Config) configsource.ConfigSource {
+	return &fileConfigSource{watchFiles: cfg.WatchFiles}
+}
+
+func (f *fileConfigSource) NewSession(context.Context) (configsource.Session, error) {
+	return &fileSession{watchFiles: f.watchFiles}, nil
+}
+
+type fileSession struct {
+	watchFiles bool
+	watchers   filewatcher.Watchers
+}
+
+func (fs *fileSession) Retrieve(_ context.Context, selector string, params interface{}) (configsource.Retrieved, error) {
+	binary, err := binaryParam(params)
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := ioutil.ReadFile(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %q: %w", selector, err)
+	}
+
+	var value interface{} = string(bytes)
+	if binary {
+		value = bytes
+	}
+
+	if !fs.watchFiles {
+		return &retrieved{value: value}, nil
+	}
+	return fs.watchers.Watch(selector, value)
+}
+
+func (fs *fileSession) RetrieveEnd(context.Context) error {
+	return nil
+}
+
+func (fs *fileSession) Close(context.Context) error {
+	return fs.watchers.Close()
+}
+
+func binaryParam(params interface{}) (bool, error) {
+	if params == nil {
+		return false, nil
+	}
+	paramsMap, ok := params.(map[string]interface{})
+	if !ok {
+		return false, fmt.Errorf("invalid parameters %v", params)
+	}
+	for k, v := range paramsMap {
+		if k != "binary" {
+			return false, fmt.Errorf("unknown parameter %q", k)
+		}
+		binary, ok := v.(bool)
+		if !ok {
+			return false, fmt.Errorf("parameter \"binary\" must be a boolean, got %v", v)
+		}
+		return binary, nil
+	}
+	return false, nil
+}
+
+type retrieved struct {
+	value interface{}
+}
+
+func (r *retrieved) Value() interface{} {
+	return r.value
+}
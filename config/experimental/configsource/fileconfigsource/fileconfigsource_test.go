@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package fileconfigsource
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/experimental/configsource"
+)
+
+func TestFactory(t *testing.T) {
+	f := NewFactory()
+	assert.Equal(t, config.Type("file"), f.Type())
+	cfg := f.CreateDefaultConfig()
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+	assert.True(t, cfg.(*Config).WatchFiles)
+}
+
+func TestFileConfigSource_Retrieve(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.WatchFiles = false
+	src, err := NewFactory().CreateConfigSource(context.Background(), cfg)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	session, err := src.NewSession(ctx)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, session.Close(ctx))
+	}()
+
+	r, err := session.Retrieve(ctx, filepath.Join("testdata", "secret.txt"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", r.Value())
+	_, watchable := r.(configsource.Watchable)
+	assert.False(t, watchable)
+
+	r, err = session.Retrieve(ctx, filepath.Join("testdata", "secret.txt"), map[string]interface{}{"binary": true})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("s3cr3t"), r.Value())
+
+	_, err = session.Retrieve(ctx, filepath.Join("testdata", "secret.txt"), map[string]interface{}{"binary": "yes"})
+	assert.Error(t, err)
+
+	_, err = session.Retrieve(ctx, filepath.Join("testdata", "secret.txt"), map[string]interface{}{"unknown": true})
+	assert.Error(t, err)
+
+	_, err = session.Retrieve(ctx, filepath.Join("testdata", "not_found.txt"), nil)
+	assert.Error(t, err)
+
+	assert.NoError(t, session.RetrieveEnd(ctx))
+}
+
+func TestFileConfigSource_WatchForUpdate(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "secret.txt")
+	require.NoError(t, ioutil.WriteFile(file, []byte("v1"), 0600))
+
+	f := NewFactory()
+	src, err := f.CreateConfigSource(context.Background(), f.CreateDefaultConfig())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	session, err := src.NewSession(ctx)
+	require.NoError(t, err)
+
+	r, err := session.Retrieve(ctx, file, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", r.Value())
+	require.NoError(t, session.RetrieveEnd(ctx))
+
+	watchable, ok := r.(configsource.Watchable)
+	require.True(t, ok)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- watchable.WatchForUpdate()
+	}()
+
+	require.NoError(t, ioutil.WriteFile(file, []byte("v2"), 0600))
+	assert.True(t, errors.Is(<-errCh, configsource.ErrValueUpdated))
+
+	go func() {
+		errCh <- watchable.WatchForUpdate()
+	}()
+	require.NoError(t, session.Close(ctx))
+	assert.True(t, errors.Is(<-errCh, configsource.ErrSessionClosed))
+}
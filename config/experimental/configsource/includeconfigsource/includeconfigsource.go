@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Package includeconfigsource implements a config source that splices YAML fragments,
+// read from other files, into the configuration:
+//
+//    config_sources:
+//      include:
+//
+//    processors:
+//      attributes: $include:/etc/otelcol/attributes.yaml
+//
+// The selector is the path of the file, its contents are parsed as YAML and the
+// resulting value, usually a map, is injected into the configuration. References to
+// config sources inside the fragment are not resolved. Unless "watch_files" is false,
+// the file is watched and any update on it triggers a reload of the configuration.
+package includeconfigsource
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+
+	"go.opentelemetry.io/collector/config/experimental/configsource"
+	"go.opentelemetry.io/collector/config/experimental/configsource/internal/filewatcher"
+)
+
+type includeConfigSource struct {
+	watchFiles bool
+}
+
+func newConfigSource(cfg *Config) configsource.ConfigSource {
+	return &includeConfigSource{watchFiles: cfg.WatchFiles}
+}
+
+func (i *includeConfigSource) NewSession(context.Context) (configsource.Session, error) {
+	return &includeSession{watchFiles: i.watchFiles}, nil
+}
+
+type includeSession struct {
+	watchFiles bool
+	watchers   filewatcher.Watchers
+}
+
+func (is *includeSession) Retrieve(_ context.Context, selector string, params interface{}) (configsource.Retrieved, error) {
+	if params != nil {
+		return nil, fmt.Errorf("the include config source doesn't support parameters, got %v", params)
+	}
+
+	bytes, err := ioutil.ReadFile(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %q: %w", selector, err)
+	}
+
+	var value interface{}
+	if err = yaml.Unmarshal(bytes, &value); err != nil {
+		return nil, fmt.Errorf("failed to parse file %q as YAML: %w", selector, err)
+	}
+
+	if !is.watchFiles {
+		return &retrieved{value: value}, nil
+	}
+	return is.watchers.Watch(selector, value)
+}
+
+func (is *includeSession) RetrieveEnd(context.Context) error {
+	return nil
+}
+
+func (is *includeSession) Close(context.Context) error {
+	return is.watchers.Close()
+}
+
+type retrieved struct {
+	value interface{}
+}
+
+func (r *retrieved) Value() interface{} {
+	return r.value
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package includeconfigsource
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/experimental/configsource"
+)
+
+func TestFactory(t *testing.T) {
+	f := NewFactory()
+	assert.Equal(t, config.Type("include"), f.Type())
+	cfg := f.CreateDefaultConfig()
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+	assert.True(t, cfg.(*Config).WatchFiles)
+}
+
+func TestIncludeConfigSource_Retrieve(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.WatchFiles = false
+	src, err := NewFactory().CreateConfigSource(context.Background(), cfg)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	session, err := src.NewSession(ctx)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, session.Close(ctx))
+	}()
+
+	r, err := session.Retrieve(ctx, filepath.Join("testdata", "fragment.yaml"), nil)
+	require.NoError(t, err)
+	expected := map[interface{}]interface{}{
+		"actions": []interface{}{
+			map[interface{}]interface{}{
+				"key":    "region",
+				"value":  "us-west-1",
+				"action": "insert",
+			},
+		},
+	}
+	assert.Equal(t, expected, r.Value())
+
+	_, err = session.Retrieve(ctx, filepath.Join("testdata", "fragment.yaml"), map[string]interface{}{"param": 1})
+	assert.Error(t, err)
+
+	_, err = session.Retrieve(ctx, filepath.Join("testdata", "not_found.yaml"), nil)
+	assert.Error(t, err)
+}
+
+func TestIncludeConfigSource_WatchForUpdate(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "fragment.yaml")
+	require.NoError(t, ioutil.WriteFile(file, []byte("key: v1"), 0600))
+
+	f := NewFactory()
+	src, err := f.CreateConfigSource(context.Background(), f.CreateDefaultConfig())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	session, err := src.NewSession(ctx)
+	require.NoError(t, err)
+
+	r, err := session.Retrieve(ctx, file, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[interface{}]interface{}{"key": "v1"}, r.Value())
+	require.NoError(t, session.RetrieveEnd(ctx))
+
+	watchable, ok := r.(configsource.Watchable)
+	require.True(t, ok)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- watchable.WatchForUpdate()
+	}()
+
+	require.NoError(t, ioutil.WriteFile(file, []byte("key: v2"), 0600))
+	assert.True(t, errors.Is(<-errCh, configsource.ErrValueUpdated))
+	require.NoError(t, session.Close(ctx))
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package filewatcher implements the watchers used by the config sources that
+// retrieve data from files.
+package filewatcher
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+
+	"go.opentelemetry.io/collector/config/experimental/configsource"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// Retrieved is a configsource.Retrieved that watches for updates on the file
+// from which its value was read.
+type Retrieved struct {
+	value   interface{}
+	file    string
+	watcher *fsnotify.Watcher
+}
+
+var _ configsource.Watchable = (*Retrieved)(nil)
+
+// Value implements configsource.Retrieved.
+func (r *Retrieved) Value() interface{} {
+	return r.value
+}
+
+// WatchForUpdate implements configsource.Watchable. It returns an error wrapping
+// configsource.ErrValueUpdated when the file is written, replaced or removed.
+func (r *Retrieved) WatchForUpdate() error {
+	for {
+		select {
+		case event, ok := <-r.watcher.Events:
+			if !ok {
+				return configsource.ErrSessionClosed
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) != 0 {
+				return fmt.Errorf("file %q was updated: %w", r.file, configsource.ErrValueUpdated)
+			}
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return configsource.ErrSessionClosed
+			}
+			return fmt.Errorf("failed to watch file %q: %w", r.file, err)
+		}
+	}
+}
+
+// Watchers keeps track of the fsnotify watchers created by a session so they can
+// be released when the session is closed.
+type Watchers struct {
+	mu       sync.Mutex
+	watchers []*fsnotify.Watcher
+}
+
+// Watch starts watching the given file and returns a Retrieved holding the given value.
+func (w *Watchers) Watch(file string, value interface{}) (*Retrieved, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err = watcher.Add(file); err != nil {
+		_ = watcher.Close()
+		return nil, fmt.Errorf("failed to watch file %q: %w", file, err)
+	}
+
+	w.mu.Lock()
+	w.watchers = append(w.watchers, watcher)
+	w.mu.Unlock()
+
+	return &Retrieved{value: value, file: file, watcher: watcher}, nil
+}
+
+// Close stops all the watchers, any pending WatchForUpdate returns configsource.ErrSessionClosed.
+func (w *Watchers) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var errs []error
+	for _, watcher := range w.watchers {
+		if err := watcher.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	w.watchers = nil
+	return consumererror.Combine(errs)
+}
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var errBatchSenderShutdown = errors.New("batch sender is shut down")
+
+// BatchSettings defines configuration for batching requests before they are queued and sent.
+type BatchSettings struct {
+	// Enabled indicates whether to merge and split requests before sending them.
+	Enabled bool `mapstructure:"enabled"`
+	// MinSizeItems is the number of spans, metric data points or log records after which a batch is
+	// sent regardless of the flush timeout.
+	MinSizeItems int `mapstructure:"min_size_items"`
+	// MaxSizeItems is the maximum number of spans, metric data points or log records in a batch.
+	// Larger batches are split. Zero means no limit.
+	MaxSizeItems int `mapstructure:"max_size_items"`
+	// FlushTimeout is the time after which a batch is sent regardless of its size.
+	FlushTimeout time.Duration `mapstructure:"flush_timeout"`
+}
+
+// DefaultBatchSettings returns the default settings for BatchSettings.
+func DefaultBatchSettings() BatchSettings {
+	return BatchSettings{
+		Enabled:      false,
+		MinSizeItems: 8192,
+		MaxSizeItems: 0,
+		FlushTimeout: 200 * time.Millisecond,
+	}
+}
+
+// Validate checks if the BatchSettings configuration is valid.
+func (cfg *BatchSettings) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.MinSizeItems < 0 {
+		return errors.New("batch.min_size_items must not be negative")
+	}
+	if cfg.MaxSizeItems < 0 {
+		return errors.New("batch.max_size_items must not be negative")
+	}
+	if cfg.MaxSizeItems > 0 && cfg.MaxSizeItems < cfg.MinSizeItems {
+		return errors.New("batch.max_size_items must be greater or equal to batch.min_size_items")
+	}
+	if cfg.FlushTimeout <= 0 {
+		return errors.New("batch.flush_timeout must be positive")
+	}
+	return nil
+}
+
+// requestBatch accumulates the data of requests of a single data type.
+type requestBatch interface {
+	// add moves the data of the request into the batch.
+	add(req request)
+	// count returns the number of spans, metric data points or log records in the batch.
+	count() int
+	// take removes up to maxSize items from the batch, or all of them if maxSize is 0,
+	// and returns them as a new request.
+	take(ctx context.Context, maxSize int) request
+}
+
+// batchSender is a request sender that merges and splits requests to the configured size
+// before handing them to the next sender.
+type batchSender struct {
+	cfg        BatchSettings
+	batch      requestBatch
+	nextSender requestSender
+	logger     *zap.Logger
+
+	timer      *time.Timer
+	newItem    chan request
+	shutdownC  chan struct{}
+	goroutines sync.WaitGroup
+}
+
+func newBatchSender(cfg BatchSettings, batch requestBatch, nextSender requestSender, logger *zap.Logger) *batchSender {
+	return &batchSender{
+		cfg:        cfg,
+		batch:      batch,
+		nextSender: nextSender,
+		logger:     logger,
+		newItem:    make(chan request, runtime.NumCPU()),
+		shutdownC:  make(chan struct{}),
+	}
+}
+
+// start is invoked during service startup.
+func (bs *batchSender) start() {
+	bs.goroutines.Add(1)
+	go bs.startProcessingCycle()
+}
+
+// send implements the requestSender interface. The request is batched asynchronously,
+// export failures are handled by the next senders. It fails once the sender is shut down,
+// or when the context of the request is done before the request is accepted.
+func (bs *batchSender) send(req request) error {
+	select {
+	case <-bs.shutdownC:
+		return errBatchSenderShutdown
+	default:
+	}
+	select {
+	case bs.newItem <- req:
+		return nil
+	case <-bs.shutdownC:
+		return errBatchSenderShutdown
+	case <-req.context().Done():
+		return req.context().Err()
+	}
+}
+
+// shutdown is invoked during service shutdown, it sends the current batch.
+func (bs *batchSender) shutdown() {
+	close(bs.shutdownC)
+	bs.goroutines.Wait()
+}
+
+func (bs *batchSender) startProcessingCycle() {
+	defer bs.goroutines.Done()
+	bs.timer = time.NewTimer(bs.cfg.FlushTimeout)
+	defer bs.timer.Stop()
+	for {
+		select {
+		case <-bs.shutdownC:
+		DONE:
+			for {
+				select {
+				case req := <-bs.newItem:
+					bs.processRequest(req)
+				default:
+					break DONE
+				}
+			}
+			for bs.batch.count() > 0 {
+				bs.sendBatch()
+			}
+			return
+		case req := <-bs.newItem:
+			bs.processRequest(req)
+		case <-bs.timer.C:
+			for bs.batch.count() > 0 {
+				bs.sendBatch()
+			}
+			bs.timer.Reset(bs.cfg.FlushTimeout)
+		}
+	}
+}
+
+func (bs *batchSender) processRequest(req request) {
+	bs.batch.add(req)
+	sent := false
+	for bs.batch.count() > 0 && bs.batch.count() >= bs.cfg.MinSizeItems {
+		sent = true
+		bs.sendBatch()
+	}
+
+	if sent {
+		if !bs.timer.Stop() {
+			<-bs.timer.C
+		}
+		bs.timer.Reset(bs.cfg.FlushTimeout)
+	}
+}
+
+func (bs *batchSender) sendBatch() {
+	// The batch mixes data from different requests, so it cannot use any of their contexts.
+	req := bs.batch.take(context.Background(), bs.cfg.MaxSizeItems)
+	if err := bs.nextSender.send(req); err != nil {
+		bs.logger.Error(
+			"Exporting batch failed. Dropping data.",
+			zap.Error(err),
+			zap.Int("dropped_items", req.count()),
+		)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestBatchSettingsValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *BatchSettings)
+		errMsg string
+	}{
+		{
+			name:   "default",
+			modify: func(cfg *BatchSettings) {},
+		},
+		{
+			name: "disabled ignores invalid values",
+			modify: func(cfg *BatchSettings) {
+				cfg.Enabled = false
+				cfg.FlushTimeout = 0
+			},
+		},
+		{
+			name: "negative min size",
+			modify: func(cfg *BatchSettings) {
+				cfg.MinSizeItems = -1
+			},
+			errMsg: "batch.min_size_items must not be negative",
+		},
+		{
+			name: "negative max size",
+			modify: func(cfg *BatchSettings) {
+				cfg.MaxSizeItems = -1
+			},
+			errMsg: "batch.max_size_items must not be negative",
+		},
+		{
+			name: "max size smaller than min size",
+			modify: func(cfg *BatchSettings) {
+				cfg.MaxSizeItems = 100
+			},
+			errMsg: "batch.max_size_items must be greater or equal to batch.min_size_items",
+		},
+		{
+			name: "no flush timeout",
+			modify: func(cfg *BatchSettings) {
+				cfg.FlushTimeout = 0
+			},
+			errMsg: "batch.flush_timeout must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultBatchSettings()
+			cfg.Enabled = true
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestBatchSender_MergeOnMinSize(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := BatchSettings{Enabled: true, MinSizeItems: 4, FlushTimeout: time.Hour}
+	te, err := NewTracesExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), sink.ConsumeTraces, WithBatch(cfg))
+	require.NoError(t, err)
+	assert.True(t, te.Capabilities().MutatesData)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTracesTwoSpansSameResource()))
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTracesTwoSpansSameResource()))
+	assert.Eventually(t, func() bool {
+		return len(sink.AllTraces()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 4, sink.AllTraces()[0].SpanCount())
+
+	require.NoError(t, te.Shutdown(context.Background()))
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
+func TestBatchSender_ReadOnlyData(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := BatchSettings{Enabled: true, MinSizeItems: 4, FlushTimeout: time.Hour}
+	te, err := NewTracesExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), sink.ConsumeTraces, WithBatch(cfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The read-only data is copied into the batch and left untouched.
+	td := testdata.GenerateTracesTwoSpansSameResource()
+	td.MarkReadOnly()
+	require.NoError(t, te.ConsumeTraces(context.Background(), td))
+	require.NoError(t, te.ConsumeTraces(context.Background(), td))
+	require.NoError(t, te.Shutdown(context.Background()))
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, 4, sink.AllTraces()[0].SpanCount())
+	assert.Equal(t, 2, td.SpanCount())
+}
+
+func TestBatchSender_SplitOnMaxSize(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	cfg := BatchSettings{Enabled: true, MinSizeItems: 5, MaxSizeItems: 5, FlushTimeout: time.Hour}
+	me, err := NewMetricsExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), sink.ConsumeMetrics, WithBatch(cfg))
+	require.NoError(t, err)
+	require.NoError(t, me.Start(context.Background(), componenttest.NewNopHost()))
+
+	md := testdata.GenerateMetricsManyMetricsSameResource(6)
+	require.Equal(t, 12, md.DataPointCount())
+	require.NoError(t, me.ConsumeMetrics(context.Background(), md))
+	assert.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// The remaining data points are sent on shutdown.
+	require.NoError(t, me.Shutdown(context.Background()))
+	require.Len(t, sink.AllMetrics(), 3)
+	assert.Equal(t, 5, sink.AllMetrics()[0].DataPointCount())
+	assert.Equal(t, 5, sink.AllMetrics()[1].DataPointCount())
+	assert.Equal(t, 2, sink.AllMetrics()[2].DataPointCount())
+}
+
+func TestBatchSender_FlushTimeout(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	cfg := BatchSettings{Enabled: true, MinSizeItems: 100, FlushTimeout: 10 * time.Millisecond}
+	le, err := NewLogsExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), sink.ConsumeLogs,
+		WithBatch(cfg), WithQueue(DefaultQueueSettings()))
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, le.Shutdown(context.Background()))
+	})
+
+	require.NoError(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord()))
+	require.NoError(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogsTwoLogRecordsSameResource()))
+	assert.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 3
+	}, time.Second, 10*time.Millisecond)
+	assert.Len(t, sink.AllLogs(), 1)
+}
+
+func TestBatchSender_SendAfterShutdown(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := BatchSettings{Enabled: true, MinSizeItems: 4, FlushTimeout: time.Hour}
+	te, err := NewTracesExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), sink.ConsumeTraces, WithBatch(cfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, te.Shutdown(context.Background()))
+
+	// Sending more than the buffered channel can hold must not block.
+	for i := 0; i <= runtime.NumCPU(); i++ {
+		assert.ErrorIs(t, te.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan()), errBatchSenderShutdown)
+	}
+	assert.Len(t, sink.AllTraces(), 0)
+}
+
+func TestBatchSender_SendBeforeStartHonorsContext(t *testing.T) {
+	cfg := BatchSettings{Enabled: true, MinSizeItems: 4, FlushTimeout: time.Hour}
+	te, err := NewTracesExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), new(consumertest.TracesSink).ConsumeTraces, WithBatch(cfg))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	for i := 0; i < runtime.NumCPU(); i++ {
+		require.NoError(t, te.ConsumeTraces(ctx, testdata.GenerateTracesOneSpan()))
+	}
+	assert.ErrorIs(t, te.ConsumeTraces(ctx, testdata.GenerateTracesOneSpan()), context.DeadlineExceeded)
+	require.NoError(t, te.Shutdown(context.Background()))
+}
+
+func TestBatchSender_LogsDroppedBatches(t *testing.T) {
+	zcore, logObserver := observer.New(zapcore.ErrorLevel)
+	set := componenttest.NewNopExporterCreateSettings()
+	set.Logger = zap.New(zcore)
+	cfg := BatchSettings{Enabled: true, MinSizeItems: 2, FlushTimeout: time.Hour}
+	te, err := NewTracesExporter(&defaultExporterCfg, set, func(context.Context, pdata.Traces) error {
+		return consumererror.Permanent(errors.New("rejected"))
+	}, WithBatch(cfg), WithRetry(RetrySettings{Enabled: false}))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTracesTwoSpansSameResource()))
+	require.NoError(t, te.Shutdown(context.Background()))
+	entries := logObserver.FilterMessage("Exporting batch failed. Dropping data.").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, int64(2), entries[0].ContextMap()["dropped_items"])
+}
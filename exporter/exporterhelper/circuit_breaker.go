@@ -0,0 +1,303 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opencensus.io/metric"
+	"go.opencensus.io/metric/metricdata"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+)
+
+var (
+	circuitBreakerStateGauge, _ = r.AddInt64DerivedGauge(
+		obsmetrics.ExporterKey+"/circuit_breaker_state",
+		metric.WithDescription("Current state of the circuit breaker (0 closed, 1 open, 2 half-open)"),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	errCircuitBreakerOpen = errors.New("circuit breaker is open")
+)
+
+// CircuitBreakerSettings defines configuration for short-circuiting the requests
+// sent to a backend that keeps failing.
+type CircuitBreakerSettings struct {
+	// Enabled indicates whether to short-circuit the requests when the backend keeps failing.
+	Enabled bool `mapstructure:"enabled"`
+	// FailureThreshold is the number of consecutive failures opening the circuit breaker.
+	// Zero disables this condition.
+	FailureThreshold int `mapstructure:"failure_threshold"`
+	// FailureRatio is the ratio of failed requests within the Window opening the circuit breaker.
+	// Zero disables this condition.
+	FailureRatio float64 `mapstructure:"failure_ratio"`
+	// MinRequests is the minimum number of requests within the Window for the FailureRatio to apply.
+	MinRequests int `mapstructure:"min_requests"`
+	// Window is the period over which the FailureRatio is computed.
+	Window time.Duration `mapstructure:"window"`
+	// CoolDown is how long the circuit breaker stays open before letting a probe request
+	// through. The circuit breaker closes if the probe succeeds, and opens again otherwise.
+	CoolDown time.Duration `mapstructure:"cool_down"`
+}
+
+// DefaultCircuitBreakerSettings returns the default settings for CircuitBreakerSettings.
+func DefaultCircuitBreakerSettings() CircuitBreakerSettings {
+	return CircuitBreakerSettings{
+		Enabled:          false,
+		FailureThreshold: 5,
+		FailureRatio:     0.5,
+		MinRequests:      20,
+		Window:           time.Minute,
+		CoolDown:         30 * time.Second,
+	}
+}
+
+// Validate checks if the circuit breaker configuration is valid.
+func (cfg *CircuitBreakerSettings) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.FailureThreshold < 0 {
+		return fmt.Errorf("circuit_breaker.failure_threshold must not be negative, got %d", cfg.FailureThreshold)
+	}
+	if cfg.FailureRatio < 0 || cfg.FailureRatio > 1 {
+		return fmt.Errorf("circuit_breaker.failure_ratio must be between 0 and 1, got %v", cfg.FailureRatio)
+	}
+	if cfg.FailureThreshold == 0 && cfg.FailureRatio == 0 {
+		return errors.New("circuit_breaker requires a failure_threshold or a failure_ratio")
+	}
+	if cfg.FailureRatio > 0 && cfg.Window <= 0 {
+		return errors.New("circuit_breaker.window must be positive when failure_ratio is set")
+	}
+	if cfg.CoolDown <= 0 {
+		return errors.New("circuit_breaker.cool_down must be positive")
+	}
+	return nil
+}
+
+// CircuitBreakerState is the state of a circuit breaker.
+type CircuitBreakerState int64
+
+const (
+	// CircuitBreakerClosed lets all the requests through.
+	CircuitBreakerClosed CircuitBreakerState = iota
+	// CircuitBreakerOpen rejects all the requests until the cool down expires.
+	CircuitBreakerOpen
+	// CircuitBreakerHalfOpen lets a single probe request through.
+	CircuitBreakerHalfOpen
+)
+
+// String returns the name of the state.
+func (s CircuitBreakerState) String() string {
+	switch s {
+	case CircuitBreakerClosed:
+		return "closed"
+	case CircuitBreakerOpen:
+		return "open"
+	case CircuitBreakerHalfOpen:
+		return "half-open"
+	}
+	return ""
+}
+
+// CircuitBreakerReporter is implemented by the exporters created by this package, e.g. for the
+// extensions reporting the health of the collector to find the exporters through component.Host.
+type CircuitBreakerReporter interface {
+	// CircuitBreakerState returns the state of the circuit breaker of the exporter. It is
+	// CircuitBreakerClosed if the exporter has no circuit breaker or is not running.
+	CircuitBreakerState() CircuitBreakerState
+}
+
+// circuitBreakerSender is a request sender rejecting the requests while the backend keeps failing.
+// The requests are rejected with a throttle error delaying the retries until the end of the cool down.
+type circuitBreakerSender struct {
+	fullName   string
+	cfg        CircuitBreakerSettings
+	nextSender requestSender
+	logger     *zap.Logger
+	now        func() time.Time
+
+	mu                  sync.Mutex
+	running             bool
+	state               CircuitBreakerState
+	openedAt            time.Time
+	probing             bool
+	consecutiveFailures int
+	windowStart         time.Time
+	windowRequests      int
+	windowFailures      int
+}
+
+func newCircuitBreakerSender(fullName string, cfg CircuitBreakerSettings, nextSender requestSender, logger *zap.Logger) *circuitBreakerSender {
+	return &circuitBreakerSender{
+		fullName:   fullName,
+		cfg:        cfg,
+		nextSender: nextSender,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// start is invoked during service startup.
+func (cbs *circuitBreakerSender) start() error {
+	cbs.mu.Lock()
+	cbs.running = true
+	cbs.mu.Unlock()
+
+	err := circuitBreakerStateGauge.UpsertEntry(func() int64 {
+		return int64(cbs.currentState())
+	}, metricdata.NewLabelValue(cbs.fullName))
+	if err != nil {
+		return fmt.Errorf("failed to create circuit breaker state metric: %w", err)
+	}
+	return nil
+}
+
+// shutdown is invoked during service shutdown.
+func (cbs *circuitBreakerSender) shutdown() {
+	_ = circuitBreakerStateGauge.UpsertEntry(func() int64 {
+		return int64(CircuitBreakerClosed)
+	}, metricdata.NewLabelValue(cbs.fullName))
+
+	cbs.mu.Lock()
+	cbs.running = false
+	cbs.mu.Unlock()
+}
+
+// send implements the requestSender interface
+func (cbs *circuitBreakerSender) send(req request) error {
+	probe, err := cbs.acquire()
+	if err != nil {
+		return err
+	}
+	err = cbs.nextSender.send(req)
+	cbs.release(probe, err)
+	return err
+}
+
+// currentState returns the state, moving an open circuit breaker to half-open once the cool down expired.
+func (cbs *circuitBreakerSender) currentState() CircuitBreakerState {
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+	cbs.updateState(cbs.now())
+	return cbs.state
+}
+
+// reportedState returns the current state of a running circuit breaker, CircuitBreakerClosed otherwise.
+func (cbs *circuitBreakerSender) reportedState() CircuitBreakerState {
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+	if !cbs.running {
+		return CircuitBreakerClosed
+	}
+	cbs.updateState(cbs.now())
+	return cbs.state
+}
+
+func (cbs *circuitBreakerSender) updateState(now time.Time) {
+	if cbs.state == CircuitBreakerOpen && now.Sub(cbs.openedAt) >= cbs.cfg.CoolDown {
+		cbs.state = CircuitBreakerHalfOpen
+		cbs.probing = false
+	}
+}
+
+// acquire checks if a request can be sent, and whether it is the probe of a half-open circuit breaker.
+func (cbs *circuitBreakerSender) acquire() (bool, error) {
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+	now := cbs.now()
+	cbs.updateState(now)
+	switch cbs.state {
+	case CircuitBreakerOpen:
+		return false, NewThrottleRetry(errCircuitBreakerOpen, cbs.cfg.CoolDown-now.Sub(cbs.openedAt))
+	case CircuitBreakerHalfOpen:
+		if cbs.probing {
+			return false, NewThrottleRetry(errCircuitBreakerOpen, cbs.cfg.CoolDown)
+		}
+		cbs.probing = true
+		return true, nil
+	}
+	return false, nil
+}
+
+// release records the result of a request.
+func (cbs *circuitBreakerSender) release(probe bool, err error) {
+	// Permanent errors are caused by the data, not by the backend.
+	failed := err != nil && !consumererror.IsPermanent(err)
+
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+	now := cbs.now()
+
+	if probe {
+		cbs.probing = false
+		if failed {
+			cbs.open(now, err)
+			return
+		}
+		cbs.close()
+		return
+	}
+	if cbs.state != CircuitBreakerClosed {
+		// Result of a request sent before the circuit breaker opened.
+		return
+	}
+
+	if cbs.cfg.FailureRatio > 0 && now.Sub(cbs.windowStart) >= cbs.cfg.Window {
+		cbs.windowStart = now
+		cbs.windowRequests = 0
+		cbs.windowFailures = 0
+	}
+	cbs.windowRequests++
+	if !failed {
+		cbs.consecutiveFailures = 0
+		return
+	}
+	cbs.windowFailures++
+	cbs.consecutiveFailures++
+
+	if cbs.cfg.FailureThreshold > 0 && cbs.consecutiveFailures >= cbs.cfg.FailureThreshold {
+		cbs.open(now, err)
+		return
+	}
+	if cbs.cfg.FailureRatio > 0 && cbs.windowRequests >= cbs.cfg.MinRequests &&
+		float64(cbs.windowFailures)/float64(cbs.windowRequests) >= cbs.cfg.FailureRatio {
+		cbs.open(now, err)
+	}
+}
+
+func (cbs *circuitBreakerSender) open(now time.Time, err error) {
+	cbs.state = CircuitBreakerOpen
+	cbs.openedAt = now
+	cbs.logger.Warn("Circuit breaker opened, requests are rejected until the cool down expires.",
+		zap.String("exporter", cbs.fullName),
+		zap.Duration("cool_down", cbs.cfg.CoolDown),
+		zap.Error(err))
+}
+
+func (cbs *circuitBreakerSender) close() {
+	cbs.state = CircuitBreakerClosed
+	cbs.consecutiveFailures = 0
+	cbs.windowStart = cbs.now()
+	cbs.windowRequests = 0
+	cbs.windowFailures = 0
+	cbs.logger.Info("Circuit breaker closed.", zap.String("exporter", cbs.fullName))
+}
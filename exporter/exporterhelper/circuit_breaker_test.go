@@ -0,0 +1,256 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+type errSender struct {
+	err   error
+	calls int
+}
+
+func (s *errSender) send(request) error {
+	s.calls++
+	return s.err
+}
+
+func newTestCircuitBreaker(cfg CircuitBreakerSettings) (*circuitBreakerSender, *errSender, *fakeClock) {
+	next := &errSender{}
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	cbs := newCircuitBreakerSender("test", cfg, next, zap.NewNop())
+	cbs.now = clock.Now
+	return cbs, next, clock
+}
+
+func TestCircuitBreakerSettings_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *CircuitBreakerSettings)
+		errMsg string
+	}{
+		{
+			name:   "default",
+			modify: func(cfg *CircuitBreakerSettings) {},
+		},
+		{
+			name:   "disabled",
+			modify: func(cfg *CircuitBreakerSettings) { *cfg = CircuitBreakerSettings{} },
+		},
+		{
+			name:   "negative_threshold",
+			modify: func(cfg *CircuitBreakerSettings) { cfg.FailureThreshold = -1 },
+			errMsg: "circuit_breaker.failure_threshold must not be negative, got -1",
+		},
+		{
+			name:   "invalid_ratio",
+			modify: func(cfg *CircuitBreakerSettings) { cfg.FailureRatio = 1.5 },
+			errMsg: "circuit_breaker.failure_ratio must be between 0 and 1, got 1.5",
+		},
+		{
+			name: "no_condition",
+			modify: func(cfg *CircuitBreakerSettings) {
+				cfg.FailureThreshold = 0
+				cfg.FailureRatio = 0
+			},
+			errMsg: "circuit_breaker requires a failure_threshold or a failure_ratio",
+		},
+		{
+			name:   "no_window",
+			modify: func(cfg *CircuitBreakerSettings) { cfg.Window = 0 },
+			errMsg: "circuit_breaker.window must be positive when failure_ratio is set",
+		},
+		{
+			name:   "no_cool_down",
+			modify: func(cfg *CircuitBreakerSettings) { cfg.CoolDown = 0 },
+			errMsg: "circuit_breaker.cool_down must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultCircuitBreakerSettings()
+			cfg.Enabled = true
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
+
+func TestCircuitBreaker_FailureThreshold(t *testing.T) {
+	cfg := CircuitBreakerSettings{Enabled: true, FailureThreshold: 3, CoolDown: 10 * time.Second}
+	cbs, next, clock := newTestCircuitBreaker(cfg)
+	req := newMockRequest(context.Background(), 1, nil)
+
+	next.err = errors.New("unavailable")
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, next.err, cbs.send(req))
+	}
+	// A success resets the consecutive failures.
+	next.err = nil
+	assert.NoError(t, cbs.send(req))
+	next.err = errors.New("unavailable")
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, next.err, cbs.send(req))
+	}
+	assert.Equal(t, CircuitBreakerOpen, cbs.currentState())
+	assert.Equal(t, 6, next.calls)
+
+	// Requests are short-circuited with a throttle error until the end of the cool down.
+	clock.advance(4 * time.Second)
+	err := cbs.send(req)
+	assert.True(t, errors.Is(err, errCircuitBreakerOpen))
+	throttleErr := throttleRetry{}
+	require.True(t, errors.As(err, &throttleErr))
+	assert.Equal(t, 6*time.Second, throttleErr.delay)
+	assert.Equal(t, 6, next.calls)
+}
+
+func TestCircuitBreaker_PermanentErrorsIgnored(t *testing.T) {
+	cfg := CircuitBreakerSettings{Enabled: true, FailureThreshold: 1, CoolDown: 10 * time.Second}
+	cbs, next, _ := newTestCircuitBreaker(cfg)
+	next.err = consumererror.Permanent(errors.New("bad data"))
+	for i := 0; i < 3; i++ {
+		assert.Error(t, cbs.send(newMockRequest(context.Background(), 1, nil)))
+	}
+	assert.Equal(t, CircuitBreakerClosed, cbs.currentState())
+}
+
+func TestCircuitBreaker_FailureRatio(t *testing.T) {
+	cfg := CircuitBreakerSettings{Enabled: true, FailureRatio: 0.5, MinRequests: 4, Window: time.Minute, CoolDown: 10 * time.Second}
+	cbs, next, clock := newTestCircuitBreaker(cfg)
+	req := newMockRequest(context.Background(), 1, nil)
+	fail := errors.New("unavailable")
+
+	// Not enough requests in the window.
+	next.err = fail
+	assert.Error(t, cbs.send(req))
+	next.err = nil
+	assert.NoError(t, cbs.send(req))
+	next.err = fail
+	assert.Error(t, cbs.send(req))
+	assert.Equal(t, CircuitBreakerClosed, cbs.currentState())
+
+	// A new window starts.
+	clock.advance(time.Minute)
+	for _, err := range []error{nil, fail, nil, fail} {
+		next.err = err
+		_ = cbs.send(req)
+	}
+	assert.Equal(t, CircuitBreakerOpen, cbs.currentState())
+}
+
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	cfg := CircuitBreakerSettings{Enabled: true, FailureThreshold: 1, CoolDown: 10 * time.Second}
+	cbs, next, clock := newTestCircuitBreaker(cfg)
+	req := newMockRequest(context.Background(), 1, nil)
+
+	next.err = errors.New("unavailable")
+	assert.Error(t, cbs.send(req))
+	assert.Equal(t, CircuitBreakerOpen, cbs.currentState())
+
+	clock.advance(10 * time.Second)
+	assert.Equal(t, CircuitBreakerHalfOpen, cbs.currentState())
+
+	// The probe is the only request let through.
+	probe, err := cbs.acquire()
+	require.NoError(t, err)
+	assert.True(t, probe)
+	_, err = cbs.acquire()
+	assert.True(t, errors.Is(err, errCircuitBreakerOpen))
+
+	// A failed probe opens the circuit breaker again.
+	cbs.release(probe, next.err)
+	assert.Equal(t, CircuitBreakerOpen, cbs.currentState())
+
+	// A successful probe closes it.
+	clock.advance(10 * time.Second)
+	next.err = nil
+	assert.NoError(t, cbs.send(req))
+	assert.Equal(t, CircuitBreakerClosed, cbs.currentState())
+	assert.NoError(t, cbs.send(req))
+	assert.Equal(t, 3, next.calls)
+}
+
+func TestCircuitBreaker_StateReported(t *testing.T) {
+	cfg := CircuitBreakerSettings{Enabled: true, FailureThreshold: 1, CoolDown: time.Hour}
+	be := newBaseExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), fromOptions(WithCircuitBreaker(cfg)), config.TracesDataType)
+	require.NotNil(t, be.cbSender)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	checkValueForProducer(t, defaultExporterTags, int64(CircuitBreakerClosed), "exporter/circuit_breaker_state")
+	assert.Equal(t, CircuitBreakerClosed, be.CircuitBreakerState())
+
+	assert.Error(t, be.sender.send(newErrorRequest(context.Background())))
+	checkValueForProducer(t, defaultExporterTags, int64(CircuitBreakerOpen), "exporter/circuit_breaker_state")
+	assert.Equal(t, CircuitBreakerOpen, be.CircuitBreakerState())
+
+	require.NoError(t, be.Shutdown(context.Background()))
+	checkValueForProducer(t, defaultExporterTags, int64(CircuitBreakerClosed), "exporter/circuit_breaker_state")
+	assert.Equal(t, CircuitBreakerClosed, be.CircuitBreakerState())
+}
+
+func TestCircuitBreaker_RetryWaitsForCoolDown(t *testing.T) {
+	rCfg := DefaultRetrySettings()
+	rCfg.InitialInterval = time.Millisecond
+	rCfg.MaxElapsedTime = 100 * time.Millisecond
+	cfg := CircuitBreakerSettings{Enabled: true, FailureThreshold: 1, CoolDown: time.Hour}
+	be := newBaseExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), fromOptions(WithRetry(rCfg), WithCircuitBreaker(cfg)), config.TracesDataType)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	mockR := newMockRequest(ctx, 2, errors.New("transient error"))
+	// The retry is throttled for the cool down, and interrupted by the request deadline.
+	assert.True(t, errors.Is(be.sender.send(mockR), errCircuitBreakerOpen))
+	mockR.checkNumRequests(t, 1)
+}
+
+func TestCircuitBreakerState_String(t *testing.T) {
+	assert.Equal(t, "closed", CircuitBreakerClosed.String())
+	assert.Equal(t, "open", CircuitBreakerOpen.String())
+	assert.Equal(t, "half-open", CircuitBreakerHalfOpen.String())
+	assert.Equal(t, "", CircuitBreakerState(42).String())
+}
@@ -16,6 +16,7 @@ package exporterhelper
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -24,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumerhelper"
+	"go.opentelemetry.io/collector/model/pdata"
 	"go.opentelemetry.io/collector/obsreport"
 )
 
@@ -52,6 +54,12 @@ type request interface {
 	onError(error) request
 	// Returns the count of spans/metric points or log records.
 	count() int
+	// split returns a request with the first size items and a request with the remaining ones,
+	// leaving the data of the original request untouched.
+	split(size int) (request, request)
+	// deadLetter sends a copy of the request data, with the given attributes added to every resource, to the
+	// given exporter.
+	deadLetter(ctx context.Context, exp component.Exporter, attrs pdata.AttributeMap) error
 }
 
 // requestSender is an abstraction of a sender for a request independent of the type of the data (traces, metrics, logs).
@@ -79,6 +87,10 @@ type baseSettings struct {
 	TimeoutSettings
 	QueueSettings
 	RetrySettings
+	CircuitBreakerSettings
+	DeadLetterSettings
+	SplitSettings
+	BatchSettings
 	ResourceToTelemetrySettings
 }
 
@@ -91,6 +103,9 @@ func fromOptions(options ...Option) *baseSettings {
 		QueueSettings: QueueSettings{Enabled: false},
 		// TODO: Enable retry by default (call DefaultRetrySettings)
 		RetrySettings:               RetrySettings{Enabled: false},
+		CircuitBreakerSettings:      CircuitBreakerSettings{Enabled: false},
+		SplitSettings:               SplitSettings{Enabled: false},
+		BatchSettings:               BatchSettings{Enabled: false},
 		ResourceToTelemetrySettings: defaultResourceToTelemetrySettings(),
 	}
 
@@ -98,6 +113,11 @@ func fromOptions(options ...Option) *baseSettings {
 		op(opts)
 	}
 
+	if opts.BatchSettings.Enabled {
+		// Batching moves the data out of the incoming pdata, so it must not be shared with other consumers.
+		opts.consumerOptions = append(opts.consumerOptions, consumerhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	}
+
 	return opts
 }
 
@@ -144,6 +164,38 @@ func WithQueue(queueSettings QueueSettings) Option {
 	}
 }
 
+// WithCircuitBreaker overrides the default CircuitBreakerSettings for an exporter.
+// The default CircuitBreakerSettings is to disable the circuit breaker.
+func WithCircuitBreaker(circuitBreakerSettings CircuitBreakerSettings) Option {
+	return func(o *baseSettings) {
+		o.CircuitBreakerSettings = circuitBreakerSettings
+	}
+}
+
+// WithDeadLetter overrides the default DeadLetterSettings for an exporter.
+// The default DeadLetterSettings is to drop the data that cannot be exported.
+func WithDeadLetter(deadLetterSettings DeadLetterSettings) Option {
+	return func(o *baseSettings) {
+		o.DeadLetterSettings = deadLetterSettings
+	}
+}
+
+// WithSplit overrides the default SplitSettings for an exporter.
+// The default SplitSettings is to not split the requests rejected as too large.
+func WithSplit(splitSettings SplitSettings) Option {
+	return func(o *baseSettings) {
+		o.SplitSettings = splitSettings
+	}
+}
+
+// WithBatch overrides the default BatchSettings for an exporter.
+// The default BatchSettings is to disable batching.
+func WithBatch(batchSettings BatchSettings) Option {
+	return func(o *baseSettings) {
+		o.BatchSettings = batchSettings
+	}
+}
+
 // WithCapabilities overrides the default Capabilities() function for a Consumer.
 // The default is non-mutable data.
 // TODO: Verify if we can change the default to be mutable as we do for processors.
@@ -167,9 +219,15 @@ type baseExporter struct {
 	obsrep   *obsExporter
 	sender   requestSender
 	qrSender *queuedRetrySender
+	cbSender *circuitBreakerSender
+	dlSender *deadLetterSender
+	splitter *splitter
+	bSender  *batchSender
+
+	shutdownOnce sync.Once
 }
 
-func newBaseExporter(cfg config.Exporter, set component.ExporterCreateSettings, bs *baseSettings) *baseExporter {
+func newBaseExporter(cfg config.Exporter, set component.ExporterCreateSettings, bs *baseSettings, dataType config.DataType) *baseExporter {
 	be := &baseExporter{
 		Component: componenthelper.New(bs.componentOptions...),
 	}
@@ -179,8 +237,23 @@ func newBaseExporter(cfg config.Exporter, set component.ExporterCreateSettings,
 		ExporterID:             cfg.ID(),
 		ExporterCreateSettings: set,
 	})
-	be.qrSender = newQueuedRetrySender(cfg.ID().String(), bs.QueueSettings, bs.RetrySettings, &timeoutSender{cfg: bs.TimeoutSettings}, set.Logger)
+	var nextSender requestSender = &timeoutSender{cfg: bs.TimeoutSettings}
+	if bs.CircuitBreakerSettings.Enabled {
+		be.cbSender = newCircuitBreakerSender(cfg.ID().String(), bs.CircuitBreakerSettings, nextSender, set.Logger)
+		nextSender = be.cbSender
+	}
+	if bs.DeadLetterSettings.Exporter != "" {
+		be.dlSender = newDeadLetterSender(cfg.ID().String(), bs.DeadLetterSettings, dataType, set.Logger)
+	}
+	if bs.SplitSettings.Enabled {
+		be.splitter = newSplitter(cfg.ID().String(), bs.SplitSettings)
+	}
+	be.qrSender = newQueuedRetrySender(cfg.ID().String(), bs.QueueSettings, bs.RetrySettings, nextSender, be.dlSender, be.splitter, set.Logger)
 	be.sender = be.qrSender
+	if bs.BatchSettings.Enabled {
+		be.bSender = newBatchSender(bs.BatchSettings, newRequestBatch(dataType), be.qrSender, set.Logger)
+		be.sender = be.bSender
+	}
 
 	return be
 }
@@ -198,16 +271,71 @@ func (be *baseExporter) Start(ctx context.Context, host component.Host) error {
 		return err
 	}
 
+	if be.cbSender != nil {
+		if err := be.cbSender.start(); err != nil {
+			return err
+		}
+	}
+
+	if be.dlSender != nil {
+		if err := be.dlSender.start(host); err != nil {
+			return err
+		}
+	}
+
+	if be.splitter != nil {
+		if err := be.splitter.start(); err != nil {
+			return err
+		}
+	}
+
 	// If no error then start the queuedRetrySender.
-	return be.qrSender.start()
+	if err := be.qrSender.start(); err != nil {
+		return err
+	}
+
+	if be.bSender != nil {
+		be.bSender.start()
+	}
+	return nil
+}
+
+// CircuitBreakerState implements CircuitBreakerReporter.
+func (be *baseExporter) CircuitBreakerState() CircuitBreakerState {
+	if be.cbSender == nil {
+		return CircuitBreakerClosed
+	}
+	return be.cbSender.reportedState()
 }
 
 // Shutdown all senders and exporter and is invoked during service shutdown.
+// Only the first call shuts the exporter down, the later ones do nothing.
 func (be *baseExporter) Shutdown(ctx context.Context) error {
-	// First shutdown the queued retry sender
-	be.qrSender.shutdown()
-	// Last shutdown the wrapped exporter itself.
-	return be.Component.Shutdown(ctx)
+	var err error
+	be.shutdownOnce.Do(func() {
+		// First send the current batch to the queued retry sender, then shut it down.
+		if be.bSender != nil {
+			be.bSender.shutdown()
+		}
+		be.qrSender.shutdown()
+		if be.cbSender != nil {
+			be.cbSender.shutdown()
+		}
+		// Last shutdown the wrapped exporter itself.
+		err = be.Component.Shutdown(ctx)
+	})
+	return err
+}
+
+// newRequestBatch returns an empty requestBatch for the given data type.
+func newRequestBatch(dataType config.DataType) requestBatch {
+	switch dataType {
+	case config.MetricsDataType:
+		return newMetricsBatch()
+	case config.LogsDataType:
+		return newLogsBatch()
+	}
+	return newTracesBatch()
 }
 
 // timeoutSender is a request sender that adds a `timeout` to every request that passes this sender.
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"fmt"
+
+	"go.opencensus.io/metric"
+	"go.opencensus.io/metric/metricdata"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+const (
+	// deadLetterExporterAttribute is the resource attribute set to the ID of the exporter that failed to send the data.
+	deadLetterExporterAttribute = "otelcol.dead_letter.exporter"
+	// deadLetterReasonAttribute is the resource attribute set to the error that caused the data to be dead-lettered.
+	deadLetterReasonAttribute = "otelcol.dead_letter.reason"
+)
+
+var deadLetteredItems, _ = r.AddInt64Cumulative(
+	obsmetrics.ExporterKey+"/dead_lettered_items",
+	metric.WithDescription("Number of spans, metric points or log records sent to the dead_letter exporter"),
+	metric.WithLabelKeys(obsmetrics.ExporterKey),
+	metric.WithUnit(metricdata.UnitDimensionless))
+
+// DeadLetterSettings defines configuration for preserving data that the exporter gives up on, either because
+// the error is permanent or because the retries are exhausted, by sending it to another exporter.
+type DeadLetterSettings struct {
+	// Exporter is the ID of the exporter, e.g. "file/dead_letter", that receives the failed data annotated with
+	// the original exporter ID and the failure reason as resource attributes. The service creates it for the
+	// data types of the exporters using it, it does not need to be used in a pipeline. Empty disables
+	// dead-lettering.
+	Exporter string `mapstructure:"exporter"`
+}
+
+// DeadLetterExporterID returns the ID of the dead_letter exporter, and false if dead-lettering is disabled.
+// The service uses it through the exporter configurations embedding DeadLetterSettings, to create the
+// dead_letter exporter for their data types.
+func (cfg DeadLetterSettings) DeadLetterExporterID() (config.ComponentID, bool) {
+	id, err := config.NewIDFromString(cfg.Exporter)
+	if cfg.Exporter == "" || err != nil {
+		return config.ComponentID{}, false
+	}
+	return id, true
+}
+
+// Validate checks if the DeadLetterSettings configuration is valid.
+func (cfg *DeadLetterSettings) Validate() error {
+	if cfg.Exporter == "" {
+		return nil
+	}
+	if _, err := config.NewIDFromString(cfg.Exporter); err != nil {
+		return fmt.Errorf("dead_letter.exporter: %w", err)
+	}
+	return nil
+}
+
+// deadLetterSender routes requests that could not be exported to the dead_letter exporter.
+type deadLetterSender struct {
+	fullName string
+	cfg      DeadLetterSettings
+	dataType config.DataType
+	exporter component.Exporter
+	items    *metric.Int64CumulativeEntry
+	logger   *zap.Logger
+}
+
+func newDeadLetterSender(fullName string, cfg DeadLetterSettings, dataType config.DataType, logger *zap.Logger) *deadLetterSender {
+	return &deadLetterSender{
+		fullName: fullName,
+		cfg:      cfg,
+		dataType: dataType,
+		logger:   logger,
+	}
+}
+
+// start looks up the dead_letter exporter, it must be invoked after all the exporters are created.
+func (dls *deadLetterSender) start(host component.Host) error {
+	id, err := config.NewIDFromString(dls.cfg.Exporter)
+	if err != nil {
+		return fmt.Errorf("dead_letter.exporter: %w", err)
+	}
+	if id.String() == dls.fullName {
+		return fmt.Errorf("exporter %q cannot be its own dead_letter exporter", dls.fullName)
+	}
+	exp, ok := host.GetExporters()[dls.dataType][id]
+	if !ok {
+		return fmt.Errorf("dead_letter exporter %q is not configured or does not support %s", id, dls.dataType)
+	}
+	dls.exporter = exp
+	dls.items, err = deadLetteredItems.GetEntry(metricdata.NewLabelValue(dls.fullName))
+	if err != nil {
+		return fmt.Errorf("failed to create dead_letter metric: %v", err)
+	}
+	return nil
+}
+
+// send sends a copy of the request annotated with the reason of the failure to the dead_letter exporter.
+func (dls *deadLetterSender) send(req request, reason error) {
+	attrs := pdata.NewAttributeMap()
+	attrs.UpsertString(deadLetterExporterAttribute, dls.fullName)
+	attrs.UpsertString(deadLetterReasonAttribute, reason.Error())
+
+	// The request context may already be cancelled, e.g. if the retries were interrupted.
+	ctx := noCancellationContext{Context: req.context()}
+	if err := req.deadLetter(ctx, dls.exporter, attrs); err != nil {
+		dls.logger.Error(
+			"Sending to the dead_letter exporter failed. Dropping data.",
+			zap.String("dead_letter_exporter", dls.cfg.Exporter),
+			zap.Error(err),
+			zap.Int("dropped_items", req.count()),
+		)
+		return
+	}
+	dls.items.Inc(int64(req.count()))
+}
+
+// upsertAttributes adds all attributes from src to dest, overwriting existing ones.
+func upsertAttributes(dest pdata.AttributeMap, src pdata.AttributeMap) {
+	src.Range(func(k string, v pdata.AttributeValue) bool {
+		dest.Upsert(k, v)
+		return true
+	})
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenthelper"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// exportersHost is a component.Host that exposes the given exporters.
+type exportersHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (h *exportersHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return h.exporters
+}
+
+func TestDeadLetterSettingsValidate(t *testing.T) {
+	assert.NoError(t, (&DeadLetterSettings{}).Validate())
+	assert.NoError(t, (&DeadLetterSettings{Exporter: "file/dead_letter"}).Validate())
+	assert.Error(t, (&DeadLetterSettings{Exporter: "file/"}).Validate())
+}
+
+func TestDeadLetter_PermanentError(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	dlID := config.NewIDWithName("dead_letter", "traces")
+	dlCfg := config.NewExporterSettings(dlID)
+	dle, err := NewTracesExporter(&dlCfg, componenttest.NewNopExporterCreateSettings(), sink.ConsumeTraces)
+	require.NoError(t, err)
+	host := &exportersHost{
+		Host:      componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{config.TracesDataType: {dlID: dle}},
+	}
+
+	want := consumererror.Permanent(errors.New("bad data"))
+	te, err := NewTracesExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), newTraceDataPusher(want),
+		WithRetry(DefaultRetrySettings()),
+		WithDeadLetter(DeadLetterSettings{Exporter: dlID.String()}))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), host))
+	t.Cleanup(func() {
+		assert.NoError(t, te.Shutdown(context.Background()))
+	})
+
+	td := testdata.GenerateTracesTwoSpansSameResource()
+	assert.Equal(t, want, te.ConsumeTraces(context.Background(), td))
+
+	require.Len(t, sink.AllTraces(), 1)
+	got := sink.AllTraces()[0]
+	assert.Equal(t, 2, got.SpanCount())
+	attrs := got.ResourceSpans().At(0).Resource().Attributes()
+	exporterAttr, ok := attrs.Get(deadLetterExporterAttribute)
+	require.True(t, ok)
+	assert.Equal(t, defaultExporterCfg.ID().String(), exporterAttr.StringVal())
+	reasonAttr, ok := attrs.Get(deadLetterReasonAttribute)
+	require.True(t, ok)
+	assert.Equal(t, want.Error(), reasonAttr.StringVal())
+
+	// The original data is not modified.
+	_, ok = td.ResourceSpans().At(0).Resource().Attributes().Get(deadLetterExporterAttribute)
+	assert.False(t, ok)
+	checkValueForProducer(t, defaultExporterTags, int64(2), "exporter/dead_lettered_items")
+}
+
+func TestDeadLetter_RetriesExhausted(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	dlID := config.NewIDWithName("dead_letter", "logs")
+	dlCfg := config.NewExporterSettings(dlID)
+	dle, err := NewLogsExporter(&dlCfg, componenttest.NewNopExporterCreateSettings(), sink.ConsumeLogs)
+	require.NoError(t, err)
+	host := &exportersHost{
+		Host:      componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{config.LogsDataType: {dlID: dle}},
+	}
+
+	rCfg := DefaultRetrySettings()
+	rCfg.InitialInterval = time.Millisecond
+	rCfg.MaxElapsedTime = 10 * time.Millisecond
+	le, err := NewLogsExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), newPushLogsData(errors.New("transient error")),
+		WithRetry(rCfg),
+		WithDeadLetter(DeadLetterSettings{Exporter: dlID.String()}))
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), host))
+	t.Cleanup(func() {
+		assert.NoError(t, le.Shutdown(context.Background()))
+	})
+
+	assert.Error(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord()))
+	require.Len(t, sink.AllLogs(), 1)
+	reasonAttr, ok := sink.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().Get(deadLetterReasonAttribute)
+	require.True(t, ok)
+	assert.Contains(t, reasonAttr.StringVal(), "max elapsed time expired")
+}
+
+func TestDeadLetter_StartErrors(t *testing.T) {
+	dlID := config.NewIDWithName("dead_letter", "traces")
+	host := &exportersHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.TracesDataType: {dlID: componenthelper.New()},
+		},
+	}
+	tests := []struct {
+		name     string
+		exporter string
+		errMsg   string
+	}{
+		{
+			name:     "missing",
+			exporter: "dead_letter/other",
+			errMsg:   `dead_letter exporter "dead_letter/other" is not configured or does not support metrics`,
+		},
+		{
+			name:     "self",
+			exporter: defaultExporterCfg.ID().String(),
+			errMsg:   `exporter "test" cannot be its own dead_letter exporter`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			me, err := NewMetricsExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), newPushMetricsData(nil),
+				WithDeadLetter(DeadLetterSettings{Exporter: tt.exporter}))
+			require.NoError(t, err)
+			assert.EqualError(t, me.Start(context.Background(), host), tt.errMsg)
+		})
+	}
+}
+
+func TestDeadLetter_UnsupportedExporter(t *testing.T) {
+	req := newMetricsRequest(context.Background(), testdata.GenerateMetricsOneMetric(), nil)
+	err := req.deadLetter(context.Background(), componenthelper.New(), pdata.NewAttributeMap())
+	assert.EqualError(t, err, "exporter does not support metrics")
+}
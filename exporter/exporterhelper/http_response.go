@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// headerRetryAfter is the HTTP header a server uses to tell the client how long to wait before retrying.
+const headerRetryAfter = "Retry-After"
+
+// NewHTTPResponseError classifies a non-2xx HTTP response returned by a backend and wraps err,
+// which describes the failure, so that the retry sender handles it as the OTLP/HTTP specification
+// recommends:
+//
+//   - 429 Too Many Requests, 502 Bad Gateway, 503 Service Unavailable and 504 Gateway Timeout are
+//     retryable and reported as throttling; a Retry-After header, given either in seconds or as an
+//     HTTP-date, delays the next attempt accordingly, otherwise the default backoff applies.
+//   - 408 Request Timeout and all other 5xx responses are retryable using the default backoff.
+//   - 413 Payload Too Large is reported with NewPayloadTooLargeError, so that the request is split
+//     and resent in parts if possible, and dropped as a permanent error otherwise.
+//   - All other responses, including the remaining 4xx, are reported as consumererror.Permanent
+//     since retrying an identical request cannot succeed.
+//
+// See https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#failures-1
+func NewHTTPResponseError(resp *http.Response, err error) error {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		// Fallback to 0 if the Retry-After header is missing or invalid, the retry sender
+		// then waits for the default backoff.
+		delay, _ := retryAfter(resp.Header.Get(headerRetryAfter), time.Now())
+		return NewThrottleRetry(err, delay)
+	case http.StatusRequestTimeout:
+		return err
+	case http.StatusRequestEntityTooLarge:
+		return NewPayloadTooLargeError(consumererror.Permanent(err))
+	}
+	if resp.StatusCode >= 500 && resp.StatusCode <= 599 {
+		return err
+	}
+	return consumererror.Permanent(err)
+}
+
+// retryAfter parses the value of a Retry-After header, which is either a number of seconds
+// or an HTTP-date. Returns false if the value is missing or cannot be parsed.
+func retryAfter(val string, now time.Time) (time.Duration, bool) {
+	if val == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(val); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(val)
+	if err != nil {
+		return 0, false
+	}
+	if delay := date.Sub(now); delay > 0 {
+		return delay, true
+	}
+	// The date is already in the past, retry right away.
+	return 0, true
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+func TestNewHTTPResponseError(t *testing.T) {
+	errResp := errors.New("request failed")
+	tests := []struct {
+		name       string
+		statusCode int
+		retryAfter string
+		permanent  bool
+		throttle   bool
+		tooLarge   bool
+		delay      time.Duration
+	}{
+		{name: "bad request", statusCode: http.StatusBadRequest, permanent: true},
+		{name: "unauthorized", statusCode: http.StatusUnauthorized, permanent: true},
+		{name: "not found", statusCode: http.StatusNotFound, permanent: true},
+		{name: "payload too large", statusCode: http.StatusRequestEntityTooLarge, permanent: true, tooLarge: true},
+		{name: "request timeout", statusCode: http.StatusRequestTimeout},
+		{name: "internal server error", statusCode: http.StatusInternalServerError},
+		{name: "too many requests", statusCode: http.StatusTooManyRequests, throttle: true},
+		{name: "too many requests with seconds", statusCode: http.StatusTooManyRequests, retryAfter: "30", throttle: true, delay: 30 * time.Second},
+		{name: "unavailable with seconds", statusCode: http.StatusServiceUnavailable, retryAfter: "5", throttle: true, delay: 5 * time.Second},
+		{name: "bad gateway with invalid header", statusCode: http.StatusBadGateway, retryAfter: "soon", throttle: true},
+		{name: "gateway timeout with past date", statusCode: http.StatusGatewayTimeout, retryAfter: "Wed, 21 Oct 2015 07:28:00 GMT", throttle: true},
+		{name: "bad request ignores retry after", statusCode: http.StatusBadRequest, retryAfter: "30", permanent: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.statusCode, Header: http.Header{}}
+			if tt.retryAfter != "" {
+				resp.Header.Set("Retry-After", tt.retryAfter)
+			}
+			err := NewHTTPResponseError(resp, errResp)
+			assert.ErrorIs(t, err, errResp)
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+			throttleErr := throttleRetry{}
+			assert.Equal(t, tt.throttle, errors.As(err, &throttleErr))
+			if tt.throttle {
+				assert.Equal(t, tt.delay, throttleErr.delay)
+			}
+			assert.Equal(t, tt.tooLarge, errors.As(err, &payloadTooLarge{}))
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2021, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	delay, ok := retryAfter("120", now)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, delay)
+
+	delay, ok = retryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, 90*time.Second, delay)
+
+	delay, ok = retryAfter(now.Add(-time.Hour).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), delay)
+
+	_, ok = retryAfter("", now)
+	assert.False(t, ok)
+	_, ok = retryAfter("-1", now)
+	assert.False(t, ok)
+	_, ok = retryAfter("tomorrow", now)
+	assert.False(t, ok)
+}
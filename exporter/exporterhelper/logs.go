@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumerhelper"
+	"go.opentelemetry.io/collector/internal/pdatasplit"
 	"go.opentelemetry.io/collector/model/pdata"
 )
 
@@ -56,6 +57,63 @@ func (req *logsRequest) count() int {
 	return req.ld.LogRecordCount()
 }
 
+func (req *logsRequest) split(size int) (request, request) {
+	ld := req.ld.Clone()
+	first := pdatasplit.Logs(size, ld)
+	return newLogsRequest(req.ctx, first, req.pusher), newLogsRequest(req.ctx, ld, req.pusher)
+}
+
+func (req *logsRequest) deadLetter(ctx context.Context, exp component.Exporter, attrs pdata.AttributeMap) error {
+	next, ok := exp.(consumer.Logs)
+	if !ok {
+		return errors.New("exporter does not support logs")
+	}
+	ld := req.ld.Clone()
+	rss := ld.ResourceLogs()
+	for i := 0; i < rss.Len(); i++ {
+		upsertAttributes(rss.At(i).Resource().Attributes(), attrs)
+	}
+	return next.ConsumeLogs(ctx, ld)
+}
+
+// logsBatch accumulates the data of logs requests.
+type logsBatch struct {
+	ld       pdata.Logs
+	logCount int
+	pusher   consumerhelper.ConsumeLogsFunc
+}
+
+func newLogsBatch() requestBatch {
+	return &logsBatch{ld: pdata.NewLogs()}
+}
+
+func (b *logsBatch) add(req request) {
+	r := req.(*logsRequest)
+	newCount := r.ld.LogRecordCount()
+	if newCount == 0 {
+		return
+	}
+	b.pusher = r.pusher
+	b.logCount += newCount
+	// Read-only data shared with other consumers is copied before being moved.
+	r.ld.Mutable().ResourceLogs().MoveAndAppendTo(b.ld.ResourceLogs())
+}
+
+func (b *logsBatch) count() int {
+	return b.logCount
+}
+
+func (b *logsBatch) take(ctx context.Context, maxSize int) request {
+	if maxSize > 0 && b.logCount > maxSize {
+		b.logCount -= maxSize
+		return newLogsRequest(ctx, pdatasplit.Logs(maxSize, b.ld), b.pusher)
+	}
+	ld := b.ld
+	b.ld = pdata.NewLogs()
+	b.logCount = 0
+	return newLogsRequest(ctx, ld, b.pusher)
+}
+
 type logsExporter struct {
 	*baseExporter
 	consumer.Logs
@@ -81,7 +139,7 @@ func NewLogsExporter(
 	}
 
 	bs := fromOptions(options...)
-	be := newBaseExporter(cfg, set, bs)
+	be := newBaseExporter(cfg, set, bs, config.LogsDataType)
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &logsExporterWithObservability{
 			obsrep:     be.obsrep,
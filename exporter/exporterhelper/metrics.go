@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumerhelper"
+	"go.opentelemetry.io/collector/internal/pdatasplit"
 	"go.opentelemetry.io/collector/model/pdata"
 )
 
@@ -56,6 +57,63 @@ func (req *metricsRequest) count() int {
 	return req.md.DataPointCount()
 }
 
+func (req *metricsRequest) split(size int) (request, request) {
+	md := req.md.Clone()
+	first := pdatasplit.Metrics(size, md)
+	return newMetricsRequest(req.ctx, first, req.pusher), newMetricsRequest(req.ctx, md, req.pusher)
+}
+
+func (req *metricsRequest) deadLetter(ctx context.Context, exp component.Exporter, attrs pdata.AttributeMap) error {
+	next, ok := exp.(consumer.Metrics)
+	if !ok {
+		return errors.New("exporter does not support metrics")
+	}
+	md := req.md.Clone()
+	rss := md.ResourceMetrics()
+	for i := 0; i < rss.Len(); i++ {
+		upsertAttributes(rss.At(i).Resource().Attributes(), attrs)
+	}
+	return next.ConsumeMetrics(ctx, md)
+}
+
+// metricsBatch accumulates the data of metrics requests.
+type metricsBatch struct {
+	md             pdata.Metrics
+	dataPointCount int
+	pusher         consumerhelper.ConsumeMetricsFunc
+}
+
+func newMetricsBatch() requestBatch {
+	return &metricsBatch{md: pdata.NewMetrics()}
+}
+
+func (b *metricsBatch) add(req request) {
+	r := req.(*metricsRequest)
+	newCount := r.md.DataPointCount()
+	if newCount == 0 {
+		return
+	}
+	b.pusher = r.pusher
+	b.dataPointCount += newCount
+	// Read-only data shared with other consumers is copied before being moved.
+	r.md.Mutable().ResourceMetrics().MoveAndAppendTo(b.md.ResourceMetrics())
+}
+
+func (b *metricsBatch) count() int {
+	return b.dataPointCount
+}
+
+func (b *metricsBatch) take(ctx context.Context, maxSize int) request {
+	if maxSize > 0 && b.dataPointCount > maxSize {
+		b.dataPointCount -= maxSize
+		return newMetricsRequest(ctx, pdatasplit.Metrics(maxSize, b.md), b.pusher)
+	}
+	md := b.md
+	b.md = pdata.NewMetrics()
+	b.dataPointCount = 0
+	return newMetricsRequest(ctx, md, b.pusher)
+}
+
 type metricsExporter struct {
 	*baseExporter
 	consumer.Metrics
@@ -81,7 +139,7 @@ func NewMetricsExporter(
 	}
 
 	bs := fromOptions(options...)
-	be := newBaseExporter(cfg, set, bs)
+	be := newBaseExporter(cfg, set, bs, config.MetricsDataType)
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &metricsSenderWithObservability{
 			obsrep:     be.obsrep,
@@ -127,7 +127,7 @@ func createSampledLogger(logger *zap.Logger) *zap.Logger {
 	return logger.WithOptions(opts)
 }
 
-func newQueuedRetrySender(fullName string, qCfg QueueSettings, rCfg RetrySettings, nextSender requestSender, logger *zap.Logger) *queuedRetrySender {
+func newQueuedRetrySender(fullName string, qCfg QueueSettings, rCfg RetrySettings, nextSender requestSender, dlSender *deadLetterSender, splitter *splitter, logger *zap.Logger) *queuedRetrySender {
 	retryStopCh := make(chan struct{})
 	sampledLogger := createSampledLogger(logger)
 	traceAttr := attribute.String(obsmetrics.ExporterKey, fullName)
@@ -138,6 +138,8 @@ func newQueuedRetrySender(fullName string, qCfg QueueSettings, rCfg RetrySetting
 			traceAttribute: traceAttr,
 			cfg:            rCfg,
 			nextSender:     nextSender,
+			deadLetter:     dlSender,
+			splitter:       splitter,
 			stopCh:         retryStopCh,
 			logger:         sampledLogger,
 		},
@@ -242,31 +244,51 @@ type retrySender struct {
 	traceAttribute attribute.KeyValue
 	cfg            RetrySettings
 	nextSender     requestSender
+	deadLetter     *deadLetterSender
+	splitter       *splitter
 	stopCh         chan struct{}
 	logger         *zap.Logger
 }
 
 // send implements the requestSender interface
 func (rs *retrySender) send(req request) error {
+	// The parts of a split request share the retry budget of the request.
+	var deadline time.Time
+	if rs.cfg.Enabled && rs.cfg.MaxElapsedTime > 0 {
+		deadline = time.Now().Add(rs.cfg.MaxElapsedTime)
+	}
+	return rs.sendWithDeadline(req, deadline)
+}
+
+// sendWithDeadline sends the request, and retries it until the deadline if it is not zero.
+func (rs *retrySender) sendWithDeadline(req request, deadline time.Time) error {
 	if !rs.cfg.Enabled {
 		err := rs.nextSender.send(req)
+		if rs.splitter.shouldSplit(req, err) {
+			return rs.sendSplit(req, err, deadline)
+		}
+		err = rs.splitter.permanentIfTooLarge(err)
 		if err != nil {
 			rs.logger.Error(
 				"Exporting failed. Try enabling retry_on_failure config option.",
 				zap.Error(err),
 			)
+			if consumererror.IsPermanent(err) {
+				rs.sendToDeadLetter(req, err)
+			}
 		}
 		return err
 	}
 
 	// Do not use NewExponentialBackOff since it calls Reset and the code here must
 	// call Reset after changing the InitialInterval (this saves an unnecessary call to Now).
+	// MaxElapsedTime is enforced by the deadline instead, it is shared with the other parts of a split request.
 	expBackoff := backoff.ExponentialBackOff{
 		InitialInterval:     rs.cfg.InitialInterval,
 		RandomizationFactor: backoff.DefaultRandomizationFactor,
 		Multiplier:          backoff.DefaultMultiplier,
 		MaxInterval:         rs.cfg.MaxInterval,
-		MaxElapsedTime:      rs.cfg.MaxElapsedTime,
+		MaxElapsedTime:      0,
 		Stop:                backoff.Stop,
 		Clock:               backoff.SystemClock,
 	}
@@ -283,6 +305,12 @@ func (rs *retrySender) send(req request) error {
 			return nil
 		}
 
+		// Send the request in parts if the backend rejected it as too large, every part is retried on its own.
+		if rs.splitter.shouldSplit(req, err) {
+			return rs.sendSplit(req, err, deadline)
+		}
+		err = rs.splitter.permanentIfTooLarge(err)
+
 		// Immediately drop data on permanent errors.
 		if consumererror.IsPermanent(err) {
 			rs.logger.Error(
@@ -290,6 +318,7 @@ func (rs *retrySender) send(req request) error {
 				zap.Error(err),
 				zap.Int("dropped_items", req.count()),
 			)
+			rs.sendToDeadLetter(req, err)
 			return err
 		}
 
@@ -298,7 +327,7 @@ func (rs *retrySender) send(req request) error {
 		req = req.onError(err)
 
 		backoffDelay := expBackoff.NextBackOff()
-		if backoffDelay == backoff.Stop {
+		if backoffDelay == backoff.Stop || (!deadline.IsZero() && time.Now().Add(backoffDelay).After(deadline)) {
 			// throw away the batch
 			err = fmt.Errorf("max elapsed time expired %w", err)
 			rs.logger.Error(
@@ -306,6 +335,7 @@ func (rs *retrySender) send(req request) error {
 				zap.Error(err),
 				zap.Int("dropped_items", req.count()),
 			)
+			rs.sendToDeadLetter(req, err)
 			return err
 		}
 
@@ -340,6 +370,35 @@ func (rs *retrySender) send(req request) error {
 	}
 }
 
+// sendSplit splits a request rejected as too large in half and sends both parts, which may be split again,
+// until the deadline of the request.
+func (rs *retrySender) sendSplit(req request, err error, deadline time.Time) error {
+	first, second := rs.splitter.split(req)
+	trace.SpanFromContext(req.context()).AddEvent(
+		"Request too large. Splitting the request.",
+		trace.WithAttributes(rs.traceAttribute, attribute.String("error", err.Error())))
+	rs.logger.Debug(
+		"Request too large. Splitting the request.",
+		zap.Error(err),
+		zap.Int("items", req.count()),
+	)
+	var errs []error
+	if err := rs.sendWithDeadline(first, deadline); err != nil {
+		errs = append(errs, err)
+	}
+	if err := rs.sendWithDeadline(second, deadline); err != nil {
+		errs = append(errs, err)
+	}
+	return consumererror.Combine(errs)
+}
+
+// sendToDeadLetter hands a request that is not going to be retried anymore to the dead_letter exporter, if any.
+func (rs *retrySender) sendToDeadLetter(req request, err error) {
+	if rs.deadLetter != nil {
+		rs.deadLetter.send(req, err)
+	}
+}
+
 // max returns the larger of x or y.
 func max(x, y time.Duration) time.Duration {
 	if x < y {
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opencensus.io/metric"
+	"go.opencensus.io/metric/metricdata"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+)
+
+var splitRequests, _ = r.AddInt64Cumulative(
+	obsmetrics.ExporterKey+"/split_requests",
+	metric.WithDescription("Number of requests split in half because the backend rejected them as too large"),
+	metric.WithLabelKeys(obsmetrics.ExporterKey),
+	metric.WithUnit(metricdata.UnitDimensionless))
+
+// SplitSettings defines configuration for splitting the requests that a backend rejects as too large.
+type SplitSettings struct {
+	// Enabled indicates whether to split the requests rejected as too large in half and resend the parts.
+	Enabled bool `mapstructure:"enabled"`
+	// MinSizeItems is the number of spans, metric data points or log records below which a request rejected
+	// as too large is not split anymore, and is handled as any other failed request.
+	MinSizeItems int `mapstructure:"min_size_items"`
+}
+
+// DefaultSplitSettings returns the default settings for SplitSettings.
+func DefaultSplitSettings() SplitSettings {
+	return SplitSettings{
+		Enabled:      true,
+		MinSizeItems: 1,
+	}
+}
+
+// Validate checks if the SplitSettings configuration is valid.
+func (cfg *SplitSettings) Validate() error {
+	if cfg.Enabled && cfg.MinSizeItems < 1 {
+		return errors.New("split.min_size_items must be at least 1")
+	}
+	return nil
+}
+
+// payloadTooLarge is an error returned by the exporters when the backend rejects a request because of its size.
+type payloadTooLarge struct {
+	err error
+}
+
+func (p payloadTooLarge) Error() string {
+	return "Payload too large, error: " + p.err.Error()
+}
+
+func (p payloadTooLarge) Unwrap() error {
+	return p.err
+}
+
+// NewPayloadTooLargeError wraps err, returned by a backend that rejected a request because of its size,
+// e.g. HTTP 413 or gRPC ResourceExhausted, so that the request is split and resent in parts. If splitting
+// is enabled and the request cannot be split anymore, the request is dropped as a permanent error. If
+// splitting is disabled, err is handled as is, hence it may be retried.
+func NewPayloadTooLargeError(err error) error {
+	return payloadTooLarge{err: err}
+}
+
+// splitter decides which failed requests are split and records how often it happens.
+type splitter struct {
+	fullName string
+	cfg      SplitSettings
+	requests *metric.Int64CumulativeEntry
+}
+
+func newSplitter(fullName string, cfg SplitSettings) *splitter {
+	return &splitter{
+		fullName: fullName,
+		cfg:      cfg,
+	}
+}
+
+// start is invoked during service startup.
+func (s *splitter) start() error {
+	var err error
+	s.requests, err = splitRequests.GetEntry(metricdata.NewLabelValue(s.fullName))
+	if err != nil {
+		return fmt.Errorf("failed to create split metric: %v", err)
+	}
+	return nil
+}
+
+// shouldSplit returns true if the request failed because it is too large and is big enough to be split.
+func (s *splitter) shouldSplit(req request, err error) bool {
+	if s == nil || !errors.As(err, &payloadTooLarge{}) {
+		return false
+	}
+	count := req.count()
+	return count > 1 && count > s.cfg.MinSizeItems
+}
+
+// permanentIfTooLarge makes the error of a request rejected as too large, which shouldSplit declined to
+// split, permanent: resending the request as is fails again.
+func (s *splitter) permanentIfTooLarge(err error) error {
+	if s == nil || !errors.As(err, &payloadTooLarge{}) || consumererror.IsPermanent(err) {
+		return err
+	}
+	return consumererror.Permanent(err)
+}
+
+// split splits the request in half and records it.
+func (s *splitter) split(req request) (request, request) {
+	s.requests.Inc(1)
+	return req.split(req.count() / 2)
+}
@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestSplitSettingsValidate(t *testing.T) {
+	cfg := DefaultSplitSettings()
+	assert.NoError(t, cfg.Validate())
+	cfg.MinSizeItems = 0
+	assert.Error(t, cfg.Validate())
+	cfg.Enabled = false
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestSplit_PayloadTooLarge(t *testing.T) {
+	var mu sync.Mutex
+	var sizes []int
+	pusher := func(_ context.Context, ld pdata.Logs) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if ld.LogRecordCount() > 2 {
+			return NewPayloadTooLargeError(consumererror.Permanent(errors.New("payload too large")))
+		}
+		sizes = append(sizes, ld.LogRecordCount())
+		return nil
+	}
+	cfg := config.NewExporterSettings(config.NewIDWithName("test", "split"))
+	le, err := NewLogsExporter(&cfg, componenttest.NewNopExporterCreateSettings(), pusher,
+		WithRetry(DefaultRetrySettings()),
+		WithSplit(DefaultSplitSettings()))
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, le.Shutdown(context.Background()))
+	})
+
+	ld := testdata.GenerateLogsManyLogRecordsSameResource(7)
+	require.NoError(t, le.ConsumeLogs(context.Background(), ld))
+	// 7 is split into 3 and 4, then 3 into 1 and 2, and 4 into 2 and 2.
+	assert.Equal(t, []int{1, 2, 2, 2}, sizes)
+	// The original data is not modified.
+	assert.Equal(t, 7, ld.LogRecordCount())
+	checkValueForProducer(t, []tag.Tag{{Key: exporterTag, Value: "test/split"}}, int64(3), "exporter/split_requests")
+}
+
+func TestSplit_MinSizeItems(t *testing.T) {
+	want := NewPayloadTooLargeError(consumererror.Permanent(errors.New("payload too large")))
+	var mu sync.Mutex
+	var sizes []int
+	pusher := func(_ context.Context, td pdata.Traces) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sizes = append(sizes, td.SpanCount())
+		return want
+	}
+	sCfg := DefaultSplitSettings()
+	sCfg.MinSizeItems = 3
+	te, err := NewTracesExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), pusher,
+		WithRetry(DefaultRetrySettings()),
+		WithSplit(sCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, te.Shutdown(context.Background()))
+	})
+
+	err = te.ConsumeTraces(context.Background(), testdata.GenerateTracesManySpansSameResource(4))
+	// Parts of 2 spans are not split anymore and dropped as permanent errors.
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, []int{4, 2, 2}, sizes)
+}
+
+func TestSplit_NotSplittableIsPermanent(t *testing.T) {
+	var calls int
+	te, err := NewTracesExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), func(context.Context, pdata.Traces) error {
+		calls++
+		return NewPayloadTooLargeError(errors.New("payload too large"))
+	}, WithRetry(DefaultRetrySettings()), WithSplit(DefaultSplitSettings()))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, te.Shutdown(context.Background()))
+	})
+
+	// A single span cannot be split, resending it would fail again.
+	err = te.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan())
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, 1, calls)
+}
+
+func TestSplit_PartsShareRetryDeadline(t *testing.T) {
+	rCfg := DefaultRetrySettings()
+	rCfg.InitialInterval = 10 * time.Millisecond
+	rCfg.MaxInterval = 10 * time.Millisecond
+	rCfg.MaxElapsedTime = 200 * time.Millisecond
+	le, err := NewLogsExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), func(_ context.Context, ld pdata.Logs) error {
+		if ld.LogRecordCount() > 1 {
+			return NewPayloadTooLargeError(errors.New("payload too large"))
+		}
+		return errors.New("transient error")
+	}, WithRetry(rCfg), WithSplit(DefaultSplitSettings()))
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, le.Shutdown(context.Background()))
+	})
+
+	start := time.Now()
+	assert.Error(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogsManyLogRecordsSameResource(4)))
+	// Every part retrying for the whole max_elapsed_time would take 4 times longer.
+	assert.Less(t, int64(time.Since(start)), int64(2*rCfg.MaxElapsedTime))
+}
+
+func TestSplit_Disabled(t *testing.T) {
+	want := NewPayloadTooLargeError(consumererror.Permanent(errors.New("payload too large")))
+	var calls int
+	me, err := NewMetricsExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), func(context.Context, pdata.Metrics) error {
+		calls++
+		return want
+	}, WithRetry(DefaultRetrySettings()))
+	require.NoError(t, err)
+	require.NoError(t, me.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, me.Shutdown(context.Background()))
+	})
+
+	assert.Equal(t, want, me.ConsumeMetrics(context.Background(), testdata.GenerateMetricsTwoMetrics()))
+	assert.Equal(t, 1, calls)
+}
+
+func TestRequestSplit(t *testing.T) {
+	td := testdata.GenerateTracesManySpansSameResource(5)
+	first, second := newTracesRequest(context.Background(), td, nil).split(2)
+	assert.Equal(t, 2, first.count())
+	assert.Equal(t, 3, second.count())
+	assert.Equal(t, 5, td.SpanCount())
+
+	md := testdata.GenerateMetricsManyMetricsSameResource(5)
+	first, second = newMetricsRequest(context.Background(), md, nil).split(md.DataPointCount() / 2)
+	assert.Equal(t, md.DataPointCount()/2, first.count())
+	assert.Equal(t, md.DataPointCount()-md.DataPointCount()/2, second.count())
+
+	ld := testdata.GenerateLogsManyLogRecordsSameResource(5)
+	first, second = newLogsRequest(context.Background(), ld, nil).split(1)
+	assert.Equal(t, 1, first.count())
+	assert.Equal(t, 4, second.count())
+	assert.Equal(t, 5, ld.LogRecordCount())
+}
@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumerhelper"
+	"go.opentelemetry.io/collector/internal/pdatasplit"
 	"go.opentelemetry.io/collector/model/pdata"
 )
 
@@ -56,6 +57,63 @@ func (req *tracesRequest) count() int {
 	return req.td.SpanCount()
 }
 
+func (req *tracesRequest) split(size int) (request, request) {
+	td := req.td.Clone()
+	first := pdatasplit.Traces(size, td)
+	return newTracesRequest(req.ctx, first, req.pusher), newTracesRequest(req.ctx, td, req.pusher)
+}
+
+func (req *tracesRequest) deadLetter(ctx context.Context, exp component.Exporter, attrs pdata.AttributeMap) error {
+	next, ok := exp.(consumer.Traces)
+	if !ok {
+		return errors.New("exporter does not support traces")
+	}
+	td := req.td.Clone()
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		upsertAttributes(rss.At(i).Resource().Attributes(), attrs)
+	}
+	return next.ConsumeTraces(ctx, td)
+}
+
+// tracesBatch accumulates the data of traces requests.
+type tracesBatch struct {
+	td        pdata.Traces
+	spanCount int
+	pusher    consumerhelper.ConsumeTracesFunc
+}
+
+func newTracesBatch() requestBatch {
+	return &tracesBatch{td: pdata.NewTraces()}
+}
+
+func (b *tracesBatch) add(req request) {
+	r := req.(*tracesRequest)
+	newCount := r.td.SpanCount()
+	if newCount == 0 {
+		return
+	}
+	b.pusher = r.pusher
+	b.spanCount += newCount
+	// Read-only data shared with other consumers is copied before being moved.
+	r.td.Mutable().ResourceSpans().MoveAndAppendTo(b.td.ResourceSpans())
+}
+
+func (b *tracesBatch) count() int {
+	return b.spanCount
+}
+
+func (b *tracesBatch) take(ctx context.Context, maxSize int) request {
+	if maxSize > 0 && b.spanCount > maxSize {
+		b.spanCount -= maxSize
+		return newTracesRequest(ctx, pdatasplit.Traces(maxSize, b.td), b.pusher)
+	}
+	td := b.td
+	b.td = pdata.NewTraces()
+	b.spanCount = 0
+	return newTracesRequest(ctx, td, b.pusher)
+}
+
 type traceExporter struct {
 	*baseExporter
 	consumer.Traces
@@ -82,7 +140,7 @@ func NewTracesExporter(
 	}
 
 	bs := fromOptions(options...)
-	be := newBaseExporter(cfg, set, bs)
+	be := newBaseExporter(cfg, set, bs, config.TracesDataType)
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &tracesExporterWithObservability{
 			obsrep:     be.obsrep,
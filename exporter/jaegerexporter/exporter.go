@@ -76,7 +76,7 @@ func newProtoGRPCSender(cfg *Config, logger *zap.Logger) *protoGRPCSender {
 	s := &protoGRPCSender{
 		name:                      cfg.ID().String(),
 		logger:                    logger,
-		metadata:                  metadata.New(cfg.GRPCClientSettings.Headers),
+		metadata:                  cfg.GRPCClientSettings.HeadersMetadata(),
 		waitForReady:              cfg.WaitForReady,
 		connStateReporterInterval: time.Second,
 		stopCh:                    make(chan struct{}),
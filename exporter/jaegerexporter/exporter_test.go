@@ -34,6 +34,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/model/pdata"
@@ -65,7 +66,7 @@ func TestNew(t *testing.T) {
 			config: Config{
 				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
 				GRPCClientSettings: configgrpc.GRPCClientSettings{
-					Headers:     map[string]string{"extra-header": "header-value"},
+					Headers:     map[string]configopaque.String{"extra-header": "header-value"},
 					Endpoint:    "foo.bar",
 					Compression: "",
 					Keepalive:   nil,
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/Shopify/sarama"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+var errProducerClosed = errors.New("kafka producer is closed")
+
+// asyncProducer pipelines the messages of concurrent requests through a
+// sarama.AsyncProducer, while still returning the delivery errors of each
+// request so that they can be retried by the exporterhelper.
+type asyncProducer struct {
+	producer sarama.AsyncProducer
+	// inFlight bounds the number of messages waiting for an acknowledgement.
+	inFlight chan struct{}
+	wg       sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+var _ sarama.SyncProducer = (*asyncProducer)(nil)
+
+// pendingMessages tracks the delivery of the messages of one request.
+type pendingMessages struct {
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+}
+
+func (p *pendingMessages) done(err error) {
+	if err != nil {
+		p.mu.Lock()
+		p.errs = append(p.errs, err)
+		p.mu.Unlock()
+	}
+	p.wg.Done()
+}
+
+func newAsyncProducer(producer sarama.AsyncProducer, maxInFlight int) *asyncProducer {
+	p := &asyncProducer{
+		producer: producer,
+		inFlight: make(chan struct{}, maxInFlight),
+	}
+	p.wg.Add(2)
+	go p.handleSuccesses()
+	go p.handleErrors()
+	return p
+}
+
+func (p *asyncProducer) handleSuccesses() {
+	defer p.wg.Done()
+	for msg := range p.producer.Successes() {
+		<-p.inFlight
+		msg.Metadata.(*pendingMessages).done(nil)
+	}
+}
+
+func (p *asyncProducer) handleErrors() {
+	defer p.wg.Done()
+	for perr := range p.producer.Errors() {
+		<-p.inFlight
+		perr.Msg.Metadata.(*pendingMessages).done(perr.Err)
+	}
+}
+
+// SendMessage produces the message and waits for its delivery.
+func (p *asyncProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	if err := p.SendMessages([]*sarama.ProducerMessage{msg}); err != nil {
+		return -1, -1, err
+	}
+	return msg.Partition, msg.Offset, nil
+}
+
+// SendMessages produces the messages and waits for all of them to be delivered.
+func (p *asyncProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return errProducerClosed
+	}
+
+	pending := &pendingMessages{}
+	pending.wg.Add(len(msgs))
+	for _, msg := range msgs {
+		msg.Metadata = pending
+		p.inFlight <- struct{}{}
+		p.producer.Input() <- msg
+	}
+	pending.wg.Wait()
+	return consumererror.Combine(pending.errs)
+}
+
+// Close flushes the buffered messages and waits for their delivery.
+func (p *asyncProducer) Close() error {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+
+	// AsyncClose lets the handlers drain the successes and errors, which
+	// are closed once all the buffered messages have been delivered.
+	p.producer.AsyncClose()
+	p.wg.Wait()
+	return nil
+}
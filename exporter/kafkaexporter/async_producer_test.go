@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMessages(n int) []*sarama.ProducerMessage {
+	msgs := make([]*sarama.ProducerMessage, n)
+	for i := range msgs {
+		msgs[i] = &sarama.ProducerMessage{Topic: "topic", Value: sarama.StringEncoder(fmt.Sprint(i))}
+	}
+	return msgs
+}
+
+func TestAsyncProducer_SendMessages(t *testing.T) {
+	c := sarama.NewConfig()
+	c.Producer.Return.Successes = true
+	mock := mocks.NewAsyncProducer(t, c)
+	for i := 0; i < 6; i++ {
+		mock.ExpectInputAndSucceed()
+	}
+
+	p := newAsyncProducer(mock, 2)
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, p.SendMessages(newTestMessages(3)))
+		}()
+	}
+	wg.Wait()
+	assert.NoError(t, p.Close())
+}
+
+func TestAsyncProducer_SendMessages_err(t *testing.T) {
+	c := sarama.NewConfig()
+	c.Producer.Return.Successes = true
+	mock := mocks.NewAsyncProducer(t, c)
+	expErr := fmt.Errorf("failed to send")
+	mock.ExpectInputAndSucceed()
+	mock.ExpectInputAndFail(expErr)
+
+	p := newAsyncProducer(mock, 10)
+	err := p.SendMessages(newTestMessages(2))
+	assert.EqualError(t, err, expErr.Error())
+	assert.NoError(t, p.Close())
+}
+
+func TestAsyncProducer_SendMessage(t *testing.T) {
+	c := sarama.NewConfig()
+	c.Producer.Return.Successes = true
+	mock := mocks.NewAsyncProducer(t, c)
+	mock.ExpectInputAndSucceed()
+
+	p := newAsyncProducer(mock, 1)
+	_, _, err := p.SendMessage(newTestMessages(1)[0])
+	require.NoError(t, err)
+	assert.NoError(t, p.Close())
+}
+
+func TestAsyncProducer_closed(t *testing.T) {
+	mock := mocks.NewAsyncProducer(t, sarama.NewConfig())
+	p := newAsyncProducer(mock, 1)
+	require.NoError(t, p.Close())
+
+	assert.Equal(t, errProducerClosed, p.SendMessages(newTestMessages(1)))
+	_, _, err := p.SendMessage(newTestMessages(1)[0])
+	assert.Equal(t, errProducerClosed, err)
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/Shopify/sarama"
 
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
 )
 
@@ -34,8 +35,8 @@ type Authentication struct {
 
 // PlainTextConfig defines plaintext authentication.
 type PlainTextConfig struct {
-	Username string `mapstructure:"username"`
-	Password string `mapstructure:"password"`
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
 }
 
 // SASLConfig defines the configuration for the SASL authentication.
@@ -43,20 +44,20 @@ type SASLConfig struct {
 	// Username to be used on authentication
 	Username string `mapstructure:"username"`
 	// Password to be used on authentication
-	Password string `mapstructure:"password"`
+	Password configopaque.String `mapstructure:"password"`
 	// SASL Mechanism to be used, possible values are: (PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512).
 	Mechanism string `mapstructure:"mechanism"`
 }
 
 // KerberosConfig defines kereros configuration.
 type KerberosConfig struct {
-	ServiceName string `mapstructure:"service_name"`
-	Realm       string `mapstructure:"realm"`
-	UseKeyTab   bool   `mapstructure:"use_keytab"`
-	Username    string `mapstructure:"username"`
-	Password    string `mapstructure:"password" json:"-"`
-	ConfigPath  string `mapstructure:"config_file"`
-	KeyTabPath  string `mapstructure:"keytab_file"`
+	ServiceName string              `mapstructure:"service_name"`
+	Realm       string              `mapstructure:"realm"`
+	UseKeyTab   bool                `mapstructure:"use_keytab"`
+	Username    string              `mapstructure:"username"`
+	Password    configopaque.String `mapstructure:"password" json:"-"`
+	ConfigPath  string              `mapstructure:"config_file"`
+	KeyTabPath  string              `mapstructure:"keytab_file"`
 }
 
 // ConfigureAuthentication configures authentication in sarama.Config.
@@ -84,7 +85,7 @@ func ConfigureAuthentication(config Authentication, saramaConfig *sarama.Config)
 func configurePlaintext(config PlainTextConfig, saramaConfig *sarama.Config) {
 	saramaConfig.Net.SASL.Enable = true
 	saramaConfig.Net.SASL.User = config.Username
-	saramaConfig.Net.SASL.Password = config.Password
+	saramaConfig.Net.SASL.Password = string(config.Password)
 }
 
 func configureSASL(config SASLConfig, saramaConfig *sarama.Config) error {
@@ -99,7 +100,7 @@ func configureSASL(config SASLConfig, saramaConfig *sarama.Config) error {
 
 	saramaConfig.Net.SASL.Enable = true
 	saramaConfig.Net.SASL.User = config.Username
-	saramaConfig.Net.SASL.Password = config.Password
+	saramaConfig.Net.SASL.Password = string(config.Password)
 
 	switch config.Mechanism {
 	case "SCRAM-SHA-512":
@@ -135,7 +136,7 @@ func configureKerberos(config KerberosConfig, saramaConfig *sarama.Config) {
 		saramaConfig.Net.SASL.GSSAPI.AuthType = sarama.KRB5_KEYTAB_AUTH
 	} else {
 		saramaConfig.Net.SASL.GSSAPI.AuthType = sarama.KRB5_USER_AUTH
-		saramaConfig.Net.SASL.GSSAPI.Password = config.Password
+		saramaConfig.Net.SASL.GSSAPI.Password = string(config.Password)
 	}
 	saramaConfig.Net.SASL.GSSAPI.KerberosConfigPath = config.ConfigPath
 	saramaConfig.Net.SASL.GSSAPI.Username = config.Username
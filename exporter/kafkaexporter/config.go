@@ -15,8 +15,11 @@
 package kafkaexporter
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/Shopify/sarama"
+
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
@@ -47,6 +50,18 @@ type Config struct {
 
 	// Authentication defines used authentication mechanism.
 	Authentication Authentication `mapstructure:"auth"`
+
+	// PartitionTracesByID sends one message per trace, keyed by trace ID, so
+	// that all the spans of a trace are written to the same partition.
+	PartitionTracesByID bool `mapstructure:"partition_traces_by_id"`
+
+	// PartitionMetricsByResourceAttributes sends one message per resource, keyed by
+	// the resource attributes, so that the metrics of a resource are written to the same partition.
+	PartitionMetricsByResourceAttributes bool `mapstructure:"partition_metrics_by_resource_attributes"`
+
+	// PartitionLogsByResourceAttributes sends one message per resource, keyed by
+	// the resource attributes, so that the logs of a resource are written to the same partition.
+	PartitionLogsByResourceAttributes bool `mapstructure:"partition_logs_by_resource_attributes"`
 }
 
 // Metadata defines configuration for retrieving metadata from the broker.
@@ -67,6 +82,21 @@ type Metadata struct {
 type Producer struct {
 	// Maximum message bytes the producer will accept to produce.
 	MaxMessageBytes int `mapstructure:"max_message_bytes"`
+
+	// RequiredAcks is the number of acknowledgements required from the brokers:
+	// 0 for none, 1 for the leader only, -1 for all the in-sync replicas (default 1).
+	RequiredAcks sarama.RequiredAcks `mapstructure:"required_acks"`
+
+	// Compression codec used to produce messages: none, gzip, snappy, lz4 or zstd (default none).
+	Compression string `mapstructure:"compression"`
+
+	// Async enables the asynchronous producer, which pipelines the messages of
+	// concurrent requests. Delivery errors are still returned for each request.
+	Async bool `mapstructure:"async"`
+
+	// MaxInFlightMessages bounds the number of messages waiting for an
+	// acknowledgement when Async is enabled (default 1000).
+	MaxInFlightMessages int `mapstructure:"max_in_flight_messages"`
 }
 
 // MetadataRetry defines retry configuration for Metadata.
@@ -81,7 +111,26 @@ type MetadataRetry struct {
 
 var _ config.Exporter = (*Config)(nil)
 
+var compressionCodecs = map[string]sarama.CompressionCodec{
+	"none":   sarama.CompressionNone,
+	"gzip":   sarama.CompressionGZIP,
+	"snappy": sarama.CompressionSnappy,
+	"lz4":    sarama.CompressionLZ4,
+	"zstd":   sarama.CompressionZSTD,
+}
+
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
+	if _, ok := compressionCodecs[cfg.Producer.Compression]; !ok && cfg.Producer.Compression != "" {
+		return fmt.Errorf("producer.compression should be one of 'none', 'gzip', 'snappy', 'lz4' or 'zstd'. configured value %v", cfg.Producer.Compression)
+	}
+	switch cfg.Producer.RequiredAcks {
+	case sarama.NoResponse, sarama.WaitForLocal, sarama.WaitForAll:
+	default:
+		return fmt.Errorf("producer.required_acks should be one of 0, 1 or -1. configured value %v", cfg.Producer.RequiredAcks)
+	}
+	if cfg.Producer.MaxInFlightMessages < 0 {
+		return fmt.Errorf("producer.max_in_flight_messages must not be negative. configured value %v", cfg.Producer.MaxInFlightMessages)
+	}
 	return nil
 }
@@ -19,6 +19,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -72,7 +73,56 @@ func TestLoadConfig(t *testing.T) {
 			},
 		},
 		Producer: Producer{
-			MaxMessageBytes: 10000000,
+			MaxMessageBytes:     10000000,
+			RequiredAcks:        sarama.WaitForAll,
+			Compression:         "gzip",
+			Async:               true,
+			MaxInFlightMessages: 100,
 		},
+		PartitionTracesByID: true,
 	}, c)
 }
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		errMsg string
+	}{
+		{
+			name:   "default",
+			modify: func(*Config) {},
+		},
+		{
+			name:   "empty_compression",
+			modify: func(cfg *Config) { cfg.Producer.Compression = "" },
+		},
+		{
+			name:   "invalid_compression",
+			modify: func(cfg *Config) { cfg.Producer.Compression = "brotli" },
+			errMsg: "producer.compression should be one of 'none', 'gzip', 'snappy', 'lz4' or 'zstd'. configured value brotli",
+		},
+		{
+			name:   "invalid_required_acks",
+			modify: func(cfg *Config) { cfg.Producer.RequiredAcks = 2 },
+			errMsg: "producer.required_acks should be one of 0, 1 or -1. configured value 2",
+		},
+		{
+			name:   "negative_max_in_flight_messages",
+			modify: func(cfg *Config) { cfg.Producer.MaxInFlightMessages = -1 },
+			errMsg: "producer.max_in_flight_messages must not be negative. configured value -1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/Shopify/sarama"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
@@ -39,6 +41,11 @@ const (
 	defaultMetadataFull = true
 	// default max.message.bytes for the producer
 	defaultProducerMaxMessageBytes = 1000000
+	// default from sarama.NewConfig()
+	defaultProducerRequiredAcks = sarama.WaitForLocal
+	defaultCompression          = "none"
+	// default number of messages waiting for an acknowledgement with the async producer
+	defaultProducerMaxInFlightMessages = 1000
 )
 
 // FactoryOption applies changes to kafkaExporterFactory.
@@ -90,7 +97,10 @@ func createDefaultConfig() config.Exporter {
 			},
 		},
 		Producer: Producer{
-			MaxMessageBytes: defaultProducerMaxMessageBytes,
+			MaxMessageBytes:     defaultProducerMaxMessageBytes,
+			RequiredAcks:        defaultProducerRequiredAcks,
+			Compression:         defaultCompression,
+			MaxInFlightMessages: defaultProducerMaxInFlightMessages,
 		},
 	}
 }
@@ -30,14 +30,15 @@ var errUnrecognizedEncoding = fmt.Errorf("unrecognized encoding")
 
 // kafkaTracesProducer uses sarama to produce trace messages to Kafka.
 type kafkaTracesProducer struct {
-	producer  sarama.SyncProducer
-	topic     string
-	marshaler TracesMarshaler
-	logger    *zap.Logger
+	producer    sarama.SyncProducer
+	topic       string
+	marshaler   TracesMarshaler
+	partitionBy bool
+	logger      *zap.Logger
 }
 
 func (e *kafkaTracesProducer) tracesPusher(_ context.Context, td pdata.Traces) error {
-	messages, err := e.marshaler.Marshal(td, e.topic)
+	messages, err := e.marshal(td)
 	if err != nil {
 		return consumererror.Permanent(err)
 	}
@@ -48,20 +49,38 @@ func (e *kafkaTracesProducer) tracesPusher(_ context.Context, td pdata.Traces) e
 	return nil
 }
 
+func (e *kafkaTracesProducer) marshal(td pdata.Traces) ([]*sarama.ProducerMessage, error) {
+	if !e.partitionBy {
+		return e.marshaler.Marshal(td, e.topic)
+	}
+
+	var messages []*sarama.ProducerMessage
+	for traceID, batch := range splitTracesByID(td) {
+		msgs, err := e.marshaler.Marshal(batch, e.topic)
+		if err != nil {
+			return nil, err
+		}
+		setMessagesKey(msgs, sarama.StringEncoder(traceID.HexString()))
+		messages = append(messages, msgs...)
+	}
+	return messages, nil
+}
+
 func (e *kafkaTracesProducer) Close(context.Context) error {
 	return e.producer.Close()
 }
 
 // kafkaMetricsProducer uses sarama to produce metrics messages to kafka
 type kafkaMetricsProducer struct {
-	producer  sarama.SyncProducer
-	topic     string
-	marshaler MetricsMarshaler
-	logger    *zap.Logger
+	producer    sarama.SyncProducer
+	topic       string
+	marshaler   MetricsMarshaler
+	partitionBy bool
+	logger      *zap.Logger
 }
 
 func (e *kafkaMetricsProducer) metricsDataPusher(_ context.Context, md pdata.Metrics) error {
-	messages, err := e.marshaler.Marshal(md, e.topic)
+	messages, err := e.marshal(md)
 	if err != nil {
 		return consumererror.Permanent(err)
 	}
@@ -72,20 +91,41 @@ func (e *kafkaMetricsProducer) metricsDataPusher(_ context.Context, md pdata.Met
 	return nil
 }
 
+func (e *kafkaMetricsProducer) marshal(md pdata.Metrics) ([]*sarama.ProducerMessage, error) {
+	if !e.partitionBy {
+		return e.marshaler.Marshal(md, e.topic)
+	}
+
+	var messages []*sarama.ProducerMessage
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		batch := pdata.NewMetrics()
+		rms.At(i).CopyTo(batch.ResourceMetrics().AppendEmpty())
+		msgs, err := e.marshaler.Marshal(batch, e.topic)
+		if err != nil {
+			return nil, err
+		}
+		setMessagesKey(msgs, resourceKey(rms.At(i).Resource()))
+		messages = append(messages, msgs...)
+	}
+	return messages, nil
+}
+
 func (e *kafkaMetricsProducer) Close(context.Context) error {
 	return e.producer.Close()
 }
 
 // kafkaLogsProducer uses sarama to produce logs messages to kafka
 type kafkaLogsProducer struct {
-	producer  sarama.SyncProducer
-	topic     string
-	marshaler LogsMarshaler
-	logger    *zap.Logger
+	producer    sarama.SyncProducer
+	topic       string
+	marshaler   LogsMarshaler
+	partitionBy bool
+	logger      *zap.Logger
 }
 
 func (e *kafkaLogsProducer) logsDataPusher(_ context.Context, ld pdata.Logs) error {
-	messages, err := e.marshaler.Marshal(ld, e.topic)
+	messages, err := e.marshal(ld)
 	if err != nil {
 		return consumererror.Permanent(err)
 	}
@@ -96,6 +136,26 @@ func (e *kafkaLogsProducer) logsDataPusher(_ context.Context, ld pdata.Logs) err
 	return nil
 }
 
+func (e *kafkaLogsProducer) marshal(ld pdata.Logs) ([]*sarama.ProducerMessage, error) {
+	if !e.partitionBy {
+		return e.marshaler.Marshal(ld, e.topic)
+	}
+
+	var messages []*sarama.ProducerMessage
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		batch := pdata.NewLogs()
+		rls.At(i).CopyTo(batch.ResourceLogs().AppendEmpty())
+		msgs, err := e.marshaler.Marshal(batch, e.topic)
+		if err != nil {
+			return nil, err
+		}
+		setMessagesKey(msgs, resourceKey(rls.At(i).Resource()))
+		messages = append(messages, msgs...)
+	}
+	return messages, nil
+}
+
 func (e *kafkaLogsProducer) Close(context.Context) error {
 	return e.producer.Close()
 }
@@ -105,10 +165,10 @@ func newSaramaProducer(config Config) (sarama.SyncProducer, error) {
 	// These setting are required by the sarama.SyncProducer implementation.
 	c.Producer.Return.Successes = true
 	c.Producer.Return.Errors = true
-	// Wait only the local commit to succeed before responding.
-	c.Producer.RequiredAcks = sarama.WaitForLocal
+	c.Producer.RequiredAcks = config.Producer.RequiredAcks
 	// Because sarama does not accept a Context for every message, set the Timeout here.
 	c.Producer.Timeout = config.Timeout
+	c.Producer.Compression = compressionCodecs[config.Producer.Compression]
 	c.Metadata.Full = config.Metadata.Full
 	c.Metadata.Retry.Max = config.Metadata.Retry.Max
 	c.Metadata.Retry.Backoff = config.Metadata.Retry.Backoff
@@ -123,6 +183,19 @@ func newSaramaProducer(config Config) (sarama.SyncProducer, error) {
 	if err := ConfigureAuthentication(config.Authentication, c); err != nil {
 		return nil, err
 	}
+
+	if config.Producer.Async {
+		producer, err := sarama.NewAsyncProducer(config.Brokers, c)
+		if err != nil {
+			return nil, err
+		}
+		maxInFlight := config.Producer.MaxInFlightMessages
+		if maxInFlight == 0 {
+			maxInFlight = defaultProducerMaxInFlightMessages
+		}
+		return newAsyncProducer(producer, maxInFlight), nil
+	}
+
 	producer, err := sarama.NewSyncProducer(config.Brokers, c)
 	if err != nil {
 		return nil, err
@@ -141,10 +214,11 @@ func newMetricsExporter(config Config, set component.ExporterCreateSettings, mar
 	}
 
 	return &kafkaMetricsProducer{
-		producer:  producer,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
+		producer:    producer,
+		topic:       config.Topic,
+		marshaler:   marshaler,
+		partitionBy: config.PartitionMetricsByResourceAttributes,
+		logger:      set.Logger,
 	}, nil
 
 }
@@ -160,10 +234,11 @@ func newTracesExporter(config Config, set component.ExporterCreateSettings, mars
 		return nil, err
 	}
 	return &kafkaTracesProducer{
-		producer:  producer,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
+		producer:    producer,
+		topic:       config.Topic,
+		marshaler:   marshaler,
+		partitionBy: config.PartitionTracesByID,
+		logger:      set.Logger,
 	}, nil
 }
 
@@ -178,10 +253,11 @@ func newLogsExporter(config Config, set component.ExporterCreateSettings, marsha
 	}
 
 	return &kafkaLogsProducer{
-		producer:  producer,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
+		producer:    producer,
+		topic:       config.Topic,
+		marshaler:   marshaler,
+		partitionBy: config.PartitionLogsByResourceAttributes,
+		logger:      set.Logger,
 	}, nil
 
 }
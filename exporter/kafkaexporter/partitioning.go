@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter
+
+import (
+	"hash/fnv"
+	"sort"
+
+	"github.com/Shopify/sarama"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// splitTracesByID splits the traces into one batch per trace, keeping the
+// resource and instrumentation library of every span.
+func splitTracesByID(td pdata.Traces) map[pdata.TraceID]pdata.Traces {
+	batches := map[pdata.TraceID]pdata.Traces{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		destRSs := map[pdata.TraceID]pdata.ResourceSpans{}
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			destILSs := map[pdata.TraceID]pdata.InstrumentationLibrarySpans{}
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				traceID := span.TraceID()
+
+				destILS, ok := destILSs[traceID]
+				if !ok {
+					destRS, found := destRSs[traceID]
+					if !found {
+						batch, exists := batches[traceID]
+						if !exists {
+							batch = pdata.NewTraces()
+							batches[traceID] = batch
+						}
+						destRS = batch.ResourceSpans().AppendEmpty()
+						rs.Resource().CopyTo(destRS.Resource())
+						destRS.SetSchemaUrl(rs.SchemaUrl())
+						destRSs[traceID] = destRS
+					}
+					destILS = destRS.InstrumentationLibrarySpans().AppendEmpty()
+					ils.InstrumentationLibrary().CopyTo(destILS.InstrumentationLibrary())
+					destILS.SetSchemaUrl(ils.SchemaUrl())
+					destILSs[traceID] = destILS
+				}
+				span.CopyTo(destILS.Spans().AppendEmpty())
+			}
+		}
+	}
+	return batches
+}
+
+// resourceKey returns a key identifying the resource by its attributes,
+// independently of their order.
+func resourceKey(resource pdata.Resource) sarama.Encoder {
+	attrs := make([]string, 0, resource.Attributes().Len())
+	resource.Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+		attrs = append(attrs, k+"="+pdata.AttributeValueToString(v))
+		return true
+	})
+	sort.Strings(attrs)
+
+	h := fnv.New128a()
+	for _, attr := range attrs {
+		h.Write([]byte(attr)) // nolint:errcheck
+		h.Write([]byte{0})    // nolint:errcheck
+	}
+	return sarama.ByteEncoder(h.Sum(nil))
+}
+
+// setMessagesKey keys the messages that were not already keyed by the marshaler.
+func setMessagesKey(messages []*sarama.ProducerMessage, key sarama.Encoder) {
+	for _, msg := range messages {
+		if msg.Key == nil {
+			msg.Key = key
+		}
+	}
+}
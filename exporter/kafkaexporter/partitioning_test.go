@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+type recordingProducer struct {
+	messages []*sarama.ProducerMessage
+}
+
+var _ sarama.SyncProducer = (*recordingProducer)(nil)
+
+func (p *recordingProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	p.messages = append(p.messages, msg)
+	return 0, 0, nil
+}
+
+func (p *recordingProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	p.messages = append(p.messages, msgs...)
+	return nil
+}
+
+func (p *recordingProducer) Close() error {
+	return nil
+}
+
+func (p *recordingProducer) keys(t *testing.T) []string {
+	var keys []string
+	for _, msg := range p.messages {
+		require.NotNil(t, msg.Key)
+		key, err := msg.Key.Encode()
+		require.NoError(t, err)
+		keys = append(keys, string(key))
+	}
+	return keys
+}
+
+func generateTracesTwoTraces() pdata.Traces {
+	td := pdata.NewTraces()
+	for _, service := range []string{"a", "b"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString("service.name", service)
+		spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+		for _, id := range []byte{1, 2} {
+			span := spans.AppendEmpty()
+			span.SetName(service)
+			span.SetTraceID(pdata.NewTraceID([16]byte{id}))
+			span.SetSpanID(pdata.NewSpanID([8]byte{id, service[0]}))
+		}
+	}
+	return td
+}
+
+func TestSplitTracesByID(t *testing.T) {
+	batches := splitTracesByID(generateTracesTwoTraces())
+	require.Len(t, batches, 2)
+	for _, id := range []byte{1, 2} {
+		batch, ok := batches[pdata.NewTraceID([16]byte{id})]
+		require.True(t, ok)
+		assert.Equal(t, 2, batch.SpanCount())
+		require.Equal(t, 2, batch.ResourceSpans().Len())
+		for i, service := range []string{"a", "b"} {
+			rs := batch.ResourceSpans().At(i)
+			v, ok := rs.Resource().Attributes().Get("service.name")
+			require.True(t, ok)
+			assert.Equal(t, service, v.StringVal())
+			span := rs.InstrumentationLibrarySpans().At(0).Spans().At(0)
+			assert.Equal(t, service, span.Name())
+			assert.Equal(t, pdata.NewTraceID([16]byte{id}), span.TraceID())
+		}
+	}
+}
+
+func TestResourceKey(t *testing.T) {
+	r1 := pdata.NewResource()
+	r1.Attributes().InsertString("service.name", "a")
+	r1.Attributes().InsertInt("pid", 1)
+	r2 := pdata.NewResource()
+	r2.Attributes().InsertInt("pid", 1)
+	r2.Attributes().InsertString("service.name", "a")
+	r3 := pdata.NewResource()
+	r3.Attributes().InsertString("service.name", "b")
+	r3.Attributes().InsertInt("pid", 1)
+
+	assert.Equal(t, resourceKey(r1), resourceKey(r2))
+	assert.NotEqual(t, resourceKey(r1), resourceKey(r3))
+}
+
+func TestTracesPusher_partitionByID(t *testing.T) {
+	producer := &recordingProducer{}
+	p := kafkaTracesProducer{
+		producer:    producer,
+		marshaler:   newPdataTracesMarshaler(otlp.NewProtobufTracesMarshaler(), defaultEncoding),
+		partitionBy: true,
+	}
+	require.NoError(t, p.tracesPusher(context.Background(), generateTracesTwoTraces()))
+	assert.ElementsMatch(t, []string{
+		pdata.NewTraceID([16]byte{1}).HexString(),
+		pdata.NewTraceID([16]byte{2}).HexString(),
+	}, producer.keys(t))
+}
+
+func TestTracesPusher_partitionByID_keyedByMarshaler(t *testing.T) {
+	marshaler := jaegerMarshaler{marshaler: jaegerProtoSpanMarshaler{}}
+	expected, err := marshaler.Marshal(generateTracesTwoTraces(), "")
+	require.NoError(t, err)
+	var expectedKeys []string
+	for _, msg := range expected {
+		key, err := msg.Key.Encode()
+		require.NoError(t, err)
+		expectedKeys = append(expectedKeys, string(key))
+	}
+
+	producer := &recordingProducer{}
+	p := kafkaTracesProducer{
+		producer:    producer,
+		marshaler:   marshaler,
+		partitionBy: true,
+	}
+	require.NoError(t, p.tracesPusher(context.Background(), generateTracesTwoTraces()))
+	assert.ElementsMatch(t, expectedKeys, producer.keys(t))
+}
+
+func TestMetricsDataPusher_partitionByResource(t *testing.T) {
+	md := pdata.NewMetrics()
+	for _, service := range []string{"a", "b", "a"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().InsertString("service.name", service)
+		rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty().SetName(service)
+	}
+
+	producer := &recordingProducer{}
+	p := kafkaMetricsProducer{
+		producer:    producer,
+		marshaler:   newPdataMetricsMarshaler(otlp.NewProtobufMetricsMarshaler(), defaultEncoding),
+		partitionBy: true,
+	}
+	require.NoError(t, p.metricsDataPusher(context.Background(), md))
+	keys := producer.keys(t)
+	require.Len(t, keys, 3)
+	assert.Equal(t, keys[0], keys[2])
+	assert.NotEqual(t, keys[0], keys[1])
+}
+
+func TestLogsDataPusher_partitionByResource(t *testing.T) {
+	ld := pdata.NewLogs()
+	for _, service := range []string{"a", "b", "a"} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().InsertString("service.name", service)
+		rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty().SetName(service)
+	}
+
+	producer := &recordingProducer{}
+	p := kafkaLogsProducer{
+		producer:    producer,
+		marshaler:   newPdataLogsMarshaler(otlp.NewProtobufLogsMarshaler(), defaultEncoding),
+		partitionBy: true,
+	}
+	require.NoError(t, p.logsDataPusher(context.Background(), ld))
+	keys := producer.keys(t)
+	require.Len(t, keys, 3)
+	assert.Equal(t, keys[0], keys[2])
+	assert.NotEqual(t, keys[0], keys[1])
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+)
+
+// RoutingKey determines which part of the data is used to select the backend.
+type RoutingKey string
+
+const (
+	// TraceIDRoutingKey routes spans and log records by their trace ID.
+	TraceIDRoutingKey RoutingKey = "traceID"
+	// ServiceRoutingKey routes the data by the "service.name" resource attribute.
+	ServiceRoutingKey RoutingKey = "service"
+	// ResourceRoutingKey routes the data by all the resource attributes.
+	ResourceRoutingKey RoutingKey = "resource"
+)
+
+// Config defines configuration for the load-balancing exporter.
+type Config struct {
+	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Protocol configures the exporters used to send data to each backend.
+	Protocol Protocol `mapstructure:"protocol"`
+
+	// Resolver configures how the list of backends is obtained.
+	Resolver ResolverSettings `mapstructure:"resolver"`
+
+	// RoutingKey selects the part of the data used to pick a backend. When empty,
+	// traces and logs are routed by trace ID and metrics by resource.
+	RoutingKey RoutingKey `mapstructure:"routing_key"`
+}
+
+// Protocol holds the settings of the exporters created for each backend.
+type Protocol struct {
+	// OTLP configures the OTLP/gRPC exporters. The endpoint is replaced by the one of each backend.
+	OTLP otlpexporter.Config `mapstructure:"otlp"`
+}
+
+// ResolverSettings defines the resolvers available to obtain the backends, only one can be used.
+type ResolverSettings struct {
+	Static *StaticResolver `mapstructure:"static"`
+	DNS    *DNSResolver    `mapstructure:"dns"`
+}
+
+// StaticResolver defines a fixed list of backends.
+type StaticResolver struct {
+	// Hostnames of the backends, with an optional port (default 4317).
+	Hostnames []string `mapstructure:"hostnames"`
+}
+
+// DNSResolver defines a list of backends obtained by periodically resolving a hostname.
+type DNSResolver struct {
+	// Hostname to resolve into the IP addresses of the backends.
+	Hostname string `mapstructure:"hostname"`
+	// Port of the backends (default 4317).
+	Port string `mapstructure:"port"`
+	// Interval between two resolutions (default 5s).
+	Interval time.Duration `mapstructure:"interval"`
+	// Timeout of a resolution (default 1s).
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+var (
+	errNoResolver       = errors.New("no resolver configured, one of static or dns is required")
+	errMultipleResolver = errors.New("only one resolver can be configured, either static or dns")
+)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	switch cfg.RoutingKey {
+	case "", TraceIDRoutingKey, ServiceRoutingKey, ResourceRoutingKey:
+	default:
+		return fmt.Errorf("unsupported routing_key %q", cfg.RoutingKey)
+	}
+
+	switch {
+	case cfg.Resolver.Static == nil && cfg.Resolver.DNS == nil:
+		return errNoResolver
+	case cfg.Resolver.Static != nil && cfg.Resolver.DNS != nil:
+		return errMultipleResolver
+	case cfg.Resolver.Static != nil && len(cfg.Resolver.Static.Hostnames) == 0:
+		return errors.New("static resolver requires at least one hostname")
+	case cfg.Resolver.DNS != nil && cfg.Resolver.DNS.Hostname == "":
+		return errors.New("dns resolver requires a hostname")
+	case cfg.Resolver.DNS != nil && (cfg.Resolver.DNS.Interval < 0 || cfg.Resolver.DNS.Timeout < 0):
+		return errors.New("dns resolver interval and timeout must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	e0 := cfg.Exporters[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, &StaticResolver{Hostnames: []string{"backend-1:4317", "backend-2", "backend-3:55690"}}, e0.Resolver.Static)
+	assert.Nil(t, e0.Resolver.DNS)
+	assert.Equal(t, time.Second, e0.Protocol.OTLP.Timeout)
+	assert.True(t, e0.Protocol.OTLP.TLSSetting.Insecure)
+	assert.Equal(t, RoutingKey(""), e0.RoutingKey)
+
+	e1 := cfg.Exporters[config.NewIDWithName(typeStr, "dns")].(*Config)
+	assert.Nil(t, e1.Resolver.Static)
+	assert.Equal(t, &DNSResolver{
+		Hostname: "service-1.headless",
+		Port:     "55690",
+		Interval: 10 * time.Second,
+		Timeout:  2 * time.Second,
+	}, e1.Resolver.DNS)
+	assert.Equal(t, ServiceRoutingKey, e1.RoutingKey)
+	assert.Equal(t, factory.CreateDefaultConfig().(*Config).Protocol, e1.Protocol)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{
+			name: "static",
+			cfg:  Config{Resolver: ResolverSettings{Static: &StaticResolver{Hostnames: []string{"backend-1"}}}},
+		},
+		{
+			name: "dns",
+			cfg:  Config{RoutingKey: ResourceRoutingKey, Resolver: ResolverSettings{DNS: &DNSResolver{Hostname: "backends"}}},
+		},
+		{
+			name:    "no resolver",
+			cfg:     Config{},
+			wantErr: errNoResolver.Error(),
+		},
+		{
+			name: "multiple resolvers",
+			cfg: Config{Resolver: ResolverSettings{
+				Static: &StaticResolver{Hostnames: []string{"backend-1"}},
+				DNS:    &DNSResolver{Hostname: "backends"},
+			}},
+			wantErr: errMultipleResolver.Error(),
+		},
+		{
+			name:    "empty static resolver",
+			cfg:     Config{Resolver: ResolverSettings{Static: &StaticResolver{}}},
+			wantErr: "static resolver requires at least one hostname",
+		},
+		{
+			name:    "dns resolver without hostname",
+			cfg:     Config{Resolver: ResolverSettings{DNS: &DNSResolver{}}},
+			wantErr: "dns resolver requires a hostname",
+		},
+		{
+			name:    "negative dns interval",
+			cfg:     Config{Resolver: ResolverSettings{DNS: &DNSResolver{Hostname: "backends", Interval: -time.Second}}},
+			wantErr: "dns resolver interval and timeout must not be negative",
+		},
+		{
+			name:    "unknown routing key",
+			cfg:     Config{RoutingKey: "spanID", Resolver: ResolverSettings{Static: &StaticResolver{Hostnames: []string{"backend-1"}}}},
+			wantErr: `unsupported routing_key "spanID"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
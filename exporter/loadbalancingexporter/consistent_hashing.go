@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"hash/crc32"
+	"sort"
+	"strconv"
+)
+
+// virtualNodesPerEndpoint is the number of positions each endpoint takes on the
+// ring, spreading the keys evenly across the endpoints.
+const virtualNodesPerEndpoint = 100
+
+type ringItem struct {
+	position uint32
+	endpoint string
+}
+
+// hashRing is a consistent hashing ring: adding or removing an endpoint only
+// moves the keys that were routed to, or are now routed to, that endpoint.
+type hashRing struct {
+	items []ringItem
+}
+
+func newHashRing(endpoints []string) *hashRing {
+	items := make([]ringItem, 0, len(endpoints)*virtualNodesPerEndpoint)
+	for _, endpoint := range endpoints {
+		for i := 0; i < virtualNodesPerEndpoint; i++ {
+			items = append(items, ringItem{
+				position: crc32.ChecksumIEEE([]byte(endpoint + "#" + strconv.Itoa(i))),
+				endpoint: endpoint,
+			})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].position == items[j].position {
+			return items[i].endpoint < items[j].endpoint
+		}
+		return items[i].position < items[j].position
+	})
+	return &hashRing{items: items}
+}
+
+// endpointFor returns the endpoint owning the given key, or an empty string if the ring is empty.
+func (r *hashRing) endpointFor(key []byte) string {
+	if len(r.items) == 0 {
+		return ""
+	}
+	position := crc32.ChecksumIEEE(key)
+	idx := sort.Search(len(r.items), func(i int) bool { return r.items[i].position >= position })
+	if idx == len(r.items) {
+		idx = 0
+	}
+	return r.items[idx].endpoint
+}
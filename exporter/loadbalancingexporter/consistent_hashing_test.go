@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashRingEmpty(t *testing.T) {
+	assert.Equal(t, "", newHashRing(nil).endpointFor([]byte("key")))
+}
+
+func TestHashRingIsDeterministic(t *testing.T) {
+	r1 := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+	r2 := newHashRing([]string{"endpoint-3", "endpoint-1", "endpoint-2"})
+	for i := 0; i < 1000; i++ {
+		key := []byte(strconv.Itoa(i))
+		assert.Equal(t, r1.endpointFor(key), r2.endpointFor(key))
+	}
+}
+
+func TestHashRingBalancesKeys(t *testing.T) {
+	endpoints := []string{"endpoint-1", "endpoint-2", "endpoint-3"}
+	r := newHashRing(endpoints)
+
+	counts := map[string]int{}
+	for i := 0; i < 9000; i++ {
+		counts[r.endpointFor([]byte(strconv.Itoa(i)))]++
+	}
+	for _, endpoint := range endpoints {
+		// Every endpoint gets a reasonable share of the keys.
+		assert.Greater(t, counts[endpoint], 1500, endpoint)
+	}
+}
+
+func TestHashRingMovesFewKeysOnChange(t *testing.T) {
+	before := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+	after := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3", "endpoint-4"})
+
+	moved := 0
+	for i := 0; i < 10000; i++ {
+		key := []byte(strconv.Itoa(i))
+		if e := after.endpointFor(key); e != before.endpointFor(key) {
+			// Keys only move to the new endpoint.
+			assert.Equal(t, "endpoint-4", e)
+			moved++
+		}
+	}
+	assert.Less(t, moved, 4000)
+}
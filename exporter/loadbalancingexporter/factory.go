@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "loadbalancing"
+)
+
+// NewFactory creates a factory for the load-balancing exporter.
+func NewFactory() component.ExporterFactory {
+	return exporterhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporterhelper.WithTraces(createTracesExporter),
+		exporterhelper.WithMetrics(createMetricsExporter),
+		exporterhelper.WithLogs(createLogsExporter))
+}
+
+func createDefaultConfig() config.Exporter {
+	otlpDefaultCfg := otlpexporter.NewFactory().CreateDefaultConfig().(*otlpexporter.Config)
+
+	return &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+		Protocol: Protocol{
+			OTLP: *otlpDefaultCfg,
+		},
+	}
+}
+
+// buildExporterConfig returns the configuration of the OTLP exporter sending data to the given backend.
+func buildExporterConfig(cfg *Config, endpoint string) *otlpexporter.Config {
+	oCfg := cfg.Protocol.OTLP
+	oCfg.ExporterSettings = config.NewExporterSettings(config.NewIDWithName("otlp", endpoint))
+	oCfg.Endpoint = endpoint
+	return &oCfg
+}
+
+// The timeout and retries are handled by the exporter of each backend.
+func exporterOptions(lb *loadBalancer) []exporterhelper.Option {
+	return []exporterhelper.Option{
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{}),
+		exporterhelper.WithStart(lb.Start),
+		exporterhelper.WithShutdown(lb.Shutdown),
+	}
+}
+
+func createTracesExporter(
+	_ context.Context,
+	set component.ExporterCreateSettings,
+	cfg config.Exporter,
+) (component.TracesExporter, error) {
+	exp, err := newTracesExporter(set, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewTracesExporter(
+		cfg,
+		set,
+		exp.ConsumeTraces,
+		exporterOptions(exp.loadBalancer)...)
+}
+
+func createMetricsExporter(
+	_ context.Context,
+	set component.ExporterCreateSettings,
+	cfg config.Exporter,
+) (component.MetricsExporter, error) {
+	exp, err := newMetricsExporter(set, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewMetricsExporter(
+		cfg,
+		set,
+		exp.ConsumeMetrics,
+		exporterOptions(exp.loadBalancer)...)
+}
+
+func createLogsExporter(
+	_ context.Context,
+	set component.ExporterCreateSettings,
+	cfg config.Exporter,
+) (component.LogsExporter, error) {
+	exp, err := newLogsExporter(set, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewLogsExporter(
+		cfg,
+		set,
+		exp.ConsumeLogs,
+		exporterOptions(exp.loadBalancer)...)
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+	assert.Equal(t, otlpexporter.NewFactory().CreateDefaultConfig(), &cfg.(*Config).Protocol.OTLP)
+}
+
+func TestCreateExporters(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Resolver.Static = &StaticResolver{Hostnames: []string{"localhost:4317"}}
+	set := componenttest.NewNopExporterCreateSettings()
+
+	te, err := factory.CreateTracesExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, te)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, te.Shutdown(context.Background()))
+
+	me, err := factory.CreateMetricsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, me)
+
+	le, err := factory.CreateLogsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, le)
+
+	cfg.RoutingKey = TraceIDRoutingKey
+	_, err = factory.CreateMetricsExporter(context.Background(), set, cfg)
+	assert.Equal(t, errTraceIDRoutingForMetrics, err)
+}
+
+func TestBuildExporterConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Protocol.OTLP.Endpoint = "ignored:4317"
+
+	oCfg := buildExporterConfig(cfg, "backend-1:4317")
+	assert.Equal(t, "backend-1:4317", oCfg.Endpoint)
+	assert.Equal(t, config.NewIDWithName("otlp", "backend-1:4317"), oCfg.ID())
+	assert.Equal(t, cfg.Protocol.OTLP.TimeoutSettings, oCfg.TimeoutSettings)
+	assert.Equal(t, "ignored:4317", cfg.Protocol.OTLP.Endpoint)
+}
@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+var errNoBackends = errors.New("no backends available")
+
+// componentFactory creates the exporter sending the data to the given backend endpoint.
+type componentFactory func(ctx context.Context, endpoint string) (component.Exporter, error)
+
+// backend is the exporter of a backend endpoint, with the consumers currently using it.
+type backend struct {
+	exporter component.Exporter
+	inUse    sync.WaitGroup
+}
+
+// shutdown waits for the consumers using the exporter and shuts it down.
+func (b *backend) shutdown(ctx context.Context) error {
+	b.inUse.Wait()
+	return b.exporter.Shutdown(ctx)
+}
+
+// loadBalancer keeps one exporter per backend, and the ring used to select a backend for a key.
+type loadBalancer struct {
+	logger   *zap.Logger
+	host     component.Host
+	res      resolver
+	factory  componentFactory
+	stopping bool
+
+	updateLock sync.RWMutex
+	ring       *hashRing
+	exporters  map[string]*backend
+}
+
+func newLoadBalancer(logger *zap.Logger, cfg *Config, factory componentFactory) (*loadBalancer, error) {
+	var res resolver
+	switch {
+	case cfg.Resolver.Static != nil:
+		res = newStaticResolver(cfg.Resolver.Static.Hostnames)
+	case cfg.Resolver.DNS != nil:
+		res = newDNSResolver(logger, cfg.Resolver.DNS)
+	default:
+		return nil, errNoResolver
+	}
+
+	return &loadBalancer{
+		logger:    logger,
+		res:       res,
+		factory:   factory,
+		ring:      newHashRing(nil),
+		exporters: map[string]*backend{},
+	}, nil
+}
+
+func (lb *loadBalancer) Start(ctx context.Context, host component.Host) error {
+	lb.host = host
+	lb.res.onChange(lb.onBackendChanges)
+	return lb.res.start(ctx)
+}
+
+func (lb *loadBalancer) Shutdown(ctx context.Context) error {
+	err := lb.res.shutdown(ctx)
+
+	lb.updateLock.Lock()
+	lb.stopping = true
+	backends := lb.exporters
+	lb.exporters = map[string]*backend{}
+	lb.updateLock.Unlock()
+
+	var errs []error
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for endpoint, b := range backends {
+		if err := b.shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to shutdown the exporter for %q: %w", endpoint, err))
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+// onBackendChanges rebuilds the ring, starts the exporters for the new backends
+// and stops the ones of the backends that are gone, once they are not used anymore.
+func (lb *loadBalancer) onBackendChanges(endpoints []string) {
+	ctx := context.Background()
+	removed := lb.updateBackends(ctx, endpoints)
+	for endpoint, b := range removed {
+		if err := b.shutdown(ctx); err != nil {
+			lb.logger.Warn("Failed to shutdown the exporter for a removed backend", zap.String("endpoint", endpoint), zap.Error(err))
+		}
+	}
+}
+
+// updateBackends rebuilds the ring and starts the exporters for the new backends.
+// It returns the backends that are gone, their exporters are not shut down yet.
+func (lb *loadBalancer) updateBackends(ctx context.Context, endpoints []string) map[string]*backend {
+	lb.updateLock.Lock()
+	defer lb.updateLock.Unlock()
+	if lb.stopping {
+		return nil
+	}
+
+	current := make(map[string]struct{}, len(endpoints))
+	for _, endpoint := range endpoints {
+		current[endpoint] = struct{}{}
+		if _, ok := lb.exporters[endpoint]; ok {
+			continue
+		}
+		exp, err := lb.factory(ctx, endpoint)
+		if err == nil {
+			err = exp.Start(ctx, lb.host)
+		}
+		if err != nil {
+			lb.logger.Error("Failed to create the exporter for a backend", zap.String("endpoint", endpoint), zap.Error(err))
+			delete(current, endpoint)
+			continue
+		}
+		lb.exporters[endpoint] = &backend{exporter: exp}
+	}
+
+	removed := map[string]*backend{}
+	for endpoint, b := range lb.exporters {
+		if _, ok := current[endpoint]; ok {
+			continue
+		}
+		removed[endpoint] = b
+		delete(lb.exporters, endpoint)
+	}
+
+	available := make([]string, 0, len(current))
+	for _, endpoint := range endpoints {
+		if _, ok := current[endpoint]; ok {
+			available = append(available, endpoint)
+		}
+	}
+	lb.ring = newHashRing(available)
+	lb.logger.Info("Backends changed, the ring was rebuilt", zap.Strings("endpoints", available))
+	return removed
+}
+
+// consumeWith calls consume with the exporter of the backend owning the given key. The exporter
+// is not shut down by backend changes before consume returns, but the backends can change meanwhile.
+func (lb *loadBalancer) consumeWith(key []byte, consume func(endpoint string, exp component.Exporter) error) error {
+	lb.updateLock.RLock()
+	endpoint := lb.ring.endpointFor(key)
+	b, ok := lb.exporters[endpoint]
+	if ok {
+		b.inUse.Add(1)
+	}
+	lb.updateLock.RUnlock()
+	if !ok {
+		return errNoBackends
+	}
+
+	defer b.inUse.Done()
+	return consume(endpoint, b.exporter)
+}
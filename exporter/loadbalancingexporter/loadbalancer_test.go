@@ -0,0 +1,295 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// mockExporter records the data it receives, and whether it is running.
+type mockExporter struct {
+	consumertest.TracesSink
+	logs    consumertest.LogsSink
+	metrics consumertest.MetricsSink
+
+	mu      sync.Mutex
+	running bool
+}
+
+func (e *mockExporter) Start(context.Context, component.Host) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.running = true
+	return nil
+}
+
+func (e *mockExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.running = false
+	return nil
+}
+
+func (e *mockExporter) isRunning() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.running
+}
+
+func (e *mockExporter) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	return e.logs.ConsumeLogs(ctx, ld)
+}
+
+func (e *mockExporter) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	return e.metrics.ConsumeMetrics(ctx, md)
+}
+
+// mockResolver lets the tests trigger backend changes.
+type mockResolver struct {
+	callbacks []func([]string)
+}
+
+func (r *mockResolver) start(context.Context) error    { return nil }
+func (r *mockResolver) shutdown(context.Context) error { return nil }
+func (r *mockResolver) onChange(callback func([]string)) {
+	r.callbacks = append(r.callbacks, callback)
+}
+
+func (r *mockResolver) update(endpoints []string) {
+	for _, callback := range r.callbacks {
+		callback(endpoints)
+	}
+}
+
+func newMockLoadBalancer(t *testing.T) (*loadBalancer, *mockResolver, map[string]*mockExporter) {
+	exporters := map[string]*mockExporter{}
+	cfg := &Config{Resolver: ResolverSettings{Static: &StaticResolver{Hostnames: []string{"unused"}}}}
+	lb, err := newLoadBalancer(zap.NewNop(), cfg, func(_ context.Context, endpoint string) (component.Exporter, error) {
+		if endpoint == "broken:4317" {
+			return nil, errors.New("invalid endpoint")
+		}
+		exp := &mockExporter{}
+		exporters[endpoint] = exp
+		return exp, nil
+	})
+	require.NoError(t, err)
+
+	res := &mockResolver{}
+	lb.res = res
+	require.NoError(t, lb.Start(context.Background(), componenttest.NewNopHost()))
+	return lb, res, exporters
+}
+
+// exporterFor returns the exporter of the backend owning the given key.
+func exporterFor(lb *loadBalancer, key string) (string, component.Exporter, error) {
+	var endpoint string
+	var exp component.Exporter
+	err := lb.consumeWith([]byte(key), func(e string, ex component.Exporter) error {
+		endpoint, exp = e, ex
+		return nil
+	})
+	return endpoint, exp, err
+}
+
+func TestLoadBalancerBackendChanges(t *testing.T) {
+	lb, res, exporters := newMockLoadBalancer(t)
+
+	_, _, err := exporterFor(lb, "key")
+	assert.Equal(t, errNoBackends, err)
+
+	res.update([]string{"backend-1:4317", "backend-2:4317", "broken:4317"})
+	require.Len(t, exporters, 2)
+	first := exporters["backend-1:4317"]
+	assert.True(t, first.isRunning())
+	assert.True(t, exporters["backend-2:4317"].isRunning())
+
+	// The broken backend is left out of the ring.
+	for _, key := range []string{"a", "b", "c", "d", "e", "f"} {
+		endpoint, exp, err := exporterFor(lb, key)
+		require.NoError(t, err)
+		assert.NotEqual(t, "broken:4317", endpoint)
+		assert.Equal(t, exporters[endpoint], exp)
+	}
+
+	res.update([]string{"backend-2:4317", "backend-3:4317"})
+	assert.False(t, first.isRunning())
+	assert.True(t, exporters["backend-2:4317"].isRunning())
+	assert.True(t, exporters["backend-3:4317"].isRunning())
+	for _, key := range []string{"a", "b", "c", "d", "e", "f"} {
+		endpoint, _, err := exporterFor(lb, key)
+		require.NoError(t, err)
+		assert.NotEqual(t, "backend-1:4317", endpoint)
+	}
+
+	require.NoError(t, lb.Shutdown(context.Background()))
+	assert.False(t, exporters["backend-2:4317"].isRunning())
+	assert.False(t, exporters["backend-3:4317"].isRunning())
+
+	// Changes after shutdown don't start exporters anymore.
+	res.update([]string{"backend-4:4317"})
+	assert.NotContains(t, exporters, "backend-4:4317")
+}
+
+func TestLoadBalancerRemovedBackendNotShutdownWhileConsuming(t *testing.T) {
+	lb, res, exporters := newMockLoadBalancer(t)
+	res.update([]string{"backend-1:4317"})
+	backend := exporters["backend-1:4317"]
+
+	consuming := make(chan struct{})
+	release := make(chan struct{})
+	consumed := make(chan error)
+	go func() {
+		consumed <- lb.consumeWith([]byte("key"), func(_ string, exp component.Exporter) error {
+			close(consuming)
+			<-release
+			assert.True(t, exp.(*mockExporter).isRunning())
+			return nil
+		})
+	}()
+	<-consuming
+
+	updated := make(chan struct{})
+	go func() {
+		res.update([]string{"backend-2:4317"})
+		close(updated)
+	}()
+	select {
+	case <-updated:
+		t.Fatal("the backend was removed while its exporter was consuming")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.True(t, backend.isRunning())
+
+	// The other consumers are not blocked by the slow export, and use the new backends.
+	endpoint, _, err := exporterFor(lb, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "backend-2:4317", endpoint)
+
+	close(release)
+	require.NoError(t, <-consumed)
+	<-updated
+	assert.False(t, backend.isRunning())
+	require.NoError(t, lb.Shutdown(context.Background()))
+}
+
+func TestTracesExporterRoutesByTraceID(t *testing.T) {
+	exp, err := newTracesExporter(componenttest.NewNopExporterCreateSettings(), &Config{
+		Resolver: ResolverSettings{Static: &StaticResolver{Hostnames: []string{"unused"}}},
+	})
+	require.NoError(t, err)
+	lb, res, exporters := newMockLoadBalancer(t)
+	exp.loadBalancer = lb
+
+	assert.Contains(t, exp.ConsumeTraces(context.Background(), testTraces()).Error(), errNoBackends.Error())
+
+	res.update([]string{"backend-1:4317", "backend-2:4317", "backend-3:4317"})
+	for i := 0; i < 3; i++ {
+		require.NoError(t, exp.ConsumeTraces(context.Background(), testTraces()))
+	}
+
+	// All the spans of a trace reach the same backend.
+	seen := map[pdata.TraceID]string{}
+	total := 0
+	for endpoint, backend := range exporters {
+		for _, td := range backend.AllTraces() {
+			total += td.SpanCount()
+			rss := td.ResourceSpans()
+			for i := 0; i < rss.Len(); i++ {
+				spans := rss.At(i).InstrumentationLibrarySpans().At(0).Spans()
+				for j := 0; j < spans.Len(); j++ {
+					traceID := spans.At(j).TraceID()
+					if prev, ok := seen[traceID]; ok {
+						assert.Equal(t, prev, endpoint)
+					}
+					seen[traceID] = endpoint
+				}
+			}
+		}
+	}
+	assert.Equal(t, 18, total)
+	assert.Len(t, seen, 2)
+}
+
+func TestMetricsExporterRoutesByResource(t *testing.T) {
+	exp, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), &Config{
+		Resolver: ResolverSettings{Static: &StaticResolver{Hostnames: []string{"unused"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ResourceRoutingKey, exp.routingKey)
+	lb, res, exporters := newMockLoadBalancer(t)
+	exp.loadBalancer = lb
+	res.update([]string{"backend-1:4317", "backend-2:4317"})
+
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("m")
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+
+	received := 0
+	for _, backend := range exporters {
+		if n := len(backend.metrics.AllMetrics()); n > 0 {
+			assert.Equal(t, 2, n)
+			received++
+		}
+	}
+	assert.Equal(t, 1, received)
+}
+
+func TestLogsExporterRoutesByTraceID(t *testing.T) {
+	exp, err := newLogsExporter(componenttest.NewNopExporterCreateSettings(), &Config{
+		Resolver: ResolverSettings{Static: &StaticResolver{Hostnames: []string{"unused"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, TraceIDRoutingKey, exp.routingKey)
+	lb, res, exporters := newMockLoadBalancer(t)
+	exp.loadBalancer = lb
+	res.update([]string{"backend-1:4317", "backend-2:4317"})
+
+	ld := pdata.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs()
+	logs.AppendEmpty().SetTraceID(traceID1)
+	logs.AppendEmpty().SetTraceID(traceID1)
+	require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+
+	received := 0
+	for _, backend := range exporters {
+		if n := backend.logs.LogRecordCount(); n > 0 {
+			assert.Equal(t, 2, n)
+			received++
+		}
+	}
+	assert.Equal(t, 1, received)
+}
+
+func TestMetricsExporterRejectsTraceIDRouting(t *testing.T) {
+	_, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), &Config{
+		RoutingKey: TraceIDRoutingKey,
+		Resolver:   ResolverSettings{Static: &StaticResolver{Hostnames: []string{"unused"}}},
+	})
+	assert.Equal(t, errTraceIDRoutingForMetrics, err)
+}
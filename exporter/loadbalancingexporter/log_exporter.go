@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+type logExporterImp struct {
+	loadBalancer *loadBalancer
+	routingKey   RoutingKey
+}
+
+func newLogsExporter(set component.ExporterCreateSettings, cfg *Config) (*logExporterImp, error) {
+	otlpFactory := otlpexporter.NewFactory()
+	lb, err := newLoadBalancer(set.Logger, cfg, func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return otlpFactory.CreateLogsExporter(ctx, set, buildExporterConfig(cfg, endpoint))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	routingKey := cfg.RoutingKey
+	if routingKey == "" {
+		routingKey = TraceIDRoutingKey
+	}
+	return &logExporterImp{loadBalancer: lb, routingKey: routingKey}, nil
+}
+
+func (e *logExporterImp) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	var errs []error
+	for key, batch := range splitLogs(e.routingKey, ld) {
+		err := e.loadBalancer.consumeWith([]byte(key), func(endpoint string, exp component.Exporter) error {
+			if err := exp.(component.LogsExporter).ConsumeLogs(ctx, batch); err != nil {
+				return fmt.Errorf("failed to export logs to %q: %w", endpoint, err)
+			}
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.Combine(errs)
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+var errTraceIDRoutingForMetrics = errors.New("routing_key traceID is not supported for metrics")
+
+type metricsExporterImp struct {
+	loadBalancer *loadBalancer
+	routingKey   RoutingKey
+}
+
+func newMetricsExporter(set component.ExporterCreateSettings, cfg *Config) (*metricsExporterImp, error) {
+	routingKey := cfg.RoutingKey
+	switch routingKey {
+	case "":
+		routingKey = ResourceRoutingKey
+	case TraceIDRoutingKey:
+		return nil, errTraceIDRoutingForMetrics
+	}
+
+	otlpFactory := otlpexporter.NewFactory()
+	lb, err := newLoadBalancer(set.Logger, cfg, func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return otlpFactory.CreateMetricsExporter(ctx, set, buildExporterConfig(cfg, endpoint))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &metricsExporterImp{loadBalancer: lb, routingKey: routingKey}, nil
+}
+
+func (e *metricsExporterImp) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	var errs []error
+	for key, batch := range splitMetrics(e.routingKey, md) {
+		err := e.loadBalancer.consumeWith([]byte(key), func(endpoint string, exp component.Exporter) error {
+			if err := exp.(component.MetricsExporter).ConsumeMetrics(ctx, batch); err != nil {
+				return fmt.Errorf("failed to export metrics to %q: %w", endpoint, err)
+			}
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.Combine(errs)
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const defaultPort = "4317"
+
+// resolver provides the list of backends, and notifies the changes to that list.
+type resolver interface {
+	// start resolves the initial list of backends and starts watching for changes.
+	start(ctx context.Context) error
+	// shutdown stops watching for changes.
+	shutdown(ctx context.Context) error
+	// onChange registers a callback invoked with the sorted list of backends every time it changes.
+	onChange(func(endpoints []string))
+}
+
+// withDefaultPort adds the default OTLP/gRPC port to the endpoint if it has none.
+func withDefaultPort(endpoint string) string {
+	if _, _, err := net.SplitHostPort(endpoint); err == nil {
+		return endpoint
+	}
+	return net.JoinHostPort(strings.TrimSuffix(strings.TrimPrefix(endpoint, "["), "]"), defaultPort)
+}
+
+type staticResolver struct {
+	endpoints []string
+
+	mu        sync.Mutex
+	callbacks []func([]string)
+}
+
+var _ resolver = (*staticResolver)(nil)
+
+func newStaticResolver(hostnames []string) *staticResolver {
+	seen := make(map[string]struct{}, len(hostnames))
+	endpoints := make([]string, 0, len(hostnames))
+	for _, hostname := range hostnames {
+		endpoint := withDefaultPort(hostname)
+		if _, ok := seen[endpoint]; ok {
+			continue
+		}
+		seen[endpoint] = struct{}{}
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	return &staticResolver{endpoints: endpoints}
+}
+
+func (r *staticResolver) start(context.Context) error {
+	r.mu.Lock()
+	callbacks := r.callbacks
+	r.mu.Unlock()
+
+	for _, callback := range callbacks {
+		callback(r.endpoints)
+	}
+	return nil
+}
+
+func (r *staticResolver) shutdown(context.Context) error {
+	return nil
+}
+
+func (r *staticResolver) onChange(callback func([]string)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.callbacks = append(r.callbacks, callback)
+}
@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultDNSInterval = 5 * time.Second
+	defaultDNSTimeout  = time.Second
+)
+
+// dnsResolver periodically resolves a hostname into the IP addresses of the backends.
+type dnsResolver struct {
+	logger   *zap.Logger
+	hostname string
+	port     string
+	interval time.Duration
+	timeout  time.Duration
+	lookup   func(ctx context.Context, host string) ([]net.IPAddr, error)
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+
+	mu        sync.Mutex
+	callbacks []func([]string)
+	endpoints []string
+}
+
+var _ resolver = (*dnsResolver)(nil)
+
+func newDNSResolver(logger *zap.Logger, cfg *DNSResolver) *dnsResolver {
+	r := &dnsResolver{
+		logger:   logger,
+		hostname: cfg.Hostname,
+		port:     cfg.Port,
+		interval: cfg.Interval,
+		timeout:  cfg.Timeout,
+		lookup:   net.DefaultResolver.LookupIPAddr,
+		stopCh:   make(chan struct{}),
+	}
+	if r.port == "" {
+		r.port = defaultPort
+	}
+	if r.interval == 0 {
+		r.interval = defaultDNSInterval
+	}
+	if r.timeout == 0 {
+		r.timeout = defaultDNSTimeout
+	}
+	return r
+}
+
+func (r *dnsResolver) start(ctx context.Context) error {
+	if err := r.resolve(ctx); err != nil {
+		// The backends may not be available yet, keep trying in the background.
+		r.logger.Warn("Failed to resolve the backends", zap.String("hostname", r.hostname), zap.Error(err))
+	}
+
+	r.wg.Add(1)
+	go r.periodicallyResolve()
+	return nil
+}
+
+func (r *dnsResolver) shutdown(context.Context) error {
+	r.stopOnce.Do(func() { close(r.stopCh) })
+	r.wg.Wait()
+	return nil
+}
+
+func (r *dnsResolver) onChange(callback func([]string)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.callbacks = append(r.callbacks, callback)
+}
+
+func (r *dnsResolver) periodicallyResolve() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.resolve(context.Background()); err != nil {
+				r.logger.Warn("Failed to resolve the backends", zap.String("hostname", r.hostname), zap.Error(err))
+			}
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+// resolve looks up the backends and notifies the callbacks if they changed.
+// The previous backends are kept if the resolution fails.
+func (r *dnsResolver) resolve(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	addrs, err := r.lookup(ctx, r.hostname)
+	if err != nil {
+		return err
+	}
+
+	endpoints := make([]string, 0, len(addrs))
+	seen := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		endpoint := net.JoinHostPort(addr.IP.String(), r.port)
+		if _, ok := seen[endpoint]; ok {
+			continue
+		}
+		seen[endpoint] = struct{}{}
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	r.mu.Lock()
+	if equalEndpoints(r.endpoints, endpoints) {
+		r.mu.Unlock()
+		return nil
+	}
+	r.endpoints = endpoints
+	callbacks := r.callbacks
+	r.mu.Unlock()
+
+	for _, callback := range callbacks {
+		callback(endpoints)
+	}
+	return nil
+}
+
+func equalEndpoints(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
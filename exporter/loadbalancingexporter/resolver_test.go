@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestStaticResolver(t *testing.T) {
+	res := newStaticResolver([]string{"backend-2", "backend-1:55690", "backend-2:4317", "[::1]"})
+
+	var got []string
+	res.onChange(func(endpoints []string) { got = endpoints })
+	require.NoError(t, res.start(context.Background()))
+	require.NoError(t, res.shutdown(context.Background()))
+
+	assert.Equal(t, []string{"[::1]:4317", "backend-1:55690", "backend-2:4317"}, got)
+}
+
+func TestDNSResolver(t *testing.T) {
+	var mu sync.Mutex
+	ips := []string{"10.0.0.2", "10.0.0.1"}
+	var lookupErr error
+
+	res := newDNSResolver(zap.NewNop(), &DNSResolver{Hostname: "backends", Port: "55690"})
+	assert.Equal(t, defaultDNSInterval, res.interval)
+	assert.Equal(t, defaultDNSTimeout, res.timeout)
+	res.lookup = func(_ context.Context, host string) ([]net.IPAddr, error) {
+		assert.Equal(t, "backends", host)
+		mu.Lock()
+		defer mu.Unlock()
+		if lookupErr != nil {
+			return nil, lookupErr
+		}
+		addrs := make([]net.IPAddr, 0, len(ips))
+		for _, ip := range ips {
+			addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+		}
+		return addrs, nil
+	}
+
+	var changes [][]string
+	res.onChange(func(endpoints []string) { changes = append(changes, endpoints) })
+
+	require.NoError(t, res.resolve(context.Background()))
+	assert.Equal(t, [][]string{{"10.0.0.1:55690", "10.0.0.2:55690"}}, changes)
+
+	// Unchanged backends don't trigger a notification.
+	ips = []string{"10.0.0.1", "10.0.0.2", "10.0.0.1"}
+	require.NoError(t, res.resolve(context.Background()))
+	assert.Len(t, changes, 1)
+
+	// A failed resolution keeps the previous backends.
+	lookupErr = errors.New("no such host")
+	assert.Error(t, res.resolve(context.Background()))
+	assert.Len(t, changes, 1)
+
+	lookupErr = nil
+	ips = []string{"10.0.0.3", "::1"}
+	require.NoError(t, res.resolve(context.Background()))
+	assert.Equal(t, []string{"10.0.0.3:55690", "[::1]:55690"}, changes[1])
+}
+
+func TestDNSResolverPeriodicallyResolves(t *testing.T) {
+	res := newDNSResolver(zap.NewNop(), &DNSResolver{Hostname: "backends", Interval: 10 * time.Millisecond})
+
+	var mu sync.Mutex
+	calls := 0
+	res.lookup = func(context.Context, string) ([]net.IPAddr, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls == 1 {
+			return nil, errors.New("not ready yet")
+		}
+		return []net.IPAddr{{IP: net.ParseIP("10.0.0.1")}}, nil
+	}
+
+	resolved := make(chan []string, 1)
+	res.onChange(func(endpoints []string) { resolved <- endpoints })
+
+	// The initial failure doesn't prevent the resolver from starting.
+	require.NoError(t, res.start(context.Background()))
+	select {
+	case endpoints := <-resolved:
+		assert.Equal(t, []string{"10.0.0.1:4317"}, endpoints)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the backends were not resolved")
+	}
+	require.NoError(t, res.shutdown(context.Background()))
+}
+
+func TestDNSResolverShutdownTwice(t *testing.T) {
+	res := newDNSResolver(zap.NewNop(), &DNSResolver{Hostname: "service-1"})
+	res.lookup = func(context.Context, string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("10.0.0.1")}}, nil
+	}
+
+	require.NoError(t, res.start(context.Background()))
+	require.NoError(t, res.shutdown(context.Background()))
+	require.NoError(t, res.shutdown(context.Background()))
+}
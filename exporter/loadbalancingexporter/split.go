@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+)
+
+// resourceKey returns the routing key of the data of the given resource.
+func resourceKey(routingKey RoutingKey, resource pdata.Resource) string {
+	if routingKey == ServiceRoutingKey {
+		if svc, ok := resource.Attributes().Get(conventions.AttributeServiceName); ok {
+			return svc.StringVal()
+		}
+		return ""
+	}
+
+	// Sort a copy of the attributes, the data is not owned by the exporter.
+	attrs := make([]string, 0, resource.Attributes().Len())
+	resource.Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+		attrs = append(attrs, k+"="+pdata.AttributeValueToString(v))
+		return true
+	})
+	sort.Strings(attrs)
+	return strings.Join(attrs, ";")
+}
+
+func traceIDKey(traceID pdata.TraceID) string {
+	id := traceID.Bytes()
+	return string(id[:])
+}
+
+// splitTraces splits the traces into one batch per routing key, keeping the
+// resource and instrumentation library of every span.
+func splitTraces(routingKey RoutingKey, td pdata.Traces) map[string]pdata.Traces {
+	batches := map[string]pdata.Traces{}
+	batchFor := func(key string) pdata.Traces {
+		batch, ok := batches[key]
+		if !ok {
+			batch = pdata.NewTraces()
+			batches[key] = batch
+		}
+		return batch
+	}
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if routingKey != TraceIDRoutingKey {
+			rs.CopyTo(batchFor(resourceKey(routingKey, rs.Resource())).ResourceSpans().AppendEmpty())
+			continue
+		}
+
+		destRSs := map[string]pdata.ResourceSpans{}
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			destILSs := map[string]pdata.InstrumentationLibrarySpans{}
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				key := traceIDKey(span.TraceID())
+
+				destILS, ok := destILSs[key]
+				if !ok {
+					destRS, found := destRSs[key]
+					if !found {
+						destRS = batchFor(key).ResourceSpans().AppendEmpty()
+						rs.Resource().CopyTo(destRS.Resource())
+						destRS.SetSchemaUrl(rs.SchemaUrl())
+						destRSs[key] = destRS
+					}
+					destILS = destRS.InstrumentationLibrarySpans().AppendEmpty()
+					ils.InstrumentationLibrary().CopyTo(destILS.InstrumentationLibrary())
+					destILS.SetSchemaUrl(ils.SchemaUrl())
+					destILSs[key] = destILS
+				}
+				span.CopyTo(destILS.Spans().AppendEmpty())
+			}
+		}
+	}
+	return batches
+}
+
+// splitLogs splits the logs into one batch per routing key, keeping the
+// resource and instrumentation library of every log record. When routing by
+// trace ID, the log records without a trace ID are routed by resource.
+func splitLogs(routingKey RoutingKey, ld pdata.Logs) map[string]pdata.Logs {
+	batches := map[string]pdata.Logs{}
+	batchFor := func(key string) pdata.Logs {
+		batch, ok := batches[key]
+		if !ok {
+			batch = pdata.NewLogs()
+			batches[key] = batch
+		}
+		return batch
+	}
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if routingKey != TraceIDRoutingKey {
+			rl.CopyTo(batchFor(resourceKey(routingKey, rl.Resource())).ResourceLogs().AppendEmpty())
+			continue
+		}
+
+		resKey := resourceKey(ResourceRoutingKey, rl.Resource())
+		destRLs := map[string]pdata.ResourceLogs{}
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			destILLs := map[string]pdata.InstrumentationLibraryLogs{}
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				key := resKey
+				if !lr.TraceID().IsEmpty() {
+					key = traceIDKey(lr.TraceID())
+				}
+
+				destILL, ok := destILLs[key]
+				if !ok {
+					destRL, found := destRLs[key]
+					if !found {
+						destRL = batchFor(key).ResourceLogs().AppendEmpty()
+						rl.Resource().CopyTo(destRL.Resource())
+						destRL.SetSchemaUrl(rl.SchemaUrl())
+						destRLs[key] = destRL
+					}
+					destILL = destRL.InstrumentationLibraryLogs().AppendEmpty()
+					ill.InstrumentationLibrary().CopyTo(destILL.InstrumentationLibrary())
+					destILL.SetSchemaUrl(ill.SchemaUrl())
+					destILLs[key] = destILL
+				}
+				lr.CopyTo(destILL.Logs().AppendEmpty())
+			}
+		}
+	}
+	return batches
+}
+
+// splitMetrics splits the metrics into one batch per routing key. Metrics are
+// always routed by resource, so that all the points of a series reach the same backend.
+func splitMetrics(routingKey RoutingKey, md pdata.Metrics) map[string]pdata.Metrics {
+	batches := map[string]pdata.Metrics{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		key := resourceKey(routingKey, rm.Resource())
+		batch, ok := batches[key]
+		if !ok {
+			batch = pdata.NewMetrics()
+			batches[key] = batch
+		}
+		rm.CopyTo(batch.ResourceMetrics().AppendEmpty())
+	}
+	return batches
+}
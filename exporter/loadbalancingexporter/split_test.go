@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+)
+
+var (
+	traceID1 = pdata.NewTraceID([16]byte{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1})
+	traceID2 = pdata.NewTraceID([16]byte{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2})
+)
+
+func testTraces() pdata.Traces {
+	td := pdata.NewTraces()
+	for _, svc := range []string{"svc-1", "svc-2"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, svc)
+		ils := rs.InstrumentationLibrarySpans().AppendEmpty()
+		ils.InstrumentationLibrary().SetName("lib")
+		for _, traceID := range []pdata.TraceID{traceID1, traceID2, traceID1} {
+			span := ils.Spans().AppendEmpty()
+			span.SetName(svc)
+			span.SetTraceID(traceID)
+		}
+	}
+	return td
+}
+
+func TestSplitTracesByTraceID(t *testing.T) {
+	batches := splitTraces(TraceIDRoutingKey, testTraces())
+	require.Len(t, batches, 2)
+
+	for _, traceID := range []pdata.TraceID{traceID1, traceID2} {
+		batch, ok := batches[traceIDKey(traceID)]
+		require.True(t, ok)
+		require.Equal(t, 2, batch.ResourceSpans().Len())
+		for i := 0; i < batch.ResourceSpans().Len(); i++ {
+			rs := batch.ResourceSpans().At(i)
+			svc, _ := rs.Resource().Attributes().Get(conventions.AttributeServiceName)
+			require.Equal(t, 1, rs.InstrumentationLibrarySpans().Len())
+			ils := rs.InstrumentationLibrarySpans().At(0)
+			assert.Equal(t, "lib", ils.InstrumentationLibrary().Name())
+			for j := 0; j < ils.Spans().Len(); j++ {
+				assert.Equal(t, traceID, ils.Spans().At(j).TraceID())
+				assert.Equal(t, svc.StringVal(), ils.Spans().At(j).Name())
+			}
+		}
+	}
+	assert.Equal(t, 4, batches[traceIDKey(traceID1)].SpanCount())
+	assert.Equal(t, 2, batches[traceIDKey(traceID2)].SpanCount())
+}
+
+func TestSplitTracesByService(t *testing.T) {
+	batches := splitTraces(ServiceRoutingKey, testTraces())
+	require.Len(t, batches, 2)
+	assert.Equal(t, 3, batches["svc-1"].SpanCount())
+	assert.Equal(t, 3, batches["svc-2"].SpanCount())
+}
+
+func TestSplitLogs(t *testing.T) {
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString(conventions.AttributeServiceName, "svc-1")
+	rl.Resource().Attributes().InsertString(conventions.AttributeHostName, "host-1")
+	logs := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+	logs.AppendEmpty().SetTraceID(traceID1)
+	logs.AppendEmpty().SetTraceID(traceID2)
+	logs.AppendEmpty()
+
+	batches := splitLogs(TraceIDRoutingKey, ld)
+	require.Len(t, batches, 3)
+	assert.Equal(t, 1, batches[traceIDKey(traceID1)].LogRecordCount())
+	assert.Equal(t, 1, batches[traceIDKey(traceID2)].LogRecordCount())
+	// Log records without a trace ID are routed by resource.
+	assert.Equal(t, 1, batches["host.name=host-1;service.name=svc-1"].LogRecordCount())
+
+	batches = splitLogs(ServiceRoutingKey, ld)
+	require.Len(t, batches, 1)
+	assert.Equal(t, 3, batches["svc-1"].LogRecordCount())
+}
+
+func TestSplitMetrics(t *testing.T) {
+	md := pdata.NewMetrics()
+	for _, host := range []string{"host-1", "host-2", "host-1"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().InsertString(conventions.AttributeServiceName, "svc-1")
+		rm.Resource().Attributes().InsertString(conventions.AttributeHostName, host)
+		rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty().SetName(host)
+	}
+
+	batches := splitMetrics(ResourceRoutingKey, md)
+	require.Len(t, batches, 2)
+	assert.Equal(t, 2, batches["host.name=host-1;service.name=svc-1"].MetricCount())
+	assert.Equal(t, 1, batches["host.name=host-2;service.name=svc-1"].MetricCount())
+
+	batches = splitMetrics(ServiceRoutingKey, md)
+	require.Len(t, batches, 1)
+	assert.Equal(t, 3, batches["svc-1"].MetricCount())
+}
@@ -57,3 +57,8 @@ func TestCreateLogsExporter(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, te)
 }
+
+func TestConformance(t *testing.T) {
+	factory := NewFactory()
+	componenttest.VerifyExporterConformance(t, factory, factory.CreateDefaultConfig(), nil, nil)
+}
@@ -69,7 +69,7 @@ func newOcExporter(_ context.Context, cfg *Config) (*ocExporter, error) {
 
 	oce := &ocExporter{
 		cfg:      cfg,
-		metadata: metadata.New(cfg.GRPCClientSettings.Headers),
+		metadata: cfg.GRPCClientSettings.HeadersMetadata(),
 	}
 	return oce, nil
 }
@@ -246,7 +246,7 @@ func (oce *ocExporter) createTraceServiceRPC() (*tracesClientWithCancel, error)
 	// Initiate the trace service by sending over node identifier info.
 	ctx, cancel := context.WithCancel(context.Background())
 	if len(oce.cfg.Headers) > 0 {
-		ctx = metadata.NewOutgoingContext(ctx, metadata.New(oce.cfg.Headers))
+		ctx = metadata.NewOutgoingContext(ctx, oce.cfg.HeadersMetadata())
 	}
 	// Cannot use grpc.WaitForReady(cfg.WaitForReady) because will block forever.
 	traceClient, err := oce.traceSvcClient.Export(ctx)
@@ -261,7 +261,7 @@ func (oce *ocExporter) createMetricsServiceRPC() (*metricsClientWithCancel, erro
 	// Initiate the trace service by sending over node identifier info.
 	ctx, cancel := context.WithCancel(context.Background())
 	if len(oce.cfg.Headers) > 0 {
-		ctx = metadata.NewOutgoingContext(ctx, metadata.New(oce.cfg.Headers))
+		ctx = metadata.NewOutgoingContext(ctx, oce.cfg.HeadersMetadata())
 	}
 	// Cannot use grpc.WaitForReady(cfg.WaitForReady) because will block forever.
 	metricsClient, err := oce.metricsSvcClient.Export(ctx)
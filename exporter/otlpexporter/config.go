@@ -22,10 +22,14 @@ import (
 
 // Config defines configuration for OpenCensus exporter.
 type Config struct {
-	config.ExporterSettings        `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
-	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
-	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
-	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+	config.ExporterSettings               `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	exporterhelper.TimeoutSettings        `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings          `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings          `mapstructure:"retry_on_failure"`
+	exporterhelper.CircuitBreakerSettings `mapstructure:"circuit_breaker"`
+	exporterhelper.DeadLetterSettings     `mapstructure:"dead_letter"`
+	exporterhelper.SplitSettings          `mapstructure:"split"`
+	exporterhelper.BatchSettings          `mapstructure:"batch"`
 
 	configgrpc.GRPCClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
 }
@@ -34,5 +38,14 @@ var _ config.Exporter = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
-	return nil
+	if err := cfg.CircuitBreakerSettings.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.DeadLetterSettings.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.SplitSettings.Validate(); err != nil {
+		return err
+	}
+	return cfg.BatchSettings.Validate()
 }
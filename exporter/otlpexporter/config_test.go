@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtest"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -69,8 +70,21 @@ func TestLoadConfig(t *testing.T) {
 				NumConsumers: 2,
 				QueueSize:    10,
 			},
+			CircuitBreakerSettings: exporterhelper.CircuitBreakerSettings{
+				Enabled:          true,
+				FailureThreshold: 10,
+				FailureRatio:     0.5,
+				MinRequests:      20,
+				Window:           time.Minute,
+				CoolDown:         time.Minute,
+			},
+			DeadLetterSettings: exporterhelper.DeadLetterSettings{
+				Exporter: "otlp/dead_letter",
+			},
+			SplitSettings: exporterhelper.DefaultSplitSettings(),
+			BatchSettings: exporterhelper.DefaultBatchSettings(),
 			GRPCClientSettings: configgrpc.GRPCClientSettings{
-				Headers: map[string]string{
+				Headers: map[string]configopaque.String{
 					"can you have a . here?": "F0000000-0000-0000-0000-000000000000",
 					"header1":                "234",
 					"another":                "somevalue",
@@ -20,6 +20,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
@@ -41,12 +42,15 @@ func NewFactory() component.ExporterFactory {
 
 func createDefaultConfig() config.Exporter {
 	return &Config{
-		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
-		TimeoutSettings:  exporterhelper.DefaultTimeoutSettings(),
-		RetrySettings:    exporterhelper.DefaultRetrySettings(),
-		QueueSettings:    exporterhelper.DefaultQueueSettings(),
+		ExporterSettings:       config.NewExporterSettings(config.NewID(typeStr)),
+		TimeoutSettings:        exporterhelper.DefaultTimeoutSettings(),
+		RetrySettings:          exporterhelper.DefaultRetrySettings(),
+		QueueSettings:          exporterhelper.DefaultQueueSettings(),
+		CircuitBreakerSettings: exporterhelper.DefaultCircuitBreakerSettings(),
+		SplitSettings:          exporterhelper.DefaultSplitSettings(),
+		BatchSettings:          exporterhelper.DefaultBatchSettings(),
 		GRPCClientSettings: configgrpc.GRPCClientSettings{
-			Headers: map[string]string{},
+			Headers: map[string]configopaque.String{},
 			// We almost read 0 bytes, so no need to tune ReadBufferSize.
 			WriteBufferSize: 512 * 1024,
 		},
@@ -71,6 +75,10 @@ func createTracesExporter(
 		exporterhelper.WithTimeout(oCfg.TimeoutSettings),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
 		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerSettings),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterSettings),
+		exporterhelper.WithSplit(oCfg.SplitSettings),
+		exporterhelper.WithBatch(oCfg.BatchSettings),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown))
 }
@@ -93,6 +101,10 @@ func createMetricsExporter(
 		exporterhelper.WithTimeout(oCfg.TimeoutSettings),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
 		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerSettings),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterSettings),
+		exporterhelper.WithSplit(oCfg.SplitSettings),
+		exporterhelper.WithBatch(oCfg.BatchSettings),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown),
 	)
@@ -116,6 +128,10 @@ func createLogsExporter(
 		exporterhelper.WithTimeout(oCfg.TimeoutSettings),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
 		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerSettings),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterSettings),
+		exporterhelper.WithSplit(oCfg.SplitSettings),
+		exporterhelper.WithBatch(oCfg.BatchSettings),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown),
 	)
@@ -26,8 +26,10 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configcheck"
 	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver"
 	"go.opentelemetry.io/collector/testutil"
 )
 
@@ -114,7 +116,7 @@ func TestCreateTracesExporter(t *testing.T) {
 				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
 				GRPCClientSettings: configgrpc.GRPCClientSettings{
 					Endpoint: endpoint,
-					Headers: map[string]string{
+					Headers: map[string]configopaque.String{
 						"hdr1": "val1",
 						"hdr2": "val2",
 					},
@@ -209,3 +211,25 @@ func TestCreateLogsExporter(t *testing.T) {
 	require.Nil(t, err)
 	require.NotNil(t, oexp)
 }
+
+func TestShutdownWithoutStart(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings.Endpoint = testutil.GetAvailableLocalAddress(t)
+	exp, err := factory.CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	assert.NoError(t, exp.Shutdown(context.Background()))
+}
+
+func TestConformance(t *testing.T) {
+	destination := otlpreceiver.NewFactory()
+	destinationCfg := destination.CreateDefaultConfig().(*otlpreceiver.Config)
+	destinationCfg.GRPC.NetAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	destinationCfg.HTTP = nil
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings.Endpoint = destinationCfg.GRPC.NetAddr.Endpoint
+	cfg.GRPCClientSettings.TLSSetting.Insecure = true
+	componenttest.VerifyExporterConformance(t, factory, cfg, destination, destinationCfg)
+}
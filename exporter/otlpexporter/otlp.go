@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -60,6 +61,10 @@ func (e *exporter) start(_ context.Context, host component.Host) (err error) {
 }
 
 func (e *exporter) shutdown(context.Context) error {
+	// The connection is only created when the exporter is started.
+	if e.w == nil {
+		return nil
+	}
 	return e.w.stop()
 }
 
@@ -110,7 +115,7 @@ func newGrpcSender(config *Config, ext map[config.ComponentID]component.Extensio
 		metricExporter: otlpgrpc.NewMetricsClient(clientConn),
 		logExporter:    otlpgrpc.NewLogsClient(clientConn),
 		clientConn:     clientConn,
-		metadata:       metadata.New(config.GRPCClientSettings.Headers),
+		metadata:       config.GRPCClientSettings.HeadersMetadata(),
 		callOptions: []grpc.CallOption{
 			grpc.WaitForReady(config.GRPCClientSettings.WaitForReady),
 		},
@@ -176,9 +181,21 @@ func processError(err error) error {
 		return exporterhelper.NewThrottleRetry(err, throttleDuration)
 	}
 
+	// ResourceExhausted is also used for exhausted quotas, the message is only too large
+	// if the server, or the client itself, says so.
+	if st.Code() == codes.ResourceExhausted && isMessageTooLarge(st) {
+		return exporterhelper.NewPayloadTooLargeError(err)
+	}
+
 	return err
 }
 
+// isMessageTooLarge returns true if the status reports a message exceeding the maximum size
+// accepted by a gRPC server or client, e.g. "grpc: received message larger than max (5 vs. 4)".
+func isMessageTooLarge(st *status.Status) bool {
+	return strings.Contains(st.Message(), "message larger than max")
+}
+
 func shouldRetry(code codes.Code) bool {
 	switch code {
 	case codes.OK:
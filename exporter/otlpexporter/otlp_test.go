@@ -26,14 +26,21 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/model/otlpgrpc"
 	"go.opentelemetry.io/collector/model/pdata"
@@ -195,7 +202,7 @@ func TestSendTraces(t *testing.T) {
 		TLSSetting: configtls.TLSClientSetting{
 			Insecure: true,
 		},
-		Headers: map[string]string{
+		Headers: map[string]configopaque.String{
 			"header": "header-value",
 		},
 	}
@@ -336,7 +343,7 @@ func TestSendMetrics(t *testing.T) {
 		TLSSetting: configtls.TLSClientSetting{
 			Insecure: true,
 		},
-		Headers: map[string]string{
+		Headers: map[string]configopaque.String{
 			"header": "header-value",
 		},
 	}
@@ -584,3 +591,22 @@ func TestSendLogData(t *testing.T) {
 	assert.EqualValues(t, 2, atomic.LoadInt32(&rcv.totalItems))
 	assert.EqualValues(t, ld, rcv.GetLastRequest())
 }
+
+func TestProcessError(t *testing.T) {
+	assert.NoError(t, processError(nil))
+	assert.True(t, consumererror.IsPermanent(processError(status.Error(codes.InvalidArgument, "invalid"))))
+
+	unavailable := processError(status.Error(codes.Unavailable, "unavailable"))
+	assert.False(t, consumererror.IsPermanent(unavailable))
+	assert.Equal(t, status.Error(codes.Unavailable, "unavailable"), unavailable)
+
+	// ResourceExhausted with RetryInfo is throttling, without it the message is too large
+	// only if the status says so, otherwise it is retried.
+	st, err := status.New(codes.ResourceExhausted, "quota exceeded").WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Second)})
+	require.NoError(t, err)
+	assert.Equal(t, exporterhelper.NewThrottleRetry(st.Err(), time.Second), processError(st.Err()))
+	tooLarge := status.Error(codes.ResourceExhausted, "grpc: received message larger than max (5 vs. 4)")
+	assert.Equal(t, exporterhelper.NewPayloadTooLargeError(tooLarge), processError(tooLarge))
+	exhausted := status.Error(codes.ResourceExhausted, "quota exceeded")
+	assert.Equal(t, exhausted, processError(exhausted))
+}
@@ -22,10 +22,14 @@ import (
 
 // Config defines configuration for OTLP/HTTP exporter.
 type Config struct {
-	config.ExporterSettings       `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
-	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
-	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
-	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+	config.ExporterSettings               `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	confighttp.HTTPClientSettings         `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings          `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings          `mapstructure:"retry_on_failure"`
+	exporterhelper.CircuitBreakerSettings `mapstructure:"circuit_breaker"`
+	exporterhelper.DeadLetterSettings     `mapstructure:"dead_letter"`
+	exporterhelper.SplitSettings          `mapstructure:"split"`
+	exporterhelper.BatchSettings          `mapstructure:"batch"`
 
 	// The URL to send traces to. If omitted the Endpoint + "/v1/traces" will be used.
 	TracesEndpoint string `mapstructure:"traces_endpoint"`
@@ -45,5 +49,14 @@ var _ config.Exporter = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
-	return nil
+	if err := cfg.CircuitBreakerSettings.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.DeadLetterSettings.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.SplitSettings.Validate(); err != nil {
+		return err
+	}
+	return cfg.BatchSettings.Validate()
 }
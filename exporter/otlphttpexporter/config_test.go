@@ -25,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtest"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -59,8 +60,19 @@ func TestLoadConfig(t *testing.T) {
 				NumConsumers: 2,
 				QueueSize:    10,
 			},
+			CircuitBreakerSettings: exporterhelper.DefaultCircuitBreakerSettings(),
+			SplitSettings: exporterhelper.SplitSettings{
+				Enabled:      true,
+				MinSizeItems: 10,
+			},
+			BatchSettings: exporterhelper.BatchSettings{
+				Enabled:      true,
+				MinSizeItems: 1000,
+				MaxSizeItems: 2000,
+				FlushTimeout: time.Second,
+			},
 			HTTPClientSettings: confighttp.HTTPClientSettings{
-				Headers: map[string]string{
+				Headers: map[string]configopaque.String{
 					"can you have a . here?": "F0000000-0000-0000-0000-000000000000",
 					"header1":                "234",
 					"another":                "somevalue",
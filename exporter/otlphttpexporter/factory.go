@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
@@ -44,13 +45,16 @@ func NewFactory() component.ExporterFactory {
 
 func createDefaultConfig() config.Exporter {
 	return &Config{
-		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
-		RetrySettings:    exporterhelper.DefaultRetrySettings(),
-		QueueSettings:    exporterhelper.DefaultQueueSettings(),
+		ExporterSettings:       config.NewExporterSettings(config.NewID(typeStr)),
+		RetrySettings:          exporterhelper.DefaultRetrySettings(),
+		QueueSettings:          exporterhelper.DefaultQueueSettings(),
+		CircuitBreakerSettings: exporterhelper.DefaultCircuitBreakerSettings(),
+		SplitSettings:          exporterhelper.DefaultSplitSettings(),
+		BatchSettings:          exporterhelper.DefaultBatchSettings(),
 		HTTPClientSettings: confighttp.HTTPClientSettings{
 			Endpoint: "",
 			Timeout:  30 * time.Second,
-			Headers:  map[string]string{},
+			Headers:  map[string]configopaque.String{},
 			// We almost read 0 bytes, so no need to tune ReadBufferSize.
 			WriteBufferSize: 512 * 1024,
 		},
@@ -97,7 +101,11 @@ func createTracesExporter(
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
-		exporterhelper.WithQueue(oCfg.QueueSettings))
+		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerSettings),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterSettings),
+		exporterhelper.WithSplit(oCfg.SplitSettings),
+		exporterhelper.WithBatch(oCfg.BatchSettings))
 }
 
 func createMetricsExporter(
@@ -125,7 +133,11 @@ func createMetricsExporter(
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
-		exporterhelper.WithQueue(oCfg.QueueSettings))
+		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerSettings),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterSettings),
+		exporterhelper.WithSplit(oCfg.SplitSettings),
+		exporterhelper.WithBatch(oCfg.BatchSettings))
 }
 
 func createLogsExporter(
@@ -153,5 +165,9 @@ func createLogsExporter(
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
-		exporterhelper.WithQueue(oCfg.QueueSettings))
+		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerSettings),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterSettings),
+		exporterhelper.WithSplit(oCfg.SplitSettings),
+		exporterhelper.WithBatch(oCfg.BatchSettings))
 }
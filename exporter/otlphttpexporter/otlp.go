@@ -23,9 +23,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
-	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/genproto/googleapis/rpc/status"
@@ -57,10 +55,7 @@ var (
 	logsMarshaler    = otlp.NewProtobufLogsMarshaler()
 )
 
-const (
-	headerRetryAfter         = "Retry-After"
-	maxHTTPResponseReadBytes = 64 * 1024
-)
+const maxHTTPResponseReadBytes = 64 * 1024
 
 // Crete new exporter.
 func newExporter(cfg config.Exporter, logger *zap.Logger) (*exporter, error) {
@@ -145,7 +140,8 @@ func (e *exporter) export(ctx context.Context, url string, request []byte) error
 	}()
 
 	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
-		// Request is successful.
+		// Request is successful, the server may still have rejected part of the data.
+		e.logPartialSuccess(url, resp)
 		return nil
 	}
 
@@ -163,28 +159,7 @@ func (e *exporter) export(ctx context.Context, url string, request []byte) error
 			url, resp.StatusCode)
 	}
 
-	// Check if the server is overwhelmed.
-	// See spec https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#throttling-1
-	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
-		// Fallback to 0 if the Retry-After header is not present. This will trigger the
-		// default backoff policy by our caller (retry handler).
-		retryAfter := 0
-		if val := resp.Header.Get(headerRetryAfter); val != "" {
-			if seconds, err2 := strconv.Atoi(val); err2 == nil {
-				retryAfter = seconds
-			}
-		}
-		// Indicate to our caller to pause for the specified number of seconds.
-		return exporterhelper.NewThrottleRetry(formattedErr, time.Duration(retryAfter)*time.Second)
-	}
-
-	if resp.StatusCode == http.StatusBadRequest {
-		// Report the failure as permanent if the server thinks the request is malformed.
-		return consumererror.Permanent(formattedErr)
-	}
-
-	// All other errors are retryable, so don't wrap them in consumererror.Permanent().
-	return formattedErr
+	return exporterhelper.NewHTTPResponseError(resp, formattedErr)
 }
 
 // Read the response and decode the status.Status from the body.
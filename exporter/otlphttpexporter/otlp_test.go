@@ -389,7 +389,12 @@ func TestErrorResponses(t *testing.T) {
 		{
 			name:           "404",
 			responseStatus: http.StatusNotFound,
-			err:            fmt.Errorf(errMsgPrefix + "404"),
+			isPermErr:      true,
+		},
+		{
+			name:           "500",
+			responseStatus: http.StatusInternalServerError,
+			err:            fmt.Errorf(errMsgPrefix + "500"),
 		},
 		{
 			name:           "419",
@@ -416,6 +421,14 @@ func TestErrorResponses(t *testing.T) {
 				fmt.Errorf(errMsgPrefix+"503, Message=Server overloaded, Details=[]"),
 				time.Duration(30)*time.Second),
 		},
+		{
+			name:           "429-Retry-After-Date",
+			responseStatus: http.StatusTooManyRequests,
+			headers:        map[string]string{"Retry-After": "Wed, 21 Oct 2015 07:28:00 GMT"},
+			err: exporterhelper.NewThrottleRetry(
+				fmt.Errorf(errMsgPrefix+"429"),
+				time.Duration(0)*time.Second),
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpexporter
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// partialSuccess holds the partial_success field of an OTLP Export*ServiceResponse.
+// The generated OTLP types vendored in pdata predate the field, so it is decoded here
+// directly from the wire format. The layout is identical for traces, metrics and logs:
+//
+//	message Export*ServiceResponse {
+//	  Export*PartialSuccess partial_success = 1;
+//	}
+//	message Export*PartialSuccess {
+//	  int64 rejected_<items> = 1;
+//	  string error_message = 2;
+//	}
+type partialSuccess struct {
+	rejected     int64
+	errorMessage string
+}
+
+var errInvalidResponse = errors.New("invalid export response")
+
+// logPartialSuccess reads an OTLP export response body and logs a warning if the server
+// reported that part of the data was rejected. Such data must not be retried.
+func (e *exporter) logPartialSuccess(url string, resp *http.Response) {
+	if resp.ContentLength == 0 {
+		return
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxHTTPResponseReadBytes))
+	if err != nil || len(body) == 0 {
+		return
+	}
+	ps, err := decodePartialSuccess(body)
+	if err != nil {
+		e.logger.Debug("Failed to decode export response", zap.String("url", url), zap.Error(err))
+		return
+	}
+	if ps.rejected == 0 && ps.errorMessage == "" {
+		return
+	}
+	e.logger.Warn("Partial success response",
+		zap.String("url", url),
+		zap.Int64("rejected", ps.rejected),
+		zap.String("message", ps.errorMessage))
+}
+
+// decodePartialSuccess decodes the partial_success field from a serialized OTLP export response.
+func decodePartialSuccess(body []byte) (partialSuccess, error) {
+	var ps partialSuccess
+	err := forEachField(body, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if num != 1 || typ != protowire.BytesType {
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+		msg, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return n, nil
+		}
+		return n, forEachField(msg, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+			switch {
+			case num == 1 && typ == protowire.VarintType:
+				v, n := protowire.ConsumeVarint(b)
+				ps.rejected = int64(v)
+				return n, nil
+			case num == 2 && typ == protowire.BytesType:
+				v, n := protowire.ConsumeString(b)
+				ps.errorMessage = v
+				return n, nil
+			}
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		})
+	})
+	return ps, err
+}
+
+// forEachField calls fn for every field in the serialized message b. fn returns the number
+// of bytes consumed from the field value, or a negative number if the value is malformed.
+func forEachField(b []byte, fn func(num protowire.Number, typ protowire.Type, b []byte) (int, error)) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return errInvalidResponse
+		}
+		b = b[n:]
+		n, err := fn(num, typ, b)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return errInvalidResponse
+		}
+		b = b[n:]
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpexporter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+func encodePartialSuccess(rejected int64, msg string) []byte {
+	var ps []byte
+	ps = protowire.AppendTag(ps, 1, protowire.VarintType)
+	ps = protowire.AppendVarint(ps, uint64(rejected))
+	ps = protowire.AppendTag(ps, 2, protowire.BytesType)
+	ps = protowire.AppendString(ps, msg)
+	// An unknown field must be skipped.
+	ps = protowire.AppendTag(ps, 3, protowire.Fixed64Type)
+	ps = protowire.AppendFixed64(ps, 42)
+
+	var resp []byte
+	resp = protowire.AppendTag(resp, 1, protowire.BytesType)
+	return protowire.AppendBytes(resp, ps)
+}
+
+func TestDecodePartialSuccess(t *testing.T) {
+	ps, err := decodePartialSuccess(encodePartialSuccess(3, "invalid spans"))
+	require.NoError(t, err)
+	assert.Equal(t, partialSuccess{rejected: 3, errorMessage: "invalid spans"}, ps)
+
+	ps, err = decodePartialSuccess(nil)
+	require.NoError(t, err)
+	assert.Equal(t, partialSuccess{}, ps)
+
+	_, err = decodePartialSuccess([]byte{0x0a, 0x05, 0x08})
+	assert.Equal(t, errInvalidResponse, err)
+}
+
+func TestExportPartialSuccess(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		_, _ = w.Write(encodePartialSuccess(2, "spans too old"))
+	}))
+	defer srv.Close()
+
+	core, logs := observer.New(zap.WarnLevel)
+	exp, err := newExporter(&Config{ExporterSettings: config.NewExporterSettings(config.NewID(typeStr))}, zap.New(core))
+	require.NoError(t, err)
+	exp.client = srv.Client()
+
+	// Partially accepted data is not retried, so the export succeeds.
+	require.NoError(t, exp.export(context.Background(), srv.URL, []byte{}))
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, "Partial success response", entry.Message)
+	assert.EqualValues(t, 2, entry.ContextMap()["rejected"])
+	assert.Equal(t, "spans too old", entry.ContextMap()["message"])
+}
@@ -16,6 +16,7 @@ package prometheusexporter
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -261,13 +262,36 @@ func timeseriesSignature(ilmName string, metric pdata.Metric, attributes pdata.A
 	b.WriteString(metric.DataType().String())
 	b.WriteString("*" + ilmName)
 	b.WriteString("*" + metric.Name())
-	attributes.Sort().Range(func(k string, v pdata.AttributeValue) bool {
+	rangeSorted(attributes, func(k string, v pdata.AttributeValue) bool {
 		b.WriteString("*" + k + "*" + pdata.AttributeValueToString(v))
 		return true
 	})
 	return b.String()
 }
 
+// rangeSorted calls f for the attributes in the order of their keys, like Range does
+// on a sorted map. The map is not sorted in place since it may be shared with other
+// consumers and read-only.
+func rangeSorted(attributes pdata.AttributeMap, f func(k string, v pdata.AttributeValue) bool) {
+	type attribute struct {
+		key   string
+		value pdata.AttributeValue
+	}
+	sorted := make([]attribute, 0, attributes.Len())
+	attributes.Range(func(k string, v pdata.AttributeValue) bool {
+		sorted = append(sorted, attribute{key: k, value: v})
+		return true
+	})
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].key < sorted[j].key
+	})
+	for _, attr := range sorted {
+		if !f(attr.key, attr.value) {
+			return
+		}
+	}
+}
+
 func createMetric(metric pdata.Metric) pdata.Metric {
 	m := pdata.NewMetric()
 	m.SetName(metric.Name())
@@ -20,6 +20,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
 
@@ -27,8 +28,8 @@ import (
 type Config struct {
 	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 
-	// The address on which the Prometheus scrape handler will be run on.
-	Endpoint string `mapstructure:"endpoint"`
+	// HTTPServerSettings configures the server on which the Prometheus scrape handler will be run on.
+	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 
 	// Namespace if set, exports metrics under the provided value.
 	Namespace string `mapstructure:"namespace"`
@@ -42,6 +43,10 @@ type Config struct {
 	// MetricExpiration defines how long metrics are kept without updates
 	MetricExpiration time.Duration `mapstructure:"metric_expiration"`
 
+	// EnableOpenMetrics enables the OpenMetrics exposition format, including exemplars,
+	// when it is requested by the scraper.
+	EnableOpenMetrics bool `mapstructure:"enable_open_metrics"`
+
 	// ResourceToTelemetrySettings defines configuration for converting resource attributes to metric labels.
 	exporterhelper.ResourceToTelemetrySettings `mapstructure:"resource_to_telemetry_conversion"`
 }
@@ -24,7 +24,9 @@ import (
 
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/config/configtls"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -45,13 +47,23 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, e1,
 		&Config{
 			ExporterSettings: config.NewExporterSettings(config.NewIDWithName(typeStr, "2")),
-			Endpoint:         "1.2.3.4:1234",
-			Namespace:        "test-space",
+			HTTPServerSettings: confighttp.HTTPServerSettings{
+				Endpoint: "1.2.3.4:1234",
+				TLSSetting: &configtls.TLSServerSetting{
+					TLSSetting: configtls.TLSSetting{
+						CertFile: "certs/server.crt",
+						KeyFile:  "certs/server.key",
+					},
+					ClientCAFile: "certs/ca.crt",
+				},
+			},
+			Namespace: "test-space",
 			ConstLabels: map[string]string{
 				"label1":        "value1",
 				"another label": "spaced value",
 			},
-			SendTimestamps:   true,
-			MetricExpiration: 60 * time.Minute,
+			SendTimestamps:    true,
+			MetricExpiration:  60 * time.Minute,
+			EnableOpenMetrics: true,
 		})
 }
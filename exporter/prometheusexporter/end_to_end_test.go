@@ -32,6 +32,7 @@ import (
 
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/receiver/prometheusreceiver"
 )
 
@@ -66,11 +67,11 @@ func TestEndToEndSummarySupport(t *testing.T) {
 
 	// 2. Create the Prometheus metrics exporter that'll receive and verify the metrics produced.
 	exporterCfg := &Config{
-		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
-		Namespace:        "test",
-		Endpoint:         ":8787",
-		SendTimestamps:   true,
-		MetricExpiration: 2 * time.Hour,
+		ExporterSettings:   config.NewExporterSettings(config.NewID(typeStr)),
+		Namespace:          "test",
+		HTTPServerSettings: confighttp.HTTPServerSettings{Endpoint: ":8787"},
+		SendTimestamps:     true,
+		MetricExpiration:   2 * time.Hour,
 	}
 	exporterFactory := NewFactory()
 	set := componenttest.NewNopExporterCreateSettings()
@@ -0,0 +1,320 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusexporter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+const (
+	traceIDLabel = "trace_id"
+	spanIDLabel  = "span_id"
+
+	// maxExemplarRunes is the maximum combined length of the exemplar label
+	// names and values allowed by the OpenMetrics specification.
+	maxExemplarRunes = 128
+)
+
+// openMetricsFamily groups the accumulated metrics that share a name.
+type openMetricsFamily struct {
+	name    string
+	metrics []pdata.Metric
+}
+
+// writeOpenMetrics writes the accumulated metrics using the OpenMetrics text
+// format, including exemplars, "_created" series and unit metadata that the
+// Prometheus text format cannot represent.
+// See https://github.com/OpenObservability/OpenMetrics/blob/main/specification/OpenMetrics.md
+func (c *collector) writeOpenMetrics(out io.Writer) error {
+	families := make(map[string]*openMetricsFamily)
+	var names []string
+	for _, metric := range c.accumulator.Collect() {
+		name := metricName(c.namespace, metric)
+		if isCounter(metric) {
+			name = strings.TrimSuffix(name, "_total")
+		}
+		family, ok := families[name]
+		if !ok {
+			family = &openMetricsFamily{name: name}
+			families[name] = family
+			names = append(names, name)
+		}
+		family.metrics = append(family.metrics, metric)
+	}
+	sort.Strings(names)
+
+	w := bufio.NewWriter(out)
+	for _, name := range names {
+		c.writeOpenMetricsFamily(w, families[name])
+	}
+	w.WriteString("# EOF\n") // nolint:errcheck
+	return w.Flush()
+}
+
+func isCounter(metric pdata.Metric) bool {
+	return metric.DataType() == pdata.MetricDataTypeSum && metric.Sum().IsMonotonic()
+}
+
+func openMetricsType(metric pdata.Metric) string {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeSum:
+		if metric.Sum().IsMonotonic() {
+			return "counter"
+		}
+		return "gauge"
+	case pdata.MetricDataTypeGauge:
+		return "gauge"
+	case pdata.MetricDataTypeHistogram:
+		return "histogram"
+	case pdata.MetricDataTypeSummary:
+		return "summary"
+	}
+	return ""
+}
+
+func (c *collector) writeOpenMetricsFamily(w *bufio.Writer, family *openMetricsFamily) {
+	first := family.metrics[0]
+	mType := openMetricsType(first)
+
+	fmt.Fprintf(w, "# TYPE %s %s\n", family.name, mType)
+	// The unit must be a suffix of the family name, otherwise the exposition is invalid.
+	if unit := first.Unit(); unit != "" && sanitize(unit) == unit && strings.HasSuffix(family.name, "_"+unit) {
+		fmt.Fprintf(w, "# UNIT %s %s\n", family.name, unit)
+	}
+	if help := first.Description(); help != "" {
+		fmt.Fprintf(w, "# HELP %s %s\n", family.name, escapeOpenMetricsString(help))
+	}
+
+	for _, metric := range family.metrics {
+		if openMetricsType(metric) != mType {
+			c.logger.Debug(fmt.Sprintf("skipping metric %s: type conflicts with other metrics of the same name", metric.Name()))
+			continue
+		}
+		switch metric.DataType() {
+		case pdata.MetricDataTypeGauge:
+			c.writeOpenMetricsNumber(w, family.name, false, metric.Gauge().DataPoints().At(0))
+		case pdata.MetricDataTypeSum:
+			c.writeOpenMetricsNumber(w, family.name, metric.Sum().IsMonotonic(), metric.Sum().DataPoints().At(0))
+		case pdata.MetricDataTypeHistogram:
+			c.writeOpenMetricsHistogram(w, family.name, metric.Histogram().DataPoints().At(0))
+		case pdata.MetricDataTypeSummary:
+			c.writeOpenMetricsSummary(w, family.name, metric.Summary().DataPoints().At(0))
+		}
+	}
+}
+
+func numberValue(ip pdata.NumberDataPoint) float64 {
+	if ip.Type() == pdata.MetricValueTypeInt {
+		return float64(ip.IntVal())
+	}
+	return ip.DoubleVal()
+}
+
+func (c *collector) writeOpenMetricsNumber(w *bufio.Writer, name string, counter bool, ip pdata.NumberDataPoint) {
+	labels := c.openMetricsLabels(ip.Attributes())
+	if !counter {
+		c.writeOpenMetricsSample(w, name, labels, "", "", numberValue(ip), ip.Timestamp(), nil)
+		return
+	}
+
+	c.writeOpenMetricsSample(w, name+"_total", labels, "", "", numberValue(ip), ip.Timestamp(), latestExemplar(ip.Exemplars()))
+	c.writeOpenMetricsCreated(w, name, labels, ip.StartTimestamp())
+}
+
+func (c *collector) writeOpenMetricsHistogram(w *bufio.Writer, name string, ip pdata.HistogramDataPoint) {
+	labels := c.openMetricsLabels(ip.Attributes())
+	bounds := ip.ExplicitBounds()
+	counts := ip.BucketCounts()
+
+	// Assign every exemplar to the bucket it falls in, keeping the latest one.
+	bucketExemplars := make([]*pdata.Exemplar, len(bounds)+1)
+	exemplars := ip.Exemplars()
+	for i := 0; i < exemplars.Len(); i++ {
+		e := exemplars.At(i)
+		idx := sort.SearchFloat64s(bounds, exemplarValue(e))
+		if prev := bucketExemplars[idx]; prev == nil || !e.Timestamp().AsTime().Before(prev.Timestamp().AsTime()) {
+			bucketExemplars[idx] = &e
+		}
+	}
+
+	var cumCount uint64
+	for i, bound := range bounds {
+		if i < len(counts) {
+			cumCount += counts[i]
+		}
+		c.writeOpenMetricsSample(w, name+"_bucket", labels, "le", formatOpenMetricsFloat(bound), float64(cumCount), ip.Timestamp(), bucketExemplars[i])
+	}
+	c.writeOpenMetricsSample(w, name+"_bucket", labels, "le", "+Inf", float64(ip.Count()), ip.Timestamp(), bucketExemplars[len(bounds)])
+	c.writeOpenMetricsSample(w, name+"_count", labels, "", "", float64(ip.Count()), ip.Timestamp(), nil)
+	c.writeOpenMetricsSample(w, name+"_sum", labels, "", "", ip.Sum(), ip.Timestamp(), nil)
+	c.writeOpenMetricsCreated(w, name, labels, ip.StartTimestamp())
+}
+
+func (c *collector) writeOpenMetricsSummary(w *bufio.Writer, name string, ip pdata.SummaryDataPoint) {
+	labels := c.openMetricsLabels(ip.Attributes())
+	qvs := ip.QuantileValues()
+	for i := 0; i < qvs.Len(); i++ {
+		qv := qvs.At(i)
+		c.writeOpenMetricsSample(w, name, labels, "quantile", formatOpenMetricsFloat(qv.Quantile()), qv.Value(), ip.Timestamp(), nil)
+	}
+	c.writeOpenMetricsSample(w, name+"_count", labels, "", "", float64(ip.Count()), ip.Timestamp(), nil)
+	c.writeOpenMetricsSample(w, name+"_sum", labels, "", "", ip.Sum(), ip.Timestamp(), nil)
+	c.writeOpenMetricsCreated(w, name, labels, ip.StartTimestamp())
+}
+
+// writeOpenMetricsCreated writes the "_created" series for metrics that have a start timestamp.
+func (c *collector) writeOpenMetricsCreated(w *bufio.Writer, name string, labels []string, start pdata.Timestamp) {
+	if start == 0 {
+		return
+	}
+	c.writeOpenMetricsSample(w, name+"_created", labels, "", "", timestampSeconds(start), 0, nil)
+}
+
+func (c *collector) writeOpenMetricsSample(
+	w *bufio.Writer,
+	name string,
+	labels []string,
+	extraName, extraValue string,
+	value float64,
+	ts pdata.Timestamp,
+	exemplar *pdata.Exemplar,
+) {
+	w.WriteString(name) // nolint:errcheck
+	if len(labels) > 0 || extraName != "" {
+		w.WriteByte('{')                         // nolint:errcheck
+		w.WriteString(strings.Join(labels, ",")) // nolint:errcheck
+		if extraName != "" {
+			if len(labels) > 0 {
+				w.WriteByte(',') // nolint:errcheck
+			}
+			w.WriteString(formatOpenMetricsLabel(extraName, extraValue)) // nolint:errcheck
+		}
+		w.WriteByte('}') // nolint:errcheck
+	}
+	w.WriteByte(' ')                             // nolint:errcheck
+	w.WriteString(formatOpenMetricsFloat(value)) // nolint:errcheck
+	if c.sendTimestamps && ts != 0 {
+		w.WriteByte(' ')                                            // nolint:errcheck
+		w.WriteString(formatOpenMetricsFloat(timestampSeconds(ts))) // nolint:errcheck
+	}
+	if exemplar != nil {
+		writeOpenMetricsExemplar(w, *exemplar)
+	}
+	w.WriteByte('\n') // nolint:errcheck
+}
+
+// openMetricsLabels returns the formatted labels from the attributes and the
+// const labels, sorted by name.
+func (c *collector) openMetricsLabels(attributes pdata.AttributeMap) []string {
+	type label struct{ name, value string }
+	labels := make([]label, 0, attributes.Len()+len(c.constLabels))
+	attributes.Range(func(k string, v pdata.AttributeValue) bool {
+		labels = append(labels, label{name: sanitize(k), value: pdata.AttributeValueToString(v)})
+		return true
+	})
+	for k, v := range c.constLabels {
+		labels = append(labels, label{name: k, value: v})
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].name < labels[j].name })
+
+	formatted := make([]string, len(labels))
+	for i, l := range labels {
+		formatted[i] = formatOpenMetricsLabel(l.name, l.value)
+	}
+	return formatted
+}
+
+func writeOpenMetricsExemplar(w *bufio.Writer, e pdata.Exemplar) {
+	var labels []string
+	runes := 0
+	if traceID := e.TraceID(); !traceID.IsEmpty() {
+		labels = append(labels, formatOpenMetricsLabel(traceIDLabel, traceID.HexString()))
+		runes += len(traceIDLabel) + len(traceID.HexString())
+	}
+	if spanID := e.SpanID(); !spanID.IsEmpty() {
+		labels = append(labels, formatOpenMetricsLabel(spanIDLabel, spanID.HexString()))
+		runes += len(spanIDLabel) + len(spanID.HexString())
+	}
+	rangeSorted(e.FilteredAttributes(), func(k string, v pdata.AttributeValue) bool {
+		name, value := sanitize(k), pdata.AttributeValueToString(v)
+		runes += len([]rune(name)) + len([]rune(value))
+		if runes > maxExemplarRunes {
+			return false
+		}
+		labels = append(labels, formatOpenMetricsLabel(name, value))
+		return true
+	})
+
+	w.WriteString(" # {")                                   // nolint:errcheck
+	w.WriteString(strings.Join(labels, ","))                // nolint:errcheck
+	w.WriteString("} ")                                     // nolint:errcheck
+	w.WriteString(formatOpenMetricsFloat(exemplarValue(e))) // nolint:errcheck
+	if e.Timestamp() != 0 {
+		w.WriteByte(' ')                                                       // nolint:errcheck
+		w.WriteString(formatOpenMetricsFloat(timestampSeconds(e.Timestamp()))) // nolint:errcheck
+	}
+}
+
+func exemplarValue(e pdata.Exemplar) float64 {
+	if e.Type() == pdata.MetricValueTypeInt {
+		return float64(e.IntVal())
+	}
+	return e.DoubleVal()
+}
+
+func latestExemplar(exemplars pdata.ExemplarSlice) *pdata.Exemplar {
+	var latest *pdata.Exemplar
+	for i := 0; i < exemplars.Len(); i++ {
+		e := exemplars.At(i)
+		if latest == nil || !e.Timestamp().AsTime().Before(latest.Timestamp().AsTime()) {
+			latest = &e
+		}
+	}
+	return latest
+}
+
+func timestampSeconds(ts pdata.Timestamp) float64 {
+	return float64(ts) / 1e9
+}
+
+func formatOpenMetricsLabel(name, value string) string {
+	return name + `="` + escapeOpenMetricsString(value) + `"`
+}
+
+var openMetricsEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeOpenMetricsString(s string) string {
+	return openMetricsEscaper.Replace(s)
+}
+
+func formatOpenMetricsFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	case math.IsNaN(f):
+		return "NaN"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,285 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusexporter
+
+import (
+	"bytes"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/prometheus/pkg/textparse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func openMetricsTestData() pdata.ResourceMetrics {
+	start := pdata.TimestampFromTime(time.Unix(1, 0))
+	ts := pdata.TimestampFromTime(time.Unix(2, 0))
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})
+	spanID := pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+
+	rm := pdata.NewResourceMetrics()
+	metrics := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+
+	counter := metrics.AppendEmpty()
+	counter.SetName("http.requests_total")
+	counter.SetDescription("Total \"HTTP\" requests\nhandled")
+	counter.SetDataType(pdata.MetricDataTypeSum)
+	counter.Sum().SetIsMonotonic(true)
+	counter.Sum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	cdp := counter.Sum().DataPoints().AppendEmpty()
+	cdp.Attributes().InsertString("method", "GET")
+	cdp.SetStartTimestamp(start)
+	cdp.SetTimestamp(ts)
+	cdp.SetIntVal(10)
+	old := cdp.Exemplars().AppendEmpty()
+	old.SetTimestamp(start)
+	old.SetIntVal(1)
+	ce := cdp.Exemplars().AppendEmpty()
+	ce.SetTimestamp(pdata.TimestampFromTime(time.Unix(1, 500000000)))
+	ce.SetIntVal(3)
+	ce.SetTraceID(traceID)
+	ce.SetSpanID(spanID)
+	ce.FilteredAttributes().InsertString("user", "alice")
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency_seconds")
+	histogram.SetUnit("seconds")
+	histogram.SetDataType(pdata.MetricDataTypeHistogram)
+	histogram.Histogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	hdp := histogram.Histogram().DataPoints().AppendEmpty()
+	hdp.SetStartTimestamp(start)
+	hdp.SetTimestamp(ts)
+	hdp.SetExplicitBounds([]float64{0.1, 1})
+	hdp.SetBucketCounts([]uint64{1, 2, 3})
+	hdp.SetCount(6)
+	hdp.SetSum(5.5)
+	he := hdp.Exemplars().AppendEmpty()
+	he.SetTimestamp(ts)
+	he.SetDoubleVal(0.05)
+	he.SetTraceID(traceID)
+	he = hdp.Exemplars().AppendEmpty()
+	he.SetTimestamp(ts)
+	he.SetDoubleVal(2)
+	he.SetSpanID(spanID)
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("temperature")
+	gauge.SetUnit("celsius")
+	gauge.SetDataType(pdata.MetricDataTypeGauge)
+	gdp := gauge.Gauge().DataPoints().AppendEmpty()
+	gdp.SetTimestamp(ts)
+	gdp.SetDoubleVal(math.Inf(1))
+
+	summary := metrics.AppendEmpty()
+	summary.SetName("rpc_duration")
+	summary.SetDataType(pdata.MetricDataTypeSummary)
+	sdp := summary.Summary().DataPoints().AppendEmpty()
+	sdp.SetStartTimestamp(start)
+	sdp.SetTimestamp(ts)
+	sdp.SetCount(4)
+	sdp.SetSum(12)
+	qv := sdp.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.5)
+	qv.SetValue(2.5)
+
+	return rm
+}
+
+func TestWriteOpenMetrics(t *testing.T) {
+	tests := []struct {
+		name           string
+		sendTimestamps bool
+		want           string
+	}{
+		{
+			name: "without timestamps",
+			want: `# TYPE test_http_requests counter
+# HELP test_http_requests Total \"HTTP\" requests\nhandled
+test_http_requests_total{env="dev",method="GET"} 10 # {trace_id="01020304050607080807060504030201",span_id="0102030405060708",user="alice"} 3 1.5
+test_http_requests_created{env="dev",method="GET"} 1
+# TYPE test_latency_seconds histogram
+# UNIT test_latency_seconds seconds
+test_latency_seconds_bucket{env="dev",le="0.1"} 1 # {trace_id="01020304050607080807060504030201"} 0.05 2
+test_latency_seconds_bucket{env="dev",le="1"} 3
+test_latency_seconds_bucket{env="dev",le="+Inf"} 6 # {span_id="0102030405060708"} 2 2
+test_latency_seconds_count{env="dev"} 6
+test_latency_seconds_sum{env="dev"} 5.5
+test_latency_seconds_created{env="dev"} 1
+# TYPE test_rpc_duration summary
+test_rpc_duration{env="dev",quantile="0.5"} 2.5
+test_rpc_duration_count{env="dev"} 4
+test_rpc_duration_sum{env="dev"} 12
+test_rpc_duration_created{env="dev"} 1
+# TYPE test_temperature gauge
+test_temperature{env="dev"} +Inf
+# EOF
+`,
+		},
+		{
+			name:           "with timestamps",
+			sendTimestamps: true,
+			want: `# TYPE test_http_requests counter
+# HELP test_http_requests Total \"HTTP\" requests\nhandled
+test_http_requests_total{env="dev",method="GET"} 10 2 # {trace_id="01020304050607080807060504030201",span_id="0102030405060708",user="alice"} 3 1.5
+test_http_requests_created{env="dev",method="GET"} 1
+# TYPE test_latency_seconds histogram
+# UNIT test_latency_seconds seconds
+test_latency_seconds_bucket{env="dev",le="0.1"} 1 2 # {trace_id="01020304050607080807060504030201"} 0.05 2
+test_latency_seconds_bucket{env="dev",le="1"} 3 2
+test_latency_seconds_bucket{env="dev",le="+Inf"} 6 2 # {span_id="0102030405060708"} 2 2
+test_latency_seconds_count{env="dev"} 6 2
+test_latency_seconds_sum{env="dev"} 5.5 2
+test_latency_seconds_created{env="dev"} 1
+# TYPE test_rpc_duration summary
+test_rpc_duration{env="dev",quantile="0.5"} 2.5 2
+test_rpc_duration_count{env="dev"} 4 2
+test_rpc_duration_sum{env="dev"} 12 2
+test_rpc_duration_created{env="dev"} 1
+# TYPE test_temperature gauge
+test_temperature{env="dev"} +Inf 2
+# EOF
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCollector(&Config{
+				Namespace:        "test",
+				ConstLabels:      map[string]string{"env": "dev"},
+				SendTimestamps:   tt.sendTimestamps,
+				MetricExpiration: time.Minute,
+			}, zap.NewNop())
+			require.Equal(t, 4, c.processMetrics(openMetricsTestData()))
+
+			var buf bytes.Buffer
+			require.NoError(t, c.writeOpenMetrics(&buf))
+			assert.Equal(t, tt.want, buf.String())
+
+			// The output must be accepted by the Prometheus OpenMetrics parser.
+			p := textparse.NewOpenMetricsParser(buf.Bytes())
+			for {
+				_, err := p.Next()
+				if err != nil {
+					require.Equal(t, "EOF", err.Error())
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestWriteOpenMetricsEmpty(t *testing.T) {
+	c := newCollector(&Config{MetricExpiration: time.Minute}, zap.NewNop())
+
+	var buf bytes.Buffer
+	require.NoError(t, c.writeOpenMetrics(&buf))
+	assert.Equal(t, "# EOF\n", buf.String())
+}
+
+func TestPrometheusExporterNegotiatesOpenMetrics(t *testing.T) {
+	tests := []struct {
+		name              string
+		enableOpenMetrics bool
+		accept            string
+		wantContentType   expfmt.Format
+	}{
+		{
+			name:              "enabled and requested",
+			enableOpenMetrics: true,
+			accept:            "application/openmetrics-text;version=0.0.1,text/plain;version=0.0.4;q=0.5",
+			wantContentType:   expfmt.FmtOpenMetrics,
+		},
+		{
+			name:              "enabled and not requested",
+			enableOpenMetrics: true,
+			accept:            "text/plain;version=0.0.4",
+			wantContentType:   expfmt.FmtText,
+		},
+		{
+			name:            "disabled and requested",
+			accept:          "application/openmetrics-text;version=0.0.1,text/plain;version=0.0.4;q=0.5",
+			wantContentType: expfmt.FmtText,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				ExporterSettings:   config.NewExporterSettings(config.NewID(typeStr)),
+				HTTPServerSettings: confighttp.HTTPServerSettings{Endpoint: "localhost:0"},
+				MetricExpiration:   time.Minute,
+				EnableOpenMetrics:  tt.enableOpenMetrics,
+			}
+			exp, err := newPrometheusExporter(cfg, componenttest.NewNopExporterCreateSettings())
+			require.NoError(t, err)
+			exp.collector.processMetrics(openMetricsTestData())
+
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			req.Header.Set("Accept", tt.accept)
+			rec := httptest.NewRecorder()
+			exp.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, string(tt.wantContentType), rec.Header().Get("Content-Type"))
+			assert.Contains(t, rec.Body.String(), "http_requests_total{method=\"GET\"} 10")
+		})
+	}
+}
+
+func TestWriteOpenMetricsDoesNotModifyAccumulatedMetrics(t *testing.T) {
+	c := newCollector(&Config{MetricExpiration: time.Minute}, zap.NewNop())
+	rm := openMetricsTestData()
+	exemplar := rm.InstrumentationLibraryMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Exemplars().At(1)
+	exemplar.FilteredAttributes().InsertString("client", "curl")
+	require.Equal(t, 4, c.processMetrics(rm))
+
+	// Concurrent scrapes share the accumulated metrics.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var buf bytes.Buffer
+			assert.NoError(t, c.writeOpenMetrics(&buf))
+			assert.Contains(t, buf.String(), `client="curl",user="alice"`)
+		}()
+	}
+	wg.Wait()
+
+	for _, metric := range c.accumulator.Collect() {
+		if metric.DataType() != pdata.MetricDataTypeSum {
+			continue
+		}
+		var keys []string
+		metric.Sum().DataPoints().At(0).Exemplars().At(1).FilteredAttributes().Range(func(k string, _ pdata.AttributeValue) bool {
+			keys = append(keys, k)
+			return true
+		})
+		assert.Equal(t, []string{"user", "client"}, keys)
+	}
+}
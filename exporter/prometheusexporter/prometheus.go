@@ -17,14 +17,16 @@ package prometheusexporter
 import (
 	"context"
 	"errors"
-	"net"
 	"net/http"
 	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/common/expfmt"
+	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.opentelemetry.io/collector/obsreport"
@@ -32,19 +34,22 @@ import (
 
 type prometheusExporter struct {
 	name         string
-	endpoint     string
+	config       confighttp.HTTPServerSettings
 	shutdownFunc func() error
 	handler      http.Handler
 	collector    *collector
 	registry     *prometheus.Registry
 	obsrep       *obsreport.Exporter
+	logger       *zap.Logger
+	openMetrics  bool
 }
 
 var errBlankPrometheusAddress = errors.New("expecting a non-blank address to run the Prometheus metrics handler")
 
 func newPrometheusExporter(config *Config, set component.ExporterCreateSettings) (*prometheusExporter, error) {
-	addr := strings.TrimSpace(config.Endpoint)
-	if strings.TrimSpace(config.Endpoint) == "" {
+	serverSettings := config.HTTPServerSettings
+	serverSettings.Endpoint = strings.TrimSpace(config.Endpoint)
+	if serverSettings.Endpoint == "" {
 		return nil, errBlankPrometheusAddress
 	}
 
@@ -60,11 +65,13 @@ func newPrometheusExporter(config *Config, set component.ExporterCreateSettings)
 
 	return &prometheusExporter{
 		name:         config.ID().String(),
-		endpoint:     addr,
+		config:       serverSettings,
 		collector:    collector,
 		registry:     registry,
 		shutdownFunc: func() error { return nil },
 		obsrep:       obsrep,
+		logger:       set.Logger,
+		openMetrics:  config.EnableOpenMetrics,
 		handler: promhttp.HandlerFor(
 			registry,
 			promhttp.HandlerOpts{
@@ -74,8 +81,17 @@ func newPrometheusExporter(config *Config, set component.ExporterCreateSettings)
 	}, nil
 }
 
-func (pe *prometheusExporter) Start(_ context.Context, _ component.Host) error {
-	ln, err := net.Listen("tcp", pe.endpoint)
+func (pe *prometheusExporter) Start(_ context.Context, host component.Host) error {
+	var opts []confighttp.ToServerOption
+	if pe.config.Auth != nil {
+		authenticator, err := pe.config.ServerAuthenticator(host.GetExtensions())
+		if err != nil {
+			return err
+		}
+		opts = append(opts, confighttp.WithAuthenticator(authenticator))
+	}
+
+	ln, err := pe.config.ToListener()
 	if err != nil {
 		return err
 	}
@@ -83,8 +99,8 @@ func (pe *prometheusExporter) Start(_ context.Context, _ component.Host) error {
 	pe.shutdownFunc = ln.Close
 
 	mux := http.NewServeMux()
-	mux.Handle("/metrics", pe.handler)
-	srv := &http.Server{Handler: mux}
+	mux.Handle("/metrics", pe)
+	srv := pe.config.ToServer(mux, opts...)
 	go func() {
 		_ = srv.Serve(ln)
 	}()
@@ -92,6 +108,20 @@ func (pe *prometheusExporter) Start(_ context.Context, _ component.Host) error {
 	return nil
 }
 
+// ServeHTTP serves the accumulated metrics, using the OpenMetrics format when
+// it is enabled and negotiated by the scraper.
+func (pe *prometheusExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !pe.openMetrics || expfmt.NegotiateIncludingOpenMetrics(r.Header) != expfmt.FmtOpenMetrics {
+		pe.handler.ServeHTTP(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", string(expfmt.FmtOpenMetrics))
+	if err := pe.collector.writeOpenMetrics(w); err != nil {
+		pe.logger.Debug("Failed to write OpenMetrics response", zap.Error(err))
+	}
+}
+
 func (pe *prometheusExporter) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
 	pe.obsrep.StartMetricsOp(ctx)
 	n := 0
@@ -16,6 +16,7 @@ package prometheusexporter
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -26,8 +27,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/model/pdata"
@@ -47,18 +52,43 @@ func TestPrometheusExporter(t *testing.T) {
 					"foo0":  "bar0",
 					"code0": "one0",
 				},
-				Endpoint:         ":8999",
-				SendTimestamps:   false,
-				MetricExpiration: 60 * time.Second,
+				HTTPServerSettings: confighttp.HTTPServerSettings{Endpoint: ":8999"},
+				SendTimestamps:     false,
+				MetricExpiration:   60 * time.Second,
 			},
 		},
 		{
 			config: &Config{
-				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
-				Endpoint:         ":88999",
+				ExporterSettings:   config.NewExporterSettings(config.NewID(typeStr)),
+				HTTPServerSettings: confighttp.HTTPServerSettings{Endpoint: ":88999"},
 			},
 			wantStartErr: "listen tcp: address 88999: invalid port",
 		},
+		{
+			config: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: ":8999",
+					Auth:     &configauth.Authentication{AuthenticatorName: "missing"},
+				},
+			},
+			wantStartErr: `failed to resolve authenticator "missing": authenticator not found`,
+		},
+		{
+			config: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: ":8999",
+					TLSSetting: &configtls.TLSServerSetting{
+						TLSSetting: configtls.TLSSetting{
+							CertFile: "missing.crt",
+							KeyFile:  "missing.key",
+						},
+					},
+				},
+			},
+			wantStartErr: "failed to load TLS config: failed to load TLS cert and key: open missing.crt: no such file or directory",
+		},
 		{
 			config: &Config{
 				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
@@ -97,6 +127,65 @@ func TestPrometheusExporter(t *testing.T) {
 	}
 }
 
+type extensionsHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *extensionsHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func TestPrometheusExporter_auth(t *testing.T) {
+	cfg := &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: "localhost:7778",
+			Auth:     &configauth.Authentication{AuthenticatorName: "mock"},
+		},
+		MetricExpiration: 120 * time.Minute,
+	}
+	host := &extensionsHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{
+			config.NewID("mock"): &configauth.MockAuthenticator{
+				AuthenticateFunc: func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+					if len(headers["Authorization"]) == 0 || headers["Authorization"][0] != "Bearer secret" {
+						return ctx, errors.New("invalid bearer token")
+					}
+					return ctx, nil
+				},
+			},
+		},
+	}
+
+	factory := NewFactory()
+	exp, err := factory.CreateMetricsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), host))
+	t.Cleanup(func() {
+		require.NoError(t, exp.Shutdown(context.Background()))
+	})
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), metricBuilder(0, "metric_1_")))
+
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+
+	res, err := client.Get("http://localhost:7778/metrics")
+	require.NoError(t, err)
+	_ = res.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost:7778/metrics", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret")
+	res, err = client.Do(req)
+	require.NoError(t, err)
+	blob, _ := ioutil.ReadAll(res.Body)
+	_ = res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, string(blob), "metric_1_this_one_there_where_")
+}
+
 func TestPrometheusExporter_endToEnd(t *testing.T) {
 	cfg := &Config{
 		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
@@ -105,8 +194,8 @@ func TestPrometheusExporter_endToEnd(t *testing.T) {
 			"foo1":  "bar1",
 			"code1": "one1",
 		},
-		Endpoint:         ":7777",
-		MetricExpiration: 120 * time.Minute,
+		HTTPServerSettings: confighttp.HTTPServerSettings{Endpoint: ":7777"},
+		MetricExpiration:   120 * time.Minute,
 	}
 
 	factory := NewFactory()
@@ -180,9 +269,9 @@ func TestPrometheusExporter_endToEndWithTimestamps(t *testing.T) {
 			"foo2":  "bar2",
 			"code2": "one2",
 		},
-		Endpoint:         ":7777",
-		SendTimestamps:   true,
-		MetricExpiration: 120 * time.Minute,
+		HTTPServerSettings: confighttp.HTTPServerSettings{Endpoint: ":7777"},
+		SendTimestamps:     true,
+		MetricExpiration:   120 * time.Minute,
 	}
 
 	factory := NewFactory()
@@ -256,9 +345,9 @@ func TestPrometheusExporter_endToEndWithResource(t *testing.T) {
 			"foo2":  "bar2",
 			"code2": "one2",
 		},
-		Endpoint:         ":7777",
-		SendTimestamps:   true,
-		MetricExpiration: 120 * time.Minute,
+		HTTPServerSettings: confighttp.HTTPServerSettings{Endpoint: ":7777"},
+		SendTimestamps:     true,
+		MetricExpiration:   120 * time.Minute,
 		ResourceToTelemetrySettings: exporterhelper.ResourceToTelemetrySettings{
 			Enabled: true,
 		},
@@ -25,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtest"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -75,7 +76,7 @@ func Test_loadConfig(t *testing.T) {
 				ReadBufferSize:  0,
 				WriteBufferSize: 512 * 1024,
 				Timeout:         5 * time.Second,
-				Headers: map[string]string{
+				Headers: map[string]configopaque.String{
 					"Prometheus-Remote-Write-Version": "0.1.0",
 					"X-Scope-OrgID":                   "234"},
 			},
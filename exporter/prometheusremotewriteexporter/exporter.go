@@ -34,6 +34,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/model/pdata"
 )
 
@@ -281,7 +282,7 @@ func (prwe *PRWExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 	defer resp.Body.Close()
 
 	// 2xx status code is considered a success
-	// 5xx errors are recoverable and the exporter should retry
+	// 5xx errors and throttling responses are recoverable and the exporter should retry
 	// Reference for different behavior according to status code:
 	// https://github.com/prometheus/prometheus/pull/2552/files#diff-ae8db9d16d8057358e49d694522e7186
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
@@ -289,8 +290,5 @@ func (prwe *PRWExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 	}
 	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 256))
 	rerr := fmt.Errorf("remote write returned HTTP status %v; err = %v: %s", resp.Status, err, body)
-	if resp.StatusCode >= 500 && resp.StatusCode < 600 {
-		return rerr
-	}
-	return consumererror.Permanent(rerr)
+	return exporterhelper.NewHTTPResponseError(resp, rerr)
 }
@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.opentelemetry.io/collector/translator/trace/zipkinv2"
 )
@@ -95,7 +96,7 @@ func (ze *zipkinExporter) pushTraces(ctx context.Context, td pdata.Traces) error
 	}
 	_ = resp.Body.Close()
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return fmt.Errorf("failed the request with status code %d", resp.StatusCode)
+		return exporterhelper.NewHTTPResponseError(resp, fmt.Errorf("failed the request with status code %d", resp.StatusCode))
 	}
 	return nil
 }
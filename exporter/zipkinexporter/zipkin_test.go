@@ -34,6 +34,8 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
 	"go.opentelemetry.io/collector/receiver/zipkinreceiver"
 	"go.opentelemetry.io/collector/testutil"
 )
@@ -293,6 +295,38 @@ func TestZipkinExporter_invalidFormat(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestZipkinExporter_errorResponses(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		permanent  bool
+	}{
+		{name: "bad request", statusCode: http.StatusBadRequest, permanent: true},
+		{name: "too many requests", statusCode: http.StatusTooManyRequests},
+		{name: "unavailable", statusCode: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cst := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "10")
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer cst.Close()
+
+			zexp, err := createZipkinExporter(&Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: cst.URL},
+				Format:             "json",
+			})
+			require.NoError(t, err)
+			require.NoError(t, zexp.start(context.Background(), componenttest.NewNopHost()))
+
+			err = zexp.pushTraces(context.Background(), pdata.NewTraces())
+			require.Error(t, err)
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+		})
+	}
+}
+
 // The rest of the fields should match up exactly
 func TestZipkinExporter_roundtripProto(t *testing.T) {
 	buf := new(bytes.Buffer)
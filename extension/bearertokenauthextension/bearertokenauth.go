@@ -52,7 +52,7 @@ var _ configauth.GRPCClientAuthenticator = (*BearerTokenAuth)(nil)
 
 func newBearerTokenAuth(cfg *Config, logger *zap.Logger) *BearerTokenAuth {
 	return &BearerTokenAuth{
-		tokenString: cfg.BearerToken,
+		tokenString: string(cfg.BearerToken),
 		logger:      logger,
 	}
 }
@@ -55,7 +55,7 @@ func TestBearerAuthenticator(t *testing.T) {
 
 	md, err := credential.GetRequestMetadata(context.Background())
 	expectedMd := map[string]string{
-		"authorization": fmt.Sprintf("Bearer %s", cfg.BearerToken),
+		"authorization": fmt.Sprintf("Bearer %s", string(cfg.BearerToken)),
 	}
 	assert.Equal(t, md, expectedMd)
 	assert.NoError(t, err)
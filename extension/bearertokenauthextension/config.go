@@ -18,6 +18,7 @@ import (
 	"errors"
 
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configopaque"
 )
 
 // Config specifies how the Per-RPC bearer token based authentication data should be obtained.
@@ -25,7 +26,7 @@ type Config struct {
 	config.ExtensionSettings `mapstructure:",squash"`
 
 	// BearerToken specifies the bearer token to use for every RPC.
-	BearerToken string `mapstructure:"token,omitempty"`
+	BearerToken configopaque.String `mapstructure:"token,omitempty"`
 }
 
 var _ config.Extension = (*Config)(nil)
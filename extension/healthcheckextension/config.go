@@ -33,6 +33,10 @@ type Config struct {
 	// check status.
 	// The default endpoint is "0.0.0.0:13133".
 	TCPAddr confignet.TCPAddr `mapstructure:",squash"`
+
+	// CheckCircuitBreakers reports the collector as unavailable while the
+	// circuit breaker of an exporter is open.
+	CheckCircuitBreakers bool `mapstructure:"check_circuit_breakers"`
 }
 
 var _ config.Extension = (*Config)(nil)
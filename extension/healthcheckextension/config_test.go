@@ -48,6 +48,7 @@ func TestLoadConfig(t *testing.T) {
 			TCPAddr: confignet.TCPAddr{
 				Endpoint: "localhost:13",
 			},
+			CheckCircuitBreakers: true,
 		},
 		ext1)
 
@@ -16,14 +16,17 @@ package healthcheckextension
 
 import (
 	"context"
+	"encoding/json"
 	"net"
 	"net/http"
+	"sort"
 	"strconv"
 
 	"github.com/jaegertracing/jaeger/pkg/healthcheck"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
 
 type healthCheckExtension struct {
@@ -32,6 +35,7 @@ type healthCheckExtension struct {
 	state  *healthcheck.HealthCheck
 	server http.Server
 	stopCh chan struct{}
+	host   component.Host
 }
 
 var _ component.PipelineWatcher = (*healthCheckExtension)(nil)
@@ -57,7 +61,8 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 	}
 
 	// Mount HC handler
-	hc.server.Handler = hc.state.Handler()
+	hc.host = host
+	hc.server.Handler = hc.handler()
 	hc.stopCh = make(chan struct{})
 	go func() {
 		defer close(hc.stopCh)
@@ -89,6 +94,52 @@ func (hc *healthCheckExtension) NotReady() error {
 	return nil
 }
 
+// circuitBreakersResponse is the response when the circuit breaker of an exporter is open.
+type circuitBreakersResponse struct {
+	Status              string   `json:"status"`
+	OpenCircuitBreakers []string `json:"openCircuitBreakers"`
+}
+
+func (hc *healthCheckExtension) handler() http.Handler {
+	stateHandler := hc.state.Handler()
+	if !hc.config.CheckCircuitBreakers {
+		return stateHandler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		open := hc.openCircuitBreakers()
+		if len(open) == 0 || hc.state.Get() != healthcheck.Ready {
+			stateHandler.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(circuitBreakersResponse{
+			// Same status message as the unavailable health check state.
+			Status:              "Server not available",
+			OpenCircuitBreakers: open,
+		})
+	})
+}
+
+// openCircuitBreakers returns the sorted names of the exporters with an open circuit breaker.
+// A half-open circuit breaker is reported as open until its probe succeeds.
+func (hc *healthCheckExtension) openCircuitBreakers() []string {
+	names := map[string]struct{}{}
+	for _, exporters := range hc.host.GetExporters() {
+		for id, exp := range exporters {
+			if cbr, ok := exp.(exporterhelper.CircuitBreakerReporter); ok && cbr.CircuitBreakerState() != exporterhelper.CircuitBreakerClosed {
+				names[id.String()] = struct{}{}
+			}
+		}
+	}
+	open := make([]string, 0, len(names))
+	for name := range names {
+		open = append(open, name)
+	}
+	sort.Strings(open)
+	return open
+}
+
 func newServer(config Config, logger *zap.Logger) *healthCheckExtension {
 	hc := &healthCheckExtension{
 		config: config,
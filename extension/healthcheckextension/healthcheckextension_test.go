@@ -16,10 +16,13 @@ package healthcheckextension
 
 import (
 	"context"
+	"errors"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -27,7 +30,10 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/model/pdata"
 	"go.opentelemetry.io/collector/testutil"
 )
 
@@ -152,3 +158,71 @@ func newAssertNoErrorHost(t *testing.T) component.Host {
 func (aneh *assertNoErrorHost) ReportFatalError(err error) {
 	assert.NoError(aneh, err)
 }
+
+// exportersHost is a component.Host returning the given exporters.
+type exportersHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (h *exportersHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return h.exporters
+}
+
+func TestHealthCheckExtensionCircuitBreakers(t *testing.T) {
+	cfg := Config{
+		TCPAddr: confignet.TCPAddr{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		CheckCircuitBreakers: true,
+	}
+
+	exp, err := exporterhelper.NewTracesExporter(
+		&exporterCfg,
+		componenttest.NewNopExporterCreateSettings(),
+		func(context.Context, pdata.Traces) error { return errors.New("unavailable") },
+		exporterhelper.WithCircuitBreaker(exporterhelper.CircuitBreakerSettings{
+			Enabled:          true,
+			FailureThreshold: 1,
+			CoolDown:         time.Hour,
+		}))
+	require.NoError(t, err)
+	host := &exportersHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.TracesDataType: {exporterCfg.ID(): exp},
+		},
+	}
+
+	hcExt := newServer(cfg, zap.NewNop())
+	require.NotNil(t, hcExt)
+	require.NoError(t, hcExt.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, hcExt.Shutdown(context.Background())) })
+	require.NoError(t, hcExt.Ready())
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	client := &http.Client{}
+	url := "http://" + cfg.TCPAddr.Endpoint
+	resp0, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp0.Body.Close()
+	require.Equal(t, http.StatusOK, resp0.StatusCode)
+
+	assert.Error(t, exp.ConsumeTraces(context.Background(), pdata.NewTraces()))
+
+	resp1, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp1.Body.Close()
+	require.Equal(t, http.StatusServiceUnavailable, resp1.StatusCode)
+	body, err := ioutil.ReadAll(resp1.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"status":"Server not available","openCircuitBreakers":["test"]}`, string(body))
+
+	require.NoError(t, exp.Shutdown(context.Background()))
+	resp2, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	require.Equal(t, http.StatusOK, resp2.StatusCode)
+}
+
+var exporterCfg = config.NewExporterSettings(config.NewID("test"))
@@ -52,3 +52,10 @@ func TestFactory_CreateExtension(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, ext)
 }
+
+func TestConformance(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.TCPAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	componenttest.VerifyExtensionConformance(t, factory, cfg)
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goldendataset
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+)
+
+var severityNumberMap = map[PICTInputSeverity]pdata.SeverityNumber{
+	LogSeverityUnspecified: pdata.SeverityNumberUNDEFINED,
+	LogSeverityTrace:       pdata.SeverityNumberTRACE,
+	LogSeverityDebug:       pdata.SeverityNumberDEBUG,
+	LogSeverityInfo:        pdata.SeverityNumberINFO,
+	LogSeverityWarn:        pdata.SeverityNumberWARN,
+	LogSeverityError:       pdata.SeverityNumberERROR,
+	LogSeverityFatal:       pdata.SeverityNumberFATAL,
+}
+
+var severityTextMap = map[PICTInputSeverity]string{
+	LogSeverityUnspecified: "",
+	LogSeverityTrace:       "TRACE",
+	LogSeverityDebug:       "DEBUG",
+	LogSeverityInfo:        "INFO",
+	LogSeverityWarn:        "WARN",
+	LogSeverityError:       "ERROR",
+	LogSeverityFatal:       "FATAL",
+}
+
+// GenerateLogs generates a slice of pdata.Logs based on the PICT-generated pairwise parameters defined in
+// the file specified by the logPairsFile parameter. Each pdata.Logs holds one ResourceLogs with one log
+// record per instrumentation library, and every log record has a unique name.
+// If an err is returned, the slice elements will be nil.
+func GenerateLogs(logPairsFile string) ([]pdata.Logs, error) {
+	random := io.Reader(rand.New(rand.NewSource(42)))
+	pairsData, err := loadPictOutputFile(logPairsFile)
+	if err != nil {
+		return nil, err
+	}
+	pairsTotal := len(pairsData) - 1
+	logs := make([]pdata.Logs, pairsTotal)
+	for index, values := range pairsData {
+		if index == 0 {
+			continue
+		}
+		logInputs := &PICTLogInputs{
+			Resource:               PICTInputResource(values[LogsColumnResource]),
+			InstrumentationLibrary: PICTInputInstrumentationLibrary(values[LogsColumnInstrumentationLibrary]),
+			Severity:               PICTInputSeverity(values[LogsColumnSeverity]),
+			Body:                   PICTInputLogBody(values[LogsColumnBody]),
+			Attributes:             PICTInputLogAttributes(values[LogsColumnAttributes]),
+			TraceContext:           PICTInputTraceContext(values[LogsColumnTraceContext]),
+		}
+		logs[index-1] = pdata.NewLogs()
+		appendResourceLogs(logInputs, index, random, logs[index-1].ResourceLogs())
+	}
+	return logs, nil
+}
+
+func appendResourceLogs(logInputs *PICTLogInputs, index int, random io.Reader, resourceLogsSlice pdata.ResourceLogsSlice) {
+	resourceLogs := resourceLogsSlice.AppendEmpty()
+	GenerateResource(logInputs.Resource).CopyTo(resourceLogs.Resource())
+	count := 1
+	if logInputs.InstrumentationLibrary == LibraryTwo {
+		count = 2
+	}
+	for i := 0; i < count; i++ {
+		ill := resourceLogs.InstrumentationLibraryLogs().AppendEmpty()
+		fillLogsInstrumentationLibrary(logInputs, i, ill.InstrumentationLibrary())
+		fillLogRecord(logInputs, generateLogRecordName(logInputs, index, i), random, ill.Logs().AppendEmpty())
+	}
+}
+
+func fillLogsInstrumentationLibrary(logInputs *PICTLogInputs, index int, instrumentationLibrary pdata.InstrumentationLibrary) {
+	if logInputs.InstrumentationLibrary == LibraryNone {
+		return
+	}
+	instrumentationLibrary.SetName(fmt.Sprintf("%s-%s-%d", logInputs.Resource, logInputs.InstrumentationLibrary, index))
+	if index == 0 {
+		instrumentationLibrary.SetVersion("semver:1.1.7")
+	}
+}
+
+func generateLogRecordName(logInputs *PICTLogInputs, index int, libraryIndex int) string {
+	return fmt.Sprintf("/%d/%d/%s/%s/%s/%s", index, libraryIndex, logInputs.Severity, logInputs.Body,
+		logInputs.Attributes, logInputs.TraceContext)
+}
+
+// fillLogRecord generates a single pdata.LogRecord based on the input values provided. They are:
+//   logInputs - the pairwise combination of field value variations for this log record
+//   name - the log record name, should be unique
+//   random - the random number generator to use in generating ID values
+func fillLogRecord(logInputs *PICTLogInputs, name string, random io.Reader, logRecord pdata.LogRecord) {
+	logRecord.SetName(name)
+	logRecord.SetTimestamp(pdata.TimestampFromTime(time.Now().Add(-50 * time.Microsecond)))
+	logRecord.SetSeverityNumber(severityNumberMap[logInputs.Severity])
+	logRecord.SetSeverityText(severityTextMap[logInputs.Severity])
+	fillLogBody(logInputs.Body, logRecord.Body())
+	appendLogAttributes(logInputs.Attributes, logRecord.Attributes())
+	logRecord.SetDroppedAttributesCount(0)
+	switch logInputs.TraceContext {
+	case LogTraceContextSampled:
+		logRecord.SetTraceID(generateTraceID(random))
+		logRecord.SetSpanID(generateSpanID(random))
+		logRecord.SetFlags(1)
+	case LogTraceContextUnsampled:
+		logRecord.SetTraceID(generateTraceID(random))
+		logRecord.SetSpanID(generateSpanID(random))
+		logRecord.SetFlags(0)
+	}
+}
+
+func fillLogBody(bodyType PICTInputLogBody, body pdata.AttributeValue) {
+	switch bodyType {
+	case LogBodyString:
+		body.SetStringVal("Customer 8312 placed order o4711 for 2 items")
+	case LogBodyMap:
+		pdata.NewAttributeValueMap().CopyTo(body)
+		body.MapVal().UpsertString("event", "order.placed")
+		body.MapVal().UpsertString("order_id", "o4711")
+		body.MapVal().UpsertInt("items", 2)
+		body.MapVal().UpsertDouble("total", 99.95)
+		body.MapVal().UpsertBool("express", true)
+		customer := pdata.NewAttributeValueMap()
+		customer.MapVal().UpsertInt("id", 8312)
+		customer.MapVal().UpsertString("tier", "gold")
+		body.MapVal().Upsert("customer", customer)
+	case LogBodyArray:
+		pdata.NewAttributeValueArray().CopyTo(body)
+		body.ArrayVal().AppendEmpty().SetStringVal("order.placed")
+		body.ArrayVal().AppendEmpty().SetIntVal(4711)
+		body.ArrayVal().AppendEmpty().SetDoubleVal(99.95)
+		body.ArrayVal().AppendEmpty().SetBoolVal(false)
+	case LogBodyBytes:
+		body.SetBytesVal([]byte{0x7b, 0x22, 0x6f, 0x22, 0x3a, 0x31, 0x7d, 0x00, 0xff})
+	case LogBodyEmpty:
+		fallthrough
+	default:
+		// Leave the body empty.
+	}
+}
+
+func appendLogAttributes(attrType PICTInputLogAttributes, attrMap pdata.AttributeMap) {
+	switch attrType {
+	case LogAttrHTTP:
+		attrMap.UpsertString(conventions.AttributeHTTPMethod, "POST")
+		attrMap.UpsertString(conventions.AttributeHTTPURL, "https://api.opentelemetry.io/blog/posts")
+		attrMap.UpsertInt(conventions.AttributeHTTPStatusCode, 201)
+		attrMap.UpsertString(conventions.AttributeHTTPUserAgent, "Mozilla/5.0 (X11; Linux x86_64)")
+	case LogAttrException:
+		attrMap.UpsertString(conventions.AttributeExceptionType, "java.net.SocketTimeoutException")
+		attrMap.UpsertString(conventions.AttributeExceptionMessage, "Read timed out")
+		attrMap.UpsertString(conventions.AttributeExceptionStacktrace,
+			"java.net.SocketTimeoutException: Read timed out\n\tat java.net.SocketInputStream.read(SocketInputStream.java:150)")
+		attrMap.UpsertBool(conventions.AttributeExceptionEscaped, false)
+	case LogAttrAllTypes:
+		attrMap.UpsertString("string", "value")
+		attrMap.UpsertInt("int", -42)
+		attrMap.UpsertDouble("double", 3.25)
+		attrMap.UpsertBool("bool", true)
+		attrMap.UpsertBytes("bytes", []byte{0x01, 0x02, 0x03})
+		mapVal := pdata.NewAttributeValueMap()
+		mapVal.MapVal().UpsertString("key", "value")
+		attrMap.Upsert("map", mapVal)
+		arrayVal := pdata.NewAttributeValueArray()
+		arrayVal.ArrayVal().AppendEmpty().SetStringVal("a")
+		arrayVal.ArrayVal().AppendEmpty().SetIntVal(1)
+		attrMap.Upsert("array", arrayVal)
+	case LogAttrEmpty:
+		fallthrough
+	default:
+		// Leave the attributes empty.
+	}
+}
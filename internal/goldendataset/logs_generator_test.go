@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goldendataset
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestGenerateLogs(t *testing.T) {
+	logs, err := GenerateLogs("testdata/generated_pict_pairs_logs.txt")
+	require.NoError(t, err)
+	assert.Equal(t, 49, len(logs))
+
+	names := map[string]bool{}
+	for _, ld := range logs {
+		assert.Equal(t, 1, ld.ResourceLogs().Len())
+		ills := ld.ResourceLogs().At(0).InstrumentationLibraryLogs()
+		for i := 0; i < ills.Len(); i++ {
+			require.Equal(t, 1, ills.At(i).Logs().Len())
+			name := ills.At(i).Logs().At(0).Name()
+			assert.False(t, names[name], "duplicate log record name %q", name)
+			names[name] = true
+		}
+	}
+}
+
+func TestGenerateLogsMissingFile(t *testing.T) {
+	_, err := GenerateLogs("testdata/missing.txt")
+	assert.Error(t, err)
+}
+
+func TestFillLogRecord(t *testing.T) {
+	logInputs := &PICTLogInputs{
+		Severity:     LogSeverityWarn,
+		Body:         LogBodyMap,
+		Attributes:   LogAttrAllTypes,
+		TraceContext: LogTraceContextSampled,
+	}
+	lr := pdata.NewLogRecord()
+	fillLogRecord(logInputs, "/gotest", rand.Reader, lr)
+	assert.Equal(t, "/gotest", lr.Name())
+	assert.Equal(t, pdata.SeverityNumberWARN, lr.SeverityNumber())
+	assert.Equal(t, "WARN", lr.SeverityText())
+	assert.Equal(t, pdata.AttributeValueTypeMap, lr.Body().Type())
+	assert.Equal(t, 6, lr.Body().MapVal().Len())
+	assert.Equal(t, 7, lr.Attributes().Len())
+	assert.False(t, lr.TraceID().IsEmpty())
+	assert.False(t, lr.SpanID().IsEmpty())
+	assert.Equal(t, uint32(1), lr.Flags())
+}
+
+func TestFillLogRecordBodies(t *testing.T) {
+	tests := map[PICTInputLogBody]pdata.AttributeValueType{
+		LogBodyEmpty:  pdata.AttributeValueTypeNull,
+		LogBodyString: pdata.AttributeValueTypeString,
+		LogBodyMap:    pdata.AttributeValueTypeMap,
+		LogBodyArray:  pdata.AttributeValueTypeArray,
+		LogBodyBytes:  pdata.AttributeValueTypeBytes,
+	}
+	for body, valueType := range tests {
+		lr := pdata.NewLogRecord()
+		fillLogRecord(&PICTLogInputs{Body: body, TraceContext: LogTraceContextNone}, "/gotest", rand.Reader, lr)
+		assert.Equal(t, valueType, lr.Body().Type(), string(body))
+		assert.True(t, lr.TraceID().IsEmpty())
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goldendataset
+
+// Start of PICT inputs for generating golden dataset logs (pict_input_logs.txt)
+
+// Input columns in pict_input_logs.txt
+const (
+	LogsColumnResource               = 0
+	LogsColumnInstrumentationLibrary = 1
+	LogsColumnSeverity               = 2
+	LogsColumnBody                   = 3
+	LogsColumnAttributes             = 4
+	LogsColumnTraceContext           = 5
+)
+
+// PICTInputSeverity enum for the severities a generated log record can be populated with.
+type PICTInputSeverity string
+
+const (
+	LogSeverityUnspecified PICTInputSeverity = "Unspecified"
+	LogSeverityTrace       PICTInputSeverity = "Trace"
+	LogSeverityDebug       PICTInputSeverity = "Debug"
+	LogSeverityInfo        PICTInputSeverity = "Info"
+	LogSeverityWarn        PICTInputSeverity = "Warn"
+	LogSeverityError       PICTInputSeverity = "Error"
+	LogSeverityFatal       PICTInputSeverity = "Fatal"
+)
+
+// PICTInputLogBody enum for the types of body a generated log record can be populated with.
+type PICTInputLogBody string
+
+const (
+	LogBodyEmpty  PICTInputLogBody = "Empty"
+	LogBodyString PICTInputLogBody = "String"
+	LogBodyMap    PICTInputLogBody = "Map"
+	LogBodyArray  PICTInputLogBody = "Array"
+	LogBodyBytes  PICTInputLogBody = "Bytes"
+)
+
+// PICTInputLogAttributes enum for the categories of attributes a generated log record can be populated with.
+type PICTInputLogAttributes string
+
+const (
+	LogAttrEmpty     PICTInputLogAttributes = "Empty"
+	LogAttrHTTP      PICTInputLogAttributes = "HTTP"
+	LogAttrException PICTInputLogAttributes = "Exception"
+	LogAttrAllTypes  PICTInputLogAttributes = "AllTypes"
+)
+
+// PICTInputTraceContext enum for the trace context a generated log record can be populated with.
+type PICTInputTraceContext string
+
+const (
+	LogTraceContextNone      PICTInputTraceContext = "None"
+	LogTraceContextSampled   PICTInputTraceContext = "Sampled"
+	LogTraceContextUnsampled PICTInputTraceContext = "Unsampled"
+)
+
+// PICTLogInputs defines one pairwise combination of ResourceLogs and LogRecord variations
+type PICTLogInputs struct {
+	// Specifies the category of attributes to populate the Resource field with
+	Resource PICTInputResource
+	// Specifies the number and library categories to populate the InstrumentationLibraryLogs field with
+	InstrumentationLibrary PICTInputInstrumentationLibrary
+	// Specifies the value to populate the SeverityNumber and SeverityText fields with
+	Severity PICTInputSeverity
+	// Specifies the type of value to populate the Body field with
+	Body PICTInputLogBody
+	// Specifies the category of values to populate the Attributes field with
+	Attributes PICTInputLogAttributes
+	// Specifies whether the TraceID, SpanID and Flags fields should be populated or not
+	TraceContext PICTInputTraceContext
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsmetrics
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+)
+
+const (
+	// FanoutKey is the key used to identify the fanout to the exporters of a pipeline in metrics.
+	FanoutKey = "fanout"
+
+	// PipelineKey is the key used to identify pipelines in metrics.
+	PipelineKey = "pipeline"
+
+	// BranchErrorsKey is the key used to track the requests that an exporter failed to consume.
+	BranchErrorsKey = "branch_errors"
+
+	// BranchDroppedKey is the key used to track the requests dropped because the queue of an exporter is full.
+	BranchDroppedKey = "branch_dropped"
+
+	// BranchLatencyKey is the key used to track the time an exporter takes to consume a request.
+	BranchLatencyKey = "branch_latency"
+)
+
+var (
+	TagKeyPipeline, _ = tag.NewKey(PipelineKey)
+
+	FanoutPrefix = FanoutKey + NameSep
+
+	// Fanout metrics, tagged with the pipeline and the exporter of every branch.
+	FanoutBranchErrors = stats.Int64(
+		FanoutPrefix+BranchErrorsKey,
+		"Number of requests that an exporter of the pipeline failed to consume.",
+		stats.UnitDimensionless)
+	FanoutBranchDropped = stats.Int64(
+		FanoutPrefix+BranchDroppedKey,
+		"Number of requests dropped because the queue of a best effort exporter of the pipeline is full.",
+		stats.UnitDimensionless)
+	FanoutBranchLatency = stats.Float64(
+		FanoutPrefix+BranchLatencyKey,
+		"Time an exporter of the pipeline takes to consume a request.",
+		stats.UnitMilliseconds)
+)
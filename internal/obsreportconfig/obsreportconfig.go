@@ -25,6 +25,9 @@ import (
 
 var (
 	Level = configtelemetry.LevelBasic
+
+	// fanoutLatencyDistribution are the bucket boundaries, in milliseconds, of the fanout latency.
+	fanoutLatencyDistribution = view.Distribution(1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000)
 )
 
 // ObsMetrics wraps OpenCensus View for Collector observability metrics
@@ -103,6 +106,21 @@ func allViews() *ObsMetrics {
 	tagKeys = []tag.Key{obsmetrics.TagKeyProcessor}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
 
+	// Fanout views.
+	measures = []*stats.Int64Measure{
+		obsmetrics.FanoutBranchErrors,
+		obsmetrics.FanoutBranchDropped,
+	}
+	tagKeys = []tag.Key{obsmetrics.TagKeyPipeline, obsmetrics.TagKeyExporter}
+	views = append(views, genViews(measures, tagKeys, view.Sum())...)
+	views = append(views, &view.View{
+		Name:        obsmetrics.FanoutBranchLatency.Name(),
+		Description: obsmetrics.FanoutBranchLatency.Description(),
+		TagKeys:     tagKeys,
+		Measure:     obsmetrics.FanoutBranchLatency,
+		Aggregation: fanoutLatencyDistribution,
+	})
+
 	return &ObsMetrics{
 		Views: views,
 	}
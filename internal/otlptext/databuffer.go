@@ -17,6 +17,7 @@ package otlptext
 import (
 	"bytes"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -246,10 +247,22 @@ func attributeMapToString(av pdata.AttributeMap) string {
 	var b strings.Builder
 	b.WriteString("{\n")
 
-	av.Sort().Range(func(k string, v pdata.AttributeValue) bool {
-		fmt.Fprintf(&b, "     -> %s: %s(%s)\n", k, v.Type(), pdata.AttributeValueToString(v))
+	// Sort a copy of the entries, the map may be shared with other consumers and read-only.
+	type attribute struct {
+		key   string
+		value pdata.AttributeValue
+	}
+	sorted := make([]attribute, 0, av.Len())
+	av.Range(func(k string, v pdata.AttributeValue) bool {
+		sorted = append(sorted, attribute{key: k, value: v})
 		return true
 	})
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].key < sorted[j].key
+	})
+	for _, attr := range sorted {
+		fmt.Fprintf(&b, "     -> %s: %s(%s)\n", attr.key, attr.value.Type(), pdata.AttributeValueToString(attr.value))
+	}
 	b.WriteByte('}')
 	return b.String()
 }
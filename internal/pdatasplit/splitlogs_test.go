@@ -12,9 +12,10 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package batchprocessor
+package pdatasplit
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,7 +27,7 @@ import (
 func TestSplitLogs_noop(t *testing.T) {
 	td := testdata.GenerateLogsManyLogRecordsSameResource(20)
 	splitSize := 40
-	split := splitLogs(splitSize, td)
+	split := Logs(splitSize, td)
 	assert.Equal(t, td, split)
 
 	i := 0
@@ -57,24 +58,24 @@ func TestSplitLogs(t *testing.T) {
 	logs.At(4).CopyTo(cpLogs.AppendEmpty())
 
 	splitSize := 5
-	split := splitLogs(splitSize, ld)
+	split := Logs(splitSize, ld)
 	assert.Equal(t, splitSize, split.LogRecordCount())
 	assert.Equal(t, cp, split)
 	assert.Equal(t, 15, ld.LogRecordCount())
 	assert.Equal(t, "test-log-int-0-0", split.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Name())
 	assert.Equal(t, "test-log-int-0-4", split.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(4).Name())
 
-	split = splitLogs(splitSize, ld)
+	split = Logs(splitSize, ld)
 	assert.Equal(t, 10, ld.LogRecordCount())
 	assert.Equal(t, "test-log-int-0-5", split.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Name())
 	assert.Equal(t, "test-log-int-0-9", split.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(4).Name())
 
-	split = splitLogs(splitSize, ld)
+	split = Logs(splitSize, ld)
 	assert.Equal(t, 5, ld.LogRecordCount())
 	assert.Equal(t, "test-log-int-0-10", split.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Name())
 	assert.Equal(t, "test-log-int-0-14", split.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(4).Name())
 
-	split = splitLogs(splitSize, ld)
+	split = Logs(splitSize, ld)
 	assert.Equal(t, 5, ld.LogRecordCount())
 	assert.Equal(t, "test-log-int-0-15", split.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Name())
 	assert.Equal(t, "test-log-int-0-19", split.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(4).Name())
@@ -95,7 +96,7 @@ func TestSplitLogsMultipleResourceLogs(t *testing.T) {
 	}
 
 	splitSize := 5
-	split := splitLogs(splitSize, td)
+	split := Logs(splitSize, td)
 	assert.Equal(t, splitSize, split.LogRecordCount())
 	assert.Equal(t, 35, td.LogRecordCount())
 	assert.Equal(t, "test-log-int-0-0", split.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Name())
@@ -117,7 +118,7 @@ func TestSplitLogsMultipleResourceLogs_split_size_greater_than_log_size(t *testi
 	}
 
 	splitSize := 25
-	split := splitLogs(splitSize, td)
+	split := Logs(splitSize, td)
 	assert.Equal(t, splitSize, split.LogRecordCount())
 	assert.Equal(t, 40-splitSize, td.LogRecordCount())
 	assert.Equal(t, 1, td.ResourceLogs().Len())
@@ -142,7 +143,7 @@ func BenchmarkSplitLogs(b *testing.B) {
 	b.ResetTimer()
 	for n := 0; n < b.N; n++ {
 		cloneReq := md.Clone()
-		split := splitLogs(128, cloneReq)
+		split := Logs(128, cloneReq)
 		if split.LogRecordCount() != 128 || cloneReq.LogRecordCount() != 400-128 {
 			b.Fail()
 		}
@@ -169,3 +170,7 @@ func BenchmarkCloneLogs(b *testing.B) {
 		}
 	}
 }
+
+func getTestLogName(requestNum, index int) string {
+	return fmt.Sprintf("test-log-int-%d-%d", requestNum, index)
+}
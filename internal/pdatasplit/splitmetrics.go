@@ -12,14 +12,14 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package batchprocessor
+package pdatasplit
 
 import (
 	"go.opentelemetry.io/collector/model/pdata"
 )
 
-// splitMetrics removes metrics from the input data and returns a new data of the specified size.
-func splitMetrics(size int, src pdata.Metrics) pdata.Metrics {
+// Metrics removes metrics from the input data and returns a new data of the specified size.
+func Metrics(size int, src pdata.Metrics) pdata.Metrics {
 	dataPoints := src.DataPointCount()
 	if dataPoints <= size {
 		return src
@@ -12,9 +12,10 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package batchprocessor
+package pdatasplit
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,7 +27,7 @@ import (
 func TestSplitMetrics_noop(t *testing.T) {
 	td := testdata.GenerateMetricsManyMetricsSameResource(20)
 	splitSize := 40
-	split := splitMetrics(splitSize, td)
+	split := Metrics(splitSize, td)
 	assert.Equal(t, td, split)
 
 	i := 0
@@ -60,24 +61,24 @@ func TestSplitMetrics(t *testing.T) {
 
 	splitMetricCount := 5
 	splitSize := splitMetricCount * dataPointCount
-	split := splitMetrics(splitSize, md)
+	split := Metrics(splitSize, md)
 	assert.Equal(t, splitMetricCount, split.MetricCount())
 	assert.Equal(t, cp, split)
 	assert.Equal(t, 15, md.MetricCount())
 	assert.Equal(t, "test-metric-int-0-0", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
 	assert.Equal(t, "test-metric-int-0-4", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(4).Name())
 
-	split = splitMetrics(splitSize, md)
+	split = Metrics(splitSize, md)
 	assert.Equal(t, 10, md.MetricCount())
 	assert.Equal(t, "test-metric-int-0-5", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
 	assert.Equal(t, "test-metric-int-0-9", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(4).Name())
 
-	split = splitMetrics(splitSize, md)
+	split = Metrics(splitSize, md)
 	assert.Equal(t, 5, md.MetricCount())
 	assert.Equal(t, "test-metric-int-0-10", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
 	assert.Equal(t, "test-metric-int-0-14", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(4).Name())
 
-	split = splitMetrics(splitSize, md)
+	split = Metrics(splitSize, md)
 	assert.Equal(t, 5, md.MetricCount())
 	assert.Equal(t, "test-metric-int-0-15", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
 	assert.Equal(t, "test-metric-int-0-19", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(4).Name())
@@ -101,7 +102,7 @@ func TestSplitMetricsMultipleResourceSpans(t *testing.T) {
 
 	splitMetricCount := 5
 	splitSize := splitMetricCount * dataPointCount
-	split := splitMetrics(splitSize, md)
+	split := Metrics(splitSize, md)
 	assert.Equal(t, splitMetricCount, split.MetricCount())
 	assert.Equal(t, 35, md.MetricCount())
 	assert.Equal(t, "test-metric-int-0-0", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
@@ -126,7 +127,7 @@ func TestSplitMetricsMultipleResourceSpans_SplitSizeGreaterThanMetricSize(t *tes
 
 	splitMetricCount := 25
 	splitSize := splitMetricCount * dataPointCount
-	split := splitMetrics(splitSize, td)
+	split := Metrics(splitSize, td)
 	assert.Equal(t, splitMetricCount, split.MetricCount())
 	assert.Equal(t, 40-splitMetricCount, td.MetricCount())
 	assert.Equal(t, 1, td.ResourceMetrics().Len())
@@ -151,7 +152,7 @@ func BenchmarkSplitMetrics(b *testing.B) {
 	b.ResetTimer()
 	for n := 0; n < b.N; n++ {
 		cloneReq := md.Clone()
-		split := splitMetrics(128, cloneReq)
+		split := Metrics(128, cloneReq)
 		if split.MetricCount() != 128 || cloneReq.MetricCount() != 400-128 {
 			b.Fail()
 		}
@@ -189,19 +190,19 @@ func TestSplitMetricsUneven(t *testing.T) {
 	}
 
 	splitSize := 9
-	split := splitMetrics(splitSize, md)
+	split := Metrics(splitSize, md)
 	assert.Equal(t, 5, split.MetricCount())
 	assert.Equal(t, 6, md.MetricCount())
 	assert.Equal(t, "test-metric-int-0-0", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
 	assert.Equal(t, "test-metric-int-0-4", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(4).Name())
 
-	split = splitMetrics(splitSize, md)
+	split = Metrics(splitSize, md)
 	assert.Equal(t, 5, split.MetricCount())
 	assert.Equal(t, 1, md.MetricCount())
 	assert.Equal(t, "test-metric-int-0-4", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
 	assert.Equal(t, "test-metric-int-0-8", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(4).Name())
 
-	split = splitMetrics(splitSize, md)
+	split = Metrics(splitSize, md)
 	assert.Equal(t, 1, split.MetricCount())
 	assert.Equal(t, "test-metric-int-0-9", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
 }
@@ -216,23 +217,27 @@ func TestSplitMetricsBatchSizeSmallerThanDataPointCount(t *testing.T) {
 	}
 
 	splitSize := 1
-	split := splitMetrics(splitSize, md)
+	split := Metrics(splitSize, md)
 	assert.Equal(t, 1, split.MetricCount())
 	assert.Equal(t, 2, md.MetricCount())
 	assert.Equal(t, "test-metric-int-0-0", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
 
-	split = splitMetrics(splitSize, md)
+	split = Metrics(splitSize, md)
 	assert.Equal(t, 1, split.MetricCount())
 	assert.Equal(t, 1, md.MetricCount())
 	assert.Equal(t, "test-metric-int-0-0", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
 
-	split = splitMetrics(splitSize, md)
+	split = Metrics(splitSize, md)
 	assert.Equal(t, 1, split.MetricCount())
 	assert.Equal(t, 1, md.MetricCount())
 	assert.Equal(t, "test-metric-int-0-1", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
 
-	split = splitMetrics(splitSize, md)
+	split = Metrics(splitSize, md)
 	assert.Equal(t, 1, split.MetricCount())
 	assert.Equal(t, 1, md.MetricCount())
 	assert.Equal(t, "test-metric-int-0-1", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
 }
+
+func getTestMetricName(requestNum, index int) string {
+	return fmt.Sprintf("test-metric-int-%d-%d", requestNum, index)
+}
@@ -12,14 +12,14 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package batchprocessor
+package pdatasplit
 
 import (
 	"go.opentelemetry.io/collector/model/pdata"
 )
 
-// splitTraces removes spans from the input trace and returns a new trace of the specified size.
-func splitTraces(size int, src pdata.Traces) pdata.Traces {
+// Traces removes spans from the input trace and returns a new trace of the specified size.
+func Traces(size int, src pdata.Traces) pdata.Traces {
 	if src.SpanCount() <= size {
 		return src
 	}
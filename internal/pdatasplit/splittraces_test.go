@@ -12,9 +12,10 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package batchprocessor
+package pdatasplit
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,7 +27,7 @@ import (
 func TestSplitTraces_noop(t *testing.T) {
 	td := testdata.GenerateTracesManySpansSameResource(20)
 	splitSize := 40
-	split := splitTraces(splitSize, td)
+	split := Traces(splitSize, td)
 	assert.Equal(t, td, split)
 
 	i := 0
@@ -57,24 +58,24 @@ func TestSplitTraces(t *testing.T) {
 	spans.At(4).CopyTo(cpSpans.AppendEmpty())
 
 	splitSize := 5
-	split := splitTraces(splitSize, td)
+	split := Traces(splitSize, td)
 	assert.Equal(t, splitSize, split.SpanCount())
 	assert.Equal(t, cp, split)
 	assert.Equal(t, 15, td.SpanCount())
 	assert.Equal(t, "test-span-0-0", split.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Name())
 	assert.Equal(t, "test-span-0-4", split.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(4).Name())
 
-	split = splitTraces(splitSize, td)
+	split = Traces(splitSize, td)
 	assert.Equal(t, 10, td.SpanCount())
 	assert.Equal(t, "test-span-0-5", split.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Name())
 	assert.Equal(t, "test-span-0-9", split.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(4).Name())
 
-	split = splitTraces(splitSize, td)
+	split = Traces(splitSize, td)
 	assert.Equal(t, 5, td.SpanCount())
 	assert.Equal(t, "test-span-0-10", split.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Name())
 	assert.Equal(t, "test-span-0-14", split.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(4).Name())
 
-	split = splitTraces(splitSize, td)
+	split = Traces(splitSize, td)
 	assert.Equal(t, 5, td.SpanCount())
 	assert.Equal(t, "test-span-0-15", split.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Name())
 	assert.Equal(t, "test-span-0-19", split.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(4).Name())
@@ -95,7 +96,7 @@ func TestSplitTracesMultipleResourceSpans(t *testing.T) {
 	}
 
 	splitSize := 5
-	split := splitTraces(splitSize, td)
+	split := Traces(splitSize, td)
 	assert.Equal(t, splitSize, split.SpanCount())
 	assert.Equal(t, 35, td.SpanCount())
 	assert.Equal(t, "test-span-0-0", split.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Name())
@@ -117,7 +118,7 @@ func TestSplitTracesMultipleResourceSpans_SplitSizeGreaterThanSpanSize(t *testin
 	}
 
 	splitSize := 25
-	split := splitTraces(splitSize, td)
+	split := Traces(splitSize, td)
 	assert.Equal(t, splitSize, split.SpanCount())
 	assert.Equal(t, 40-splitSize, td.SpanCount())
 	assert.Equal(t, 1, td.ResourceSpans().Len())
@@ -142,7 +143,7 @@ func BenchmarkSplitTraces(b *testing.B) {
 	b.ResetTimer()
 	for n := 0; n < b.N; n++ {
 		cloneReq := td.Clone()
-		split := splitTraces(128, cloneReq)
+		split := Traces(128, cloneReq)
 		if split.SpanCount() != 128 || cloneReq.SpanCount() != 400-128 {
 			b.Fail()
 		}
@@ -169,3 +170,7 @@ func BenchmarkCloneSpans(b *testing.B) {
 		}
 	}
 }
+
+func getTestSpanName(requestNum, index int) string {
+	return fmt.Sprintf("test-span-%d-%d", requestNum, index)
+}
@@ -81,53 +81,54 @@ func (avt AttributeValueType) String() string {
 // Important: zero-initialized instance is not valid for use. All AttributeValue functions below must
 // be called only on instances that are created via NewAttributeValue+ functions.
 type AttributeValue struct {
-	orig *otlpcommon.AnyValue
+	orig  *otlpcommon.AnyValue
+	state *sharedState
 }
 
-func newAttributeValue(orig *otlpcommon.AnyValue) AttributeValue {
-	return AttributeValue{orig}
+func newAttributeValue(orig *otlpcommon.AnyValue, state *sharedState) AttributeValue {
+	return AttributeValue{orig: orig, state: state}
 }
 
 // NewAttributeValueNull creates a new AttributeValue with a null value.
 func NewAttributeValueNull() AttributeValue {
-	return AttributeValue{orig: &otlpcommon.AnyValue{}}
+	return newAttributeValue(&otlpcommon.AnyValue{}, newSharedState())
 }
 
 // NewAttributeValueString creates a new AttributeValue with the given string value.
 func NewAttributeValueString(v string) AttributeValue {
-	return AttributeValue{orig: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: v}}}
+	return newAttributeValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: v}}, newSharedState())
 }
 
 // NewAttributeValueInt creates a new AttributeValue with the given int64 value.
 func NewAttributeValueInt(v int64) AttributeValue {
-	return AttributeValue{orig: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_IntValue{IntValue: v}}}
+	return newAttributeValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_IntValue{IntValue: v}}, newSharedState())
 }
 
 // NewAttributeValueDouble creates a new AttributeValue with the given float64 value.
 func NewAttributeValueDouble(v float64) AttributeValue {
-	return AttributeValue{orig: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_DoubleValue{DoubleValue: v}}}
+	return newAttributeValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_DoubleValue{DoubleValue: v}}, newSharedState())
 }
 
 // NewAttributeValueBool creates a new AttributeValue with the given bool value.
 func NewAttributeValueBool(v bool) AttributeValue {
-	return AttributeValue{orig: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_BoolValue{BoolValue: v}}}
+	return newAttributeValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_BoolValue{BoolValue: v}}, newSharedState())
 }
 
 // NewAttributeValueMap creates a new AttributeValue of map type.
 func NewAttributeValueMap() AttributeValue {
-	return AttributeValue{orig: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_KvlistValue{KvlistValue: &otlpcommon.KeyValueList{}}}}
+	return newAttributeValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_KvlistValue{KvlistValue: &otlpcommon.KeyValueList{}}}, newSharedState())
 }
 
 // NewAttributeValueArray creates a new AttributeValue of array type.
 func NewAttributeValueArray() AttributeValue {
-	return AttributeValue{orig: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: &otlpcommon.ArrayValue{}}}}
+	return newAttributeValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: &otlpcommon.ArrayValue{}}}, newSharedState())
 }
 
 // NewAttributeValueBytes creates a new AttributeValue with the given []byte value.
 // The caller must ensure the []byte passed in is not modified after the call is made, sharing the data
 // across multiple attributes is forbidden.
 func NewAttributeValueBytes(v []byte) AttributeValue {
-	return AttributeValue{orig: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_BytesValue{BytesValue: v}}}
+	return newAttributeValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_BytesValue{BytesValue: v}}, newSharedState())
 }
 
 // Type returns the type of the value for this AttributeValue.
@@ -193,7 +194,7 @@ func (a AttributeValue) MapVal() AttributeMap {
 	if kvlist == nil {
 		return NewAttributeMap()
 	}
-	return newAttributeMap(&kvlist.Values)
+	return newAttributeMap(&kvlist.Values, a.state)
 }
 
 // ArrayVal returns the array value associated with this AttributeValue.
@@ -206,7 +207,7 @@ func (a AttributeValue) ArrayVal() AnyValueArray {
 	if arr == nil {
 		return NewAnyValueArray()
 	}
-	return newAnyValueArray(&arr.Values)
+	return newAnyValueArray(&arr.Values, a.state)
 }
 
 // BytesVal returns the []byte value associated with this AttributeValue.
@@ -221,6 +222,7 @@ func (a AttributeValue) BytesVal() []byte {
 // it also changes the type to be AttributeValueTypeString.
 // Calling this function on zero-initialized AttributeValue will cause a panic.
 func (a AttributeValue) SetStringVal(v string) {
+	a.state.assertMutable()
 	a.orig.Value = &otlpcommon.AnyValue_StringValue{StringValue: v}
 }
 
@@ -228,6 +230,7 @@ func (a AttributeValue) SetStringVal(v string) {
 // it also changes the type to be AttributeValueTypeInt.
 // Calling this function on zero-initialized AttributeValue will cause a panic.
 func (a AttributeValue) SetIntVal(v int64) {
+	a.state.assertMutable()
 	a.orig.Value = &otlpcommon.AnyValue_IntValue{IntValue: v}
 }
 
@@ -235,6 +238,7 @@ func (a AttributeValue) SetIntVal(v int64) {
 // it also changes the type to be AttributeValueTypeDouble.
 // Calling this function on zero-initialized AttributeValue will cause a panic.
 func (a AttributeValue) SetDoubleVal(v float64) {
+	a.state.assertMutable()
 	a.orig.Value = &otlpcommon.AnyValue_DoubleValue{DoubleValue: v}
 }
 
@@ -242,6 +246,7 @@ func (a AttributeValue) SetDoubleVal(v float64) {
 // it also changes the type to be AttributeValueTypeBool.
 // Calling this function on zero-initialized AttributeValue will cause a panic.
 func (a AttributeValue) SetBoolVal(v bool) {
+	a.state.assertMutable()
 	a.orig.Value = &otlpcommon.AnyValue_BoolValue{BoolValue: v}
 }
 
@@ -251,6 +256,7 @@ func (a AttributeValue) SetBoolVal(v bool) {
 // The caller must ensure the []byte passed in is not modified after the call is made, sharing the data
 // across multiple attributes is forbidden.
 func (a AttributeValue) SetBytesVal(v []byte) {
+	a.state.assertMutable()
 	a.orig.Value = &otlpcommon.AnyValue_BytesValue{BytesValue: v}
 }
 
@@ -268,7 +274,7 @@ func (a AttributeValue) copyTo(dest *otlpcommon.AnyValue) {
 			return
 		}
 		// Deep copy to dest.
-		newAttributeMap(&v.KvlistValue.Values).CopyTo(newAttributeMap(&kv.KvlistValue.Values))
+		newAttributeMap(&v.KvlistValue.Values, a.state).CopyTo(newAttributeMap(&kv.KvlistValue.Values, newSharedState()))
 	case *otlpcommon.AnyValue_ArrayValue:
 		av, ok := dest.Value.(*otlpcommon.AnyValue_ArrayValue)
 		if !ok {
@@ -280,7 +286,7 @@ func (a AttributeValue) copyTo(dest *otlpcommon.AnyValue) {
 			return
 		}
 		// Deep copy to dest.
-		newAnyValueArray(&v.ArrayValue.Values).CopyTo(newAnyValueArray(&av.ArrayValue.Values))
+		newAnyValueArray(&v.ArrayValue.Values, a.state).CopyTo(newAnyValueArray(&av.ArrayValue.Values, newSharedState()))
 	default:
 		// Primitive immutable type, no need for deep copy.
 		dest.Value = a.orig.Value
@@ -289,6 +295,7 @@ func (a AttributeValue) copyTo(dest *otlpcommon.AnyValue) {
 
 // CopyTo copies the attribute to a destination.
 func (a AttributeValue) CopyTo(dest AttributeValue) {
+	dest.state.assertMutable()
 	a.copyTo(dest.orig)
 }
 
@@ -324,14 +331,14 @@ func (a AttributeValue) Equal(av AttributeValue) bool {
 
 		for i, val := range avv {
 			val := val
-			newAv := newAttributeValue(&vv[i])
+			newAv := newAttributeValue(&vv[i], a.state)
 
 			// According to the specification, array values must be scalar.
 			if avType := newAv.Type(); avType == AttributeValueTypeArray || avType == AttributeValueTypeMap {
 				return false
 			}
 
-			if !newAv.Equal(newAttributeValue(&val)) {
+			if !newAv.Equal(newAttributeValue(&val, av.state)) {
 				return false
 			}
 		}
@@ -343,7 +350,7 @@ func (a AttributeValue) Equal(av AttributeValue) bool {
 			return false
 		}
 
-		am := newAttributeMap(&avv)
+		am := newAttributeMap(&avv, av.state)
 
 		for _, val := range cc {
 			newAv, ok := am.Get(val.Key)
@@ -351,7 +358,7 @@ func (a AttributeValue) Equal(av AttributeValue) bool {
 				return false
 			}
 
-			if !newAv.Equal(newAttributeValue(&val.Value)) {
+			if !newAv.Equal(newAttributeValue(&val.Value, a.state)) {
 				return false
 			}
 		}
@@ -365,29 +372,25 @@ func (a AttributeValue) Equal(av AttributeValue) bool {
 
 func newAttributeKeyValueString(k string, v string) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
-	akv := AttributeValue{&orig.Value}
-	akv.SetStringVal(v)
+	orig.Value.Value = &otlpcommon.AnyValue_StringValue{StringValue: v}
 	return orig
 }
 
 func newAttributeKeyValueInt(k string, v int64) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
-	akv := AttributeValue{&orig.Value}
-	akv.SetIntVal(v)
+	orig.Value.Value = &otlpcommon.AnyValue_IntValue{IntValue: v}
 	return orig
 }
 
 func newAttributeKeyValueDouble(k string, v float64) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
-	akv := AttributeValue{&orig.Value}
-	akv.SetDoubleVal(v)
+	orig.Value.Value = &otlpcommon.AnyValue_DoubleValue{DoubleValue: v}
 	return orig
 }
 
 func newAttributeKeyValueBool(k string, v bool) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
-	akv := AttributeValue{&orig.Value}
-	akv.SetBoolVal(v)
+	orig.Value.Value = &otlpcommon.AnyValue_BoolValue{BoolValue: v}
 	return orig
 }
 
@@ -404,24 +407,24 @@ func newAttributeKeyValue(k string, av AttributeValue) otlpcommon.KeyValue {
 
 func newAttributeKeyValueBytes(k string, v []byte) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
-	akv := AttributeValue{&orig.Value}
-	akv.SetBytesVal(v)
+	orig.Value.Value = &otlpcommon.AnyValue_BytesValue{BytesValue: v}
 	return orig
 }
 
 // AttributeMap stores a map of attribute keys to values.
 type AttributeMap struct {
-	orig *[]otlpcommon.KeyValue
+	orig  *[]otlpcommon.KeyValue
+	state *sharedState
 }
 
 // NewAttributeMap creates a AttributeMap with 0 elements.
 func NewAttributeMap() AttributeMap {
 	orig := []otlpcommon.KeyValue(nil)
-	return AttributeMap{&orig}
+	return newAttributeMap(&orig, newSharedState())
 }
 
-func newAttributeMap(orig *[]otlpcommon.KeyValue) AttributeMap {
-	return AttributeMap{orig}
+func newAttributeMap(orig *[]otlpcommon.KeyValue, state *sharedState) AttributeMap {
+	return AttributeMap{orig: orig, state: state}
 }
 
 // InitFromMap overwrites the entire AttributeMap and reconstructs the AttributeMap
@@ -430,6 +433,7 @@ func newAttributeMap(orig *[]otlpcommon.KeyValue) AttributeMap {
 // Returns the same instance to allow nicer code like:
 //   assert.EqualValues(t, NewAttributeMap().InitFromMap(map[string]AttributeValue{...}), actual)
 func (am AttributeMap) InitFromMap(attrMap map[string]AttributeValue) AttributeMap {
+	am.state.assertMutable()
 	if len(attrMap) == 0 {
 		*am.orig = []otlpcommon.KeyValue(nil)
 		return am
@@ -447,12 +451,14 @@ func (am AttributeMap) InitFromMap(attrMap map[string]AttributeValue) AttributeM
 
 // Clear erases any existing entries in this AttributeMap instance.
 func (am AttributeMap) Clear() {
+	am.state.assertMutable()
 	*am.orig = nil
 }
 
 // EnsureCapacity increases the capacity of this AttributeMap instance, if necessary,
 // to ensure that it can hold at least the number of elements specified by the capacity argument.
 func (am AttributeMap) EnsureCapacity(capacity int) {
+	am.state.assertMutable()
 	if capacity <= cap(*am.orig) {
 		return
 	}
@@ -472,15 +478,16 @@ func (am AttributeMap) Get(key string) (AttributeValue, bool) {
 	for i := range *am.orig {
 		akv := &(*am.orig)[i]
 		if akv.Key == key {
-			return AttributeValue{&akv.Value}, true
+			return newAttributeValue(&akv.Value, am.state), true
 		}
 	}
-	return AttributeValue{nil}, false
+	return AttributeValue{}, false
 }
 
 // Delete deletes the entry associated with the key and returns true if the key
 // was present in the map, otherwise returns false.
 func (am AttributeMap) Delete(key string) bool {
+	am.state.assertMutable()
 	for i := range *am.orig {
 		akv := &(*am.orig)[i]
 		if akv.Key == key {
@@ -500,6 +507,7 @@ func (am AttributeMap) Delete(key string) bool {
 // Important: this function should not be used if the caller has access to
 // the raw value to avoid an extra allocation.
 func (am AttributeMap) Insert(k string, v AttributeValue) {
+	am.state.assertMutable()
 	if _, existing := am.Get(k); !existing {
 		*am.orig = append(*am.orig, newAttributeKeyValue(k, v))
 	}
@@ -508,6 +516,7 @@ func (am AttributeMap) Insert(k string, v AttributeValue) {
 // InsertNull adds a null Value to the map when the key does not exist.
 // No action is applied to the map where the key already exists.
 func (am AttributeMap) InsertNull(k string) {
+	am.state.assertMutable()
 	if _, existing := am.Get(k); !existing {
 		*am.orig = append(*am.orig, newAttributeKeyValueNull(k))
 	}
@@ -516,6 +525,7 @@ func (am AttributeMap) InsertNull(k string) {
 // InsertString adds the string Value to the map when the key does not exist.
 // No action is applied to the map where the key already exists.
 func (am AttributeMap) InsertString(k string, v string) {
+	am.state.assertMutable()
 	if _, existing := am.Get(k); !existing {
 		*am.orig = append(*am.orig, newAttributeKeyValueString(k, v))
 	}
@@ -524,6 +534,7 @@ func (am AttributeMap) InsertString(k string, v string) {
 // InsertInt adds the int Value to the map when the key does not exist.
 // No action is applied to the map where the key already exists.
 func (am AttributeMap) InsertInt(k string, v int64) {
+	am.state.assertMutable()
 	if _, existing := am.Get(k); !existing {
 		*am.orig = append(*am.orig, newAttributeKeyValueInt(k, v))
 	}
@@ -532,6 +543,7 @@ func (am AttributeMap) InsertInt(k string, v int64) {
 // InsertDouble adds the double Value to the map when the key does not exist.
 // No action is applied to the map where the key already exists.
 func (am AttributeMap) InsertDouble(k string, v float64) {
+	am.state.assertMutable()
 	if _, existing := am.Get(k); !existing {
 		*am.orig = append(*am.orig, newAttributeKeyValueDouble(k, v))
 	}
@@ -540,6 +552,7 @@ func (am AttributeMap) InsertDouble(k string, v float64) {
 // InsertBool adds the bool Value to the map when the key does not exist.
 // No action is applied to the map where the key already exists.
 func (am AttributeMap) InsertBool(k string, v bool) {
+	am.state.assertMutable()
 	if _, existing := am.Get(k); !existing {
 		*am.orig = append(*am.orig, newAttributeKeyValueBool(k, v))
 	}
@@ -550,6 +563,7 @@ func (am AttributeMap) InsertBool(k string, v bool) {
 // The caller must ensure the []byte passed in is not modified after the call is made, sharing the data
 // across multiple attributes is forbidden.
 func (am AttributeMap) InsertBytes(k string, v []byte) {
+	am.state.assertMutable()
 	if _, existing := am.Get(k); !existing {
 		*am.orig = append(*am.orig, newAttributeKeyValueBytes(k, v))
 	}
@@ -563,6 +577,7 @@ func (am AttributeMap) InsertBytes(k string, v []byte) {
 // Important: this function should not be used if the caller has access to
 // the raw value to avoid an extra allocation.
 func (am AttributeMap) Update(k string, v AttributeValue) {
+	am.state.assertMutable()
 	if av, existing := am.Get(k); existing {
 		v.copyTo(av.orig)
 	}
@@ -571,6 +586,7 @@ func (am AttributeMap) Update(k string, v AttributeValue) {
 // UpdateString updates an existing string Value with a value.
 // No action is applied to the map where the key does not exist.
 func (am AttributeMap) UpdateString(k string, v string) {
+	am.state.assertMutable()
 	if av, existing := am.Get(k); existing {
 		av.SetStringVal(v)
 	}
@@ -579,6 +595,7 @@ func (am AttributeMap) UpdateString(k string, v string) {
 // UpdateInt updates an existing int Value with a value.
 // No action is applied to the map where the key does not exist.
 func (am AttributeMap) UpdateInt(k string, v int64) {
+	am.state.assertMutable()
 	if av, existing := am.Get(k); existing {
 		av.SetIntVal(v)
 	}
@@ -587,6 +604,7 @@ func (am AttributeMap) UpdateInt(k string, v int64) {
 // UpdateDouble updates an existing double Value with a value.
 // No action is applied to the map where the key does not exist.
 func (am AttributeMap) UpdateDouble(k string, v float64) {
+	am.state.assertMutable()
 	if av, existing := am.Get(k); existing {
 		av.SetDoubleVal(v)
 	}
@@ -595,6 +613,7 @@ func (am AttributeMap) UpdateDouble(k string, v float64) {
 // UpdateBool updates an existing bool Value with a value.
 // No action is applied to the map where the key does not exist.
 func (am AttributeMap) UpdateBool(k string, v bool) {
+	am.state.assertMutable()
 	if av, existing := am.Get(k); existing {
 		av.SetBoolVal(v)
 	}
@@ -605,6 +624,7 @@ func (am AttributeMap) UpdateBool(k string, v bool) {
 // The caller must ensure the []byte passed in is not modified after the call is made, sharing the data
 // across multiple attributes is forbidden.
 func (am AttributeMap) UpdateBytes(k string, v []byte) {
+	am.state.assertMutable()
 	if av, existing := am.Get(k); existing {
 		av.SetBytesVal(v)
 	}
@@ -619,6 +639,7 @@ func (am AttributeMap) UpdateBytes(k string, v []byte) {
 // Important: this function should not be used if the caller has access to
 // the raw value to avoid an extra allocation.
 func (am AttributeMap) Upsert(k string, v AttributeValue) {
+	am.state.assertMutable()
 	if av, existing := am.Get(k); existing {
 		v.copyTo(av.orig)
 	} else {
@@ -630,6 +651,7 @@ func (am AttributeMap) Upsert(k string, v AttributeValue) {
 // inserted to the map that did not originally have the key. The key/value is
 // updated to the map where the key already existed.
 func (am AttributeMap) UpsertString(k string, v string) {
+	am.state.assertMutable()
 	if av, existing := am.Get(k); existing {
 		av.SetStringVal(v)
 	} else {
@@ -641,6 +663,7 @@ func (am AttributeMap) UpsertString(k string, v string) {
 // inserted to the map that did not originally have the key. The key/value is
 // updated to the map where the key already existed.
 func (am AttributeMap) UpsertInt(k string, v int64) {
+	am.state.assertMutable()
 	if av, existing := am.Get(k); existing {
 		av.SetIntVal(v)
 	} else {
@@ -652,6 +675,7 @@ func (am AttributeMap) UpsertInt(k string, v int64) {
 // inserted to the map that did not originally have the key. The key/value is
 // updated to the map where the key already existed.
 func (am AttributeMap) UpsertDouble(k string, v float64) {
+	am.state.assertMutable()
 	if av, existing := am.Get(k); existing {
 		av.SetDoubleVal(v)
 	} else {
@@ -663,6 +687,7 @@ func (am AttributeMap) UpsertDouble(k string, v float64) {
 // inserted to the map that did not originally have the key. The key/value is
 // updated to the map where the key already existed.
 func (am AttributeMap) UpsertBool(k string, v bool) {
+	am.state.assertMutable()
 	if av, existing := am.Get(k); existing {
 		av.SetBoolVal(v)
 	} else {
@@ -676,6 +701,7 @@ func (am AttributeMap) UpsertBool(k string, v bool) {
 // The caller must ensure the []byte passed in is not modified after the call is made, sharing the data
 // across multiple attributes is forbidden.
 func (am AttributeMap) UpsertBytes(k string, v []byte) {
+	am.state.assertMutable()
 	if av, existing := am.Get(k); existing {
 		av.SetBytesVal(v)
 	} else {
@@ -687,6 +713,7 @@ func (am AttributeMap) UpsertBytes(k string, v []byte) {
 // Returns the same instance to allow nicer code like:
 //   assert.EqualValues(t, expected.Sort(), actual.Sort())
 func (am AttributeMap) Sort() AttributeMap {
+	am.state.assertMutable()
 	// Intention is to move the nil values at the end.
 	sort.SliceStable(*am.orig, func(i, j int) bool {
 		return (*am.orig)[i].Key < (*am.orig)[j].Key
@@ -712,7 +739,7 @@ func (am AttributeMap) Len() int {
 func (am AttributeMap) Range(f func(k string, v AttributeValue) bool) {
 	for i := range *am.orig {
 		kv := &(*am.orig)[i]
-		if !f(kv.Key, AttributeValue{&kv.Value}) {
+		if !f(kv.Key, newAttributeValue(&kv.Value, am.state)) {
 			break
 		}
 	}
@@ -720,6 +747,7 @@ func (am AttributeMap) Range(f func(k string, v AttributeValue) bool) {
 
 // CopyTo copies all elements from the current map to the dest.
 func (am AttributeMap) CopyTo(dest AttributeMap) {
+	dest.state.assertMutable()
 	newLen := len(*am.orig)
 	oldCap := cap(*dest.orig)
 	if newLen <= oldCap {
@@ -729,7 +757,7 @@ func (am AttributeMap) CopyTo(dest AttributeMap) {
 			akv := &(*am.orig)[i]
 			destAkv := &(*dest.orig)[i]
 			destAkv.Key = akv.Key
-			AttributeValue{&akv.Value}.copyTo(&destAkv.Value)
+			newAttributeValue(&akv.Value, am.state).copyTo(&destAkv.Value)
 		}
 		return
 	}
@@ -739,7 +767,7 @@ func (am AttributeMap) CopyTo(dest AttributeMap) {
 	for i := range *am.orig {
 		akv := &(*am.orig)[i]
 		origs[i].Key = akv.Key
-		AttributeValue{&akv.Value}.copyTo(&origs[i].Value)
+		newAttributeValue(&akv.Value, am.state).copyTo(&origs[i].Value)
 	}
 	*dest.orig = origs
 }
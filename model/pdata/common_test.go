@@ -150,7 +150,7 @@ func TestAttributeValueMap(t *testing.T) {
 
 	// Test nil KvlistValue case for MapVal() func.
 	orig := &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_KvlistValue{KvlistValue: nil}}
-	m1 = AttributeValue{orig: orig}
+	m1 = newAttributeValue(orig, newSharedState())
 	assert.EqualValues(t, NewAttributeMap(), m1.MapVal())
 }
 
@@ -273,7 +273,7 @@ func TestNilAttributeMap(t *testing.T) {
 
 	val, exist := NewAttributeMap().Get("test_key")
 	assert.False(t, exist)
-	assert.EqualValues(t, AttributeValue{nil}, val)
+	assert.EqualValues(t, AttributeValue{}, val)
 
 	insertMap := NewAttributeMap()
 	insertMap.Insert("k", NewAttributeValueString("v"))
@@ -371,9 +371,7 @@ func TestAttributeMapWithEmpty(t *testing.T) {
 			Value: otlpcommon.AnyValue{Value: nil},
 		},
 	}
-	sm := AttributeMap{
-		orig: &origWithNil,
-	}
+	sm := newAttributeMap(&origWithNil, newSharedState())
 	val, exist := sm.Get("test_key")
 	assert.True(t, exist)
 	assert.EqualValues(t, AttributeValueTypeString, val.Type())
@@ -558,7 +556,7 @@ func TestAttributeMapWithEmpty(t *testing.T) {
 	assert.False(t, exist)
 
 	// Test Sort
-	assert.EqualValues(t, AttributeMap{orig: &origWithNil}, sm.Sort())
+	assert.EqualValues(t, newAttributeMap(&origWithNil, newSharedState()), sm.Sort())
 }
 
 func TestAttributeMapIterationNil(t *testing.T) {
@@ -617,24 +615,24 @@ func TestAttributeMap_InitFromMap(t *testing.T) {
 		newAttributeKeyValueBytes("k_bytes", []byte{1, 2, 3}),
 	}
 	am = NewAttributeMap().InitFromMap(rawMap)
-	assert.EqualValues(t, AttributeMap{orig: &rawOrig}.Sort(), am.Sort())
+	assert.EqualValues(t, newAttributeMap(&rawOrig, newSharedState()).Sort(), am.Sort())
 }
 
 func TestAttributeValue_CopyTo(t *testing.T) {
 	// Test nil KvlistValue case for MapVal() func.
 	dest := NewAttributeValueNull()
 	orig := &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_KvlistValue{KvlistValue: nil}}
-	AttributeValue{orig: orig}.CopyTo(dest)
+	newAttributeValue(orig, newSharedState()).CopyTo(dest)
 	assert.Nil(t, dest.orig.Value.(*otlpcommon.AnyValue_KvlistValue).KvlistValue)
 
 	// Test nil ArrayValue case for ArrayVal() func.
 	dest = NewAttributeValueNull()
 	orig = &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: nil}}
-	AttributeValue{orig: orig}.CopyTo(dest)
+	newAttributeValue(orig, newSharedState()).CopyTo(dest)
 	assert.Nil(t, dest.orig.Value.(*otlpcommon.AnyValue_ArrayValue).ArrayValue)
 
 	// Test copy empty value.
-	AttributeValue{orig: &otlpcommon.AnyValue{}}.CopyTo(dest)
+	AttributeValue{orig: &otlpcommon.AnyValue{}, state: newSharedState()}.CopyTo(dest)
 	assert.Nil(t, dest.orig.Value)
 }
 
@@ -676,9 +674,7 @@ func TestAttributeMap_Update(t *testing.T) {
 			Value: otlpcommon.AnyValue{Value: nil},
 		},
 	}
-	sm := AttributeMap{
-		orig: &origWithNil,
-	}
+	sm := newAttributeMap(&origWithNil, newSharedState())
 
 	av, exists := sm.Get("test_key")
 	assert.True(t, exists)
@@ -768,9 +764,7 @@ func BenchmarkAttributeMap_Range(b *testing.B) {
 			Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: "v" + strconv.Itoa(i)}},
 		}
 	}
-	am := AttributeMap{
-		orig: &rawOrig,
-	}
+	am := newAttributeMap(&rawOrig, newSharedState())
 	b.ResetTimer()
 	for n := 0; n < b.N; n++ {
 		numEls := 0
@@ -924,7 +918,7 @@ func TestAttributeValueArray(t *testing.T) {
 	assert.EqualValues(t, "somestr", v.StringVal())
 
 	// Test nil values case for ArrayVal() func.
-	a1 = AttributeValue{orig: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: nil}}}
+	a1 = AttributeValue{orig: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: nil}}, state: newSharedState()}
 	assert.EqualValues(t, NewAnyValueArray(), a1.ArrayVal())
 }
 
@@ -933,9 +927,7 @@ func TestAnyValueArrayWithNilValues(t *testing.T) {
 		{},
 		{Value: &otlpcommon.AnyValue_StringValue{StringValue: "test_value"}},
 	}
-	sm := AnyValueArray{
-		orig: &origWithNil,
-	}
+	sm := newAnyValueArray(&origWithNil, newSharedState())
 
 	val := sm.At(0)
 	assert.EqualValues(t, AttributeValueTypeNull, val.Type())
@@ -28,20 +28,21 @@ import (
 //
 // Must use NewInstrumentationLibrary function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type InstrumentationLibrary struct {
-	orig *otlpcommon.InstrumentationLibrary
+	orig  *otlpcommon.InstrumentationLibrary
+	state *sharedState
 }
 
-func newInstrumentationLibrary(orig *otlpcommon.InstrumentationLibrary) InstrumentationLibrary {
-	return InstrumentationLibrary{orig: orig}
+func newInstrumentationLibrary(orig *otlpcommon.InstrumentationLibrary, state *sharedState) InstrumentationLibrary {
+	return InstrumentationLibrary{orig: orig, state: state}
 }
 
 // NewInstrumentationLibrary creates a new empty InstrumentationLibrary.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewInstrumentationLibrary() InstrumentationLibrary {
-	return newInstrumentationLibrary(&otlpcommon.InstrumentationLibrary{})
+	return newInstrumentationLibrary(&otlpcommon.InstrumentationLibrary{}, newSharedState())
 }
 
 // Name returns the name associated with this InstrumentationLibrary.
@@ -51,6 +52,7 @@ func (ms InstrumentationLibrary) Name() string {
 
 // SetName replaces the name associated with this InstrumentationLibrary.
 func (ms InstrumentationLibrary) SetName(v string) {
+	ms.state.assertMutable()
 	(*ms.orig).Name = v
 }
 
@@ -61,11 +63,13 @@ func (ms InstrumentationLibrary) Version() string {
 
 // SetVersion replaces the version associated with this InstrumentationLibrary.
 func (ms InstrumentationLibrary) SetVersion(v string) {
+	ms.state.assertMutable()
 	(*ms.orig).Version = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms InstrumentationLibrary) CopyTo(dest InstrumentationLibrary) {
+	dest.state.assertMutable()
 	dest.SetName(ms.Name())
 	dest.SetVersion(ms.Version())
 }
@@ -80,18 +84,19 @@ func (ms InstrumentationLibrary) CopyTo(dest InstrumentationLibrary) {
 type AnyValueArray struct {
 	// orig points to the slice otlpcommon.AnyValue field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]otlpcommon.AnyValue
+	orig  *[]otlpcommon.AnyValue
+	state *sharedState
 }
 
-func newAnyValueArray(orig *[]otlpcommon.AnyValue) AnyValueArray {
-	return AnyValueArray{orig}
+func newAnyValueArray(orig *[]otlpcommon.AnyValue, state *sharedState) AnyValueArray {
+	return AnyValueArray{orig: orig, state: state}
 }
 
 // NewAnyValueArray creates a AnyValueArray with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewAnyValueArray() AnyValueArray {
 	orig := []otlpcommon.AnyValue(nil)
-	return AnyValueArray{&orig}
+	return newAnyValueArray(&orig, newSharedState())
 }
 
 // Len returns the number of elements in the slice.
@@ -109,11 +114,12 @@ func (es AnyValueArray) Len() int {
 //       ... // Do something with the element
 //   }
 func (es AnyValueArray) At(ix int) AttributeValue {
-	return newAttributeValue(&(*es.orig)[ix])
+	return newAttributeValue(&(*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es AnyValueArray) CopyTo(dest AnyValueArray) {
+	dest.state.assertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
@@ -123,7 +129,7 @@ func (es AnyValueArray) CopyTo(dest AnyValueArray) {
 	}
 
 	for i := range *es.orig {
-		newAttributeValue(&(*es.orig)[i]).CopyTo(newAttributeValue(&(*dest.orig)[i]))
+		newAttributeValue(&(*es.orig)[i], es.state).CopyTo(newAttributeValue(&(*dest.orig)[i], dest.state))
 	}
 }
 
@@ -139,6 +145,7 @@ func (es AnyValueArray) CopyTo(dest AnyValueArray) {
 //       // Here should set all the values for e.
 //   }
 func (es AnyValueArray) EnsureCapacity(newCap int) {
+	es.state.assertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -152,6 +159,7 @@ func (es AnyValueArray) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty AttributeValue.
 // It returns the newly added AttributeValue.
 func (es AnyValueArray) AppendEmpty() AttributeValue {
+	es.state.assertMutable()
 	*es.orig = append(*es.orig, otlpcommon.AnyValue{})
 	return es.At(es.Len() - 1)
 }
@@ -159,6 +167,8 @@ func (es AnyValueArray) AppendEmpty() AttributeValue {
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es AnyValueArray) MoveAndAppendTo(dest AnyValueArray) {
+	es.state.assertMutable()
+	dest.state.assertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -171,6 +181,7 @@ func (es AnyValueArray) MoveAndAppendTo(dest AnyValueArray) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es AnyValueArray) RemoveIf(f func(AttributeValue) bool) {
+	es.state.assertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -50,11 +50,11 @@ func TestInstrumentationLibrary_Version(t *testing.T) {
 func TestAnyValueArray(t *testing.T) {
 	es := NewAnyValueArray()
 	assert.EqualValues(t, 0, es.Len())
-	es = newAnyValueArray(&[]otlpcommon.AnyValue{})
+	es = newAnyValueArray(&[]otlpcommon.AnyValue{}, newSharedState())
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newAttributeValue(&otlpcommon.AnyValue{})
+	emptyVal := newAttributeValue(&otlpcommon.AnyValue{}, newSharedState())
 	testVal := generateTestAttributeValue()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
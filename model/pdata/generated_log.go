@@ -33,18 +33,19 @@ import (
 type ResourceLogsSlice struct {
 	// orig points to the slice otlplogs.ResourceLogs field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlplogs.ResourceLogs
+	orig  *[]*otlplogs.ResourceLogs
+	state *sharedState
 }
 
-func newResourceLogsSlice(orig *[]*otlplogs.ResourceLogs) ResourceLogsSlice {
-	return ResourceLogsSlice{orig}
+func newResourceLogsSlice(orig *[]*otlplogs.ResourceLogs, state *sharedState) ResourceLogsSlice {
+	return ResourceLogsSlice{orig: orig, state: state}
 }
 
 // NewResourceLogsSlice creates a ResourceLogsSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewResourceLogsSlice() ResourceLogsSlice {
 	orig := []*otlplogs.ResourceLogs(nil)
-	return ResourceLogsSlice{&orig}
+	return newResourceLogsSlice(&orig, newSharedState())
 }
 
 // Len returns the number of elements in the slice.
@@ -62,17 +63,18 @@ func (es ResourceLogsSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ResourceLogsSlice) At(ix int) ResourceLogs {
-	return newResourceLogs((*es.orig)[ix])
+	return newResourceLogs((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ResourceLogsSlice) CopyTo(dest ResourceLogsSlice) {
+	dest.state.assertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newResourceLogs((*es.orig)[i]).CopyTo(newResourceLogs((*dest.orig)[i]))
+			newResourceLogs((*es.orig)[i], es.state).CopyTo(newResourceLogs((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -80,7 +82,7 @@ func (es ResourceLogsSlice) CopyTo(dest ResourceLogsSlice) {
 	wrappers := make([]*otlplogs.ResourceLogs, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newResourceLogs((*es.orig)[i]).CopyTo(newResourceLogs(wrappers[i]))
+		newResourceLogs((*es.orig)[i], es.state).CopyTo(newResourceLogs(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -97,6 +99,7 @@ func (es ResourceLogsSlice) CopyTo(dest ResourceLogsSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es ResourceLogsSlice) EnsureCapacity(newCap int) {
+	es.state.assertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -110,6 +113,7 @@ func (es ResourceLogsSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty ResourceLogs.
 // It returns the newly added ResourceLogs.
 func (es ResourceLogsSlice) AppendEmpty() ResourceLogs {
+	es.state.assertMutable()
 	*es.orig = append(*es.orig, &otlplogs.ResourceLogs{})
 	return es.At(es.Len() - 1)
 }
@@ -124,6 +128,7 @@ func (es ResourceLogsSlice) AppendEmpty() ResourceLogs {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es ResourceLogsSlice) Sort(less func(a, b ResourceLogs) bool) ResourceLogsSlice {
+	es.state.assertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -131,6 +136,8 @@ func (es ResourceLogsSlice) Sort(less func(a, b ResourceLogs) bool) ResourceLogs
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ResourceLogsSlice) MoveAndAppendTo(dest ResourceLogsSlice) {
+	es.state.assertMutable()
+	dest.state.assertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -143,6 +150,7 @@ func (es ResourceLogsSlice) MoveAndAppendTo(dest ResourceLogsSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ResourceLogsSlice) RemoveIf(f func(ResourceLogs) bool) {
+	es.state.assertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -167,25 +175,26 @@ func (es ResourceLogsSlice) RemoveIf(f func(ResourceLogs) bool) {
 //
 // Must use NewResourceLogs function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type ResourceLogs struct {
-	orig *otlplogs.ResourceLogs
+	orig  *otlplogs.ResourceLogs
+	state *sharedState
 }
 
-func newResourceLogs(orig *otlplogs.ResourceLogs) ResourceLogs {
-	return ResourceLogs{orig: orig}
+func newResourceLogs(orig *otlplogs.ResourceLogs, state *sharedState) ResourceLogs {
+	return ResourceLogs{orig: orig, state: state}
 }
 
 // NewResourceLogs creates a new empty ResourceLogs.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewResourceLogs() ResourceLogs {
-	return newResourceLogs(&otlplogs.ResourceLogs{})
+	return newResourceLogs(&otlplogs.ResourceLogs{}, newSharedState())
 }
 
 // Resource returns the resource associated with this ResourceLogs.
 func (ms ResourceLogs) Resource() Resource {
-	return newResource(&(*ms.orig).Resource)
+	return newResource(&(*ms.orig).Resource, ms.state)
 }
 
 // SchemaUrl returns the schemaurl associated with this ResourceLogs.
@@ -195,16 +204,18 @@ func (ms ResourceLogs) SchemaUrl() string {
 
 // SetSchemaUrl replaces the schemaurl associated with this ResourceLogs.
 func (ms ResourceLogs) SetSchemaUrl(v string) {
+	ms.state.assertMutable()
 	(*ms.orig).SchemaUrl = v
 }
 
 // InstrumentationLibraryLogs returns the InstrumentationLibraryLogs associated with this ResourceLogs.
 func (ms ResourceLogs) InstrumentationLibraryLogs() InstrumentationLibraryLogsSlice {
-	return newInstrumentationLibraryLogsSlice(&(*ms.orig).InstrumentationLibraryLogs)
+	return newInstrumentationLibraryLogsSlice(&(*ms.orig).InstrumentationLibraryLogs, ms.state)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms ResourceLogs) CopyTo(dest ResourceLogs) {
+	dest.state.assertMutable()
 	ms.Resource().CopyTo(dest.Resource())
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.InstrumentationLibraryLogs().CopyTo(dest.InstrumentationLibraryLogs())
@@ -220,18 +231,19 @@ func (ms ResourceLogs) CopyTo(dest ResourceLogs) {
 type InstrumentationLibraryLogsSlice struct {
 	// orig points to the slice otlplogs.InstrumentationLibraryLogs field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlplogs.InstrumentationLibraryLogs
+	orig  *[]*otlplogs.InstrumentationLibraryLogs
+	state *sharedState
 }
 
-func newInstrumentationLibraryLogsSlice(orig *[]*otlplogs.InstrumentationLibraryLogs) InstrumentationLibraryLogsSlice {
-	return InstrumentationLibraryLogsSlice{orig}
+func newInstrumentationLibraryLogsSlice(orig *[]*otlplogs.InstrumentationLibraryLogs, state *sharedState) InstrumentationLibraryLogsSlice {
+	return InstrumentationLibraryLogsSlice{orig: orig, state: state}
 }
 
 // NewInstrumentationLibraryLogsSlice creates a InstrumentationLibraryLogsSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewInstrumentationLibraryLogsSlice() InstrumentationLibraryLogsSlice {
 	orig := []*otlplogs.InstrumentationLibraryLogs(nil)
-	return InstrumentationLibraryLogsSlice{&orig}
+	return newInstrumentationLibraryLogsSlice(&orig, newSharedState())
 }
 
 // Len returns the number of elements in the slice.
@@ -249,17 +261,18 @@ func (es InstrumentationLibraryLogsSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es InstrumentationLibraryLogsSlice) At(ix int) InstrumentationLibraryLogs {
-	return newInstrumentationLibraryLogs((*es.orig)[ix])
+	return newInstrumentationLibraryLogs((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es InstrumentationLibraryLogsSlice) CopyTo(dest InstrumentationLibraryLogsSlice) {
+	dest.state.assertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newInstrumentationLibraryLogs((*es.orig)[i]).CopyTo(newInstrumentationLibraryLogs((*dest.orig)[i]))
+			newInstrumentationLibraryLogs((*es.orig)[i], es.state).CopyTo(newInstrumentationLibraryLogs((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -267,7 +280,7 @@ func (es InstrumentationLibraryLogsSlice) CopyTo(dest InstrumentationLibraryLogs
 	wrappers := make([]*otlplogs.InstrumentationLibraryLogs, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newInstrumentationLibraryLogs((*es.orig)[i]).CopyTo(newInstrumentationLibraryLogs(wrappers[i]))
+		newInstrumentationLibraryLogs((*es.orig)[i], es.state).CopyTo(newInstrumentationLibraryLogs(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -284,6 +297,7 @@ func (es InstrumentationLibraryLogsSlice) CopyTo(dest InstrumentationLibraryLogs
 //       // Here should set all the values for e.
 //   }
 func (es InstrumentationLibraryLogsSlice) EnsureCapacity(newCap int) {
+	es.state.assertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -297,6 +311,7 @@ func (es InstrumentationLibraryLogsSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty InstrumentationLibraryLogs.
 // It returns the newly added InstrumentationLibraryLogs.
 func (es InstrumentationLibraryLogsSlice) AppendEmpty() InstrumentationLibraryLogs {
+	es.state.assertMutable()
 	*es.orig = append(*es.orig, &otlplogs.InstrumentationLibraryLogs{})
 	return es.At(es.Len() - 1)
 }
@@ -311,6 +326,7 @@ func (es InstrumentationLibraryLogsSlice) AppendEmpty() InstrumentationLibraryLo
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es InstrumentationLibraryLogsSlice) Sort(less func(a, b InstrumentationLibraryLogs) bool) InstrumentationLibraryLogsSlice {
+	es.state.assertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -318,6 +334,8 @@ func (es InstrumentationLibraryLogsSlice) Sort(less func(a, b InstrumentationLib
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es InstrumentationLibraryLogsSlice) MoveAndAppendTo(dest InstrumentationLibraryLogsSlice) {
+	es.state.assertMutable()
+	dest.state.assertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -330,6 +348,7 @@ func (es InstrumentationLibraryLogsSlice) MoveAndAppendTo(dest InstrumentationLi
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es InstrumentationLibraryLogsSlice) RemoveIf(f func(InstrumentationLibraryLogs) bool) {
+	es.state.assertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -354,25 +373,26 @@ func (es InstrumentationLibraryLogsSlice) RemoveIf(f func(InstrumentationLibrary
 //
 // Must use NewInstrumentationLibraryLogs function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type InstrumentationLibraryLogs struct {
-	orig *otlplogs.InstrumentationLibraryLogs
+	orig  *otlplogs.InstrumentationLibraryLogs
+	state *sharedState
 }
 
-func newInstrumentationLibraryLogs(orig *otlplogs.InstrumentationLibraryLogs) InstrumentationLibraryLogs {
-	return InstrumentationLibraryLogs{orig: orig}
+func newInstrumentationLibraryLogs(orig *otlplogs.InstrumentationLibraryLogs, state *sharedState) InstrumentationLibraryLogs {
+	return InstrumentationLibraryLogs{orig: orig, state: state}
 }
 
 // NewInstrumentationLibraryLogs creates a new empty InstrumentationLibraryLogs.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewInstrumentationLibraryLogs() InstrumentationLibraryLogs {
-	return newInstrumentationLibraryLogs(&otlplogs.InstrumentationLibraryLogs{})
+	return newInstrumentationLibraryLogs(&otlplogs.InstrumentationLibraryLogs{}, newSharedState())
 }
 
 // InstrumentationLibrary returns the instrumentationlibrary associated with this InstrumentationLibraryLogs.
 func (ms InstrumentationLibraryLogs) InstrumentationLibrary() InstrumentationLibrary {
-	return newInstrumentationLibrary(&(*ms.orig).InstrumentationLibrary)
+	return newInstrumentationLibrary(&(*ms.orig).InstrumentationLibrary, ms.state)
 }
 
 // SchemaUrl returns the schemaurl associated with this InstrumentationLibraryLogs.
@@ -382,16 +402,18 @@ func (ms InstrumentationLibraryLogs) SchemaUrl() string {
 
 // SetSchemaUrl replaces the schemaurl associated with this InstrumentationLibraryLogs.
 func (ms InstrumentationLibraryLogs) SetSchemaUrl(v string) {
+	ms.state.assertMutable()
 	(*ms.orig).SchemaUrl = v
 }
 
 // Logs returns the Logs associated with this InstrumentationLibraryLogs.
 func (ms InstrumentationLibraryLogs) Logs() LogSlice {
-	return newLogSlice(&(*ms.orig).Logs)
+	return newLogSlice(&(*ms.orig).Logs, ms.state)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms InstrumentationLibraryLogs) CopyTo(dest InstrumentationLibraryLogs) {
+	dest.state.assertMutable()
 	ms.InstrumentationLibrary().CopyTo(dest.InstrumentationLibrary())
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.Logs().CopyTo(dest.Logs())
@@ -407,18 +429,19 @@ func (ms InstrumentationLibraryLogs) CopyTo(dest InstrumentationLibraryLogs) {
 type LogSlice struct {
 	// orig points to the slice otlplogs.LogRecord field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlplogs.LogRecord
+	orig  *[]*otlplogs.LogRecord
+	state *sharedState
 }
 
-func newLogSlice(orig *[]*otlplogs.LogRecord) LogSlice {
-	return LogSlice{orig}
+func newLogSlice(orig *[]*otlplogs.LogRecord, state *sharedState) LogSlice {
+	return LogSlice{orig: orig, state: state}
 }
 
 // NewLogSlice creates a LogSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewLogSlice() LogSlice {
 	orig := []*otlplogs.LogRecord(nil)
-	return LogSlice{&orig}
+	return newLogSlice(&orig, newSharedState())
 }
 
 // Len returns the number of elements in the slice.
@@ -436,17 +459,18 @@ func (es LogSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es LogSlice) At(ix int) LogRecord {
-	return newLogRecord((*es.orig)[ix])
+	return newLogRecord((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es LogSlice) CopyTo(dest LogSlice) {
+	dest.state.assertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newLogRecord((*es.orig)[i]).CopyTo(newLogRecord((*dest.orig)[i]))
+			newLogRecord((*es.orig)[i], es.state).CopyTo(newLogRecord((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -454,7 +478,7 @@ func (es LogSlice) CopyTo(dest LogSlice) {
 	wrappers := make([]*otlplogs.LogRecord, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newLogRecord((*es.orig)[i]).CopyTo(newLogRecord(wrappers[i]))
+		newLogRecord((*es.orig)[i], es.state).CopyTo(newLogRecord(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -471,6 +495,7 @@ func (es LogSlice) CopyTo(dest LogSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es LogSlice) EnsureCapacity(newCap int) {
+	es.state.assertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -484,6 +509,7 @@ func (es LogSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty LogRecord.
 // It returns the newly added LogRecord.
 func (es LogSlice) AppendEmpty() LogRecord {
+	es.state.assertMutable()
 	*es.orig = append(*es.orig, &otlplogs.LogRecord{})
 	return es.At(es.Len() - 1)
 }
@@ -498,6 +524,7 @@ func (es LogSlice) AppendEmpty() LogRecord {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es LogSlice) Sort(less func(a, b LogRecord) bool) LogSlice {
+	es.state.assertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -505,6 +532,8 @@ func (es LogSlice) Sort(less func(a, b LogRecord) bool) LogSlice {
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es LogSlice) MoveAndAppendTo(dest LogSlice) {
+	es.state.assertMutable()
+	dest.state.assertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -517,6 +546,7 @@ func (es LogSlice) MoveAndAppendTo(dest LogSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es LogSlice) RemoveIf(f func(LogRecord) bool) {
+	es.state.assertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -542,20 +572,21 @@ func (es LogSlice) RemoveIf(f func(LogRecord) bool) {
 //
 // Must use NewLogRecord function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type LogRecord struct {
-	orig *otlplogs.LogRecord
+	orig  *otlplogs.LogRecord
+	state *sharedState
 }
 
-func newLogRecord(orig *otlplogs.LogRecord) LogRecord {
-	return LogRecord{orig: orig}
+func newLogRecord(orig *otlplogs.LogRecord, state *sharedState) LogRecord {
+	return LogRecord{orig: orig, state: state}
 }
 
 // NewLogRecord creates a new empty LogRecord.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewLogRecord() LogRecord {
-	return newLogRecord(&otlplogs.LogRecord{})
+	return newLogRecord(&otlplogs.LogRecord{}, newSharedState())
 }
 
 // Timestamp returns the timestamp associated with this LogRecord.
@@ -565,6 +596,7 @@ func (ms LogRecord) Timestamp() Timestamp {
 
 // SetTimestamp replaces the timestamp associated with this LogRecord.
 func (ms LogRecord) SetTimestamp(v Timestamp) {
+	ms.state.assertMutable()
 	(*ms.orig).TimeUnixNano = uint64(v)
 }
 
@@ -575,6 +607,7 @@ func (ms LogRecord) TraceID() TraceID {
 
 // SetTraceID replaces the traceid associated with this LogRecord.
 func (ms LogRecord) SetTraceID(v TraceID) {
+	ms.state.assertMutable()
 	(*ms.orig).TraceId = v.orig
 }
 
@@ -585,6 +618,7 @@ func (ms LogRecord) SpanID() SpanID {
 
 // SetSpanID replaces the spanid associated with this LogRecord.
 func (ms LogRecord) SetSpanID(v SpanID) {
+	ms.state.assertMutable()
 	(*ms.orig).SpanId = v.orig
 }
 
@@ -595,6 +629,7 @@ func (ms LogRecord) Flags() uint32 {
 
 // SetFlags replaces the flags associated with this LogRecord.
 func (ms LogRecord) SetFlags(v uint32) {
+	ms.state.assertMutable()
 	(*ms.orig).Flags = uint32(v)
 }
 
@@ -605,6 +640,7 @@ func (ms LogRecord) SeverityText() string {
 
 // SetSeverityText replaces the severitytext associated with this LogRecord.
 func (ms LogRecord) SetSeverityText(v string) {
+	ms.state.assertMutable()
 	(*ms.orig).SeverityText = v
 }
 
@@ -615,6 +651,7 @@ func (ms LogRecord) SeverityNumber() SeverityNumber {
 
 // SetSeverityNumber replaces the severitynumber associated with this LogRecord.
 func (ms LogRecord) SetSeverityNumber(v SeverityNumber) {
+	ms.state.assertMutable()
 	(*ms.orig).SeverityNumber = otlplogs.SeverityNumber(v)
 }
 
@@ -625,17 +662,18 @@ func (ms LogRecord) Name() string {
 
 // SetName replaces the name associated with this LogRecord.
 func (ms LogRecord) SetName(v string) {
+	ms.state.assertMutable()
 	(*ms.orig).Name = v
 }
 
 // Body returns the body associated with this LogRecord.
 func (ms LogRecord) Body() AttributeValue {
-	return newAttributeValue(&(*ms.orig).Body)
+	return newAttributeValue(&(*ms.orig).Body, ms.state)
 }
 
 // Attributes returns the Attributes associated with this LogRecord.
 func (ms LogRecord) Attributes() AttributeMap {
-	return newAttributeMap(&(*ms.orig).Attributes)
+	return newAttributeMap(&(*ms.orig).Attributes, ms.state)
 }
 
 // DroppedAttributesCount returns the droppedattributescount associated with this LogRecord.
@@ -645,11 +683,13 @@ func (ms LogRecord) DroppedAttributesCount() uint32 {
 
 // SetDroppedAttributesCount replaces the droppedattributescount associated with this LogRecord.
 func (ms LogRecord) SetDroppedAttributesCount(v uint32) {
+	ms.state.assertMutable()
 	(*ms.orig).DroppedAttributesCount = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms LogRecord) CopyTo(dest LogRecord) {
+	dest.state.assertMutable()
 	dest.SetTimestamp(ms.Timestamp())
 	dest.SetTraceID(ms.TraceID())
 	dest.SetSpanID(ms.SpanID())
@@ -28,11 +28,11 @@ import (
 func TestResourceLogsSlice(t *testing.T) {
 	es := NewResourceLogsSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newResourceLogsSlice(&[]*otlplogs.ResourceLogs{})
+	es = newResourceLogsSlice(&[]*otlplogs.ResourceLogs{}, newSharedState())
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newResourceLogs(&otlplogs.ResourceLogs{})
+	emptyVal := newResourceLogs(&otlplogs.ResourceLogs{}, newSharedState())
 	testVal := generateTestResourceLogs()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -166,11 +166,11 @@ func TestResourceLogs_InstrumentationLibraryLogs(t *testing.T) {
 func TestInstrumentationLibraryLogsSlice(t *testing.T) {
 	es := NewInstrumentationLibraryLogsSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newInstrumentationLibraryLogsSlice(&[]*otlplogs.InstrumentationLibraryLogs{})
+	es = newInstrumentationLibraryLogsSlice(&[]*otlplogs.InstrumentationLibraryLogs{}, newSharedState())
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newInstrumentationLibraryLogs(&otlplogs.InstrumentationLibraryLogs{})
+	emptyVal := newInstrumentationLibraryLogs(&otlplogs.InstrumentationLibraryLogs{}, newSharedState())
 	testVal := generateTestInstrumentationLibraryLogs()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -304,11 +304,11 @@ func TestInstrumentationLibraryLogs_Logs(t *testing.T) {
 func TestLogSlice(t *testing.T) {
 	es := NewLogSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newLogSlice(&[]*otlplogs.LogRecord{})
+	es = newLogSlice(&[]*otlplogs.LogRecord{}, newSharedState())
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newLogRecord(&otlplogs.LogRecord{})
+	emptyVal := newLogRecord(&otlplogs.LogRecord{}, newSharedState())
 	testVal := generateTestLogRecord()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
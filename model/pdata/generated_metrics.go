@@ -33,18 +33,19 @@ import (
 type ResourceMetricsSlice struct {
 	// orig points to the slice otlpmetrics.ResourceMetrics field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlpmetrics.ResourceMetrics
+	orig  *[]*otlpmetrics.ResourceMetrics
+	state *sharedState
 }
 
-func newResourceMetricsSlice(orig *[]*otlpmetrics.ResourceMetrics) ResourceMetricsSlice {
-	return ResourceMetricsSlice{orig}
+func newResourceMetricsSlice(orig *[]*otlpmetrics.ResourceMetrics, state *sharedState) ResourceMetricsSlice {
+	return ResourceMetricsSlice{orig: orig, state: state}
 }
 
 // NewResourceMetricsSlice creates a ResourceMetricsSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewResourceMetricsSlice() ResourceMetricsSlice {
 	orig := []*otlpmetrics.ResourceMetrics(nil)
-	return ResourceMetricsSlice{&orig}
+	return newResourceMetricsSlice(&orig, newSharedState())
 }
 
 // Len returns the number of elements in the slice.
@@ -62,17 +63,18 @@ func (es ResourceMetricsSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ResourceMetricsSlice) At(ix int) ResourceMetrics {
-	return newResourceMetrics((*es.orig)[ix])
+	return newResourceMetrics((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ResourceMetricsSlice) CopyTo(dest ResourceMetricsSlice) {
+	dest.state.assertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newResourceMetrics((*es.orig)[i]).CopyTo(newResourceMetrics((*dest.orig)[i]))
+			newResourceMetrics((*es.orig)[i], es.state).CopyTo(newResourceMetrics((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -80,7 +82,7 @@ func (es ResourceMetricsSlice) CopyTo(dest ResourceMetricsSlice) {
 	wrappers := make([]*otlpmetrics.ResourceMetrics, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newResourceMetrics((*es.orig)[i]).CopyTo(newResourceMetrics(wrappers[i]))
+		newResourceMetrics((*es.orig)[i], es.state).CopyTo(newResourceMetrics(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -97,6 +99,7 @@ func (es ResourceMetricsSlice) CopyTo(dest ResourceMetricsSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es ResourceMetricsSlice) EnsureCapacity(newCap int) {
+	es.state.assertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -110,6 +113,7 @@ func (es ResourceMetricsSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty ResourceMetrics.
 // It returns the newly added ResourceMetrics.
 func (es ResourceMetricsSlice) AppendEmpty() ResourceMetrics {
+	es.state.assertMutable()
 	*es.orig = append(*es.orig, &otlpmetrics.ResourceMetrics{})
 	return es.At(es.Len() - 1)
 }
@@ -124,6 +128,7 @@ func (es ResourceMetricsSlice) AppendEmpty() ResourceMetrics {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es ResourceMetricsSlice) Sort(less func(a, b ResourceMetrics) bool) ResourceMetricsSlice {
+	es.state.assertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -131,6 +136,8 @@ func (es ResourceMetricsSlice) Sort(less func(a, b ResourceMetrics) bool) Resour
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ResourceMetricsSlice) MoveAndAppendTo(dest ResourceMetricsSlice) {
+	es.state.assertMutable()
+	dest.state.assertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -143,6 +150,7 @@ func (es ResourceMetricsSlice) MoveAndAppendTo(dest ResourceMetricsSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ResourceMetricsSlice) RemoveIf(f func(ResourceMetrics) bool) {
+	es.state.assertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -167,25 +175,26 @@ func (es ResourceMetricsSlice) RemoveIf(f func(ResourceMetrics) bool) {
 //
 // Must use NewResourceMetrics function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type ResourceMetrics struct {
-	orig *otlpmetrics.ResourceMetrics
+	orig  *otlpmetrics.ResourceMetrics
+	state *sharedState
 }
 
-func newResourceMetrics(orig *otlpmetrics.ResourceMetrics) ResourceMetrics {
-	return ResourceMetrics{orig: orig}
+func newResourceMetrics(orig *otlpmetrics.ResourceMetrics, state *sharedState) ResourceMetrics {
+	return ResourceMetrics{orig: orig, state: state}
 }
 
 // NewResourceMetrics creates a new empty ResourceMetrics.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewResourceMetrics() ResourceMetrics {
-	return newResourceMetrics(&otlpmetrics.ResourceMetrics{})
+	return newResourceMetrics(&otlpmetrics.ResourceMetrics{}, newSharedState())
 }
 
 // Resource returns the resource associated with this ResourceMetrics.
 func (ms ResourceMetrics) Resource() Resource {
-	return newResource(&(*ms.orig).Resource)
+	return newResource(&(*ms.orig).Resource, ms.state)
 }
 
 // SchemaUrl returns the schemaurl associated with this ResourceMetrics.
@@ -195,16 +204,18 @@ func (ms ResourceMetrics) SchemaUrl() string {
 
 // SetSchemaUrl replaces the schemaurl associated with this ResourceMetrics.
 func (ms ResourceMetrics) SetSchemaUrl(v string) {
+	ms.state.assertMutable()
 	(*ms.orig).SchemaUrl = v
 }
 
 // InstrumentationLibraryMetrics returns the InstrumentationLibraryMetrics associated with this ResourceMetrics.
 func (ms ResourceMetrics) InstrumentationLibraryMetrics() InstrumentationLibraryMetricsSlice {
-	return newInstrumentationLibraryMetricsSlice(&(*ms.orig).InstrumentationLibraryMetrics)
+	return newInstrumentationLibraryMetricsSlice(&(*ms.orig).InstrumentationLibraryMetrics, ms.state)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms ResourceMetrics) CopyTo(dest ResourceMetrics) {
+	dest.state.assertMutable()
 	ms.Resource().CopyTo(dest.Resource())
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.InstrumentationLibraryMetrics().CopyTo(dest.InstrumentationLibraryMetrics())
@@ -220,18 +231,19 @@ func (ms ResourceMetrics) CopyTo(dest ResourceMetrics) {
 type InstrumentationLibraryMetricsSlice struct {
 	// orig points to the slice otlpmetrics.InstrumentationLibraryMetrics field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlpmetrics.InstrumentationLibraryMetrics
+	orig  *[]*otlpmetrics.InstrumentationLibraryMetrics
+	state *sharedState
 }
 
-func newInstrumentationLibraryMetricsSlice(orig *[]*otlpmetrics.InstrumentationLibraryMetrics) InstrumentationLibraryMetricsSlice {
-	return InstrumentationLibraryMetricsSlice{orig}
+func newInstrumentationLibraryMetricsSlice(orig *[]*otlpmetrics.InstrumentationLibraryMetrics, state *sharedState) InstrumentationLibraryMetricsSlice {
+	return InstrumentationLibraryMetricsSlice{orig: orig, state: state}
 }
 
 // NewInstrumentationLibraryMetricsSlice creates a InstrumentationLibraryMetricsSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewInstrumentationLibraryMetricsSlice() InstrumentationLibraryMetricsSlice {
 	orig := []*otlpmetrics.InstrumentationLibraryMetrics(nil)
-	return InstrumentationLibraryMetricsSlice{&orig}
+	return newInstrumentationLibraryMetricsSlice(&orig, newSharedState())
 }
 
 // Len returns the number of elements in the slice.
@@ -249,17 +261,18 @@ func (es InstrumentationLibraryMetricsSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es InstrumentationLibraryMetricsSlice) At(ix int) InstrumentationLibraryMetrics {
-	return newInstrumentationLibraryMetrics((*es.orig)[ix])
+	return newInstrumentationLibraryMetrics((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es InstrumentationLibraryMetricsSlice) CopyTo(dest InstrumentationLibraryMetricsSlice) {
+	dest.state.assertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newInstrumentationLibraryMetrics((*es.orig)[i]).CopyTo(newInstrumentationLibraryMetrics((*dest.orig)[i]))
+			newInstrumentationLibraryMetrics((*es.orig)[i], es.state).CopyTo(newInstrumentationLibraryMetrics((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -267,7 +280,7 @@ func (es InstrumentationLibraryMetricsSlice) CopyTo(dest InstrumentationLibraryM
 	wrappers := make([]*otlpmetrics.InstrumentationLibraryMetrics, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newInstrumentationLibraryMetrics((*es.orig)[i]).CopyTo(newInstrumentationLibraryMetrics(wrappers[i]))
+		newInstrumentationLibraryMetrics((*es.orig)[i], es.state).CopyTo(newInstrumentationLibraryMetrics(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -284,6 +297,7 @@ func (es InstrumentationLibraryMetricsSlice) CopyTo(dest InstrumentationLibraryM
 //       // Here should set all the values for e.
 //   }
 func (es InstrumentationLibraryMetricsSlice) EnsureCapacity(newCap int) {
+	es.state.assertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -297,6 +311,7 @@ func (es InstrumentationLibraryMetricsSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty InstrumentationLibraryMetrics.
 // It returns the newly added InstrumentationLibraryMetrics.
 func (es InstrumentationLibraryMetricsSlice) AppendEmpty() InstrumentationLibraryMetrics {
+	es.state.assertMutable()
 	*es.orig = append(*es.orig, &otlpmetrics.InstrumentationLibraryMetrics{})
 	return es.At(es.Len() - 1)
 }
@@ -311,6 +326,7 @@ func (es InstrumentationLibraryMetricsSlice) AppendEmpty() InstrumentationLibrar
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es InstrumentationLibraryMetricsSlice) Sort(less func(a, b InstrumentationLibraryMetrics) bool) InstrumentationLibraryMetricsSlice {
+	es.state.assertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -318,6 +334,8 @@ func (es InstrumentationLibraryMetricsSlice) Sort(less func(a, b Instrumentation
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es InstrumentationLibraryMetricsSlice) MoveAndAppendTo(dest InstrumentationLibraryMetricsSlice) {
+	es.state.assertMutable()
+	dest.state.assertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -330,6 +348,7 @@ func (es InstrumentationLibraryMetricsSlice) MoveAndAppendTo(dest Instrumentatio
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es InstrumentationLibraryMetricsSlice) RemoveIf(f func(InstrumentationLibraryMetrics) bool) {
+	es.state.assertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -354,25 +373,26 @@ func (es InstrumentationLibraryMetricsSlice) RemoveIf(f func(InstrumentationLibr
 //
 // Must use NewInstrumentationLibraryMetrics function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type InstrumentationLibraryMetrics struct {
-	orig *otlpmetrics.InstrumentationLibraryMetrics
+	orig  *otlpmetrics.InstrumentationLibraryMetrics
+	state *sharedState
 }
 
-func newInstrumentationLibraryMetrics(orig *otlpmetrics.InstrumentationLibraryMetrics) InstrumentationLibraryMetrics {
-	return InstrumentationLibraryMetrics{orig: orig}
+func newInstrumentationLibraryMetrics(orig *otlpmetrics.InstrumentationLibraryMetrics, state *sharedState) InstrumentationLibraryMetrics {
+	return InstrumentationLibraryMetrics{orig: orig, state: state}
 }
 
 // NewInstrumentationLibraryMetrics creates a new empty InstrumentationLibraryMetrics.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewInstrumentationLibraryMetrics() InstrumentationLibraryMetrics {
-	return newInstrumentationLibraryMetrics(&otlpmetrics.InstrumentationLibraryMetrics{})
+	return newInstrumentationLibraryMetrics(&otlpmetrics.InstrumentationLibraryMetrics{}, newSharedState())
 }
 
 // InstrumentationLibrary returns the instrumentationlibrary associated with this InstrumentationLibraryMetrics.
 func (ms InstrumentationLibraryMetrics) InstrumentationLibrary() InstrumentationLibrary {
-	return newInstrumentationLibrary(&(*ms.orig).InstrumentationLibrary)
+	return newInstrumentationLibrary(&(*ms.orig).InstrumentationLibrary, ms.state)
 }
 
 // SchemaUrl returns the schemaurl associated with this InstrumentationLibraryMetrics.
@@ -382,16 +402,18 @@ func (ms InstrumentationLibraryMetrics) SchemaUrl() string {
 
 // SetSchemaUrl replaces the schemaurl associated with this InstrumentationLibraryMetrics.
 func (ms InstrumentationLibraryMetrics) SetSchemaUrl(v string) {
+	ms.state.assertMutable()
 	(*ms.orig).SchemaUrl = v
 }
 
 // Metrics returns the Metrics associated with this InstrumentationLibraryMetrics.
 func (ms InstrumentationLibraryMetrics) Metrics() MetricSlice {
-	return newMetricSlice(&(*ms.orig).Metrics)
+	return newMetricSlice(&(*ms.orig).Metrics, ms.state)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms InstrumentationLibraryMetrics) CopyTo(dest InstrumentationLibraryMetrics) {
+	dest.state.assertMutable()
 	ms.InstrumentationLibrary().CopyTo(dest.InstrumentationLibrary())
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.Metrics().CopyTo(dest.Metrics())
@@ -407,18 +429,19 @@ func (ms InstrumentationLibraryMetrics) CopyTo(dest InstrumentationLibraryMetric
 type MetricSlice struct {
 	// orig points to the slice otlpmetrics.Metric field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlpmetrics.Metric
+	orig  *[]*otlpmetrics.Metric
+	state *sharedState
 }
 
-func newMetricSlice(orig *[]*otlpmetrics.Metric) MetricSlice {
-	return MetricSlice{orig}
+func newMetricSlice(orig *[]*otlpmetrics.Metric, state *sharedState) MetricSlice {
+	return MetricSlice{orig: orig, state: state}
 }
 
 // NewMetricSlice creates a MetricSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewMetricSlice() MetricSlice {
 	orig := []*otlpmetrics.Metric(nil)
-	return MetricSlice{&orig}
+	return newMetricSlice(&orig, newSharedState())
 }
 
 // Len returns the number of elements in the slice.
@@ -436,17 +459,18 @@ func (es MetricSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es MetricSlice) At(ix int) Metric {
-	return newMetric((*es.orig)[ix])
+	return newMetric((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es MetricSlice) CopyTo(dest MetricSlice) {
+	dest.state.assertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newMetric((*es.orig)[i]).CopyTo(newMetric((*dest.orig)[i]))
+			newMetric((*es.orig)[i], es.state).CopyTo(newMetric((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -454,7 +478,7 @@ func (es MetricSlice) CopyTo(dest MetricSlice) {
 	wrappers := make([]*otlpmetrics.Metric, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newMetric((*es.orig)[i]).CopyTo(newMetric(wrappers[i]))
+		newMetric((*es.orig)[i], es.state).CopyTo(newMetric(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -471,6 +495,7 @@ func (es MetricSlice) CopyTo(dest MetricSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es MetricSlice) EnsureCapacity(newCap int) {
+	es.state.assertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -484,6 +509,7 @@ func (es MetricSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty Metric.
 // It returns the newly added Metric.
 func (es MetricSlice) AppendEmpty() Metric {
+	es.state.assertMutable()
 	*es.orig = append(*es.orig, &otlpmetrics.Metric{})
 	return es.At(es.Len() - 1)
 }
@@ -498,6 +524,7 @@ func (es MetricSlice) AppendEmpty() Metric {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es MetricSlice) Sort(less func(a, b Metric) bool) MetricSlice {
+	es.state.assertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -505,6 +532,8 @@ func (es MetricSlice) Sort(less func(a, b Metric) bool) MetricSlice {
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es MetricSlice) MoveAndAppendTo(dest MetricSlice) {
+	es.state.assertMutable()
+	dest.state.assertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -517,6 +546,7 @@ func (es MetricSlice) MoveAndAppendTo(dest MetricSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es MetricSlice) RemoveIf(f func(Metric) bool) {
+	es.state.assertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -542,20 +572,21 @@ func (es MetricSlice) RemoveIf(f func(Metric) bool) {
 //
 // Must use NewMetric function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type Metric struct {
-	orig *otlpmetrics.Metric
+	orig  *otlpmetrics.Metric
+	state *sharedState
 }
 
-func newMetric(orig *otlpmetrics.Metric) Metric {
-	return Metric{orig: orig}
+func newMetric(orig *otlpmetrics.Metric, state *sharedState) Metric {
+	return Metric{orig: orig, state: state}
 }
 
 // NewMetric creates a new empty Metric.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewMetric() Metric {
-	return newMetric(&otlpmetrics.Metric{})
+	return newMetric(&otlpmetrics.Metric{}, newSharedState())
 }
 
 // Name returns the name associated with this Metric.
@@ -565,6 +596,7 @@ func (ms Metric) Name() string {
 
 // SetName replaces the name associated with this Metric.
 func (ms Metric) SetName(v string) {
+	ms.state.assertMutable()
 	(*ms.orig).Name = v
 }
 
@@ -575,6 +607,7 @@ func (ms Metric) Description() string {
 
 // SetDescription replaces the description associated with this Metric.
 func (ms Metric) SetDescription(v string) {
+	ms.state.assertMutable()
 	(*ms.orig).Description = v
 }
 
@@ -585,15 +618,17 @@ func (ms Metric) Unit() string {
 
 // SetUnit replaces the unit associated with this Metric.
 func (ms Metric) SetUnit(v string) {
+	ms.state.assertMutable()
 	(*ms.orig).Unit = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms Metric) CopyTo(dest Metric) {
+	dest.state.assertMutable()
 	dest.SetName(ms.Name())
 	dest.SetDescription(ms.Description())
 	dest.SetUnit(ms.Unit())
-	copyData(ms.orig, dest.orig)
+	copyData(ms, dest)
 }
 
 // Gauge represents the type of a double scalar metric that always exports the "current value" for every data point.
@@ -603,29 +638,31 @@ func (ms Metric) CopyTo(dest Metric) {
 //
 // Must use NewGauge function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type Gauge struct {
-	orig *otlpmetrics.Gauge
+	orig  *otlpmetrics.Gauge
+	state *sharedState
 }
 
-func newGauge(orig *otlpmetrics.Gauge) Gauge {
-	return Gauge{orig: orig}
+func newGauge(orig *otlpmetrics.Gauge, state *sharedState) Gauge {
+	return Gauge{orig: orig, state: state}
 }
 
 // NewGauge creates a new empty Gauge.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewGauge() Gauge {
-	return newGauge(&otlpmetrics.Gauge{})
+	return newGauge(&otlpmetrics.Gauge{}, newSharedState())
 }
 
 // DataPoints returns the DataPoints associated with this Gauge.
 func (ms Gauge) DataPoints() NumberDataPointSlice {
-	return newNumberDataPointSlice(&(*ms.orig).DataPoints)
+	return newNumberDataPointSlice(&(*ms.orig).DataPoints, ms.state)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms Gauge) CopyTo(dest Gauge) {
+	dest.state.assertMutable()
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
 
@@ -636,20 +673,21 @@ func (ms Gauge) CopyTo(dest Gauge) {
 //
 // Must use NewSum function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type Sum struct {
-	orig *otlpmetrics.Sum
+	orig  *otlpmetrics.Sum
+	state *sharedState
 }
 
-func newSum(orig *otlpmetrics.Sum) Sum {
-	return Sum{orig: orig}
+func newSum(orig *otlpmetrics.Sum, state *sharedState) Sum {
+	return Sum{orig: orig, state: state}
 }
 
 // NewSum creates a new empty Sum.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewSum() Sum {
-	return newSum(&otlpmetrics.Sum{})
+	return newSum(&otlpmetrics.Sum{}, newSharedState())
 }
 
 // AggregationTemporality returns the aggregationtemporality associated with this Sum.
@@ -659,6 +697,7 @@ func (ms Sum) AggregationTemporality() AggregationTemporality {
 
 // SetAggregationTemporality replaces the aggregationtemporality associated with this Sum.
 func (ms Sum) SetAggregationTemporality(v AggregationTemporality) {
+	ms.state.assertMutable()
 	(*ms.orig).AggregationTemporality = otlpmetrics.AggregationTemporality(v)
 }
 
@@ -669,16 +708,18 @@ func (ms Sum) IsMonotonic() bool {
 
 // SetIsMonotonic replaces the ismonotonic associated with this Sum.
 func (ms Sum) SetIsMonotonic(v bool) {
+	ms.state.assertMutable()
 	(*ms.orig).IsMonotonic = v
 }
 
 // DataPoints returns the DataPoints associated with this Sum.
 func (ms Sum) DataPoints() NumberDataPointSlice {
-	return newNumberDataPointSlice(&(*ms.orig).DataPoints)
+	return newNumberDataPointSlice(&(*ms.orig).DataPoints, ms.state)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms Sum) CopyTo(dest Sum) {
+	dest.state.assertMutable()
 	dest.SetAggregationTemporality(ms.AggregationTemporality())
 	dest.SetIsMonotonic(ms.IsMonotonic())
 	ms.DataPoints().CopyTo(dest.DataPoints())
@@ -691,20 +732,21 @@ func (ms Sum) CopyTo(dest Sum) {
 //
 // Must use NewHistogram function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type Histogram struct {
-	orig *otlpmetrics.Histogram
+	orig  *otlpmetrics.Histogram
+	state *sharedState
 }
 
-func newHistogram(orig *otlpmetrics.Histogram) Histogram {
-	return Histogram{orig: orig}
+func newHistogram(orig *otlpmetrics.Histogram, state *sharedState) Histogram {
+	return Histogram{orig: orig, state: state}
 }
 
 // NewHistogram creates a new empty Histogram.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewHistogram() Histogram {
-	return newHistogram(&otlpmetrics.Histogram{})
+	return newHistogram(&otlpmetrics.Histogram{}, newSharedState())
 }
 
 // AggregationTemporality returns the aggregationtemporality associated with this Histogram.
@@ -714,16 +756,18 @@ func (ms Histogram) AggregationTemporality() AggregationTemporality {
 
 // SetAggregationTemporality replaces the aggregationtemporality associated with this Histogram.
 func (ms Histogram) SetAggregationTemporality(v AggregationTemporality) {
+	ms.state.assertMutable()
 	(*ms.orig).AggregationTemporality = otlpmetrics.AggregationTemporality(v)
 }
 
 // DataPoints returns the DataPoints associated with this Histogram.
 func (ms Histogram) DataPoints() HistogramDataPointSlice {
-	return newHistogramDataPointSlice(&(*ms.orig).DataPoints)
+	return newHistogramDataPointSlice(&(*ms.orig).DataPoints, ms.state)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms Histogram) CopyTo(dest Histogram) {
+	dest.state.assertMutable()
 	dest.SetAggregationTemporality(ms.AggregationTemporality())
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
@@ -735,29 +779,31 @@ func (ms Histogram) CopyTo(dest Histogram) {
 //
 // Must use NewSummary function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type Summary struct {
-	orig *otlpmetrics.Summary
+	orig  *otlpmetrics.Summary
+	state *sharedState
 }
 
-func newSummary(orig *otlpmetrics.Summary) Summary {
-	return Summary{orig: orig}
+func newSummary(orig *otlpmetrics.Summary, state *sharedState) Summary {
+	return Summary{orig: orig, state: state}
 }
 
 // NewSummary creates a new empty Summary.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewSummary() Summary {
-	return newSummary(&otlpmetrics.Summary{})
+	return newSummary(&otlpmetrics.Summary{}, newSharedState())
 }
 
 // DataPoints returns the DataPoints associated with this Summary.
 func (ms Summary) DataPoints() SummaryDataPointSlice {
-	return newSummaryDataPointSlice(&(*ms.orig).DataPoints)
+	return newSummaryDataPointSlice(&(*ms.orig).DataPoints, ms.state)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms Summary) CopyTo(dest Summary) {
+	dest.state.assertMutable()
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
 
@@ -771,18 +817,19 @@ func (ms Summary) CopyTo(dest Summary) {
 type NumberDataPointSlice struct {
 	// orig points to the slice otlpmetrics.NumberDataPoint field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlpmetrics.NumberDataPoint
+	orig  *[]*otlpmetrics.NumberDataPoint
+	state *sharedState
 }
 
-func newNumberDataPointSlice(orig *[]*otlpmetrics.NumberDataPoint) NumberDataPointSlice {
-	return NumberDataPointSlice{orig}
+func newNumberDataPointSlice(orig *[]*otlpmetrics.NumberDataPoint, state *sharedState) NumberDataPointSlice {
+	return NumberDataPointSlice{orig: orig, state: state}
 }
 
 // NewNumberDataPointSlice creates a NumberDataPointSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewNumberDataPointSlice() NumberDataPointSlice {
 	orig := []*otlpmetrics.NumberDataPoint(nil)
-	return NumberDataPointSlice{&orig}
+	return newNumberDataPointSlice(&orig, newSharedState())
 }
 
 // Len returns the number of elements in the slice.
@@ -800,17 +847,18 @@ func (es NumberDataPointSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es NumberDataPointSlice) At(ix int) NumberDataPoint {
-	return newNumberDataPoint((*es.orig)[ix])
+	return newNumberDataPoint((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es NumberDataPointSlice) CopyTo(dest NumberDataPointSlice) {
+	dest.state.assertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newNumberDataPoint((*es.orig)[i]).CopyTo(newNumberDataPoint((*dest.orig)[i]))
+			newNumberDataPoint((*es.orig)[i], es.state).CopyTo(newNumberDataPoint((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -818,7 +866,7 @@ func (es NumberDataPointSlice) CopyTo(dest NumberDataPointSlice) {
 	wrappers := make([]*otlpmetrics.NumberDataPoint, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newNumberDataPoint((*es.orig)[i]).CopyTo(newNumberDataPoint(wrappers[i]))
+		newNumberDataPoint((*es.orig)[i], es.state).CopyTo(newNumberDataPoint(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -835,6 +883,7 @@ func (es NumberDataPointSlice) CopyTo(dest NumberDataPointSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es NumberDataPointSlice) EnsureCapacity(newCap int) {
+	es.state.assertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -848,6 +897,7 @@ func (es NumberDataPointSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty NumberDataPoint.
 // It returns the newly added NumberDataPoint.
 func (es NumberDataPointSlice) AppendEmpty() NumberDataPoint {
+	es.state.assertMutable()
 	*es.orig = append(*es.orig, &otlpmetrics.NumberDataPoint{})
 	return es.At(es.Len() - 1)
 }
@@ -862,6 +912,7 @@ func (es NumberDataPointSlice) AppendEmpty() NumberDataPoint {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es NumberDataPointSlice) Sort(less func(a, b NumberDataPoint) bool) NumberDataPointSlice {
+	es.state.assertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -869,6 +920,8 @@ func (es NumberDataPointSlice) Sort(less func(a, b NumberDataPoint) bool) Number
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es NumberDataPointSlice) MoveAndAppendTo(dest NumberDataPointSlice) {
+	es.state.assertMutable()
+	dest.state.assertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -881,6 +934,7 @@ func (es NumberDataPointSlice) MoveAndAppendTo(dest NumberDataPointSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es NumberDataPointSlice) RemoveIf(f func(NumberDataPoint) bool) {
+	es.state.assertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -905,25 +959,26 @@ func (es NumberDataPointSlice) RemoveIf(f func(NumberDataPoint) bool) {
 //
 // Must use NewNumberDataPoint function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type NumberDataPoint struct {
-	orig *otlpmetrics.NumberDataPoint
+	orig  *otlpmetrics.NumberDataPoint
+	state *sharedState
 }
 
-func newNumberDataPoint(orig *otlpmetrics.NumberDataPoint) NumberDataPoint {
-	return NumberDataPoint{orig: orig}
+func newNumberDataPoint(orig *otlpmetrics.NumberDataPoint, state *sharedState) NumberDataPoint {
+	return NumberDataPoint{orig: orig, state: state}
 }
 
 // NewNumberDataPoint creates a new empty NumberDataPoint.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewNumberDataPoint() NumberDataPoint {
-	return newNumberDataPoint(&otlpmetrics.NumberDataPoint{})
+	return newNumberDataPoint(&otlpmetrics.NumberDataPoint{}, newSharedState())
 }
 
 // Attributes returns the Attributes associated with this NumberDataPoint.
 func (ms NumberDataPoint) Attributes() AttributeMap {
-	return newAttributeMap(&(*ms.orig).Attributes)
+	return newAttributeMap(&(*ms.orig).Attributes, ms.state)
 }
 
 // StartTimestamp returns the starttimestamp associated with this NumberDataPoint.
@@ -933,6 +988,7 @@ func (ms NumberDataPoint) StartTimestamp() Timestamp {
 
 // SetStartTimestamp replaces the starttimestamp associated with this NumberDataPoint.
 func (ms NumberDataPoint) SetStartTimestamp(v Timestamp) {
+	ms.state.assertMutable()
 	(*ms.orig).StartTimeUnixNano = uint64(v)
 }
 
@@ -943,6 +999,7 @@ func (ms NumberDataPoint) Timestamp() Timestamp {
 
 // SetTimestamp replaces the timestamp associated with this NumberDataPoint.
 func (ms NumberDataPoint) SetTimestamp(v Timestamp) {
+	ms.state.assertMutable()
 	(*ms.orig).TimeUnixNano = uint64(v)
 }
 
@@ -953,6 +1010,7 @@ func (ms NumberDataPoint) DoubleVal() float64 {
 
 // SetDoubleVal replaces the doubleval associated with this NumberDataPoint.
 func (ms NumberDataPoint) SetDoubleVal(v float64) {
+	ms.state.assertMutable()
 	(*ms.orig).Value = &otlpmetrics.NumberDataPoint_AsDouble{
 		AsDouble: v,
 	}
@@ -965,6 +1023,7 @@ func (ms NumberDataPoint) IntVal() int64 {
 
 // SetIntVal replaces the intval associated with this NumberDataPoint.
 func (ms NumberDataPoint) SetIntVal(v int64) {
+	ms.state.assertMutable()
 	(*ms.orig).Value = &otlpmetrics.NumberDataPoint_AsInt{
 		AsInt: v,
 	}
@@ -972,11 +1031,12 @@ func (ms NumberDataPoint) SetIntVal(v int64) {
 
 // Exemplars returns the Exemplars associated with this NumberDataPoint.
 func (ms NumberDataPoint) Exemplars() ExemplarSlice {
-	return newExemplarSlice(&(*ms.orig).Exemplars)
+	return newExemplarSlice(&(*ms.orig).Exemplars, ms.state)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms NumberDataPoint) CopyTo(dest NumberDataPoint) {
+	dest.state.assertMutable()
 	ms.Attributes().CopyTo(dest.Attributes())
 	dest.SetStartTimestamp(ms.StartTimestamp())
 	dest.SetTimestamp(ms.Timestamp())
@@ -1000,18 +1060,19 @@ func (ms NumberDataPoint) CopyTo(dest NumberDataPoint) {
 type HistogramDataPointSlice struct {
 	// orig points to the slice otlpmetrics.HistogramDataPoint field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlpmetrics.HistogramDataPoint
+	orig  *[]*otlpmetrics.HistogramDataPoint
+	state *sharedState
 }
 
-func newHistogramDataPointSlice(orig *[]*otlpmetrics.HistogramDataPoint) HistogramDataPointSlice {
-	return HistogramDataPointSlice{orig}
+func newHistogramDataPointSlice(orig *[]*otlpmetrics.HistogramDataPoint, state *sharedState) HistogramDataPointSlice {
+	return HistogramDataPointSlice{orig: orig, state: state}
 }
 
 // NewHistogramDataPointSlice creates a HistogramDataPointSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewHistogramDataPointSlice() HistogramDataPointSlice {
 	orig := []*otlpmetrics.HistogramDataPoint(nil)
-	return HistogramDataPointSlice{&orig}
+	return newHistogramDataPointSlice(&orig, newSharedState())
 }
 
 // Len returns the number of elements in the slice.
@@ -1029,17 +1090,18 @@ func (es HistogramDataPointSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es HistogramDataPointSlice) At(ix int) HistogramDataPoint {
-	return newHistogramDataPoint((*es.orig)[ix])
+	return newHistogramDataPoint((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es HistogramDataPointSlice) CopyTo(dest HistogramDataPointSlice) {
+	dest.state.assertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newHistogramDataPoint((*es.orig)[i]).CopyTo(newHistogramDataPoint((*dest.orig)[i]))
+			newHistogramDataPoint((*es.orig)[i], es.state).CopyTo(newHistogramDataPoint((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -1047,7 +1109,7 @@ func (es HistogramDataPointSlice) CopyTo(dest HistogramDataPointSlice) {
 	wrappers := make([]*otlpmetrics.HistogramDataPoint, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newHistogramDataPoint((*es.orig)[i]).CopyTo(newHistogramDataPoint(wrappers[i]))
+		newHistogramDataPoint((*es.orig)[i], es.state).CopyTo(newHistogramDataPoint(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -1064,6 +1126,7 @@ func (es HistogramDataPointSlice) CopyTo(dest HistogramDataPointSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es HistogramDataPointSlice) EnsureCapacity(newCap int) {
+	es.state.assertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -1077,6 +1140,7 @@ func (es HistogramDataPointSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty HistogramDataPoint.
 // It returns the newly added HistogramDataPoint.
 func (es HistogramDataPointSlice) AppendEmpty() HistogramDataPoint {
+	es.state.assertMutable()
 	*es.orig = append(*es.orig, &otlpmetrics.HistogramDataPoint{})
 	return es.At(es.Len() - 1)
 }
@@ -1091,6 +1155,7 @@ func (es HistogramDataPointSlice) AppendEmpty() HistogramDataPoint {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es HistogramDataPointSlice) Sort(less func(a, b HistogramDataPoint) bool) HistogramDataPointSlice {
+	es.state.assertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -1098,6 +1163,8 @@ func (es HistogramDataPointSlice) Sort(less func(a, b HistogramDataPoint) bool)
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es HistogramDataPointSlice) MoveAndAppendTo(dest HistogramDataPointSlice) {
+	es.state.assertMutable()
+	dest.state.assertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -1110,6 +1177,7 @@ func (es HistogramDataPointSlice) MoveAndAppendTo(dest HistogramDataPointSlice)
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es HistogramDataPointSlice) RemoveIf(f func(HistogramDataPoint) bool) {
+	es.state.assertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -1134,25 +1202,26 @@ func (es HistogramDataPointSlice) RemoveIf(f func(HistogramDataPoint) bool) {
 //
 // Must use NewHistogramDataPoint function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type HistogramDataPoint struct {
-	orig *otlpmetrics.HistogramDataPoint
+	orig  *otlpmetrics.HistogramDataPoint
+	state *sharedState
 }
 
-func newHistogramDataPoint(orig *otlpmetrics.HistogramDataPoint) HistogramDataPoint {
-	return HistogramDataPoint{orig: orig}
+func newHistogramDataPoint(orig *otlpmetrics.HistogramDataPoint, state *sharedState) HistogramDataPoint {
+	return HistogramDataPoint{orig: orig, state: state}
 }
 
 // NewHistogramDataPoint creates a new empty HistogramDataPoint.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewHistogramDataPoint() HistogramDataPoint {
-	return newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{})
+	return newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{}, newSharedState())
 }
 
 // Attributes returns the Attributes associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) Attributes() AttributeMap {
-	return newAttributeMap(&(*ms.orig).Attributes)
+	return newAttributeMap(&(*ms.orig).Attributes, ms.state)
 }
 
 // StartTimestamp returns the starttimestamp associated with this HistogramDataPoint.
@@ -1162,6 +1231,7 @@ func (ms HistogramDataPoint) StartTimestamp() Timestamp {
 
 // SetStartTimestamp replaces the starttimestamp associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) SetStartTimestamp(v Timestamp) {
+	ms.state.assertMutable()
 	(*ms.orig).StartTimeUnixNano = uint64(v)
 }
 
@@ -1172,6 +1242,7 @@ func (ms HistogramDataPoint) Timestamp() Timestamp {
 
 // SetTimestamp replaces the timestamp associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) SetTimestamp(v Timestamp) {
+	ms.state.assertMutable()
 	(*ms.orig).TimeUnixNano = uint64(v)
 }
 
@@ -1182,6 +1253,7 @@ func (ms HistogramDataPoint) Count() uint64 {
 
 // SetCount replaces the count associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) SetCount(v uint64) {
+	ms.state.assertMutable()
 	(*ms.orig).Count = v
 }
 
@@ -1192,6 +1264,7 @@ func (ms HistogramDataPoint) Sum() float64 {
 
 // SetSum replaces the sum associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) SetSum(v float64) {
+	ms.state.assertMutable()
 	(*ms.orig).Sum = v
 }
 
@@ -1202,6 +1275,7 @@ func (ms HistogramDataPoint) BucketCounts() []uint64 {
 
 // SetBucketCounts replaces the bucketcounts associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) SetBucketCounts(v []uint64) {
+	ms.state.assertMutable()
 	(*ms.orig).BucketCounts = v
 }
 
@@ -1212,16 +1286,18 @@ func (ms HistogramDataPoint) ExplicitBounds() []float64 {
 
 // SetExplicitBounds replaces the explicitbounds associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) SetExplicitBounds(v []float64) {
+	ms.state.assertMutable()
 	(*ms.orig).ExplicitBounds = v
 }
 
 // Exemplars returns the Exemplars associated with this HistogramDataPoint.
 func (ms HistogramDataPoint) Exemplars() ExemplarSlice {
-	return newExemplarSlice(&(*ms.orig).Exemplars)
+	return newExemplarSlice(&(*ms.orig).Exemplars, ms.state)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms HistogramDataPoint) CopyTo(dest HistogramDataPoint) {
+	dest.state.assertMutable()
 	ms.Attributes().CopyTo(dest.Attributes())
 	dest.SetStartTimestamp(ms.StartTimestamp())
 	dest.SetTimestamp(ms.Timestamp())
@@ -1242,18 +1318,19 @@ func (ms HistogramDataPoint) CopyTo(dest HistogramDataPoint) {
 type SummaryDataPointSlice struct {
 	// orig points to the slice otlpmetrics.SummaryDataPoint field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlpmetrics.SummaryDataPoint
+	orig  *[]*otlpmetrics.SummaryDataPoint
+	state *sharedState
 }
 
-func newSummaryDataPointSlice(orig *[]*otlpmetrics.SummaryDataPoint) SummaryDataPointSlice {
-	return SummaryDataPointSlice{orig}
+func newSummaryDataPointSlice(orig *[]*otlpmetrics.SummaryDataPoint, state *sharedState) SummaryDataPointSlice {
+	return SummaryDataPointSlice{orig: orig, state: state}
 }
 
 // NewSummaryDataPointSlice creates a SummaryDataPointSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewSummaryDataPointSlice() SummaryDataPointSlice {
 	orig := []*otlpmetrics.SummaryDataPoint(nil)
-	return SummaryDataPointSlice{&orig}
+	return newSummaryDataPointSlice(&orig, newSharedState())
 }
 
 // Len returns the number of elements in the slice.
@@ -1271,17 +1348,18 @@ func (es SummaryDataPointSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es SummaryDataPointSlice) At(ix int) SummaryDataPoint {
-	return newSummaryDataPoint((*es.orig)[ix])
+	return newSummaryDataPoint((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es SummaryDataPointSlice) CopyTo(dest SummaryDataPointSlice) {
+	dest.state.assertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newSummaryDataPoint((*es.orig)[i]).CopyTo(newSummaryDataPoint((*dest.orig)[i]))
+			newSummaryDataPoint((*es.orig)[i], es.state).CopyTo(newSummaryDataPoint((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -1289,7 +1367,7 @@ func (es SummaryDataPointSlice) CopyTo(dest SummaryDataPointSlice) {
 	wrappers := make([]*otlpmetrics.SummaryDataPoint, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newSummaryDataPoint((*es.orig)[i]).CopyTo(newSummaryDataPoint(wrappers[i]))
+		newSummaryDataPoint((*es.orig)[i], es.state).CopyTo(newSummaryDataPoint(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -1306,6 +1384,7 @@ func (es SummaryDataPointSlice) CopyTo(dest SummaryDataPointSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es SummaryDataPointSlice) EnsureCapacity(newCap int) {
+	es.state.assertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -1319,6 +1398,7 @@ func (es SummaryDataPointSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty SummaryDataPoint.
 // It returns the newly added SummaryDataPoint.
 func (es SummaryDataPointSlice) AppendEmpty() SummaryDataPoint {
+	es.state.assertMutable()
 	*es.orig = append(*es.orig, &otlpmetrics.SummaryDataPoint{})
 	return es.At(es.Len() - 1)
 }
@@ -1333,6 +1413,7 @@ func (es SummaryDataPointSlice) AppendEmpty() SummaryDataPoint {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es SummaryDataPointSlice) Sort(less func(a, b SummaryDataPoint) bool) SummaryDataPointSlice {
+	es.state.assertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -1340,6 +1421,8 @@ func (es SummaryDataPointSlice) Sort(less func(a, b SummaryDataPoint) bool) Summ
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es SummaryDataPointSlice) MoveAndAppendTo(dest SummaryDataPointSlice) {
+	es.state.assertMutable()
+	dest.state.assertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -1352,6 +1435,7 @@ func (es SummaryDataPointSlice) MoveAndAppendTo(dest SummaryDataPointSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es SummaryDataPointSlice) RemoveIf(f func(SummaryDataPoint) bool) {
+	es.state.assertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -1376,25 +1460,26 @@ func (es SummaryDataPointSlice) RemoveIf(f func(SummaryDataPoint) bool) {
 //
 // Must use NewSummaryDataPoint function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type SummaryDataPoint struct {
-	orig *otlpmetrics.SummaryDataPoint
+	orig  *otlpmetrics.SummaryDataPoint
+	state *sharedState
 }
 
-func newSummaryDataPoint(orig *otlpmetrics.SummaryDataPoint) SummaryDataPoint {
-	return SummaryDataPoint{orig: orig}
+func newSummaryDataPoint(orig *otlpmetrics.SummaryDataPoint, state *sharedState) SummaryDataPoint {
+	return SummaryDataPoint{orig: orig, state: state}
 }
 
 // NewSummaryDataPoint creates a new empty SummaryDataPoint.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewSummaryDataPoint() SummaryDataPoint {
-	return newSummaryDataPoint(&otlpmetrics.SummaryDataPoint{})
+	return newSummaryDataPoint(&otlpmetrics.SummaryDataPoint{}, newSharedState())
 }
 
 // Attributes returns the Attributes associated with this SummaryDataPoint.
 func (ms SummaryDataPoint) Attributes() AttributeMap {
-	return newAttributeMap(&(*ms.orig).Attributes)
+	return newAttributeMap(&(*ms.orig).Attributes, ms.state)
 }
 
 // StartTimestamp returns the starttimestamp associated with this SummaryDataPoint.
@@ -1404,6 +1489,7 @@ func (ms SummaryDataPoint) StartTimestamp() Timestamp {
 
 // SetStartTimestamp replaces the starttimestamp associated with this SummaryDataPoint.
 func (ms SummaryDataPoint) SetStartTimestamp(v Timestamp) {
+	ms.state.assertMutable()
 	(*ms.orig).StartTimeUnixNano = uint64(v)
 }
 
@@ -1414,6 +1500,7 @@ func (ms SummaryDataPoint) Timestamp() Timestamp {
 
 // SetTimestamp replaces the timestamp associated with this SummaryDataPoint.
 func (ms SummaryDataPoint) SetTimestamp(v Timestamp) {
+	ms.state.assertMutable()
 	(*ms.orig).TimeUnixNano = uint64(v)
 }
 
@@ -1424,6 +1511,7 @@ func (ms SummaryDataPoint) Count() uint64 {
 
 // SetCount replaces the count associated with this SummaryDataPoint.
 func (ms SummaryDataPoint) SetCount(v uint64) {
+	ms.state.assertMutable()
 	(*ms.orig).Count = v
 }
 
@@ -1434,16 +1522,18 @@ func (ms SummaryDataPoint) Sum() float64 {
 
 // SetSum replaces the sum associated with this SummaryDataPoint.
 func (ms SummaryDataPoint) SetSum(v float64) {
+	ms.state.assertMutable()
 	(*ms.orig).Sum = v
 }
 
 // QuantileValues returns the QuantileValues associated with this SummaryDataPoint.
 func (ms SummaryDataPoint) QuantileValues() ValueAtQuantileSlice {
-	return newValueAtQuantileSlice(&(*ms.orig).QuantileValues)
+	return newValueAtQuantileSlice(&(*ms.orig).QuantileValues, ms.state)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms SummaryDataPoint) CopyTo(dest SummaryDataPoint) {
+	dest.state.assertMutable()
 	ms.Attributes().CopyTo(dest.Attributes())
 	dest.SetStartTimestamp(ms.StartTimestamp())
 	dest.SetTimestamp(ms.Timestamp())
@@ -1462,18 +1552,19 @@ func (ms SummaryDataPoint) CopyTo(dest SummaryDataPoint) {
 type ValueAtQuantileSlice struct {
 	// orig points to the slice otlpmetrics.SummaryDataPoint_ValueAtQuantile field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlpmetrics.SummaryDataPoint_ValueAtQuantile
+	orig  *[]*otlpmetrics.SummaryDataPoint_ValueAtQuantile
+	state *sharedState
 }
 
-func newValueAtQuantileSlice(orig *[]*otlpmetrics.SummaryDataPoint_ValueAtQuantile) ValueAtQuantileSlice {
-	return ValueAtQuantileSlice{orig}
+func newValueAtQuantileSlice(orig *[]*otlpmetrics.SummaryDataPoint_ValueAtQuantile, state *sharedState) ValueAtQuantileSlice {
+	return ValueAtQuantileSlice{orig: orig, state: state}
 }
 
 // NewValueAtQuantileSlice creates a ValueAtQuantileSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewValueAtQuantileSlice() ValueAtQuantileSlice {
 	orig := []*otlpmetrics.SummaryDataPoint_ValueAtQuantile(nil)
-	return ValueAtQuantileSlice{&orig}
+	return newValueAtQuantileSlice(&orig, newSharedState())
 }
 
 // Len returns the number of elements in the slice.
@@ -1491,17 +1582,18 @@ func (es ValueAtQuantileSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ValueAtQuantileSlice) At(ix int) ValueAtQuantile {
-	return newValueAtQuantile((*es.orig)[ix])
+	return newValueAtQuantile((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ValueAtQuantileSlice) CopyTo(dest ValueAtQuantileSlice) {
+	dest.state.assertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newValueAtQuantile((*es.orig)[i]).CopyTo(newValueAtQuantile((*dest.orig)[i]))
+			newValueAtQuantile((*es.orig)[i], es.state).CopyTo(newValueAtQuantile((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -1509,7 +1601,7 @@ func (es ValueAtQuantileSlice) CopyTo(dest ValueAtQuantileSlice) {
 	wrappers := make([]*otlpmetrics.SummaryDataPoint_ValueAtQuantile, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newValueAtQuantile((*es.orig)[i]).CopyTo(newValueAtQuantile(wrappers[i]))
+		newValueAtQuantile((*es.orig)[i], es.state).CopyTo(newValueAtQuantile(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -1526,6 +1618,7 @@ func (es ValueAtQuantileSlice) CopyTo(dest ValueAtQuantileSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es ValueAtQuantileSlice) EnsureCapacity(newCap int) {
+	es.state.assertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -1539,6 +1632,7 @@ func (es ValueAtQuantileSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty ValueAtQuantile.
 // It returns the newly added ValueAtQuantile.
 func (es ValueAtQuantileSlice) AppendEmpty() ValueAtQuantile {
+	es.state.assertMutable()
 	*es.orig = append(*es.orig, &otlpmetrics.SummaryDataPoint_ValueAtQuantile{})
 	return es.At(es.Len() - 1)
 }
@@ -1553,6 +1647,7 @@ func (es ValueAtQuantileSlice) AppendEmpty() ValueAtQuantile {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es ValueAtQuantileSlice) Sort(less func(a, b ValueAtQuantile) bool) ValueAtQuantileSlice {
+	es.state.assertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -1560,6 +1655,8 @@ func (es ValueAtQuantileSlice) Sort(less func(a, b ValueAtQuantile) bool) ValueA
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ValueAtQuantileSlice) MoveAndAppendTo(dest ValueAtQuantileSlice) {
+	es.state.assertMutable()
+	dest.state.assertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -1572,6 +1669,7 @@ func (es ValueAtQuantileSlice) MoveAndAppendTo(dest ValueAtQuantileSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ValueAtQuantileSlice) RemoveIf(f func(ValueAtQuantile) bool) {
+	es.state.assertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -1596,20 +1694,21 @@ func (es ValueAtQuantileSlice) RemoveIf(f func(ValueAtQuantile) bool) {
 //
 // Must use NewValueAtQuantile function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type ValueAtQuantile struct {
-	orig *otlpmetrics.SummaryDataPoint_ValueAtQuantile
+	orig  *otlpmetrics.SummaryDataPoint_ValueAtQuantile
+	state *sharedState
 }
 
-func newValueAtQuantile(orig *otlpmetrics.SummaryDataPoint_ValueAtQuantile) ValueAtQuantile {
-	return ValueAtQuantile{orig: orig}
+func newValueAtQuantile(orig *otlpmetrics.SummaryDataPoint_ValueAtQuantile, state *sharedState) ValueAtQuantile {
+	return ValueAtQuantile{orig: orig, state: state}
 }
 
 // NewValueAtQuantile creates a new empty ValueAtQuantile.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewValueAtQuantile() ValueAtQuantile {
-	return newValueAtQuantile(&otlpmetrics.SummaryDataPoint_ValueAtQuantile{})
+	return newValueAtQuantile(&otlpmetrics.SummaryDataPoint_ValueAtQuantile{}, newSharedState())
 }
 
 // Quantile returns the quantile associated with this ValueAtQuantile.
@@ -1619,6 +1718,7 @@ func (ms ValueAtQuantile) Quantile() float64 {
 
 // SetQuantile replaces the quantile associated with this ValueAtQuantile.
 func (ms ValueAtQuantile) SetQuantile(v float64) {
+	ms.state.assertMutable()
 	(*ms.orig).Quantile = v
 }
 
@@ -1629,11 +1729,13 @@ func (ms ValueAtQuantile) Value() float64 {
 
 // SetValue replaces the value associated with this ValueAtQuantile.
 func (ms ValueAtQuantile) SetValue(v float64) {
+	ms.state.assertMutable()
 	(*ms.orig).Value = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms ValueAtQuantile) CopyTo(dest ValueAtQuantile) {
+	dest.state.assertMutable()
 	dest.SetQuantile(ms.Quantile())
 	dest.SetValue(ms.Value())
 }
@@ -1648,18 +1750,19 @@ func (ms ValueAtQuantile) CopyTo(dest ValueAtQuantile) {
 type ExemplarSlice struct {
 	// orig points to the slice otlpmetrics.Exemplar field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]otlpmetrics.Exemplar
+	orig  *[]otlpmetrics.Exemplar
+	state *sharedState
 }
 
-func newExemplarSlice(orig *[]otlpmetrics.Exemplar) ExemplarSlice {
-	return ExemplarSlice{orig}
+func newExemplarSlice(orig *[]otlpmetrics.Exemplar, state *sharedState) ExemplarSlice {
+	return ExemplarSlice{orig: orig, state: state}
 }
 
 // NewExemplarSlice creates a ExemplarSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewExemplarSlice() ExemplarSlice {
 	orig := []otlpmetrics.Exemplar(nil)
-	return ExemplarSlice{&orig}
+	return newExemplarSlice(&orig, newSharedState())
 }
 
 // Len returns the number of elements in the slice.
@@ -1677,11 +1780,12 @@ func (es ExemplarSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ExemplarSlice) At(ix int) Exemplar {
-	return newExemplar(&(*es.orig)[ix])
+	return newExemplar(&(*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ExemplarSlice) CopyTo(dest ExemplarSlice) {
+	dest.state.assertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
@@ -1691,7 +1795,7 @@ func (es ExemplarSlice) CopyTo(dest ExemplarSlice) {
 	}
 
 	for i := range *es.orig {
-		newExemplar(&(*es.orig)[i]).CopyTo(newExemplar(&(*dest.orig)[i]))
+		newExemplar(&(*es.orig)[i], es.state).CopyTo(newExemplar(&(*dest.orig)[i], dest.state))
 	}
 }
 
@@ -1707,6 +1811,7 @@ func (es ExemplarSlice) CopyTo(dest ExemplarSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es ExemplarSlice) EnsureCapacity(newCap int) {
+	es.state.assertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -1720,6 +1825,7 @@ func (es ExemplarSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty Exemplar.
 // It returns the newly added Exemplar.
 func (es ExemplarSlice) AppendEmpty() Exemplar {
+	es.state.assertMutable()
 	*es.orig = append(*es.orig, otlpmetrics.Exemplar{})
 	return es.At(es.Len() - 1)
 }
@@ -1727,6 +1833,8 @@ func (es ExemplarSlice) AppendEmpty() Exemplar {
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ExemplarSlice) MoveAndAppendTo(dest ExemplarSlice) {
+	es.state.assertMutable()
+	dest.state.assertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -1739,6 +1847,7 @@ func (es ExemplarSlice) MoveAndAppendTo(dest ExemplarSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ExemplarSlice) RemoveIf(f func(Exemplar) bool) {
+	es.state.assertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -1766,20 +1875,21 @@ func (es ExemplarSlice) RemoveIf(f func(Exemplar) bool) {
 //
 // Must use NewExemplar function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type Exemplar struct {
-	orig *otlpmetrics.Exemplar
+	orig  *otlpmetrics.Exemplar
+	state *sharedState
 }
 
-func newExemplar(orig *otlpmetrics.Exemplar) Exemplar {
-	return Exemplar{orig: orig}
+func newExemplar(orig *otlpmetrics.Exemplar, state *sharedState) Exemplar {
+	return Exemplar{orig: orig, state: state}
 }
 
 // NewExemplar creates a new empty Exemplar.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewExemplar() Exemplar {
-	return newExemplar(&otlpmetrics.Exemplar{})
+	return newExemplar(&otlpmetrics.Exemplar{}, newSharedState())
 }
 
 // Timestamp returns the timestamp associated with this Exemplar.
@@ -1789,6 +1899,7 @@ func (ms Exemplar) Timestamp() Timestamp {
 
 // SetTimestamp replaces the timestamp associated with this Exemplar.
 func (ms Exemplar) SetTimestamp(v Timestamp) {
+	ms.state.assertMutable()
 	(*ms.orig).TimeUnixNano = uint64(v)
 }
 
@@ -1799,6 +1910,7 @@ func (ms Exemplar) DoubleVal() float64 {
 
 // SetDoubleVal replaces the doubleval associated with this Exemplar.
 func (ms Exemplar) SetDoubleVal(v float64) {
+	ms.state.assertMutable()
 	(*ms.orig).Value = &otlpmetrics.Exemplar_AsDouble{
 		AsDouble: v,
 	}
@@ -1811,6 +1923,7 @@ func (ms Exemplar) IntVal() int64 {
 
 // SetIntVal replaces the intval associated with this Exemplar.
 func (ms Exemplar) SetIntVal(v int64) {
+	ms.state.assertMutable()
 	(*ms.orig).Value = &otlpmetrics.Exemplar_AsInt{
 		AsInt: v,
 	}
@@ -1818,11 +1931,34 @@ func (ms Exemplar) SetIntVal(v int64) {
 
 // FilteredAttributes returns the FilteredAttributes associated with this Exemplar.
 func (ms Exemplar) FilteredAttributes() AttributeMap {
-	return newAttributeMap(&(*ms.orig).FilteredAttributes)
+	return newAttributeMap(&(*ms.orig).FilteredAttributes, ms.state)
+}
+
+// TraceID returns the traceid associated with this Exemplar.
+func (ms Exemplar) TraceID() TraceID {
+	return TraceID{orig: ((*ms.orig).TraceId)}
+}
+
+// SetTraceID replaces the traceid associated with this Exemplar.
+func (ms Exemplar) SetTraceID(v TraceID) {
+	ms.state.assertMutable()
+	(*ms.orig).TraceId = v.orig
+}
+
+// SpanID returns the spanid associated with this Exemplar.
+func (ms Exemplar) SpanID() SpanID {
+	return SpanID{orig: ((*ms.orig).SpanId)}
+}
+
+// SetSpanID replaces the spanid associated with this Exemplar.
+func (ms Exemplar) SetSpanID(v SpanID) {
+	ms.state.assertMutable()
+	(*ms.orig).SpanId = v.orig
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms Exemplar) CopyTo(dest Exemplar) {
+	dest.state.assertMutable()
 	dest.SetTimestamp(ms.Timestamp())
 	switch ms.Type() {
 	case MetricValueTypeDouble:
@@ -1832,4 +1968,6 @@ func (ms Exemplar) CopyTo(dest Exemplar) {
 	}
 
 	ms.FilteredAttributes().CopyTo(dest.FilteredAttributes())
+	dest.SetTraceID(ms.TraceID())
+	dest.SetSpanID(ms.SpanID())
 }
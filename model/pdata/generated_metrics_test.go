@@ -28,11 +28,11 @@ import (
 func TestResourceMetricsSlice(t *testing.T) {
 	es := NewResourceMetricsSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newResourceMetricsSlice(&[]*otlpmetrics.ResourceMetrics{})
+	es = newResourceMetricsSlice(&[]*otlpmetrics.ResourceMetrics{}, newSharedState())
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newResourceMetrics(&otlpmetrics.ResourceMetrics{})
+	emptyVal := newResourceMetrics(&otlpmetrics.ResourceMetrics{}, newSharedState())
 	testVal := generateTestResourceMetrics()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -166,11 +166,11 @@ func TestResourceMetrics_InstrumentationLibraryMetrics(t *testing.T) {
 func TestInstrumentationLibraryMetricsSlice(t *testing.T) {
 	es := NewInstrumentationLibraryMetricsSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newInstrumentationLibraryMetricsSlice(&[]*otlpmetrics.InstrumentationLibraryMetrics{})
+	es = newInstrumentationLibraryMetricsSlice(&[]*otlpmetrics.InstrumentationLibraryMetrics{}, newSharedState())
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newInstrumentationLibraryMetrics(&otlpmetrics.InstrumentationLibraryMetrics{})
+	emptyVal := newInstrumentationLibraryMetrics(&otlpmetrics.InstrumentationLibraryMetrics{}, newSharedState())
 	testVal := generateTestInstrumentationLibraryMetrics()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -304,11 +304,11 @@ func TestInstrumentationLibraryMetrics_Metrics(t *testing.T) {
 func TestMetricSlice(t *testing.T) {
 	es := NewMetricSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newMetricSlice(&[]*otlpmetrics.Metric{})
+	es = newMetricSlice(&[]*otlpmetrics.Metric{}, newSharedState())
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newMetric(&otlpmetrics.Metric{})
+	emptyVal := newMetric(&otlpmetrics.Metric{}, newSharedState())
 	testVal := generateTestMetric()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -524,11 +524,11 @@ func TestSummary_DataPoints(t *testing.T) {
 func TestNumberDataPointSlice(t *testing.T) {
 	es := NewNumberDataPointSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newNumberDataPointSlice(&[]*otlpmetrics.NumberDataPoint{})
+	es = newNumberDataPointSlice(&[]*otlpmetrics.NumberDataPoint{}, newSharedState())
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newNumberDataPoint(&otlpmetrics.NumberDataPoint{})
+	emptyVal := newNumberDataPoint(&otlpmetrics.NumberDataPoint{}, newSharedState())
 	testVal := generateTestNumberDataPoint()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -687,11 +687,11 @@ func TestNumberDataPoint_Exemplars(t *testing.T) {
 func TestHistogramDataPointSlice(t *testing.T) {
 	es := NewHistogramDataPointSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newHistogramDataPointSlice(&[]*otlpmetrics.HistogramDataPoint{})
+	es = newHistogramDataPointSlice(&[]*otlpmetrics.HistogramDataPoint{}, newSharedState())
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{})
+	emptyVal := newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{}, newSharedState())
 	testVal := generateTestHistogramDataPoint()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -867,11 +867,11 @@ func TestHistogramDataPoint_Exemplars(t *testing.T) {
 func TestSummaryDataPointSlice(t *testing.T) {
 	es := NewSummaryDataPointSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newSummaryDataPointSlice(&[]*otlpmetrics.SummaryDataPoint{})
+	es = newSummaryDataPointSlice(&[]*otlpmetrics.SummaryDataPoint{}, newSharedState())
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newSummaryDataPoint(&otlpmetrics.SummaryDataPoint{})
+	emptyVal := newSummaryDataPoint(&otlpmetrics.SummaryDataPoint{}, newSharedState())
 	testVal := generateTestSummaryDataPoint()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -1031,11 +1031,11 @@ func TestSummaryDataPoint_QuantileValues(t *testing.T) {
 func TestValueAtQuantileSlice(t *testing.T) {
 	es := NewValueAtQuantileSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newValueAtQuantileSlice(&[]*otlpmetrics.SummaryDataPoint_ValueAtQuantile{})
+	es = newValueAtQuantileSlice(&[]*otlpmetrics.SummaryDataPoint_ValueAtQuantile{}, newSharedState())
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newValueAtQuantile(&otlpmetrics.SummaryDataPoint_ValueAtQuantile{})
+	emptyVal := newValueAtQuantile(&otlpmetrics.SummaryDataPoint_ValueAtQuantile{}, newSharedState())
 	testVal := generateTestValueAtQuantile()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -1163,11 +1163,11 @@ func TestValueAtQuantile_Value(t *testing.T) {
 func TestExemplarSlice(t *testing.T) {
 	es := NewExemplarSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newExemplarSlice(&[]otlpmetrics.Exemplar{})
+	es = newExemplarSlice(&[]otlpmetrics.Exemplar{}, newSharedState())
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newExemplar(&otlpmetrics.Exemplar{})
+	emptyVal := newExemplar(&otlpmetrics.Exemplar{}, newSharedState())
 	testVal := generateTestExemplar()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -1298,6 +1298,22 @@ func TestExemplar_FilteredAttributes(t *testing.T) {
 	assert.EqualValues(t, testValFilteredAttributes, ms.FilteredAttributes())
 }
 
+func TestExemplar_TraceID(t *testing.T) {
+	ms := NewExemplar()
+	assert.EqualValues(t, NewTraceID([16]byte{}), ms.TraceID())
+	testValTraceID := NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})
+	ms.SetTraceID(testValTraceID)
+	assert.EqualValues(t, testValTraceID, ms.TraceID())
+}
+
+func TestExemplar_SpanID(t *testing.T) {
+	ms := NewExemplar()
+	assert.EqualValues(t, NewSpanID([8]byte{}), ms.SpanID())
+	testValSpanID := NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	ms.SetSpanID(testValSpanID)
+	assert.EqualValues(t, testValSpanID, ms.SpanID())
+}
+
 func generateTestResourceMetricsSlice() ResourceMetricsSlice {
 	tv := NewResourceMetricsSlice()
 	fillTestResourceMetricsSlice(tv)
@@ -1560,4 +1576,6 @@ func fillTestExemplar(tv Exemplar) {
 	tv.SetDoubleVal(float64(17.13))
 
 	fillTestAttributeMap(tv.FilteredAttributes())
+	tv.SetTraceID(NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1}))
+	tv.SetSpanID(NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
 }
@@ -28,28 +28,30 @@ import (
 //
 // Must use NewResource function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type Resource struct {
-	orig *otlpresource.Resource
+	orig  *otlpresource.Resource
+	state *sharedState
 }
 
-func newResource(orig *otlpresource.Resource) Resource {
-	return Resource{orig: orig}
+func newResource(orig *otlpresource.Resource, state *sharedState) Resource {
+	return Resource{orig: orig, state: state}
 }
 
 // NewResource creates a new empty Resource.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewResource() Resource {
-	return newResource(&otlpresource.Resource{})
+	return newResource(&otlpresource.Resource{}, newSharedState())
 }
 
 // Attributes returns the Attributes associated with this Resource.
 func (ms Resource) Attributes() AttributeMap {
-	return newAttributeMap(&(*ms.orig).Attributes)
+	return newAttributeMap(&(*ms.orig).Attributes, ms.state)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms Resource) CopyTo(dest Resource) {
+	dest.state.assertMutable()
 	ms.Attributes().CopyTo(dest.Attributes())
 }
@@ -33,18 +33,19 @@ import (
 type ResourceSpansSlice struct {
 	// orig points to the slice otlptrace.ResourceSpans field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlptrace.ResourceSpans
+	orig  *[]*otlptrace.ResourceSpans
+	state *sharedState
 }
 
-func newResourceSpansSlice(orig *[]*otlptrace.ResourceSpans) ResourceSpansSlice {
-	return ResourceSpansSlice{orig}
+func newResourceSpansSlice(orig *[]*otlptrace.ResourceSpans, state *sharedState) ResourceSpansSlice {
+	return ResourceSpansSlice{orig: orig, state: state}
 }
 
 // NewResourceSpansSlice creates a ResourceSpansSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewResourceSpansSlice() ResourceSpansSlice {
 	orig := []*otlptrace.ResourceSpans(nil)
-	return ResourceSpansSlice{&orig}
+	return newResourceSpansSlice(&orig, newSharedState())
 }
 
 // Len returns the number of elements in the slice.
@@ -62,17 +63,18 @@ func (es ResourceSpansSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ResourceSpansSlice) At(ix int) ResourceSpans {
-	return newResourceSpans((*es.orig)[ix])
+	return newResourceSpans((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ResourceSpansSlice) CopyTo(dest ResourceSpansSlice) {
+	dest.state.assertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newResourceSpans((*es.orig)[i]).CopyTo(newResourceSpans((*dest.orig)[i]))
+			newResourceSpans((*es.orig)[i], es.state).CopyTo(newResourceSpans((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -80,7 +82,7 @@ func (es ResourceSpansSlice) CopyTo(dest ResourceSpansSlice) {
 	wrappers := make([]*otlptrace.ResourceSpans, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newResourceSpans((*es.orig)[i]).CopyTo(newResourceSpans(wrappers[i]))
+		newResourceSpans((*es.orig)[i], es.state).CopyTo(newResourceSpans(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -97,6 +99,7 @@ func (es ResourceSpansSlice) CopyTo(dest ResourceSpansSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es ResourceSpansSlice) EnsureCapacity(newCap int) {
+	es.state.assertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -110,6 +113,7 @@ func (es ResourceSpansSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty ResourceSpans.
 // It returns the newly added ResourceSpans.
 func (es ResourceSpansSlice) AppendEmpty() ResourceSpans {
+	es.state.assertMutable()
 	*es.orig = append(*es.orig, &otlptrace.ResourceSpans{})
 	return es.At(es.Len() - 1)
 }
@@ -124,6 +128,7 @@ func (es ResourceSpansSlice) AppendEmpty() ResourceSpans {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es ResourceSpansSlice) Sort(less func(a, b ResourceSpans) bool) ResourceSpansSlice {
+	es.state.assertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -131,6 +136,8 @@ func (es ResourceSpansSlice) Sort(less func(a, b ResourceSpans) bool) ResourceSp
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ResourceSpansSlice) MoveAndAppendTo(dest ResourceSpansSlice) {
+	es.state.assertMutable()
+	dest.state.assertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -143,6 +150,7 @@ func (es ResourceSpansSlice) MoveAndAppendTo(dest ResourceSpansSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ResourceSpansSlice) RemoveIf(f func(ResourceSpans) bool) {
+	es.state.assertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -167,25 +175,26 @@ func (es ResourceSpansSlice) RemoveIf(f func(ResourceSpans) bool) {
 //
 // Must use NewResourceSpans function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type ResourceSpans struct {
-	orig *otlptrace.ResourceSpans
+	orig  *otlptrace.ResourceSpans
+	state *sharedState
 }
 
-func newResourceSpans(orig *otlptrace.ResourceSpans) ResourceSpans {
-	return ResourceSpans{orig: orig}
+func newResourceSpans(orig *otlptrace.ResourceSpans, state *sharedState) ResourceSpans {
+	return ResourceSpans{orig: orig, state: state}
 }
 
 // NewResourceSpans creates a new empty ResourceSpans.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewResourceSpans() ResourceSpans {
-	return newResourceSpans(&otlptrace.ResourceSpans{})
+	return newResourceSpans(&otlptrace.ResourceSpans{}, newSharedState())
 }
 
 // Resource returns the resource associated with this ResourceSpans.
 func (ms ResourceSpans) Resource() Resource {
-	return newResource(&(*ms.orig).Resource)
+	return newResource(&(*ms.orig).Resource, ms.state)
 }
 
 // SchemaUrl returns the schemaurl associated with this ResourceSpans.
@@ -195,16 +204,18 @@ func (ms ResourceSpans) SchemaUrl() string {
 
 // SetSchemaUrl replaces the schemaurl associated with this ResourceSpans.
 func (ms ResourceSpans) SetSchemaUrl(v string) {
+	ms.state.assertMutable()
 	(*ms.orig).SchemaUrl = v
 }
 
 // InstrumentationLibrarySpans returns the InstrumentationLibrarySpans associated with this ResourceSpans.
 func (ms ResourceSpans) InstrumentationLibrarySpans() InstrumentationLibrarySpansSlice {
-	return newInstrumentationLibrarySpansSlice(&(*ms.orig).InstrumentationLibrarySpans)
+	return newInstrumentationLibrarySpansSlice(&(*ms.orig).InstrumentationLibrarySpans, ms.state)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms ResourceSpans) CopyTo(dest ResourceSpans) {
+	dest.state.assertMutable()
 	ms.Resource().CopyTo(dest.Resource())
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.InstrumentationLibrarySpans().CopyTo(dest.InstrumentationLibrarySpans())
@@ -220,18 +231,19 @@ func (ms ResourceSpans) CopyTo(dest ResourceSpans) {
 type InstrumentationLibrarySpansSlice struct {
 	// orig points to the slice otlptrace.InstrumentationLibrarySpans field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlptrace.InstrumentationLibrarySpans
+	orig  *[]*otlptrace.InstrumentationLibrarySpans
+	state *sharedState
 }
 
-func newInstrumentationLibrarySpansSlice(orig *[]*otlptrace.InstrumentationLibrarySpans) InstrumentationLibrarySpansSlice {
-	return InstrumentationLibrarySpansSlice{orig}
+func newInstrumentationLibrarySpansSlice(orig *[]*otlptrace.InstrumentationLibrarySpans, state *sharedState) InstrumentationLibrarySpansSlice {
+	return InstrumentationLibrarySpansSlice{orig: orig, state: state}
 }
 
 // NewInstrumentationLibrarySpansSlice creates a InstrumentationLibrarySpansSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewInstrumentationLibrarySpansSlice() InstrumentationLibrarySpansSlice {
 	orig := []*otlptrace.InstrumentationLibrarySpans(nil)
-	return InstrumentationLibrarySpansSlice{&orig}
+	return newInstrumentationLibrarySpansSlice(&orig, newSharedState())
 }
 
 // Len returns the number of elements in the slice.
@@ -249,17 +261,18 @@ func (es InstrumentationLibrarySpansSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es InstrumentationLibrarySpansSlice) At(ix int) InstrumentationLibrarySpans {
-	return newInstrumentationLibrarySpans((*es.orig)[ix])
+	return newInstrumentationLibrarySpans((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es InstrumentationLibrarySpansSlice) CopyTo(dest InstrumentationLibrarySpansSlice) {
+	dest.state.assertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newInstrumentationLibrarySpans((*es.orig)[i]).CopyTo(newInstrumentationLibrarySpans((*dest.orig)[i]))
+			newInstrumentationLibrarySpans((*es.orig)[i], es.state).CopyTo(newInstrumentationLibrarySpans((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -267,7 +280,7 @@ func (es InstrumentationLibrarySpansSlice) CopyTo(dest InstrumentationLibrarySpa
 	wrappers := make([]*otlptrace.InstrumentationLibrarySpans, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newInstrumentationLibrarySpans((*es.orig)[i]).CopyTo(newInstrumentationLibrarySpans(wrappers[i]))
+		newInstrumentationLibrarySpans((*es.orig)[i], es.state).CopyTo(newInstrumentationLibrarySpans(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -284,6 +297,7 @@ func (es InstrumentationLibrarySpansSlice) CopyTo(dest InstrumentationLibrarySpa
 //       // Here should set all the values for e.
 //   }
 func (es InstrumentationLibrarySpansSlice) EnsureCapacity(newCap int) {
+	es.state.assertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -297,6 +311,7 @@ func (es InstrumentationLibrarySpansSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty InstrumentationLibrarySpans.
 // It returns the newly added InstrumentationLibrarySpans.
 func (es InstrumentationLibrarySpansSlice) AppendEmpty() InstrumentationLibrarySpans {
+	es.state.assertMutable()
 	*es.orig = append(*es.orig, &otlptrace.InstrumentationLibrarySpans{})
 	return es.At(es.Len() - 1)
 }
@@ -311,6 +326,7 @@ func (es InstrumentationLibrarySpansSlice) AppendEmpty() InstrumentationLibraryS
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es InstrumentationLibrarySpansSlice) Sort(less func(a, b InstrumentationLibrarySpans) bool) InstrumentationLibrarySpansSlice {
+	es.state.assertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -318,6 +334,8 @@ func (es InstrumentationLibrarySpansSlice) Sort(less func(a, b InstrumentationLi
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es InstrumentationLibrarySpansSlice) MoveAndAppendTo(dest InstrumentationLibrarySpansSlice) {
+	es.state.assertMutable()
+	dest.state.assertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -330,6 +348,7 @@ func (es InstrumentationLibrarySpansSlice) MoveAndAppendTo(dest InstrumentationL
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es InstrumentationLibrarySpansSlice) RemoveIf(f func(InstrumentationLibrarySpans) bool) {
+	es.state.assertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -354,25 +373,26 @@ func (es InstrumentationLibrarySpansSlice) RemoveIf(f func(InstrumentationLibrar
 //
 // Must use NewInstrumentationLibrarySpans function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type InstrumentationLibrarySpans struct {
-	orig *otlptrace.InstrumentationLibrarySpans
+	orig  *otlptrace.InstrumentationLibrarySpans
+	state *sharedState
 }
 
-func newInstrumentationLibrarySpans(orig *otlptrace.InstrumentationLibrarySpans) InstrumentationLibrarySpans {
-	return InstrumentationLibrarySpans{orig: orig}
+func newInstrumentationLibrarySpans(orig *otlptrace.InstrumentationLibrarySpans, state *sharedState) InstrumentationLibrarySpans {
+	return InstrumentationLibrarySpans{orig: orig, state: state}
 }
 
 // NewInstrumentationLibrarySpans creates a new empty InstrumentationLibrarySpans.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewInstrumentationLibrarySpans() InstrumentationLibrarySpans {
-	return newInstrumentationLibrarySpans(&otlptrace.InstrumentationLibrarySpans{})
+	return newInstrumentationLibrarySpans(&otlptrace.InstrumentationLibrarySpans{}, newSharedState())
 }
 
 // InstrumentationLibrary returns the instrumentationlibrary associated with this InstrumentationLibrarySpans.
 func (ms InstrumentationLibrarySpans) InstrumentationLibrary() InstrumentationLibrary {
-	return newInstrumentationLibrary(&(*ms.orig).InstrumentationLibrary)
+	return newInstrumentationLibrary(&(*ms.orig).InstrumentationLibrary, ms.state)
 }
 
 // SchemaUrl returns the schemaurl associated with this InstrumentationLibrarySpans.
@@ -382,16 +402,18 @@ func (ms InstrumentationLibrarySpans) SchemaUrl() string {
 
 // SetSchemaUrl replaces the schemaurl associated with this InstrumentationLibrarySpans.
 func (ms InstrumentationLibrarySpans) SetSchemaUrl(v string) {
+	ms.state.assertMutable()
 	(*ms.orig).SchemaUrl = v
 }
 
 // Spans returns the Spans associated with this InstrumentationLibrarySpans.
 func (ms InstrumentationLibrarySpans) Spans() SpanSlice {
-	return newSpanSlice(&(*ms.orig).Spans)
+	return newSpanSlice(&(*ms.orig).Spans, ms.state)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms InstrumentationLibrarySpans) CopyTo(dest InstrumentationLibrarySpans) {
+	dest.state.assertMutable()
 	ms.InstrumentationLibrary().CopyTo(dest.InstrumentationLibrary())
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.Spans().CopyTo(dest.Spans())
@@ -407,18 +429,19 @@ func (ms InstrumentationLibrarySpans) CopyTo(dest InstrumentationLibrarySpans) {
 type SpanSlice struct {
 	// orig points to the slice otlptrace.Span field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlptrace.Span
+	orig  *[]*otlptrace.Span
+	state *sharedState
 }
 
-func newSpanSlice(orig *[]*otlptrace.Span) SpanSlice {
-	return SpanSlice{orig}
+func newSpanSlice(orig *[]*otlptrace.Span, state *sharedState) SpanSlice {
+	return SpanSlice{orig: orig, state: state}
 }
 
 // NewSpanSlice creates a SpanSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewSpanSlice() SpanSlice {
 	orig := []*otlptrace.Span(nil)
-	return SpanSlice{&orig}
+	return newSpanSlice(&orig, newSharedState())
 }
 
 // Len returns the number of elements in the slice.
@@ -436,17 +459,18 @@ func (es SpanSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es SpanSlice) At(ix int) Span {
-	return newSpan((*es.orig)[ix])
+	return newSpan((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es SpanSlice) CopyTo(dest SpanSlice) {
+	dest.state.assertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newSpan((*es.orig)[i]).CopyTo(newSpan((*dest.orig)[i]))
+			newSpan((*es.orig)[i], es.state).CopyTo(newSpan((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -454,7 +478,7 @@ func (es SpanSlice) CopyTo(dest SpanSlice) {
 	wrappers := make([]*otlptrace.Span, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newSpan((*es.orig)[i]).CopyTo(newSpan(wrappers[i]))
+		newSpan((*es.orig)[i], es.state).CopyTo(newSpan(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -471,6 +495,7 @@ func (es SpanSlice) CopyTo(dest SpanSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es SpanSlice) EnsureCapacity(newCap int) {
+	es.state.assertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -484,6 +509,7 @@ func (es SpanSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty Span.
 // It returns the newly added Span.
 func (es SpanSlice) AppendEmpty() Span {
+	es.state.assertMutable()
 	*es.orig = append(*es.orig, &otlptrace.Span{})
 	return es.At(es.Len() - 1)
 }
@@ -498,6 +524,7 @@ func (es SpanSlice) AppendEmpty() Span {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es SpanSlice) Sort(less func(a, b Span) bool) SpanSlice {
+	es.state.assertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -505,6 +532,8 @@ func (es SpanSlice) Sort(less func(a, b Span) bool) SpanSlice {
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es SpanSlice) MoveAndAppendTo(dest SpanSlice) {
+	es.state.assertMutable()
+	dest.state.assertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -517,6 +546,7 @@ func (es SpanSlice) MoveAndAppendTo(dest SpanSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es SpanSlice) RemoveIf(f func(Span) bool) {
+	es.state.assertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -542,20 +572,21 @@ func (es SpanSlice) RemoveIf(f func(Span) bool) {
 //
 // Must use NewSpan function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type Span struct {
-	orig *otlptrace.Span
+	orig  *otlptrace.Span
+	state *sharedState
 }
 
-func newSpan(orig *otlptrace.Span) Span {
-	return Span{orig: orig}
+func newSpan(orig *otlptrace.Span, state *sharedState) Span {
+	return Span{orig: orig, state: state}
 }
 
 // NewSpan creates a new empty Span.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewSpan() Span {
-	return newSpan(&otlptrace.Span{})
+	return newSpan(&otlptrace.Span{}, newSharedState())
 }
 
 // TraceID returns the traceid associated with this Span.
@@ -565,6 +596,7 @@ func (ms Span) TraceID() TraceID {
 
 // SetTraceID replaces the traceid associated with this Span.
 func (ms Span) SetTraceID(v TraceID) {
+	ms.state.assertMutable()
 	(*ms.orig).TraceId = v.orig
 }
 
@@ -575,6 +607,7 @@ func (ms Span) SpanID() SpanID {
 
 // SetSpanID replaces the spanid associated with this Span.
 func (ms Span) SetSpanID(v SpanID) {
+	ms.state.assertMutable()
 	(*ms.orig).SpanId = v.orig
 }
 
@@ -585,6 +618,7 @@ func (ms Span) TraceState() TraceState {
 
 // SetTraceState replaces the tracestate associated with this Span.
 func (ms Span) SetTraceState(v TraceState) {
+	ms.state.assertMutable()
 	(*ms.orig).TraceState = string(v)
 }
 
@@ -595,6 +629,7 @@ func (ms Span) ParentSpanID() SpanID {
 
 // SetParentSpanID replaces the parentspanid associated with this Span.
 func (ms Span) SetParentSpanID(v SpanID) {
+	ms.state.assertMutable()
 	(*ms.orig).ParentSpanId = v.orig
 }
 
@@ -605,6 +640,7 @@ func (ms Span) Name() string {
 
 // SetName replaces the name associated with this Span.
 func (ms Span) SetName(v string) {
+	ms.state.assertMutable()
 	(*ms.orig).Name = v
 }
 
@@ -615,6 +651,7 @@ func (ms Span) Kind() SpanKind {
 
 // SetKind replaces the kind associated with this Span.
 func (ms Span) SetKind(v SpanKind) {
+	ms.state.assertMutable()
 	(*ms.orig).Kind = otlptrace.Span_SpanKind(v)
 }
 
@@ -625,6 +662,7 @@ func (ms Span) StartTimestamp() Timestamp {
 
 // SetStartTimestamp replaces the starttimestamp associated with this Span.
 func (ms Span) SetStartTimestamp(v Timestamp) {
+	ms.state.assertMutable()
 	(*ms.orig).StartTimeUnixNano = uint64(v)
 }
 
@@ -635,12 +673,13 @@ func (ms Span) EndTimestamp() Timestamp {
 
 // SetEndTimestamp replaces the endtimestamp associated with this Span.
 func (ms Span) SetEndTimestamp(v Timestamp) {
+	ms.state.assertMutable()
 	(*ms.orig).EndTimeUnixNano = uint64(v)
 }
 
 // Attributes returns the Attributes associated with this Span.
 func (ms Span) Attributes() AttributeMap {
-	return newAttributeMap(&(*ms.orig).Attributes)
+	return newAttributeMap(&(*ms.orig).Attributes, ms.state)
 }
 
 // DroppedAttributesCount returns the droppedattributescount associated with this Span.
@@ -650,12 +689,13 @@ func (ms Span) DroppedAttributesCount() uint32 {
 
 // SetDroppedAttributesCount replaces the droppedattributescount associated with this Span.
 func (ms Span) SetDroppedAttributesCount(v uint32) {
+	ms.state.assertMutable()
 	(*ms.orig).DroppedAttributesCount = v
 }
 
 // Events returns the Events associated with this Span.
 func (ms Span) Events() SpanEventSlice {
-	return newSpanEventSlice(&(*ms.orig).Events)
+	return newSpanEventSlice(&(*ms.orig).Events, ms.state)
 }
 
 // DroppedEventsCount returns the droppedeventscount associated with this Span.
@@ -665,12 +705,13 @@ func (ms Span) DroppedEventsCount() uint32 {
 
 // SetDroppedEventsCount replaces the droppedeventscount associated with this Span.
 func (ms Span) SetDroppedEventsCount(v uint32) {
+	ms.state.assertMutable()
 	(*ms.orig).DroppedEventsCount = v
 }
 
 // Links returns the Links associated with this Span.
 func (ms Span) Links() SpanLinkSlice {
-	return newSpanLinkSlice(&(*ms.orig).Links)
+	return newSpanLinkSlice(&(*ms.orig).Links, ms.state)
 }
 
 // DroppedLinksCount returns the droppedlinkscount associated with this Span.
@@ -680,16 +721,18 @@ func (ms Span) DroppedLinksCount() uint32 {
 
 // SetDroppedLinksCount replaces the droppedlinkscount associated with this Span.
 func (ms Span) SetDroppedLinksCount(v uint32) {
+	ms.state.assertMutable()
 	(*ms.orig).DroppedLinksCount = v
 }
 
 // Status returns the status associated with this Span.
 func (ms Span) Status() SpanStatus {
-	return newSpanStatus(&(*ms.orig).Status)
+	return newSpanStatus(&(*ms.orig).Status, ms.state)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms Span) CopyTo(dest Span) {
+	dest.state.assertMutable()
 	dest.SetTraceID(ms.TraceID())
 	dest.SetSpanID(ms.SpanID())
 	dest.SetTraceState(ms.TraceState())
@@ -717,18 +760,19 @@ func (ms Span) CopyTo(dest Span) {
 type SpanEventSlice struct {
 	// orig points to the slice otlptrace.Span_Event field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlptrace.Span_Event
+	orig  *[]*otlptrace.Span_Event
+	state *sharedState
 }
 
-func newSpanEventSlice(orig *[]*otlptrace.Span_Event) SpanEventSlice {
-	return SpanEventSlice{orig}
+func newSpanEventSlice(orig *[]*otlptrace.Span_Event, state *sharedState) SpanEventSlice {
+	return SpanEventSlice{orig: orig, state: state}
 }
 
 // NewSpanEventSlice creates a SpanEventSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewSpanEventSlice() SpanEventSlice {
 	orig := []*otlptrace.Span_Event(nil)
-	return SpanEventSlice{&orig}
+	return newSpanEventSlice(&orig, newSharedState())
 }
 
 // Len returns the number of elements in the slice.
@@ -746,17 +790,18 @@ func (es SpanEventSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es SpanEventSlice) At(ix int) SpanEvent {
-	return newSpanEvent((*es.orig)[ix])
+	return newSpanEvent((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es SpanEventSlice) CopyTo(dest SpanEventSlice) {
+	dest.state.assertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newSpanEvent((*es.orig)[i]).CopyTo(newSpanEvent((*dest.orig)[i]))
+			newSpanEvent((*es.orig)[i], es.state).CopyTo(newSpanEvent((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -764,7 +809,7 @@ func (es SpanEventSlice) CopyTo(dest SpanEventSlice) {
 	wrappers := make([]*otlptrace.Span_Event, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newSpanEvent((*es.orig)[i]).CopyTo(newSpanEvent(wrappers[i]))
+		newSpanEvent((*es.orig)[i], es.state).CopyTo(newSpanEvent(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -781,6 +826,7 @@ func (es SpanEventSlice) CopyTo(dest SpanEventSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es SpanEventSlice) EnsureCapacity(newCap int) {
+	es.state.assertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -794,6 +840,7 @@ func (es SpanEventSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty SpanEvent.
 // It returns the newly added SpanEvent.
 func (es SpanEventSlice) AppendEmpty() SpanEvent {
+	es.state.assertMutable()
 	*es.orig = append(*es.orig, &otlptrace.Span_Event{})
 	return es.At(es.Len() - 1)
 }
@@ -808,6 +855,7 @@ func (es SpanEventSlice) AppendEmpty() SpanEvent {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es SpanEventSlice) Sort(less func(a, b SpanEvent) bool) SpanEventSlice {
+	es.state.assertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -815,6 +863,8 @@ func (es SpanEventSlice) Sort(less func(a, b SpanEvent) bool) SpanEventSlice {
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es SpanEventSlice) MoveAndAppendTo(dest SpanEventSlice) {
+	es.state.assertMutable()
+	dest.state.assertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -827,6 +877,7 @@ func (es SpanEventSlice) MoveAndAppendTo(dest SpanEventSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es SpanEventSlice) RemoveIf(f func(SpanEvent) bool) {
+	es.state.assertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -852,20 +903,21 @@ func (es SpanEventSlice) RemoveIf(f func(SpanEvent) bool) {
 //
 // Must use NewSpanEvent function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type SpanEvent struct {
-	orig *otlptrace.Span_Event
+	orig  *otlptrace.Span_Event
+	state *sharedState
 }
 
-func newSpanEvent(orig *otlptrace.Span_Event) SpanEvent {
-	return SpanEvent{orig: orig}
+func newSpanEvent(orig *otlptrace.Span_Event, state *sharedState) SpanEvent {
+	return SpanEvent{orig: orig, state: state}
 }
 
 // NewSpanEvent creates a new empty SpanEvent.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewSpanEvent() SpanEvent {
-	return newSpanEvent(&otlptrace.Span_Event{})
+	return newSpanEvent(&otlptrace.Span_Event{}, newSharedState())
 }
 
 // Timestamp returns the timestamp associated with this SpanEvent.
@@ -875,6 +927,7 @@ func (ms SpanEvent) Timestamp() Timestamp {
 
 // SetTimestamp replaces the timestamp associated with this SpanEvent.
 func (ms SpanEvent) SetTimestamp(v Timestamp) {
+	ms.state.assertMutable()
 	(*ms.orig).TimeUnixNano = uint64(v)
 }
 
@@ -885,12 +938,13 @@ func (ms SpanEvent) Name() string {
 
 // SetName replaces the name associated with this SpanEvent.
 func (ms SpanEvent) SetName(v string) {
+	ms.state.assertMutable()
 	(*ms.orig).Name = v
 }
 
 // Attributes returns the Attributes associated with this SpanEvent.
 func (ms SpanEvent) Attributes() AttributeMap {
-	return newAttributeMap(&(*ms.orig).Attributes)
+	return newAttributeMap(&(*ms.orig).Attributes, ms.state)
 }
 
 // DroppedAttributesCount returns the droppedattributescount associated with this SpanEvent.
@@ -900,11 +954,13 @@ func (ms SpanEvent) DroppedAttributesCount() uint32 {
 
 // SetDroppedAttributesCount replaces the droppedattributescount associated with this SpanEvent.
 func (ms SpanEvent) SetDroppedAttributesCount(v uint32) {
+	ms.state.assertMutable()
 	(*ms.orig).DroppedAttributesCount = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms SpanEvent) CopyTo(dest SpanEvent) {
+	dest.state.assertMutable()
 	dest.SetTimestamp(ms.Timestamp())
 	dest.SetName(ms.Name())
 	ms.Attributes().CopyTo(dest.Attributes())
@@ -921,18 +977,19 @@ func (ms SpanEvent) CopyTo(dest SpanEvent) {
 type SpanLinkSlice struct {
 	// orig points to the slice otlptrace.Span_Link field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlptrace.Span_Link
+	orig  *[]*otlptrace.Span_Link
+	state *sharedState
 }
 
-func newSpanLinkSlice(orig *[]*otlptrace.Span_Link) SpanLinkSlice {
-	return SpanLinkSlice{orig}
+func newSpanLinkSlice(orig *[]*otlptrace.Span_Link, state *sharedState) SpanLinkSlice {
+	return SpanLinkSlice{orig: orig, state: state}
 }
 
 // NewSpanLinkSlice creates a SpanLinkSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewSpanLinkSlice() SpanLinkSlice {
 	orig := []*otlptrace.Span_Link(nil)
-	return SpanLinkSlice{&orig}
+	return newSpanLinkSlice(&orig, newSharedState())
 }
 
 // Len returns the number of elements in the slice.
@@ -950,17 +1007,18 @@ func (es SpanLinkSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es SpanLinkSlice) At(ix int) SpanLink {
-	return newSpanLink((*es.orig)[ix])
+	return newSpanLink((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es SpanLinkSlice) CopyTo(dest SpanLinkSlice) {
+	dest.state.assertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newSpanLink((*es.orig)[i]).CopyTo(newSpanLink((*dest.orig)[i]))
+			newSpanLink((*es.orig)[i], es.state).CopyTo(newSpanLink((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -968,7 +1026,7 @@ func (es SpanLinkSlice) CopyTo(dest SpanLinkSlice) {
 	wrappers := make([]*otlptrace.Span_Link, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newSpanLink((*es.orig)[i]).CopyTo(newSpanLink(wrappers[i]))
+		newSpanLink((*es.orig)[i], es.state).CopyTo(newSpanLink(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -985,6 +1043,7 @@ func (es SpanLinkSlice) CopyTo(dest SpanLinkSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es SpanLinkSlice) EnsureCapacity(newCap int) {
+	es.state.assertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -998,6 +1057,7 @@ func (es SpanLinkSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty SpanLink.
 // It returns the newly added SpanLink.
 func (es SpanLinkSlice) AppendEmpty() SpanLink {
+	es.state.assertMutable()
 	*es.orig = append(*es.orig, &otlptrace.Span_Link{})
 	return es.At(es.Len() - 1)
 }
@@ -1012,6 +1072,7 @@ func (es SpanLinkSlice) AppendEmpty() SpanLink {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es SpanLinkSlice) Sort(less func(a, b SpanLink) bool) SpanLinkSlice {
+	es.state.assertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -1019,6 +1080,8 @@ func (es SpanLinkSlice) Sort(less func(a, b SpanLink) bool) SpanLinkSlice {
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es SpanLinkSlice) MoveAndAppendTo(dest SpanLinkSlice) {
+	es.state.assertMutable()
+	dest.state.assertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -1031,6 +1094,7 @@ func (es SpanLinkSlice) MoveAndAppendTo(dest SpanLinkSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es SpanLinkSlice) RemoveIf(f func(SpanLink) bool) {
+	es.state.assertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -1057,20 +1121,21 @@ func (es SpanLinkSlice) RemoveIf(f func(SpanLink) bool) {
 //
 // Must use NewSpanLink function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type SpanLink struct {
-	orig *otlptrace.Span_Link
+	orig  *otlptrace.Span_Link
+	state *sharedState
 }
 
-func newSpanLink(orig *otlptrace.Span_Link) SpanLink {
-	return SpanLink{orig: orig}
+func newSpanLink(orig *otlptrace.Span_Link, state *sharedState) SpanLink {
+	return SpanLink{orig: orig, state: state}
 }
 
 // NewSpanLink creates a new empty SpanLink.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewSpanLink() SpanLink {
-	return newSpanLink(&otlptrace.Span_Link{})
+	return newSpanLink(&otlptrace.Span_Link{}, newSharedState())
 }
 
 // TraceID returns the traceid associated with this SpanLink.
@@ -1080,6 +1145,7 @@ func (ms SpanLink) TraceID() TraceID {
 
 // SetTraceID replaces the traceid associated with this SpanLink.
 func (ms SpanLink) SetTraceID(v TraceID) {
+	ms.state.assertMutable()
 	(*ms.orig).TraceId = v.orig
 }
 
@@ -1090,6 +1156,7 @@ func (ms SpanLink) SpanID() SpanID {
 
 // SetSpanID replaces the spanid associated with this SpanLink.
 func (ms SpanLink) SetSpanID(v SpanID) {
+	ms.state.assertMutable()
 	(*ms.orig).SpanId = v.orig
 }
 
@@ -1100,12 +1167,13 @@ func (ms SpanLink) TraceState() TraceState {
 
 // SetTraceState replaces the tracestate associated with this SpanLink.
 func (ms SpanLink) SetTraceState(v TraceState) {
+	ms.state.assertMutable()
 	(*ms.orig).TraceState = string(v)
 }
 
 // Attributes returns the Attributes associated with this SpanLink.
 func (ms SpanLink) Attributes() AttributeMap {
-	return newAttributeMap(&(*ms.orig).Attributes)
+	return newAttributeMap(&(*ms.orig).Attributes, ms.state)
 }
 
 // DroppedAttributesCount returns the droppedattributescount associated with this SpanLink.
@@ -1115,11 +1183,13 @@ func (ms SpanLink) DroppedAttributesCount() uint32 {
 
 // SetDroppedAttributesCount replaces the droppedattributescount associated with this SpanLink.
 func (ms SpanLink) SetDroppedAttributesCount(v uint32) {
+	ms.state.assertMutable()
 	(*ms.orig).DroppedAttributesCount = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms SpanLink) CopyTo(dest SpanLink) {
+	dest.state.assertMutable()
 	dest.SetTraceID(ms.TraceID())
 	dest.SetSpanID(ms.SpanID())
 	dest.SetTraceState(ms.TraceState())
@@ -1135,20 +1205,21 @@ func (ms SpanLink) CopyTo(dest SpanLink) {
 //
 // Must use NewSpanStatus function to create new instances.
 // Important: zero-initialized instance is not valid for use.
-//
+// 
 type SpanStatus struct {
-	orig *otlptrace.Status
+	orig  *otlptrace.Status
+	state *sharedState
 }
 
-func newSpanStatus(orig *otlptrace.Status) SpanStatus {
-	return SpanStatus{orig: orig}
+func newSpanStatus(orig *otlptrace.Status, state *sharedState) SpanStatus {
+	return SpanStatus{orig: orig, state: state}
 }
 
 // NewSpanStatus creates a new empty SpanStatus.
 //
 // This must be used only in testing code since no "Set" method available.
 func NewSpanStatus() SpanStatus {
-	return newSpanStatus(&otlptrace.Status{})
+	return newSpanStatus(&otlptrace.Status{}, newSharedState())
 }
 
 // Code returns the code associated with this SpanStatus.
@@ -1163,11 +1234,13 @@ func (ms SpanStatus) Message() string {
 
 // SetMessage replaces the message associated with this SpanStatus.
 func (ms SpanStatus) SetMessage(v string) {
+	ms.state.assertMutable()
 	(*ms.orig).Message = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms SpanStatus) CopyTo(dest SpanStatus) {
+	dest.state.assertMutable()
 	dest.SetCode(ms.Code())
 	dest.SetMessage(ms.Message())
 }
@@ -28,11 +28,11 @@ import (
 func TestResourceSpansSlice(t *testing.T) {
 	es := NewResourceSpansSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newResourceSpansSlice(&[]*otlptrace.ResourceSpans{})
+	es = newResourceSpansSlice(&[]*otlptrace.ResourceSpans{}, newSharedState())
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newResourceSpans(&otlptrace.ResourceSpans{})
+	emptyVal := newResourceSpans(&otlptrace.ResourceSpans{}, newSharedState())
 	testVal := generateTestResourceSpans()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -166,11 +166,11 @@ func TestResourceSpans_InstrumentationLibrarySpans(t *testing.T) {
 func TestInstrumentationLibrarySpansSlice(t *testing.T) {
 	es := NewInstrumentationLibrarySpansSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newInstrumentationLibrarySpansSlice(&[]*otlptrace.InstrumentationLibrarySpans{})
+	es = newInstrumentationLibrarySpansSlice(&[]*otlptrace.InstrumentationLibrarySpans{}, newSharedState())
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newInstrumentationLibrarySpans(&otlptrace.InstrumentationLibrarySpans{})
+	emptyVal := newInstrumentationLibrarySpans(&otlptrace.InstrumentationLibrarySpans{}, newSharedState())
 	testVal := generateTestInstrumentationLibrarySpans()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -304,11 +304,11 @@ func TestInstrumentationLibrarySpans_Spans(t *testing.T) {
 func TestSpanSlice(t *testing.T) {
 	es := NewSpanSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newSpanSlice(&[]*otlptrace.Span{})
+	es = newSpanSlice(&[]*otlptrace.Span{}, newSharedState())
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newSpan(&otlptrace.Span{})
+	emptyVal := newSpan(&otlptrace.Span{}, newSharedState())
 	testVal := generateTestSpan()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -538,11 +538,11 @@ func TestSpan_Status(t *testing.T) {
 func TestSpanEventSlice(t *testing.T) {
 	es := NewSpanEventSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newSpanEventSlice(&[]*otlptrace.Span_Event{})
+	es = newSpanEventSlice(&[]*otlptrace.Span_Event{}, newSharedState())
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newSpanEvent(&otlptrace.Span_Event{})
+	emptyVal := newSpanEvent(&otlptrace.Span_Event{}, newSharedState())
 	testVal := generateTestSpanEvent()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -686,11 +686,11 @@ func TestSpanEvent_DroppedAttributesCount(t *testing.T) {
 func TestSpanLinkSlice(t *testing.T) {
 	es := NewSpanLinkSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newSpanLinkSlice(&[]*otlptrace.Span_Link{})
+	es = newSpanLinkSlice(&[]*otlptrace.Span_Link{}, newSharedState())
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newSpanLink(&otlptrace.Span_Link{})
+	emptyVal := newSpanLink(&otlptrace.Span_Link{}, newSharedState())
 	testVal := generateTestSpanLink()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -47,19 +47,20 @@ type LogsSizer interface {
 // Must use NewLogs functions to create new instances.
 // Important: zero-initialized instance is not valid for use.
 type Logs struct {
-	orig *otlpcollectorlog.ExportLogsServiceRequest
+	orig  *otlpcollectorlog.ExportLogsServiceRequest
+	state *sharedState
 }
 
 // NewLogs creates a new Logs.
 func NewLogs() Logs {
-	return Logs{orig: &otlpcollectorlog.ExportLogsServiceRequest{}}
+	return Logs{orig: &otlpcollectorlog.ExportLogsServiceRequest{}, state: newSharedState()}
 }
 
 // LogsFromInternalRep creates the internal Logs representation from the ProtoBuf. Should
 // not be used outside this module. This is intended to be used only by OTLP exporter and
 // File exporter, which legitimately need to work with OTLP Protobuf structs.
 func LogsFromInternalRep(logs internal.LogsWrapper) Logs {
-	return Logs{orig: internal.LogsToOtlp(logs)}
+	return Logs{orig: internal.LogsToOtlp(logs), state: newSharedState()}
 }
 
 // InternalRep returns internal representation of the logs. Should not be used outside
@@ -76,6 +77,28 @@ func (ld Logs) Clone() Logs {
 	return cloneLd
 }
 
+// MarkReadOnly marks the Logs as shared between multiple components, the data cannot be
+// modified anymore: all the functions modifying the Logs, or any struct obtained from it,
+// panic afterwards. It must be called before the data is shared and cannot be undone.
+func (ld Logs) MarkReadOnly() {
+	ld.state.readOnly = true
+}
+
+// IsReadOnly returns true if the Logs is marked as read-only.
+func (ld Logs) IsReadOnly() bool {
+	return ld.state.readOnly
+}
+
+// Mutable returns the Logs itself if it can be modified, otherwise a copy of it that can be.
+// Components modifying the data they consume call it before modifying it, so that read-only
+// data shared with other components is only copied by the ones modifying it.
+func (ld Logs) Mutable() Logs {
+	if !ld.state.readOnly {
+		return ld
+	}
+	return ld.Clone()
+}
+
 // LogRecordCount calculates the total number of log records.
 func (ld Logs) LogRecordCount() int {
 	logCount := 0
@@ -93,7 +116,7 @@ func (ld Logs) LogRecordCount() int {
 
 // ResourceLogs returns the ResourceLogsSlice associated with this Logs.
 func (ld Logs) ResourceLogs() ResourceLogsSlice {
-	return newResourceLogsSlice(&ld.orig.ResourceLogs)
+	return newResourceLogsSlice(&ld.orig.ResourceLogs, ld.state)
 }
 
 // SeverityNumber is the public alias of otlplogs.SeverityNumber from internal package.
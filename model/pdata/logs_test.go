@@ -86,6 +86,27 @@ func TestLogsClone(t *testing.T) {
 	assert.EqualValues(t, logs, logs.Clone())
 }
 
+func TestLogsReadOnly(t *testing.T) {
+	logs := NewLogs()
+	fillTestResourceLogsSlice(logs.ResourceLogs())
+	lr := logs.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.False(t, logs.IsReadOnly())
+	assert.Equal(t, logs, logs.Mutable())
+
+	logs.MarkReadOnly()
+	assert.True(t, logs.IsReadOnly())
+	assert.Panics(t, func() { lr.SetName("new_name") })
+	assert.Panics(t, func() { lr.Body().SetStringVal("new_body") })
+	assert.Panics(t, func() { logs.ResourceLogs().RemoveIf(func(ResourceLogs) bool { return true }) })
+	assert.Equal(t, 7*7*7, logs.LogRecordCount())
+
+	mutable := logs.Mutable()
+	assert.False(t, mutable.IsReadOnly())
+	mutable.ResourceLogs().RemoveIf(func(ResourceLogs) bool { return true })
+	assert.Equal(t, 0, mutable.LogRecordCount())
+	assert.Equal(t, 7*7*7, logs.LogRecordCount())
+}
+
 func BenchmarkLogsClone(b *testing.B) {
 	logs := NewLogs()
 	fillTestResourceLogsSlice(logs.ResourceLogs())
@@ -46,18 +46,19 @@ type MetricsSizer interface {
 // Outside of the core repository, the metrics pipeline cannot be converted to the new model since data.MetricData is
 // part of the internal package.
 type Metrics struct {
-	orig *otlpcollectormetrics.ExportMetricsServiceRequest
+	orig  *otlpcollectormetrics.ExportMetricsServiceRequest
+	state *sharedState
 }
 
 // NewMetrics creates a new Metrics.
 func NewMetrics() Metrics {
-	return Metrics{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{}}
+	return Metrics{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{}, state: newSharedState()}
 }
 
 // MetricsFromInternalRep creates Metrics from the internal representation.
 // Should not be used outside this module.
 func MetricsFromInternalRep(wrapper internal.MetricsWrapper) Metrics {
-	return Metrics{orig: internal.MetricsToOtlp(wrapper)}
+	return Metrics{orig: internal.MetricsToOtlp(wrapper), state: newSharedState()}
 }
 
 // InternalRep returns internal representation of the Metrics.
@@ -73,9 +74,31 @@ func (md Metrics) Clone() Metrics {
 	return cloneMd
 }
 
+// MarkReadOnly marks the Metrics as shared between multiple components, the data cannot be
+// modified anymore: all the functions modifying the Metrics, or any struct obtained from it,
+// panic afterwards. It must be called before the data is shared and cannot be undone.
+func (md Metrics) MarkReadOnly() {
+	md.state.readOnly = true
+}
+
+// IsReadOnly returns true if the Metrics is marked as read-only.
+func (md Metrics) IsReadOnly() bool {
+	return md.state.readOnly
+}
+
+// Mutable returns the Metrics itself if it can be modified, otherwise a copy of it that can be.
+// Components modifying the data they consume call it before modifying it, so that read-only
+// data shared with other components is only copied by the ones modifying it.
+func (md Metrics) Mutable() Metrics {
+	if !md.state.readOnly {
+		return md
+	}
+	return md.Clone()
+}
+
 // ResourceMetrics returns the ResourceMetricsSlice associated with this Metrics.
 func (md Metrics) ResourceMetrics() ResourceMetricsSlice {
-	return newResourceMetricsSlice(&md.orig.ResourceMetrics)
+	return newResourceMetricsSlice(&md.orig.ResourceMetrics, md.state)
 }
 
 // MetricCount calculates the total number of metrics.
@@ -167,6 +190,7 @@ func (ms Metric) DataType() MetricDataType {
 // SetDataType clears any existing data and initialize it with an empty data of the given type.
 // Calling this function on zero-initialized Metric will cause a panic.
 func (ms Metric) SetDataType(ty MetricDataType) {
+	ms.state.assertMutable()
 	switch ty {
 	case MetricDataTypeGauge:
 		ms.orig.Data = &otlpmetrics.Metric_Gauge{Gauge: &otlpmetrics.Gauge{}}
@@ -183,48 +207,48 @@ func (ms Metric) SetDataType(ty MetricDataType) {
 // Calling this function when DataType() != MetricDataTypeGauge will cause a panic.
 // Calling this function on zero-initialized Metric will cause a panic.
 func (ms Metric) Gauge() Gauge {
-	return newGauge(ms.orig.Data.(*otlpmetrics.Metric_Gauge).Gauge)
+	return newGauge(ms.orig.Data.(*otlpmetrics.Metric_Gauge).Gauge, ms.state)
 }
 
 // Sum returns the data as Sum.
 // Calling this function when DataType() != MetricDataTypeSum will cause a panic.
 // Calling this function on zero-initialized Metric will cause a panic.
 func (ms Metric) Sum() Sum {
-	return newSum(ms.orig.Data.(*otlpmetrics.Metric_Sum).Sum)
+	return newSum(ms.orig.Data.(*otlpmetrics.Metric_Sum).Sum, ms.state)
 }
 
 // Histogram returns the data as Histogram.
 // Calling this function when DataType() != MetricDataTypeHistogram will cause a panic.
 // Calling this function on zero-initialized Metric will cause a panic.
 func (ms Metric) Histogram() Histogram {
-	return newHistogram(ms.orig.Data.(*otlpmetrics.Metric_Histogram).Histogram)
+	return newHistogram(ms.orig.Data.(*otlpmetrics.Metric_Histogram).Histogram, ms.state)
 }
 
 // Summary returns the data as Summary.
 // Calling this function when DataType() != MetricDataTypeSummary will cause a panic.
 // Calling this function on zero-initialized Metric will cause a panic.
 func (ms Metric) Summary() Summary {
-	return newSummary(ms.orig.Data.(*otlpmetrics.Metric_Summary).Summary)
+	return newSummary(ms.orig.Data.(*otlpmetrics.Metric_Summary).Summary, ms.state)
 }
 
-func copyData(src, dest *otlpmetrics.Metric) {
-	switch srcData := (src).Data.(type) {
+func copyData(src, dest Metric) {
+	switch srcData := src.orig.Data.(type) {
 	case *otlpmetrics.Metric_Gauge:
 		data := &otlpmetrics.Metric_Gauge{Gauge: &otlpmetrics.Gauge{}}
-		newGauge(srcData.Gauge).CopyTo(newGauge(data.Gauge))
-		dest.Data = data
+		newGauge(srcData.Gauge, src.state).CopyTo(newGauge(data.Gauge, dest.state))
+		dest.orig.Data = data
 	case *otlpmetrics.Metric_Sum:
 		data := &otlpmetrics.Metric_Sum{Sum: &otlpmetrics.Sum{}}
-		newSum(srcData.Sum).CopyTo(newSum(data.Sum))
-		dest.Data = data
+		newSum(srcData.Sum, src.state).CopyTo(newSum(data.Sum, dest.state))
+		dest.orig.Data = data
 	case *otlpmetrics.Metric_Histogram:
 		data := &otlpmetrics.Metric_Histogram{Histogram: &otlpmetrics.Histogram{}}
-		newHistogram(srcData.Histogram).CopyTo(newHistogram(data.Histogram))
-		dest.Data = data
+		newHistogram(srcData.Histogram, src.state).CopyTo(newHistogram(data.Histogram, dest.state))
+		dest.orig.Data = data
 	case *otlpmetrics.Metric_Summary:
 		data := &otlpmetrics.Metric_Summary{Summary: &otlpmetrics.Summary{}}
-		newSummary(srcData.Summary).CopyTo(newSummary(data.Summary))
-		dest.Data = data
+		newSummary(srcData.Summary, src.state).CopyTo(newSummary(data.Summary, dest.state))
+		dest.orig.Data = data
 	}
 }
 
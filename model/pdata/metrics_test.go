@@ -70,7 +70,7 @@ func TestCopyData(t *testing.T) {
 			dest := &otlpmetrics.Metric{}
 			assert.Nil(t, dest.Data)
 			assert.NotNil(t, test.src.Data)
-			copyData(test.src, dest)
+			copyData(newMetric(test.src, newSharedState()), newMetric(dest, newSharedState()))
 			assert.EqualValues(t, test.src, dest)
 		})
 	}
@@ -228,7 +228,7 @@ func TestOtlpToInternalReadOnly(t *testing.T) {
 				},
 			},
 		},
-	}}
+	}, state: newSharedState()}
 	resourceMetrics := md.ResourceMetrics()
 	assert.EqualValues(t, 1, resourceMetrics.Len())
 
@@ -591,6 +591,27 @@ func TestMetricsClone(t *testing.T) {
 	assert.EqualValues(t, metrics, metrics.Clone())
 }
 
+func TestMetricsReadOnly(t *testing.T) {
+	metrics := NewMetrics()
+	fillTestResourceMetricsSlice(metrics.ResourceMetrics())
+	metric := metrics.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	assert.False(t, metrics.IsReadOnly())
+	assert.Equal(t, metrics, metrics.Mutable())
+
+	metrics.MarkReadOnly()
+	assert.True(t, metrics.IsReadOnly())
+	assert.Panics(t, func() { metric.SetDataType(MetricDataTypeSum) })
+	assert.Panics(t, func() { metric.Gauge().DataPoints().At(0).SetDoubleVal(1) })
+	assert.Panics(t, func() { metrics.ResourceMetrics().At(0).Resource().Attributes().Clear() })
+	assert.Equal(t, 7*7*7, metrics.MetricCount())
+
+	mutable := metrics.Mutable()
+	assert.False(t, mutable.IsReadOnly())
+	mutable.ResourceMetrics().At(0).Resource().Attributes().Clear()
+	assert.Equal(t, 0, mutable.ResourceMetrics().At(0).Resource().Attributes().Len())
+	assert.NotEqual(t, 0, metrics.ResourceMetrics().At(0).Resource().Attributes().Len())
+}
+
 func BenchmarkMetricsClone(b *testing.B) {
 	metrics := NewMetrics()
 	fillTestResourceMetricsSlice(metrics.ResourceMetrics())
@@ -846,7 +867,7 @@ func generateTestProtoDoubleHistogramMetric() *otlpmetrics.Metric {
 func generateMetricsEmptyResource() Metrics {
 	return Metrics{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{
 		ResourceMetrics: []*otlpmetrics.ResourceMetrics{{}},
-	}}
+	}, state: newSharedState()}
 }
 
 func generateMetricsEmptyInstrumentation() Metrics {
@@ -856,7 +877,7 @@ func generateMetricsEmptyInstrumentation() Metrics {
 				InstrumentationLibraryMetrics: []*otlpmetrics.InstrumentationLibraryMetrics{{}},
 			},
 		},
-	}}
+	}, state: newSharedState()}
 }
 
 func generateMetricsEmptyMetrics() Metrics {
@@ -870,7 +891,7 @@ func generateMetricsEmptyMetrics() Metrics {
 				},
 			},
 		},
-	}}
+	}, state: newSharedState()}
 }
 
 func generateMetricsEmptyDataPoints() Metrics {
@@ -894,5 +915,5 @@ func generateMetricsEmptyDataPoints() Metrics {
 				},
 			},
 		},
-	}}
+	}, state: newSharedState()}
 }
@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdatatest
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// orderKind is a kind of slice whose order can be ignored.
+type orderKind int
+
+const (
+	resourceOrder orderKind = iota
+	scopeOrder
+	spanOrder
+	metricOrder
+	logRecordOrder
+	dataPointOrder
+	attributeOrder
+	numOrderKinds
+)
+
+type options struct {
+	ignoredOrders     [numOrderKinds]bool
+	ignoreTimestamps  bool
+	ignoredAttributes map[string]bool
+}
+
+// element stands for a slice element present only in the expected or the actual data.
+type element struct{}
+
+func (element) String() string {
+	return "element"
+}
+
+// differ accumulates the differences found by the diff functions, most of them
+// generated by "cmd/pdatagen/main.go".
+type differ struct {
+	opts  *options
+	diffs []Difference
+}
+
+func newDiffer(opts []Option) *differ {
+	o := &options{ignoredAttributes: map[string]bool{}}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &differ{opts: o}
+}
+
+func (d *differ) ignoreOrder(order orderKind) bool {
+	return d.opts.ignoredOrders[order]
+}
+
+// equal returns whether diff finds no difference.
+func (d *differ) equal(diff func(d *differ)) bool {
+	sub := &differ{opts: d.opts}
+	diff(sub)
+	return len(sub.diffs) == 0
+}
+
+// compare records a difference if the values are not equal, and returns whether they
+// are equal. Empty slices are equal to nil ones, and NaNs are equal to each other.
+func (d *differ) compare(path string, expected, actual interface{}) bool {
+	if valuesEqual(expected, actual) {
+		return true
+	}
+	d.diffs = append(d.diffs, Difference{Path: path, Expected: expected, Actual: actual})
+	return false
+}
+
+func (d *differ) compareTimestamp(path string, expected, actual pdata.Timestamp) {
+	if d.opts.ignoreTimestamps {
+		return
+	}
+	d.compare(path, expected, actual)
+}
+
+func valuesEqual(expected, actual interface{}) bool {
+	if e, ok := expected.(float64); ok {
+		a, ok := actual.(float64)
+		return ok && (e == a || math.IsNaN(e) && math.IsNaN(a))
+	}
+	ev, av := reflect.ValueOf(expected), reflect.ValueOf(actual)
+	if ev.Kind() == reflect.Slice && av.Kind() == reflect.Slice && ev.Len() == 0 && av.Len() == 0 {
+		return ev.Type() == av.Type()
+	}
+	return reflect.DeepEqual(expected, actual)
+}
+
+// diffSlice diffs the elements of a slice with diffAt. Unless the order is ignored,
+// diffAt is called with the elements at the same index. Otherwise, the expected
+// elements are matched with equal actual elements first, and the remaining ones are
+// diffed in order.
+func (d *differ) diffSlice(path string, expectedLen, actualLen int, ignoreOrder bool, diffAt func(d *differ, path string, i, j int)) {
+	matched := make([]bool, actualLen)
+	var unmatched []int
+	for i := 0; i < expectedLen; i++ {
+		found := false
+		for j := 0; ignoreOrder && !found && j < actualLen; j++ {
+			if !matched[j] && d.equal(func(d *differ) { diffAt(d, path, i, j) }) {
+				matched[j], found = true, true
+			}
+		}
+		if !found {
+			unmatched = append(unmatched, i)
+		}
+	}
+
+	j := 0
+	for _, i := range unmatched {
+		for j < actualLen && matched[j] {
+			j++
+		}
+		if j == actualLen {
+			d.diffs = append(d.diffs, Difference{Path: indexPath(path, i), Expected: element{}})
+			continue
+		}
+		matched[j] = true
+		diffAt(d, indexPath(path, i), i, j)
+	}
+	for j := range matched {
+		if !matched[j] {
+			d.diffs = append(d.diffs, Difference{Path: indexPath(path, j), Actual: element{}})
+		}
+	}
+}
+
+func indexPath(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
+
+// attributeKeys returns the keys of the attributes, except for the ignored ones.
+func (d *differ) attributeKeys(attrs pdata.AttributeMap) []string {
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pdata.AttributeValue) bool {
+		if !d.opts.ignoredAttributes[k] {
+			keys = append(keys, k)
+		}
+		return true
+	})
+	return keys
+}
+
+func diffAttributeMap(d *differ, path string, expected, actual pdata.AttributeMap) {
+	expectedKeys := d.attributeKeys(expected)
+	actualKeys := d.attributeKeys(actual)
+	sameKeys := len(expectedKeys) == len(actualKeys)
+	for _, k := range expectedKeys {
+		ev, _ := expected.Get(k)
+		av, ok := actual.Get(k)
+		if !ok {
+			sameKeys = false
+			d.diffs = append(d.diffs, Difference{Path: keyPath(path, k), Expected: pdata.AttributeValueToString(ev)})
+			continue
+		}
+		diffAttributeValue(d, keyPath(path, k), ev, av)
+	}
+	for _, k := range actualKeys {
+		if _, ok := expected.Get(k); !ok {
+			av, _ := actual.Get(k)
+			d.diffs = append(d.diffs, Difference{Path: keyPath(path, k), Actual: pdata.AttributeValueToString(av)})
+		}
+	}
+	// The order is only reported when the keys are the same.
+	if sameKeys && !d.ignoreOrder(attributeOrder) {
+		d.compare(path, expectedKeys, actualKeys)
+	}
+}
+
+func keyPath(path string, key string) string {
+	return fmt.Sprintf("%s[%q]", path, key)
+}
+
+func diffAttributeValue(d *differ, path string, expected, actual pdata.AttributeValue) {
+	if !d.compare(path+".Type", expected.Type(), actual.Type()) {
+		return
+	}
+	switch expected.Type() {
+	case pdata.AttributeValueTypeString:
+		d.compare(path, expected.StringVal(), actual.StringVal())
+	case pdata.AttributeValueTypeInt:
+		d.compare(path, expected.IntVal(), actual.IntVal())
+	case pdata.AttributeValueTypeDouble:
+		d.compare(path, expected.DoubleVal(), actual.DoubleVal())
+	case pdata.AttributeValueTypeBool:
+		d.compare(path, expected.BoolVal(), actual.BoolVal())
+	case pdata.AttributeValueTypeBytes:
+		d.compare(path, expected.BytesVal(), actual.BytesVal())
+	case pdata.AttributeValueTypeMap:
+		diffAttributeMap(d, path, expected.MapVal(), actual.MapVal())
+	case pdata.AttributeValueTypeArray:
+		diffAnyValueArray(d, path, expected.ArrayVal(), actual.ArrayVal())
+	}
+}
+
+// diffData diffs the data of the metrics.
+func diffData(d *differ, path string, expected, actual pdata.Metric) {
+	if !d.compare(path+".DataType", expected.DataType(), actual.DataType()) {
+		return
+	}
+	switch expected.DataType() {
+	case pdata.MetricDataTypeGauge:
+		diffGauge(d, path+".Gauge", expected.Gauge(), actual.Gauge())
+	case pdata.MetricDataTypeSum:
+		diffSum(d, path+".Sum", expected.Sum(), actual.Sum())
+	case pdata.MetricDataTypeHistogram:
+		diffHistogram(d, path+".Histogram", expected.Histogram(), actual.Histogram())
+	case pdata.MetricDataTypeSummary:
+		diffSummary(d, path+".Summary", expected.Summary(), actual.Summary())
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by "cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "go run cmd/pdatagen/main.go".
+
+package pdatatest
+
+import (
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func diffInstrumentationLibrary(d *differ, path string, expected, actual pdata.InstrumentationLibrary) {
+	d.compare(path+".Name", expected.Name(), actual.Name())
+	d.compare(path+".Version", expected.Version(), actual.Version())
+}
+
+func diffAnyValueArray(d *differ, path string, expected, actual pdata.AnyValueArray) {
+	d.diffSlice(path, expected.Len(), actual.Len(), false, func(d *differ, path string, i, j int) {
+		diffAttributeValue(d, path, expected.At(i), actual.At(j))
+	})
+}
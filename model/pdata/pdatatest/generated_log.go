@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by "cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "go run cmd/pdatagen/main.go".
+
+package pdatatest
+
+import (
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func diffResourceLogsSlice(d *differ, path string, expected, actual pdata.ResourceLogsSlice) {
+	d.diffSlice(path, expected.Len(), actual.Len(), d.ignoreOrder(resourceOrder), func(d *differ, path string, i, j int) {
+		diffResourceLogs(d, path, expected.At(i), actual.At(j))
+	})
+}
+
+func diffResourceLogs(d *differ, path string, expected, actual pdata.ResourceLogs) {
+	diffResource(d, path+".Resource", expected.Resource(), actual.Resource())
+	d.compare(path+".SchemaUrl", expected.SchemaUrl(), actual.SchemaUrl())
+	diffInstrumentationLibraryLogsSlice(d, path+".InstrumentationLibraryLogs", expected.InstrumentationLibraryLogs(), actual.InstrumentationLibraryLogs())
+}
+
+func diffInstrumentationLibraryLogsSlice(d *differ, path string, expected, actual pdata.InstrumentationLibraryLogsSlice) {
+	d.diffSlice(path, expected.Len(), actual.Len(), d.ignoreOrder(scopeOrder), func(d *differ, path string, i, j int) {
+		diffInstrumentationLibraryLogs(d, path, expected.At(i), actual.At(j))
+	})
+}
+
+func diffInstrumentationLibraryLogs(d *differ, path string, expected, actual pdata.InstrumentationLibraryLogs) {
+	diffInstrumentationLibrary(d, path+".InstrumentationLibrary", expected.InstrumentationLibrary(), actual.InstrumentationLibrary())
+	d.compare(path+".SchemaUrl", expected.SchemaUrl(), actual.SchemaUrl())
+	diffLogSlice(d, path+".Logs", expected.Logs(), actual.Logs())
+}
+
+func diffLogSlice(d *differ, path string, expected, actual pdata.LogSlice) {
+	d.diffSlice(path, expected.Len(), actual.Len(), d.ignoreOrder(logRecordOrder), func(d *differ, path string, i, j int) {
+		diffLogRecord(d, path, expected.At(i), actual.At(j))
+	})
+}
+
+func diffLogRecord(d *differ, path string, expected, actual pdata.LogRecord) {
+	d.compareTimestamp(path+".Timestamp", expected.Timestamp(), actual.Timestamp())
+	d.compare(path+".TraceID", expected.TraceID().HexString(), actual.TraceID().HexString())
+	d.compare(path+".SpanID", expected.SpanID().HexString(), actual.SpanID().HexString())
+	d.compare(path+".Flags", expected.Flags(), actual.Flags())
+	d.compare(path+".SeverityText", expected.SeverityText(), actual.SeverityText())
+	d.compare(path+".SeverityNumber", expected.SeverityNumber(), actual.SeverityNumber())
+	d.compare(path+".Name", expected.Name(), actual.Name())
+	diffAttributeValue(d, path+".Body", expected.Body(), actual.Body())
+	diffAttributeMap(d, path+".Attributes", expected.Attributes(), actual.Attributes())
+	d.compare(path+".DroppedAttributesCount", expected.DroppedAttributesCount(), actual.DroppedAttributesCount())
+}
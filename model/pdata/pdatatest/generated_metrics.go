@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by "cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "go run cmd/pdatagen/main.go".
+
+package pdatatest
+
+import (
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func diffResourceMetricsSlice(d *differ, path string, expected, actual pdata.ResourceMetricsSlice) {
+	d.diffSlice(path, expected.Len(), actual.Len(), d.ignoreOrder(resourceOrder), func(d *differ, path string, i, j int) {
+		diffResourceMetrics(d, path, expected.At(i), actual.At(j))
+	})
+}
+
+func diffResourceMetrics(d *differ, path string, expected, actual pdata.ResourceMetrics) {
+	diffResource(d, path+".Resource", expected.Resource(), actual.Resource())
+	d.compare(path+".SchemaUrl", expected.SchemaUrl(), actual.SchemaUrl())
+	diffInstrumentationLibraryMetricsSlice(d, path+".InstrumentationLibraryMetrics", expected.InstrumentationLibraryMetrics(), actual.InstrumentationLibraryMetrics())
+}
+
+func diffInstrumentationLibraryMetricsSlice(d *differ, path string, expected, actual pdata.InstrumentationLibraryMetricsSlice) {
+	d.diffSlice(path, expected.Len(), actual.Len(), d.ignoreOrder(scopeOrder), func(d *differ, path string, i, j int) {
+		diffInstrumentationLibraryMetrics(d, path, expected.At(i), actual.At(j))
+	})
+}
+
+func diffInstrumentationLibraryMetrics(d *differ, path string, expected, actual pdata.InstrumentationLibraryMetrics) {
+	diffInstrumentationLibrary(d, path+".InstrumentationLibrary", expected.InstrumentationLibrary(), actual.InstrumentationLibrary())
+	d.compare(path+".SchemaUrl", expected.SchemaUrl(), actual.SchemaUrl())
+	diffMetricSlice(d, path+".Metrics", expected.Metrics(), actual.Metrics())
+}
+
+func diffMetricSlice(d *differ, path string, expected, actual pdata.MetricSlice) {
+	d.diffSlice(path, expected.Len(), actual.Len(), d.ignoreOrder(metricOrder), func(d *differ, path string, i, j int) {
+		diffMetric(d, path, expected.At(i), actual.At(j))
+	})
+}
+
+func diffMetric(d *differ, path string, expected, actual pdata.Metric) {
+	d.compare(path+".Name", expected.Name(), actual.Name())
+	d.compare(path+".Description", expected.Description(), actual.Description())
+	d.compare(path+".Unit", expected.Unit(), actual.Unit())
+	diffData(d, path, expected, actual)
+}
+
+func diffGauge(d *differ, path string, expected, actual pdata.Gauge) {
+	diffNumberDataPointSlice(d, path+".DataPoints", expected.DataPoints(), actual.DataPoints())
+}
+
+func diffSum(d *differ, path string, expected, actual pdata.Sum) {
+	d.compare(path+".AggregationTemporality", expected.AggregationTemporality(), actual.AggregationTemporality())
+	d.compare(path+".IsMonotonic", expected.IsMonotonic(), actual.IsMonotonic())
+	diffNumberDataPointSlice(d, path+".DataPoints", expected.DataPoints(), actual.DataPoints())
+}
+
+func diffHistogram(d *differ, path string, expected, actual pdata.Histogram) {
+	d.compare(path+".AggregationTemporality", expected.AggregationTemporality(), actual.AggregationTemporality())
+	diffHistogramDataPointSlice(d, path+".DataPoints", expected.DataPoints(), actual.DataPoints())
+}
+
+func diffSummary(d *differ, path string, expected, actual pdata.Summary) {
+	diffSummaryDataPointSlice(d, path+".DataPoints", expected.DataPoints(), actual.DataPoints())
+}
+
+func diffNumberDataPointSlice(d *differ, path string, expected, actual pdata.NumberDataPointSlice) {
+	d.diffSlice(path, expected.Len(), actual.Len(), d.ignoreOrder(dataPointOrder), func(d *differ, path string, i, j int) {
+		diffNumberDataPoint(d, path, expected.At(i), actual.At(j))
+	})
+}
+
+func diffNumberDataPoint(d *differ, path string, expected, actual pdata.NumberDataPoint) {
+	diffAttributeMap(d, path+".Attributes", expected.Attributes(), actual.Attributes())
+	d.compareTimestamp(path+".StartTimestamp", expected.StartTimestamp(), actual.StartTimestamp())
+	d.compareTimestamp(path+".Timestamp", expected.Timestamp(), actual.Timestamp())
+	if d.compare(path+".Type", expected.Type(), actual.Type()) {
+		switch expected.Type() {
+		case pdata.MetricValueTypeDouble:
+			d.compare(path+".DoubleVal", expected.DoubleVal(), actual.DoubleVal())
+		case pdata.MetricValueTypeInt:
+			d.compare(path+".IntVal", expected.IntVal(), actual.IntVal())
+		}
+	}
+	diffExemplarSlice(d, path+".Exemplars", expected.Exemplars(), actual.Exemplars())
+}
+
+func diffHistogramDataPointSlice(d *differ, path string, expected, actual pdata.HistogramDataPointSlice) {
+	d.diffSlice(path, expected.Len(), actual.Len(), d.ignoreOrder(dataPointOrder), func(d *differ, path string, i, j int) {
+		diffHistogramDataPoint(d, path, expected.At(i), actual.At(j))
+	})
+}
+
+func diffHistogramDataPoint(d *differ, path string, expected, actual pdata.HistogramDataPoint) {
+	diffAttributeMap(d, path+".Attributes", expected.Attributes(), actual.Attributes())
+	d.compareTimestamp(path+".StartTimestamp", expected.StartTimestamp(), actual.StartTimestamp())
+	d.compareTimestamp(path+".Timestamp", expected.Timestamp(), actual.Timestamp())
+	d.compare(path+".Count", expected.Count(), actual.Count())
+	d.compare(path+".Sum", expected.Sum(), actual.Sum())
+	d.compare(path+".BucketCounts", expected.BucketCounts(), actual.BucketCounts())
+	d.compare(path+".ExplicitBounds", expected.ExplicitBounds(), actual.ExplicitBounds())
+	diffExemplarSlice(d, path+".Exemplars", expected.Exemplars(), actual.Exemplars())
+}
+
+func diffSummaryDataPointSlice(d *differ, path string, expected, actual pdata.SummaryDataPointSlice) {
+	d.diffSlice(path, expected.Len(), actual.Len(), d.ignoreOrder(dataPointOrder), func(d *differ, path string, i, j int) {
+		diffSummaryDataPoint(d, path, expected.At(i), actual.At(j))
+	})
+}
+
+func diffSummaryDataPoint(d *differ, path string, expected, actual pdata.SummaryDataPoint) {
+	diffAttributeMap(d, path+".Attributes", expected.Attributes(), actual.Attributes())
+	d.compareTimestamp(path+".StartTimestamp", expected.StartTimestamp(), actual.StartTimestamp())
+	d.compareTimestamp(path+".Timestamp", expected.Timestamp(), actual.Timestamp())
+	d.compare(path+".Count", expected.Count(), actual.Count())
+	d.compare(path+".Sum", expected.Sum(), actual.Sum())
+	diffValueAtQuantileSlice(d, path+".QuantileValues", expected.QuantileValues(), actual.QuantileValues())
+}
+
+func diffValueAtQuantileSlice(d *differ, path string, expected, actual pdata.ValueAtQuantileSlice) {
+	d.diffSlice(path, expected.Len(), actual.Len(), false, func(d *differ, path string, i, j int) {
+		diffValueAtQuantile(d, path, expected.At(i), actual.At(j))
+	})
+}
+
+func diffValueAtQuantile(d *differ, path string, expected, actual pdata.ValueAtQuantile) {
+	d.compare(path+".Quantile", expected.Quantile(), actual.Quantile())
+	d.compare(path+".Value", expected.Value(), actual.Value())
+}
+
+func diffExemplarSlice(d *differ, path string, expected, actual pdata.ExemplarSlice) {
+	d.diffSlice(path, expected.Len(), actual.Len(), d.ignoreOrder(dataPointOrder), func(d *differ, path string, i, j int) {
+		diffExemplar(d, path, expected.At(i), actual.At(j))
+	})
+}
+
+func diffExemplar(d *differ, path string, expected, actual pdata.Exemplar) {
+	d.compareTimestamp(path+".Timestamp", expected.Timestamp(), actual.Timestamp())
+	if d.compare(path+".Type", expected.Type(), actual.Type()) {
+		switch expected.Type() {
+		case pdata.MetricValueTypeDouble:
+			d.compare(path+".DoubleVal", expected.DoubleVal(), actual.DoubleVal())
+		case pdata.MetricValueTypeInt:
+			d.compare(path+".IntVal", expected.IntVal(), actual.IntVal())
+		}
+	}
+	diffAttributeMap(d, path+".FilteredAttributes", expected.FilteredAttributes(), actual.FilteredAttributes())
+	d.compare(path+".TraceID", expected.TraceID().HexString(), actual.TraceID().HexString())
+	d.compare(path+".SpanID", expected.SpanID().HexString(), actual.SpanID().HexString())
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by "cmd/pdatagen/main.go". DO NOT EDIT.
+// To regenerate this file run "go run cmd/pdatagen/main.go".
+
+package pdatatest
+
+import (
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func diffResourceSpansSlice(d *differ, path string, expected, actual pdata.ResourceSpansSlice) {
+	d.diffSlice(path, expected.Len(), actual.Len(), d.ignoreOrder(resourceOrder), func(d *differ, path string, i, j int) {
+		diffResourceSpans(d, path, expected.At(i), actual.At(j))
+	})
+}
+
+func diffResourceSpans(d *differ, path string, expected, actual pdata.ResourceSpans) {
+	diffResource(d, path+".Resource", expected.Resource(), actual.Resource())
+	d.compare(path+".SchemaUrl", expected.SchemaUrl(), actual.SchemaUrl())
+	diffInstrumentationLibrarySpansSlice(d, path+".InstrumentationLibrarySpans", expected.InstrumentationLibrarySpans(), actual.InstrumentationLibrarySpans())
+}
+
+func diffInstrumentationLibrarySpansSlice(d *differ, path string, expected, actual pdata.InstrumentationLibrarySpansSlice) {
+	d.diffSlice(path, expected.Len(), actual.Len(), d.ignoreOrder(scopeOrder), func(d *differ, path string, i, j int) {
+		diffInstrumentationLibrarySpans(d, path, expected.At(i), actual.At(j))
+	})
+}
+
+func diffInstrumentationLibrarySpans(d *differ, path string, expected, actual pdata.InstrumentationLibrarySpans) {
+	diffInstrumentationLibrary(d, path+".InstrumentationLibrary", expected.InstrumentationLibrary(), actual.InstrumentationLibrary())
+	d.compare(path+".SchemaUrl", expected.SchemaUrl(), actual.SchemaUrl())
+	diffSpanSlice(d, path+".Spans", expected.Spans(), actual.Spans())
+}
+
+func diffSpanSlice(d *differ, path string, expected, actual pdata.SpanSlice) {
+	d.diffSlice(path, expected.Len(), actual.Len(), d.ignoreOrder(spanOrder), func(d *differ, path string, i, j int) {
+		diffSpan(d, path, expected.At(i), actual.At(j))
+	})
+}
+
+func diffSpan(d *differ, path string, expected, actual pdata.Span) {
+	d.compare(path+".TraceID", expected.TraceID().HexString(), actual.TraceID().HexString())
+	d.compare(path+".SpanID", expected.SpanID().HexString(), actual.SpanID().HexString())
+	d.compare(path+".TraceState", expected.TraceState(), actual.TraceState())
+	d.compare(path+".ParentSpanID", expected.ParentSpanID().HexString(), actual.ParentSpanID().HexString())
+	d.compare(path+".Name", expected.Name(), actual.Name())
+	d.compare(path+".Kind", expected.Kind(), actual.Kind())
+	d.compareTimestamp(path+".StartTimestamp", expected.StartTimestamp(), actual.StartTimestamp())
+	d.compareTimestamp(path+".EndTimestamp", expected.EndTimestamp(), actual.EndTimestamp())
+	diffAttributeMap(d, path+".Attributes", expected.Attributes(), actual.Attributes())
+	d.compare(path+".DroppedAttributesCount", expected.DroppedAttributesCount(), actual.DroppedAttributesCount())
+	diffSpanEventSlice(d, path+".Events", expected.Events(), actual.Events())
+	d.compare(path+".DroppedEventsCount", expected.DroppedEventsCount(), actual.DroppedEventsCount())
+	diffSpanLinkSlice(d, path+".Links", expected.Links(), actual.Links())
+	d.compare(path+".DroppedLinksCount", expected.DroppedLinksCount(), actual.DroppedLinksCount())
+	diffSpanStatus(d, path+".Status", expected.Status(), actual.Status())
+}
+
+func diffSpanEventSlice(d *differ, path string, expected, actual pdata.SpanEventSlice) {
+	d.diffSlice(path, expected.Len(), actual.Len(), d.ignoreOrder(spanOrder), func(d *differ, path string, i, j int) {
+		diffSpanEvent(d, path, expected.At(i), actual.At(j))
+	})
+}
+
+func diffSpanEvent(d *differ, path string, expected, actual pdata.SpanEvent) {
+	d.compareTimestamp(path+".Timestamp", expected.Timestamp(), actual.Timestamp())
+	d.compare(path+".Name", expected.Name(), actual.Name())
+	diffAttributeMap(d, path+".Attributes", expected.Attributes(), actual.Attributes())
+	d.compare(path+".DroppedAttributesCount", expected.DroppedAttributesCount(), actual.DroppedAttributesCount())
+}
+
+func diffSpanLinkSlice(d *differ, path string, expected, actual pdata.SpanLinkSlice) {
+	d.diffSlice(path, expected.Len(), actual.Len(), d.ignoreOrder(spanOrder), func(d *differ, path string, i, j int) {
+		diffSpanLink(d, path, expected.At(i), actual.At(j))
+	})
+}
+
+func diffSpanLink(d *differ, path string, expected, actual pdata.SpanLink) {
+	d.compare(path+".TraceID", expected.TraceID().HexString(), actual.TraceID().HexString())
+	d.compare(path+".SpanID", expected.SpanID().HexString(), actual.SpanID().HexString())
+	d.compare(path+".TraceState", expected.TraceState(), actual.TraceState())
+	diffAttributeMap(d, path+".Attributes", expected.Attributes(), actual.Attributes())
+	d.compare(path+".DroppedAttributesCount", expected.DroppedAttributesCount(), actual.DroppedAttributesCount())
+}
+
+func diffSpanStatus(d *differ, path string, expected, actual pdata.SpanStatus) {
+	d.compare(path+".Code", expected.Code(), actual.Code())
+	d.compare(path+".Message", expected.Message(), actual.Message())
+}
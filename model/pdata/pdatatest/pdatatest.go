@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pdatatest compares pdata.Traces, pdata.Metrics and pdata.Logs in tests and
+// reports their differences by path, for example
+// `ResourceSpans[0].InstrumentationLibrarySpans[0].Spans[1].Attributes["http.method"]`.
+// Options ignore the order of the slices and of the attributes, the timestamps, or
+// specific attributes.
+package pdatatest
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// Difference is a difference between the expected and the actual data.
+type Difference struct {
+	// Path locates the differing value, or the element or attribute present only in
+	// one of the data. When the order of a slice is ignored, the index in the path is
+	// the one of the expected element, or of the actual element if it is unexpected.
+	Path string
+	// Expected is the expected value, nil if only the actual element or attribute exists.
+	Expected interface{}
+	// Actual is the actual value, nil if only the expected element or attribute exists.
+	Actual interface{}
+}
+
+func (d Difference) String() string {
+	switch {
+	case d.Expected == nil:
+		return fmt.Sprintf("%s: unexpected %s", d.Path, formatValue(d.Actual))
+	case d.Actual == nil:
+		return fmt.Sprintf("%s: missing %s", d.Path, formatValue(d.Expected))
+	}
+	return fmt.Sprintf("%s: expected %s, actual %s", d.Path, formatValue(d.Expected), formatValue(d.Actual))
+}
+
+// Option changes how the data are compared.
+type Option func(*options)
+
+// IgnoreResourceOrder ignores the order of the ResourceSpans, ResourceMetrics and
+// ResourceLogs.
+func IgnoreResourceOrder() Option {
+	return ignoreOrderOption(resourceOrder)
+}
+
+// IgnoreScopeOrder ignores the order of the InstrumentationLibrarySpans,
+// InstrumentationLibraryMetrics and InstrumentationLibraryLogs.
+func IgnoreScopeOrder() Option {
+	return ignoreOrderOption(scopeOrder)
+}
+
+// IgnoreSpanOrder ignores the order of the spans, and of their events and links.
+func IgnoreSpanOrder() Option {
+	return ignoreOrderOption(spanOrder)
+}
+
+// IgnoreMetricOrder ignores the order of the metrics.
+func IgnoreMetricOrder() Option {
+	return ignoreOrderOption(metricOrder)
+}
+
+// IgnoreLogRecordOrder ignores the order of the log records.
+func IgnoreLogRecordOrder() Option {
+	return ignoreOrderOption(logRecordOrder)
+}
+
+// IgnoreDataPointOrder ignores the order of the data points, and of their exemplars.
+func IgnoreDataPointOrder() Option {
+	return ignoreOrderOption(dataPointOrder)
+}
+
+// IgnoreAttributeOrder ignores the order of the attributes.
+func IgnoreAttributeOrder() Option {
+	return ignoreOrderOption(attributeOrder)
+}
+
+// IgnoreOrder ignores the order of the slices and of the attributes, except for the
+// array values and the quantiles of the summaries.
+func IgnoreOrder() Option {
+	return func(o *options) {
+		for order := range o.ignoredOrders {
+			o.ignoredOrders[order] = true
+		}
+	}
+}
+
+// IgnoreTimestamps ignores the timestamps.
+func IgnoreTimestamps() Option {
+	return func(o *options) {
+		o.ignoreTimestamps = true
+	}
+}
+
+// IgnoreAttributes ignores the attributes with the given keys, in every attribute map.
+func IgnoreAttributes(keys ...string) Option {
+	return func(o *options) {
+		for _, key := range keys {
+			o.ignoredAttributes[key] = true
+		}
+	}
+}
+
+func ignoreOrderOption(order orderKind) Option {
+	return func(o *options) {
+		o.ignoredOrders[order] = true
+	}
+}
+
+// DiffTraces returns the differences between the expected and the actual traces.
+func DiffTraces(expected, actual pdata.Traces, opts ...Option) []Difference {
+	d := newDiffer(opts)
+	diffResourceSpansSlice(d, "ResourceSpans", expected.ResourceSpans(), actual.ResourceSpans())
+	return d.diffs
+}
+
+// DiffMetrics returns the differences between the expected and the actual metrics.
+func DiffMetrics(expected, actual pdata.Metrics, opts ...Option) []Difference {
+	d := newDiffer(opts)
+	diffResourceMetricsSlice(d, "ResourceMetrics", expected.ResourceMetrics(), actual.ResourceMetrics())
+	return d.diffs
+}
+
+// DiffLogs returns the differences between the expected and the actual logs.
+func DiffLogs(expected, actual pdata.Logs, opts ...Option) []Difference {
+	d := newDiffer(opts)
+	diffResourceLogsSlice(d, "ResourceLogs", expected.ResourceLogs(), actual.ResourceLogs())
+	return d.diffs
+}
+
+// TestingT is the subset of testing.TB used to report the differences.
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+// AssertEqualTraces reports the differences between the expected and the actual
+// traces as an error of t, and returns whether there are none.
+func AssertEqualTraces(t TestingT, expected, actual pdata.Traces, opts ...Option) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	return report(t, "traces", DiffTraces(expected, actual, opts...))
+}
+
+// AssertEqualMetrics reports the differences between the expected and the actual
+// metrics as an error of t, and returns whether there are none.
+func AssertEqualMetrics(t TestingT, expected, actual pdata.Metrics, opts ...Option) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	return report(t, "metrics", DiffMetrics(expected, actual, opts...))
+}
+
+// AssertEqualLogs reports the differences between the expected and the actual logs
+// as an error of t, and returns whether there are none.
+func AssertEqualLogs(t TestingT, expected, actual pdata.Logs, opts ...Option) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	return report(t, "logs", DiffLogs(expected, actual, opts...))
+}
+
+func report(t TestingT, signal string, diffs []Difference) bool {
+	if len(diffs) == 0 {
+		return true
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s differ in %d place(s):", signal, len(diffs))
+	for _, diff := range diffs {
+		sb.WriteString("\n\t")
+		sb.WriteString(diff.String())
+	}
+	t.Errorf("%s", sb.String())
+	return false
+}
+
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []string:
+		return fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdatatest
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func generateTraces() pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "test")
+	ils := rs.InstrumentationLibrarySpans().AppendEmpty()
+	ils.InstrumentationLibrary().SetName("lib")
+	for i := 0; i < 2; i++ {
+		span := ils.Spans().AppendEmpty()
+		span.SetName(fmt.Sprintf("span-%d", i))
+		span.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, byte(i)}))
+		span.SetSpanID(pdata.NewSpanID([8]byte{1, 2, byte(i)}))
+		span.SetStartTimestamp(pdata.Timestamp(1000 + i))
+		span.SetEndTimestamp(pdata.Timestamp(2000 + i))
+		span.Attributes().InsertString("http.method", "GET")
+		span.Attributes().InsertInt("http.status_code", 200)
+	}
+	return td
+}
+
+func generateMetrics() pdata.Metrics {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "test")
+	ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+	gauge := ilm.Metrics().AppendEmpty()
+	gauge.SetName("gauge")
+	gauge.SetDataType(pdata.MetricDataTypeGauge)
+	for i := 0; i < 2; i++ {
+		dp := gauge.Gauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(pdata.Timestamp(1000 + i))
+		dp.SetDoubleVal(float64(i))
+		dp.Attributes().InsertInt("index", int64(i))
+	}
+	histogram := ilm.Metrics().AppendEmpty()
+	histogram.SetName("histogram")
+	histogram.SetDataType(pdata.MetricDataTypeHistogram)
+	dp := histogram.Histogram().DataPoints().AppendEmpty()
+	dp.SetCount(3)
+	dp.SetBucketCounts([]uint64{1, 2})
+	dp.SetExplicitBounds([]float64{10})
+	return md
+}
+
+func generateLogs() pdata.Logs {
+	ld := pdata.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.SetName("log")
+	lr.Body().SetStringVal("hello")
+	return ld
+}
+
+func TestDiffTracesEqual(t *testing.T) {
+	assert.Empty(t, DiffTraces(generateTraces(), generateTraces()))
+	assert.True(t, AssertEqualTraces(t, generateTraces(), generateTraces()))
+}
+
+func TestDiffTracesValues(t *testing.T) {
+	actual := generateTraces()
+	span := actual.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(1)
+	span.SetName("other")
+	span.SetSpanID(pdata.NewSpanID([8]byte{9}))
+	span.Attributes().UpsertInt("http.method", 1)
+	span.Attributes().UpdateInt("http.status_code", 500)
+	span.Attributes().InsertBool("error", true)
+	actual.ResourceSpans().At(0).Resource().Attributes().Delete("service.name")
+
+	const spanPath = "ResourceSpans[0].InstrumentationLibrarySpans[0].Spans[1]"
+	assert.Equal(t, []Difference{
+		{Path: `ResourceSpans[0].Resource.Attributes["service.name"]`, Expected: "test"},
+		{Path: spanPath + ".SpanID", Expected: "0102010000000000", Actual: "0900000000000000"},
+		{Path: spanPath + ".Name", Expected: "span-1", Actual: "other"},
+		{Path: spanPath + `.Attributes["http.method"].Type`, Expected: pdata.AttributeValueTypeString, Actual: pdata.AttributeValueTypeInt},
+		{Path: spanPath + `.Attributes["http.status_code"]`, Expected: int64(200), Actual: int64(500)},
+		{Path: spanPath + `.Attributes["error"]`, Actual: "true"},
+	}, DiffTraces(generateTraces(), actual))
+}
+
+func TestDiffTracesSpanOrder(t *testing.T) {
+	actual := generateTraces()
+	spans := actual.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans()
+	spans.Sort(func(a, b pdata.Span) bool { return a.Name() > b.Name() })
+
+	assert.NotEmpty(t, DiffTraces(generateTraces(), actual))
+	assert.Empty(t, DiffTraces(generateTraces(), actual, IgnoreSpanOrder()))
+	assert.Empty(t, DiffTraces(generateTraces(), actual, IgnoreOrder()))
+
+	// The unmatched spans are diffed in order.
+	spans.At(0).SetName("other")
+	assert.Equal(t, []Difference{
+		{Path: "ResourceSpans[0].InstrumentationLibrarySpans[0].Spans[1].Name", Expected: "span-1", Actual: "other"},
+	}, DiffTraces(generateTraces(), actual, IgnoreSpanOrder()))
+}
+
+func TestDiffTracesMissingAndUnexpectedElements(t *testing.T) {
+	actual := generateTraces()
+	spans := actual.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans()
+	spans.RemoveIf(func(span pdata.Span) bool { return span.Name() == "span-1" })
+	assert.Equal(t, []Difference{
+		{Path: "ResourceSpans[0].InstrumentationLibrarySpans[0].Spans[1]", Expected: element{}},
+	}, DiffTraces(generateTraces(), actual))
+
+	assert.Equal(t, []Difference{
+		{Path: "ResourceSpans[0].InstrumentationLibrarySpans[0].Spans[1]", Actual: element{}},
+	}, DiffTraces(actual, generateTraces(), IgnoreSpanOrder()))
+}
+
+func TestDiffTracesAttributeOrder(t *testing.T) {
+	actual := generateTraces()
+	attrs := actual.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes()
+	attrs.Delete("http.method")
+	attrs.InsertString("http.method", "GET")
+
+	assert.Equal(t, []Difference{
+		{
+			Path:     "ResourceSpans[0].InstrumentationLibrarySpans[0].Spans[0].Attributes",
+			Expected: []string{"http.method", "http.status_code"},
+			Actual:   []string{"http.status_code", "http.method"},
+		},
+	}, DiffTraces(generateTraces(), actual))
+	assert.Empty(t, DiffTraces(generateTraces(), actual, IgnoreAttributeOrder()))
+}
+
+func TestDiffTracesIgnoreTimestampsAndAttributes(t *testing.T) {
+	actual := generateTraces()
+	span := actual.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	span.SetStartTimestamp(5)
+	span.SetEndTimestamp(6)
+	span.Attributes().UpsertString("http.method", "POST")
+	span.Attributes().InsertString("host.name", "localhost")
+
+	assert.Len(t, DiffTraces(generateTraces(), actual), 4)
+	assert.Len(t, DiffTraces(generateTraces(), actual, IgnoreTimestamps()), 2)
+	assert.Empty(t, DiffTraces(generateTraces(), actual, IgnoreTimestamps(), IgnoreAttributes("http.method", "host.name")))
+}
+
+func TestDiffMetrics(t *testing.T) {
+	assert.Empty(t, DiffMetrics(generateMetrics(), generateMetrics()))
+
+	actual := generateMetrics()
+	metrics := actual.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	metrics.At(0).Gauge().DataPoints().At(1).SetIntVal(1)
+	metrics.At(1).Histogram().DataPoints().At(0).SetBucketCounts([]uint64{1, 3})
+	const ilmPath = "ResourceMetrics[0].InstrumentationLibraryMetrics[0]"
+	assert.Equal(t, []Difference{
+		{Path: ilmPath + ".Metrics[0].Gauge.DataPoints[1].Type", Expected: pdata.MetricValueTypeDouble, Actual: pdata.MetricValueTypeInt},
+		{Path: ilmPath + ".Metrics[1].Histogram.DataPoints[0].BucketCounts", Expected: []uint64{1, 2}, Actual: []uint64{1, 3}},
+	}, DiffMetrics(generateMetrics(), actual))
+
+	actual = generateMetrics()
+	metrics = actual.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	metrics.At(1).SetDataType(pdata.MetricDataTypeSum)
+	assert.Equal(t, []Difference{
+		{Path: ilmPath + ".Metrics[1].DataType", Expected: pdata.MetricDataTypeHistogram, Actual: pdata.MetricDataTypeSum},
+	}, DiffMetrics(generateMetrics(), actual))
+}
+
+func TestDiffMetricsOrder(t *testing.T) {
+	actual := generateMetrics()
+	metrics := actual.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	metrics.Sort(func(a, b pdata.Metric) bool { return a.Name() > b.Name() })
+	metrics.At(1).Gauge().DataPoints().Sort(func(a, b pdata.NumberDataPoint) bool { return a.DoubleVal() > b.DoubleVal() })
+
+	assert.NotEmpty(t, DiffMetrics(generateMetrics(), actual, IgnoreMetricOrder()))
+	assert.NotEmpty(t, DiffMetrics(generateMetrics(), actual, IgnoreDataPointOrder()))
+	assert.Empty(t, DiffMetrics(generateMetrics(), actual, IgnoreMetricOrder(), IgnoreDataPointOrder()))
+}
+
+func TestDiffMetricsValues(t *testing.T) {
+	expected := generateMetrics()
+	actual := generateMetrics()
+	for _, md := range []pdata.Metrics{expected, actual} {
+		md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).SetDoubleVal(math.NaN())
+	}
+	expected.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(1).Histogram().DataPoints().At(0).SetExplicitBounds(nil)
+	actual.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(1).Histogram().DataPoints().At(0).SetExplicitBounds([]float64{})
+	assert.Empty(t, DiffMetrics(expected, actual))
+}
+
+func TestDiffLogs(t *testing.T) {
+	assert.Empty(t, DiffLogs(generateLogs(), generateLogs()))
+
+	actual := generateLogs()
+	actual.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Body().SetStringVal("bye")
+	assert.Equal(t, []Difference{
+		{Path: "ResourceLogs[0].InstrumentationLibraryLogs[0].Logs[0].Body", Expected: "hello", Actual: "bye"},
+	}, DiffLogs(generateLogs(), actual))
+}
+
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertEqual(t *testing.T) {
+	rt := &recordingT{}
+	actual := generateLogs()
+	actual.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).SetName("other")
+	actual.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().AppendEmpty()
+	assert.False(t, AssertEqualLogs(rt, generateLogs(), actual))
+	assert.Equal(t, []string{"logs differ in 2 place(s):\n" +
+		"\tResourceLogs[0].InstrumentationLibraryLogs[0].Logs[0].Name: expected \"log\", actual \"other\"\n" +
+		"\tResourceLogs[0].InstrumentationLibraryLogs[0].Logs[1]: unexpected element"}, rt.errors)
+
+	rt = &recordingT{}
+	assert.True(t, AssertEqualMetrics(rt, generateMetrics(), generateMetrics()))
+	assert.Empty(t, rt.errors)
+}
+
+func TestDifferenceString(t *testing.T) {
+	assert.Equal(t, `a.B: expected "x", actual "y"`, Difference{Path: "a.B", Expected: "x", Actual: "y"}.String())
+	assert.Equal(t, `a["k"]: missing "v"`, Difference{Path: `a["k"]`, Expected: "v"}.String())
+	assert.Equal(t, "a[1]: unexpected element", Difference{Path: "a[1]", Actual: element{}}.String())
+	assert.Equal(t, "a.Count: expected 1, actual 2", Difference{Path: "a.Count", Expected: uint64(1), Actual: uint64(2)}.String())
+}
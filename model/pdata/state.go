@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+// sharedState is shared by all the wrappers of the same data, e.g. a Traces and all the
+// ResourceSpans, Span or AttributeMap obtained from it, and tells whether they can be modified.
+type sharedState struct {
+	readOnly bool
+}
+
+func newSharedState() *sharedState {
+	return &sharedState{}
+}
+
+// assertMutable panics if the data is read-only. It is called by all the functions
+// modifying the data, so that components modifying shared data are caught in tests.
+func (s *sharedState) assertMutable() {
+	if s.readOnly {
+		panic("invalid access to shared data, the data is read-only")
+	}
+}
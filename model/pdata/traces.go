@@ -42,18 +42,19 @@ type TracesSizer interface {
 
 // Traces is the top-level struct that is propagated through the traces pipeline.
 type Traces struct {
-	orig *otlpcollectortrace.ExportTraceServiceRequest
+	orig  *otlpcollectortrace.ExportTraceServiceRequest
+	state *sharedState
 }
 
 // NewTraces creates a new Traces.
 func NewTraces() Traces {
-	return Traces{orig: &otlpcollectortrace.ExportTraceServiceRequest{}}
+	return Traces{orig: &otlpcollectortrace.ExportTraceServiceRequest{}, state: newSharedState()}
 }
 
 // TracesFromInternalRep creates Traces from the internal representation.
 // Should not be used outside this module.
 func TracesFromInternalRep(wrapper internal.TracesWrapper) Traces {
-	return Traces{orig: internal.TracesToOtlp(wrapper)}
+	return Traces{orig: internal.TracesToOtlp(wrapper), state: newSharedState()}
 }
 
 // InternalRep returns internal representation of the Traces.
@@ -69,6 +70,28 @@ func (td Traces) Clone() Traces {
 	return cloneTd
 }
 
+// MarkReadOnly marks the Traces as shared between multiple components, the data cannot be
+// modified anymore: all the functions modifying the Traces, or any struct obtained from it,
+// panic afterwards. It must be called before the data is shared and cannot be undone.
+func (td Traces) MarkReadOnly() {
+	td.state.readOnly = true
+}
+
+// IsReadOnly returns true if the Traces is marked as read-only.
+func (td Traces) IsReadOnly() bool {
+	return td.state.readOnly
+}
+
+// Mutable returns the Traces itself if it can be modified, otherwise a copy of it that can be.
+// Components modifying the data they consume call it before modifying it, so that read-only
+// data shared with other components is only copied by the ones modifying it.
+func (td Traces) Mutable() Traces {
+	if !td.state.readOnly {
+		return td
+	}
+	return td.Clone()
+}
+
 // SpanCount calculates the total number of spans.
 func (td Traces) SpanCount() int {
 	spanCount := 0
@@ -85,7 +108,7 @@ func (td Traces) SpanCount() int {
 
 // ResourceSpans returns the ResourceSpansSlice associated with this Metrics.
 func (td Traces) ResourceSpans() ResourceSpansSlice {
-	return newResourceSpansSlice(&td.orig.ResourceSpans)
+	return newResourceSpansSlice(&td.orig.ResourceSpans, td.state)
 }
 
 // TraceState is a string representing the tracestate in w3c-trace-context format: https://www.w3.org/TR/trace-context/#tracestate-header
@@ -141,6 +164,7 @@ func (sc StatusCode) String() string { return otlptrace.Status_StatusCode(sc).St
 
 // SetCode replaces the code associated with this SpanStatus.
 func (ms SpanStatus) SetCode(v StatusCode) {
+	ms.state.assertMutable()
 	ms.orig.Code = otlptrace.Status_StatusCode(v)
 
 	// According to OTLP spec we also need to set the deprecated_code field as we are a new sender:
@@ -152,6 +152,29 @@ func TestTracesClone(t *testing.T) {
 	assert.EqualValues(t, traces, traces.Clone())
 }
 
+func TestTracesReadOnly(t *testing.T) {
+	traces := NewTraces()
+	fillTestResourceSpansSlice(traces.ResourceSpans())
+	span := traces.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	assert.False(t, traces.IsReadOnly())
+	assert.Equal(t, traces, traces.Mutable())
+
+	traces.MarkReadOnly()
+	assert.True(t, traces.IsReadOnly())
+	assert.Panics(t, func() { span.SetName("new_name") })
+	assert.Panics(t, func() { span.Status().SetCode(StatusCodeError) })
+	assert.Panics(t, func() { span.Attributes().UpsertString("k", "v") })
+	assert.Panics(t, func() { traces.ResourceSpans().AppendEmpty() })
+	assert.Panics(t, func() { NewResourceSpansSlice().CopyTo(traces.ResourceSpans()) })
+	assert.Equal(t, 7*7*7, traces.SpanCount())
+
+	mutable := traces.Mutable()
+	assert.False(t, mutable.IsReadOnly())
+	mutable.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).SetName("new_name")
+	assert.Equal(t, "new_name", mutable.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Name())
+	assert.NotEqual(t, "new_name", span.Name())
+}
+
 func BenchmarkTracesClone(b *testing.B) {
 	traces := NewTraces()
 	fillTestResourceSpansSlice(traces.ResourceSpans())
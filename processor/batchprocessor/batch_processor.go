@@ -27,6 +27,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/pdatasplit"
 	"go.opentelemetry.io/collector/model/otlp"
 	"go.opentelemetry.io/collector/model/pdata"
 )
@@ -50,8 +51,9 @@ type batchProcessor struct {
 	newItem chan interface{}
 	batch   batch
 
-	shutdownC  chan struct{}
-	goroutines sync.WaitGroup
+	shutdownC    chan struct{}
+	shutdownOnce sync.Once
+	goroutines   sync.WaitGroup
 
 	telemetryLevel configtelemetry.Level
 }
@@ -106,7 +108,7 @@ func (bp *batchProcessor) Start(context.Context, component.Host) error {
 
 // Shutdown is invoked during service shutdown.
 func (bp *batchProcessor) Shutdown(context.Context) error {
-	close(bp.shutdownC)
+	bp.shutdownOnce.Do(func() { close(bp.shutdownC) })
 
 	// Wait until all goroutines are done.
 	bp.goroutines.Wait()
@@ -188,20 +190,21 @@ func (bp *batchProcessor) sendItems(triggerMeasure *stats.Int64Measure) {
 
 // ConsumeTraces implements TracesProcessor
 func (bp *batchProcessor) ConsumeTraces(_ context.Context, td pdata.Traces) error {
-	bp.newItem <- td
+	// The data is moved to the batch, it is copied if it is shared with other pipelines.
+	bp.newItem <- td.Mutable()
 	return nil
 }
 
 // ConsumeMetrics implements MetricsProcessor
 func (bp *batchProcessor) ConsumeMetrics(_ context.Context, md pdata.Metrics) error {
 	// First thing is convert into a different internal format
-	bp.newItem <- md
+	bp.newItem <- md.Mutable()
 	return nil
 }
 
 // ConsumeLogs implements LogsProcessor
 func (bp *batchProcessor) ConsumeLogs(_ context.Context, ld pdata.Logs) error {
-	bp.newItem <- ld
+	bp.newItem <- ld.Mutable()
 	return nil
 }
 
@@ -246,7 +249,7 @@ func (bt *batchTraces) add(item interface{}) {
 func (bt *batchTraces) export(ctx context.Context, sendBatchMaxSize int) error {
 	var req pdata.Traces
 	if sendBatchMaxSize > 0 && bt.itemCount() > sendBatchMaxSize {
-		req = splitTraces(sendBatchMaxSize, bt.traceData)
+		req = pdatasplit.Traces(sendBatchMaxSize, bt.traceData)
 		bt.spanCount -= sendBatchMaxSize
 	} else {
 		req = bt.traceData
@@ -278,7 +281,7 @@ func newBatchMetrics(nextConsumer consumer.Metrics) *batchMetrics {
 func (bm *batchMetrics) export(ctx context.Context, sendBatchMaxSize int) error {
 	var req pdata.Metrics
 	if sendBatchMaxSize > 0 && bm.dataPointCount > sendBatchMaxSize {
-		req = splitMetrics(sendBatchMaxSize, bm.metricData)
+		req = pdatasplit.Metrics(sendBatchMaxSize, bm.metricData)
 		bm.dataPointCount -= sendBatchMaxSize
 	} else {
 		req = bm.metricData
@@ -321,7 +324,7 @@ func newBatchLogs(nextConsumer consumer.Logs) *batchLogs {
 func (bl *batchLogs) export(ctx context.Context, sendBatchMaxSize int) error {
 	var req pdata.Logs
 	if sendBatchMaxSize > 0 && bl.logCount > sendBatchMaxSize {
-		req = splitLogs(sendBatchMaxSize, bl.logData)
+		req = pdatasplit.Logs(sendBatchMaxSize, bl.logData)
 		bl.logCount -= sendBatchMaxSize
 	} else {
 		req = bl.logData
@@ -258,6 +258,23 @@ func TestBatchProcessorTraceSendWhenClosing(t *testing.T) {
 	require.Equal(t, 1, len(sink.AllTraces()))
 }
 
+func TestBatchProcessorReadOnlyData(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	sink := new(consumertest.TracesSink)
+	batcher, err := newBatchTracesProcessor(componenttest.NewNopProcessorCreateSettings(), sink, cfg, configtelemetry.LevelDetailed)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := testdata.GenerateTracesManySpansSameResource(10)
+	td.MarkReadOnly()
+	assert.NoError(t, batcher.ConsumeTraces(context.Background(), td))
+	assert.NoError(t, batcher.ConsumeTraces(context.Background(), td))
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	require.Equal(t, 20, sink.SpanCount())
+	require.Equal(t, 10, td.SpanCount())
+}
+
 func TestBatchMetricProcessor_ReceivingData(t *testing.T) {
 	// Instantiate the batch processor with low config values to test data
 	// gets sent through the processor.
@@ -790,3 +807,8 @@ func TestShutdown(t *testing.T) {
 	factory := NewFactory()
 	componenttest.VerifyProcessorShutdown(t, factory, factory.CreateDefaultConfig())
 }
+
+func TestConformance(t *testing.T) {
+	factory := NewFactory()
+	componenttest.VerifyProcessorConformance(t, factory, factory.CreateDefaultConfig())
+}
@@ -59,6 +59,10 @@ func NewLogsProcessor(
 	logsConsumer, err := consumerhelper.NewLogs(func(ctx context.Context, ld pdata.Logs) error {
 		span := trace.SpanFromContext(ctx)
 		span.AddEvent("Start processing.", eventOptions)
+		if bs.capabilities.MutatesData {
+			// The data may be shared with other pipelines, it is only copied if it is read-only.
+			ld = ld.Mutable()
+		}
 		var err error
 		ld, err = logsFunc(ctx, ld)
 		span.AddEvent("End processing.", eventOptions)
@@ -59,6 +59,10 @@ func NewMetricsProcessor(
 	metricsConsumer, err := consumerhelper.NewMetrics(func(ctx context.Context, md pdata.Metrics) error {
 		span := trace.SpanFromContext(ctx)
 		span.AddEvent("Start processing.", eventOptions)
+		if bs.capabilities.MutatesData {
+			// The data may be shared with other pipelines, it is only copied if it is read-only.
+			md = md.Mutable()
+		}
 		var err error
 		md, err = metricsFunc(ctx, md)
 		span.AddEvent("End processing.", eventOptions)
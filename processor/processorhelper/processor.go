@@ -53,27 +53,30 @@ func WithShutdown(shutdown componenthelper.ShutdownFunc) Option {
 
 // WithCapabilities overrides the default GetCapabilities function for an processor.
 // The default GetCapabilities function returns mutable capabilities.
+// Processors mutating the data get a copy of it if it is read-only, see pdata.Traces.Mutable.
 func WithCapabilities(capabilities consumer.Capabilities) Option {
 	return func(o *baseSettings) {
-		o.consumerOptions = append(o.consumerOptions, consumerhelper.WithCapabilities(capabilities))
+		o.capabilities = capabilities
 	}
 }
 
 type baseSettings struct {
 	componentOptions []componenthelper.Option
 	consumerOptions  []consumerhelper.Option
+	capabilities     consumer.Capabilities
 }
 
 // fromOptions returns the internal settings starting from the default and applying all options.
 func fromOptions(options []Option) *baseSettings {
 	// Start from the default options:
 	opts := &baseSettings{
-		consumerOptions: []consumerhelper.Option{consumerhelper.WithCapabilities(consumer.Capabilities{MutatesData: true})},
+		capabilities: consumer.Capabilities{MutatesData: true},
 	}
 
 	for _, op := range options {
 		op(opts)
 	}
+	opts.consumerOptions = append(opts.consumerOptions, consumerhelper.WithCapabilities(opts.capabilities))
 
 	return opts
 }
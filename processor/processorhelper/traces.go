@@ -59,6 +59,10 @@ func NewTracesProcessor(
 	traceConsumer, err := consumerhelper.NewTraces(func(ctx context.Context, td pdata.Traces) error {
 		span := trace.SpanFromContext(ctx)
 		span.AddEvent("Start processing.", eventOptions)
+		if bs.capabilities.MutatesData {
+			// The data may be shared with other pipelines, it is only copied if it is read-only.
+			td = td.Mutable()
+		}
 		var err error
 		td, err = tracesFunc(ctx, td)
 		span.AddEvent("End processing.", eventOptions)
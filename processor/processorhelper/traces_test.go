@@ -77,6 +77,31 @@ func TestNewTracesProcessor_ProcessTracesErrSkipProcessingData(t *testing.T) {
 	assert.Equal(t, nil, tp.ConsumeTraces(context.Background(), pdata.NewTraces()))
 }
 
+func TestNewTracesProcessor_ReadOnlyData(t *testing.T) {
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty()
+	td.MarkReadOnly()
+
+	// Mutating processors get a copy of the read-only data.
+	sink := new(consumertest.TracesSink)
+	tp, err := NewTracesProcessor(&testTracesCfg, sink, func(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+		td.ResourceSpans().AppendEmpty()
+		return td, nil
+	})
+	require.NoError(t, err)
+	assert.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, 2, sink.AllTraces()[0].ResourceSpans().Len())
+	assert.Equal(t, 1, td.ResourceSpans().Len())
+
+	// Other processors get the read-only data.
+	sink.Reset()
+	tp, err = NewTracesProcessor(&testTracesCfg, sink, newTestTProcessor(nil),
+		WithCapabilities(consumer.Capabilities{MutatesData: false}))
+	require.NoError(t, err)
+	assert.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	assert.True(t, sink.AllTraces()[0].IsReadOnly())
+}
+
 func newTestTProcessor(retError error) ProcessTracesFunc {
 	return func(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
 		return td, retError
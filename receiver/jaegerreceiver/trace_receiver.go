@@ -437,6 +437,11 @@ func (jr *jReceiver) startCollector(host component.Host) error {
 	}
 
 	if jr.collectorHTTPEnabled() {
+		authenticator, err := jr.config.CollectorHTTPSettings.ServerAuthenticator(host.GetExtensions())
+		if err != nil {
+			return err
+		}
+
 		cln, cerr := jr.config.CollectorHTTPSettings.ToListener()
 		if cerr != nil {
 			return fmt.Errorf("failed to bind to Collector address %q: %v",
@@ -445,7 +450,7 @@ func (jr *jReceiver) startCollector(host component.Host) error {
 
 		nr := mux.NewRouter()
 		nr.HandleFunc("/api/traces", jr.HandleThriftHTTPBatch).Methods(http.MethodPost)
-		jr.collectorServer = jr.config.CollectorHTTPSettings.ToServer(nr)
+		jr.collectorServer = jr.config.CollectorHTTPSettings.ToServer(nr, confighttp.WithAuthenticator(authenticator))
 		jr.goroutines.Add(1)
 		go func() {
 			defer jr.goroutines.Done()
@@ -15,6 +15,8 @@
 package kafkareceiver
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/kafkaexporter"
 )
@@ -40,11 +42,60 @@ type Config struct {
 	Metadata kafkaexporter.Metadata `mapstructure:"metadata"`
 
 	Authentication kafkaexporter.Authentication `mapstructure:"auth"`
+
+	// InitialOffset is the offset to start consuming from when the consumer group
+	// has no committed offset: "latest" or "earliest" (default "latest").
+	InitialOffset string `mapstructure:"initial_offset"`
+
+	// DeliveryGuarantee controls when the offset of a message is marked as consumed:
+	// "at_most_once" marks it as soon as it is received, "at_least_once" marks it only
+	// after the next consumer successfully processed it (default "at_most_once").
+	DeliveryGuarantee string `mapstructure:"delivery_guarantee"`
+
+	// OnUnmarshalError controls what happens to messages that cannot be unmarshaled:
+	// "skip" drops and counts them, "halt" stops consuming the partition (default "skip").
+	OnUnmarshalError string `mapstructure:"on_unmarshal_error"`
+
+	// HeaderExtraction defines the Kafka message headers added as resource attributes.
+	HeaderExtraction HeaderExtraction `mapstructure:"header_extraction"`
 }
 
+// HeaderExtraction defines the Kafka message headers added as resource attributes.
+type HeaderExtraction struct {
+	// Headers is the list of header keys to extract. Every header found in a message
+	// is added to all its resources as the "kafka.header.<key>" attribute.
+	Headers []string `mapstructure:"headers"`
+}
+
+const (
+	offsetLatest   = "latest"
+	offsetEarliest = "earliest"
+
+	atMostOnce  = "at_most_once"
+	atLeastOnce = "at_least_once"
+
+	unmarshalErrorSkip = "skip"
+	unmarshalErrorHalt = "halt"
+)
+
 var _ config.Receiver = (*Config)(nil)
 
 // Validate checks the receiver configuration is valid
 func (cfg *Config) Validate() error {
+	switch cfg.InitialOffset {
+	case "", offsetLatest, offsetEarliest:
+	default:
+		return fmt.Errorf("initial_offset should be one of 'latest' or 'earliest'. configured value %v", cfg.InitialOffset)
+	}
+	switch cfg.DeliveryGuarantee {
+	case "", atMostOnce, atLeastOnce:
+	default:
+		return fmt.Errorf("delivery_guarantee should be one of 'at_most_once' or 'at_least_once'. configured value %v", cfg.DeliveryGuarantee)
+	}
+	switch cfg.OnUnmarshalError {
+	case "", unmarshalErrorSkip, unmarshalErrorHalt:
+	default:
+		return fmt.Errorf("on_unmarshal_error should be one of 'skip' or 'halt'. configured value %v", cfg.OnUnmarshalError)
+	}
 	return nil
 }
@@ -63,5 +63,59 @@ func TestLoadConfig(t *testing.T) {
 				Backoff: time.Second * 5,
 			},
 		},
+		InitialOffset:     "earliest",
+		DeliveryGuarantee: "at_least_once",
+		OnUnmarshalError:  "halt",
+		HeaderExtraction: HeaderExtraction{
+			Headers: []string{"tenant"},
+		},
 	}, r)
 }
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		errMsg string
+	}{
+		{
+			name:   "default",
+			modify: func(*Config) {},
+		},
+		{
+			name: "empty",
+			modify: func(cfg *Config) {
+				cfg.InitialOffset = ""
+				cfg.DeliveryGuarantee = ""
+				cfg.OnUnmarshalError = ""
+			},
+		},
+		{
+			name:   "invalid_initial_offset",
+			modify: func(cfg *Config) { cfg.InitialOffset = "oldest" },
+			errMsg: "initial_offset should be one of 'latest' or 'earliest'. configured value oldest",
+		},
+		{
+			name:   "invalid_delivery_guarantee",
+			modify: func(cfg *Config) { cfg.DeliveryGuarantee = "exactly_once" },
+			errMsg: "delivery_guarantee should be one of 'at_most_once' or 'at_least_once'. configured value exactly_once",
+		},
+		{
+			name:   "invalid_on_unmarshal_error",
+			modify: func(cfg *Config) { cfg.OnUnmarshalError = "retry" },
+			errMsg: "on_unmarshal_error should be one of 'skip' or 'halt'. configured value retry",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
@@ -106,6 +106,9 @@ func createDefaultConfig() config.Receiver {
 				Backoff: defaultMetadataRetryBackoff,
 			},
 		},
+		InitialOffset:     offsetLatest,
+		DeliveryGuarantee: atMostOnce,
+		OnUnmarshalError:  unmarshalErrorSkip,
 	}
 }
 
@@ -17,6 +17,7 @@ package kafkareceiver
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/Shopify/sarama"
@@ -28,6 +29,7 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter/kafkaexporter"
+	"go.opentelemetry.io/collector/model/pdata"
 	"go.opentelemetry.io/collector/obsreport"
 )
 
@@ -45,6 +47,7 @@ type kafkaTracesConsumer struct {
 	topics            []string
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       TracesUnmarshaler
+	handling          messageHandling
 
 	logger *zap.Logger
 }
@@ -57,6 +60,7 @@ type kafkaMetricsConsumer struct {
 	topics            []string
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       MetricsUnmarshaler
+	handling          messageHandling
 
 	logger *zap.Logger
 }
@@ -69,6 +73,7 @@ type kafkaLogsConsumer struct {
 	topics            []string
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       LogsUnmarshaler
+	handling          messageHandling
 
 	logger *zap.Logger
 }
@@ -77,17 +82,17 @@ var _ component.Receiver = (*kafkaTracesConsumer)(nil)
 var _ component.Receiver = (*kafkaMetricsConsumer)(nil)
 var _ component.Receiver = (*kafkaLogsConsumer)(nil)
 
-func newTracesReceiver(config Config, set component.ReceiverCreateSettings, unmarshalers map[string]TracesUnmarshaler, nextConsumer consumer.Traces) (*kafkaTracesConsumer, error) {
-	unmarshaler := unmarshalers[config.Encoding]
-	if unmarshaler == nil {
-		return nil, errUnrecognizedEncoding
-	}
-
+func newSaramaConfig(config Config) (*sarama.Config, error) {
 	c := sarama.NewConfig()
 	c.ClientID = config.ClientID
 	c.Metadata.Full = config.Metadata.Full
 	c.Metadata.Retry.Max = config.Metadata.Retry.Max
 	c.Metadata.Retry.Backoff = config.Metadata.Retry.Backoff
+	if config.InitialOffset == offsetEarliest {
+		c.Consumer.Offsets.Initial = sarama.OffsetOldest
+	} else {
+		c.Consumer.Offsets.Initial = sarama.OffsetNewest
+	}
 	if config.ProtocolVersion != "" {
 		version, err := sarama.ParseKafkaVersion(config.ProtocolVersion)
 		if err != nil {
@@ -98,6 +103,19 @@ func newTracesReceiver(config Config, set component.ReceiverCreateSettings, unma
 	if err := kafkaexporter.ConfigureAuthentication(config.Authentication, c); err != nil {
 		return nil, err
 	}
+	return c, nil
+}
+
+func newTracesReceiver(config Config, set component.ReceiverCreateSettings, unmarshalers map[string]TracesUnmarshaler, nextConsumer consumer.Traces) (*kafkaTracesConsumer, error) {
+	unmarshaler := unmarshalers[config.Encoding]
+	if unmarshaler == nil {
+		return nil, errUnrecognizedEncoding
+	}
+
+	c, err := newSaramaConfig(config)
+	if err != nil {
+		return nil, err
+	}
 	client, err := sarama.NewConsumerGroup(config.Brokers, config.GroupID, c)
 	if err != nil {
 		return nil, err
@@ -108,6 +126,7 @@ func newTracesReceiver(config Config, set component.ReceiverCreateSettings, unma
 		topics:        []string{config.Topic},
 		nextConsumer:  nextConsumer,
 		unmarshaler:   unmarshaler,
+		handling:      newMessageHandling(config),
 		logger:        set.Logger,
 	}, nil
 }
@@ -120,6 +139,7 @@ func (c *kafkaTracesConsumer) Start(context.Context, component.Host) error {
 		logger:       c.logger,
 		unmarshaler:  c.unmarshaler,
 		nextConsumer: c.nextConsumer,
+		handling:     c.handling,
 		ready:        make(chan bool),
 		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: c.id, Transport: transport}),
 	}
@@ -155,19 +175,8 @@ func newMetricsReceiver(config Config, set component.ReceiverCreateSettings, unm
 		return nil, errUnrecognizedEncoding
 	}
 
-	c := sarama.NewConfig()
-	c.ClientID = config.ClientID
-	c.Metadata.Full = config.Metadata.Full
-	c.Metadata.Retry.Max = config.Metadata.Retry.Max
-	c.Metadata.Retry.Backoff = config.Metadata.Retry.Backoff
-	if config.ProtocolVersion != "" {
-		version, err := sarama.ParseKafkaVersion(config.ProtocolVersion)
-		if err != nil {
-			return nil, err
-		}
-		c.Version = version
-	}
-	if err := kafkaexporter.ConfigureAuthentication(config.Authentication, c); err != nil {
+	c, err := newSaramaConfig(config)
+	if err != nil {
 		return nil, err
 	}
 	client, err := sarama.NewConsumerGroup(config.Brokers, config.GroupID, c)
@@ -180,6 +189,7 @@ func newMetricsReceiver(config Config, set component.ReceiverCreateSettings, unm
 		topics:        []string{config.Topic},
 		nextConsumer:  nextConsumer,
 		unmarshaler:   unmarshaler,
+		handling:      newMessageHandling(config),
 		logger:        set.Logger,
 	}, nil
 }
@@ -192,6 +202,7 @@ func (c *kafkaMetricsConsumer) Start(context.Context, component.Host) error {
 		logger:       c.logger,
 		unmarshaler:  c.unmarshaler,
 		nextConsumer: c.nextConsumer,
+		handling:     c.handling,
 		ready:        make(chan bool),
 		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: c.id, Transport: transport}),
 	}
@@ -222,23 +233,19 @@ func (c *kafkaMetricsConsumer) Shutdown(context.Context) error {
 
 func newLogsReceiver(config Config, set component.ReceiverCreateSettings, unmarshalers map[string]LogsUnmarshaler, nextConsumer consumer.Logs) (*kafkaLogsConsumer, error) {
 	unmarshaler := unmarshalers[config.Encoding]
-	if unmarshaler == nil {
-		return nil, errUnrecognizedEncoding
-	}
-
-	c := sarama.NewConfig()
-	c.ClientID = config.ClientID
-	c.Metadata.Full = config.Metadata.Full
-	c.Metadata.Retry.Max = config.Metadata.Retry.Max
-	c.Metadata.Retry.Backoff = config.Metadata.Retry.Backoff
-	if config.ProtocolVersion != "" {
-		version, err := sarama.ParseKafkaVersion(config.ProtocolVersion)
+	if unmarshaler == nil && strings.HasPrefix(config.Encoding, textEncodingPrefix) {
+		text, err := newTextLogsUnmarshaler(config.Encoding)
 		if err != nil {
 			return nil, err
 		}
-		c.Version = version
+		unmarshaler = text
 	}
-	if err := kafkaexporter.ConfigureAuthentication(config.Authentication, c); err != nil {
+	if unmarshaler == nil {
+		return nil, errUnrecognizedEncoding
+	}
+
+	c, err := newSaramaConfig(config)
+	if err != nil {
 		return nil, err
 	}
 	client, err := sarama.NewConsumerGroup(config.Brokers, config.GroupID, c)
@@ -251,6 +258,7 @@ func newLogsReceiver(config Config, set component.ReceiverCreateSettings, unmars
 		topics:        []string{config.Topic},
 		nextConsumer:  nextConsumer,
 		unmarshaler:   unmarshaler,
+		handling:      newMessageHandling(config),
 		logger:        set.Logger,
 	}, nil
 }
@@ -263,6 +271,7 @@ func (c *kafkaLogsConsumer) Start(context.Context, component.Host) error {
 		logger:       c.logger,
 		unmarshaler:  c.unmarshaler,
 		nextConsumer: c.nextConsumer,
+		handling:     c.handling,
 		ready:        make(chan bool),
 		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: c.id, Transport: transport}),
 	}
@@ -296,6 +305,7 @@ type tracesConsumerGroupHandler struct {
 	id           config.ComponentID
 	unmarshaler  TracesUnmarshaler
 	nextConsumer consumer.Traces
+	handling     messageHandling
 	ready        chan bool
 	readyCloser  sync.Once
 
@@ -308,6 +318,7 @@ type metricsConsumerGroupHandler struct {
 	id           config.ComponentID
 	unmarshaler  MetricsUnmarshaler
 	nextConsumer consumer.Metrics
+	handling     messageHandling
 	ready        chan bool
 	readyCloser  sync.Once
 
@@ -320,6 +331,7 @@ type logsConsumerGroupHandler struct {
 	id           config.ComponentID
 	unmarshaler  LogsUnmarshaler
 	nextConsumer consumer.Logs
+	handling     messageHandling
 	ready        chan bool
 	readyCloser  sync.Once
 
@@ -337,13 +349,15 @@ func (c *tracesConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession)
 		close(c.ready)
 	})
 	statsTags := []tag.Mutator{tag.Insert(tagInstanceName, c.id.Name())}
-	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionStart.M(1))
+	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionStart.M(1), statRebalanceCount.M(1))
 	return nil
 }
 
 func (c *tracesConsumerGroupHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	// Commit the marked offsets before the partitions are released.
+	session.Commit()
 	statsTags := []tag.Mutator{tag.Insert(tagInstanceName, c.id.Name())}
-	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionClose.M(1))
+	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionClose.M(1), statOffsetCommitCount.M(1))
 	return nil
 }
 
@@ -354,7 +368,7 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 			zap.String("value", string(message.Value)),
 			zap.Time("timestamp", message.Timestamp),
 			zap.String("topic", message.Topic))
-		session.MarkMessage(message, "")
+		c.handling.received(session, message)
 
 		ctx := c.obsrecv.StartTracesOp(session.Context())
 		statsTags := []tag.Mutator{tag.Insert(tagInstanceName, c.id.String())}
@@ -365,9 +379,14 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 
 		traces, err := c.unmarshaler.Unmarshal(message.Value)
 		if err != nil {
-			c.logger.Error("failed to unmarshal message", zap.Error(err))
-			return err
+			handlingErr := c.handling.unmarshalFailed(ctx, session, message, statsTags, c.logger, err)
+			c.obsrecv.EndTracesOp(ctx, c.unmarshaler.Encoding(), 0, err)
+			if handlingErr != nil {
+				return handlingErr
+			}
+			continue
 		}
+		c.handling.addTracesHeaders(traces, message)
 
 		spanCount := traces.SpanCount()
 		err = c.nextConsumer.ConsumeTraces(session.Context(), traces)
@@ -375,6 +394,7 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 		if err != nil {
 			return err
 		}
+		c.handling.consumed(session, message)
 	}
 	return nil
 }
@@ -384,13 +404,15 @@ func (c *metricsConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession)
 		close(c.ready)
 	})
 	statsTags := []tag.Mutator{tag.Insert(tagInstanceName, c.id.Name())}
-	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionStart.M(1))
+	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionStart.M(1), statRebalanceCount.M(1))
 	return nil
 }
 
 func (c *metricsConsumerGroupHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	// Commit the marked offsets before the partitions are released.
+	session.Commit()
 	statsTags := []tag.Mutator{tag.Insert(tagInstanceName, c.id.Name())}
-	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionClose.M(1))
+	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionClose.M(1), statOffsetCommitCount.M(1))
 	return nil
 }
 
@@ -401,7 +423,7 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 			zap.String("value", string(message.Value)),
 			zap.Time("timestamp", message.Timestamp),
 			zap.String("topic", message.Topic))
-		session.MarkMessage(message, "")
+		c.handling.received(session, message)
 
 		ctx := c.obsrecv.StartMetricsOp(session.Context())
 		statsTags := []tag.Mutator{tag.Insert(tagInstanceName, c.id.String())}
@@ -412,9 +434,14 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 
 		metrics, err := c.unmarshaler.Unmarshal(message.Value)
 		if err != nil {
-			c.logger.Error("failed to unmarshal message", zap.Error(err))
-			return err
+			handlingErr := c.handling.unmarshalFailed(ctx, session, message, statsTags, c.logger, err)
+			c.obsrecv.EndMetricsOp(ctx, c.unmarshaler.Encoding(), 0, err)
+			if handlingErr != nil {
+				return handlingErr
+			}
+			continue
 		}
+		c.handling.addMetricsHeaders(metrics, message)
 
 		dataPointCount := metrics.DataPointCount()
 		err = c.nextConsumer.ConsumeMetrics(session.Context(), metrics)
@@ -422,6 +449,7 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 		if err != nil {
 			return err
 		}
+		c.handling.consumed(session, message)
 	}
 	return nil
 }
@@ -433,18 +461,29 @@ func (c *logsConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) er
 	_ = stats.RecordWithTags(
 		session.Context(),
 		[]tag.Mutator{tag.Insert(tagInstanceName, c.id.String())},
-		statPartitionStart.M(1))
+		statPartitionStart.M(1),
+		statRebalanceCount.M(1))
 	return nil
 }
 
 func (c *logsConsumerGroupHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	// Commit the marked offsets before the partitions are released.
+	session.Commit()
 	_ = stats.RecordWithTags(
 		session.Context(),
 		[]tag.Mutator{tag.Insert(tagInstanceName, c.id.String())},
-		statPartitionClose.M(1))
+		statPartitionClose.M(1),
+		statOffsetCommitCount.M(1))
 	return nil
 }
 
+func (c *logsConsumerGroupHandler) unmarshal(message *sarama.ConsumerMessage) (pdata.Logs, error) {
+	if unmarshaler, ok := c.unmarshaler.(LogsMessageUnmarshaler); ok {
+		return unmarshaler.UnmarshalMessage(message)
+	}
+	return c.unmarshaler.Unmarshal(message.Value)
+}
+
 func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 	c.logger.Info("Starting consumer group", zap.Int32("partition", claim.Partition()))
 	for message := range claim.Messages() {
@@ -452,21 +491,27 @@ func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSess
 			zap.String("value", string(message.Value)),
 			zap.Time("timestamp", message.Timestamp),
 			zap.String("topic", message.Topic))
-		session.MarkMessage(message, "")
+		c.handling.received(session, message)
 
 		ctx := c.obsrecv.StartTracesOp(session.Context())
+		statsTags := []tag.Mutator{tag.Insert(tagInstanceName, c.id.String())}
 		_ = stats.RecordWithTags(
 			ctx,
-			[]tag.Mutator{tag.Insert(tagInstanceName, c.id.String())},
+			statsTags,
 			statMessageCount.M(1),
 			statMessageOffset.M(message.Offset),
 			statMessageOffsetLag.M(claim.HighWaterMarkOffset()-message.Offset-1))
 
-		logs, err := c.unmarshaler.Unmarshal(message.Value)
+		logs, err := c.unmarshal(message)
 		if err != nil {
-			c.logger.Error("failed to unmarshal message", zap.Error(err))
-			return err
+			handlingErr := c.handling.unmarshalFailed(ctx, session, message, statsTags, c.logger, err)
+			c.obsrecv.EndTracesOp(ctx, c.unmarshaler.Encoding(), 0, err)
+			if handlingErr != nil {
+				return handlingErr
+			}
+			continue
 		}
+		c.handling.addLogsHeaders(logs, message)
 
 		err = c.nextConsumer.ConsumeLogs(session.Context(), logs)
 		// TODO
@@ -474,6 +519,7 @@ func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSess
 		if err != nil {
 			return err
 		}
+		c.handling.consumed(session, message)
 	}
 	return nil
 }
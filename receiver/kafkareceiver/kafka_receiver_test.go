@@ -25,6 +25,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/oteltest"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
@@ -176,6 +180,7 @@ func TestTracesConsumerGroupHandler_error_unmarshal(t *testing.T) {
 		logger:       zap.NewNop(),
 		ready:        make(chan bool),
 		nextConsumer: consumertest.NewNop(),
+		handling:     messageHandling{haltOnUnmarshalError: true},
 		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{}),
 	}
 
@@ -361,6 +366,7 @@ func TestMetricsConsumerGroupHandler_error_unmarshal(t *testing.T) {
 		logger:       zap.NewNop(),
 		ready:        make(chan bool),
 		nextConsumer: consumertest.NewNop(),
+		handling:     messageHandling{haltOnUnmarshalError: true},
 		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{}),
 	}
 
@@ -545,6 +551,7 @@ func TestLogsConsumerGroupHandler_error_unmarshal(t *testing.T) {
 		logger:       zap.NewNop(),
 		ready:        make(chan bool),
 		nextConsumer: consumertest.NewNop(),
+		handling:     messageHandling{haltOnUnmarshalError: true},
 		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{}),
 	}
 
@@ -626,11 +633,11 @@ func (t testConsumerGroupClaim) Messages() <-chan *sarama.ConsumerMessage {
 }
 
 type testConsumerGroupSession struct {
+	// marked records the offsets of the marked messages, when set.
+	marked *[]int64
 }
 
-func (t testConsumerGroupSession) Commit() {
-	panic("implement me")
-}
+func (t testConsumerGroupSession) Commit() {}
 
 var _ sarama.ConsumerGroupSession = (*testConsumerGroupSession)(nil)
 
@@ -654,7 +661,11 @@ func (t testConsumerGroupSession) ResetOffset(string, int32, int64, string) {
 	panic("implement me")
 }
 
-func (t testConsumerGroupSession) MarkMessage(*sarama.ConsumerMessage, string) {}
+func (t testConsumerGroupSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	if t.marked != nil {
+		*t.marked = append(*t.marked, msg.Offset)
+	}
+}
 
 func (t testConsumerGroupSession) Context() context.Context {
 	return context.Background()
@@ -681,3 +692,51 @@ func (t *testConsumerGroup) Errors() <-chan error {
 func (t *testConsumerGroup) Close() error {
 	return nil
 }
+
+func TestConsumerGroupHandler_skip_unmarshal_endsOperation(t *testing.T) {
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	handlers := map[string]func() sarama.ConsumerGroupHandler{
+		"traces": func() sarama.ConsumerGroupHandler {
+			return &tracesConsumerGroupHandler{
+				unmarshaler:  newPdataTracesUnmarshaler(otlp.NewProtobufTracesUnmarshaler(), defaultEncoding),
+				logger:       zap.NewNop(),
+				nextConsumer: consumertest.NewNop(),
+				obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{}),
+			}
+		},
+		"metrics": func() sarama.ConsumerGroupHandler {
+			return &metricsConsumerGroupHandler{
+				unmarshaler:  newPdataMetricsUnmarshaler(otlp.NewProtobufMetricsUnmarshaler(), defaultEncoding),
+				logger:       zap.NewNop(),
+				nextConsumer: consumertest.NewNop(),
+				obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{}),
+			}
+		},
+		"logs": func() sarama.ConsumerGroupHandler {
+			return &logsConsumerGroupHandler{
+				unmarshaler:  newPdataLogsUnmarshaler(otlp.NewProtobufLogsUnmarshaler(), defaultEncoding),
+				logger:       zap.NewNop(),
+				nextConsumer: consumertest.NewNop(),
+				obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{}),
+			}
+		},
+	}
+	for name, newHandler := range handlers {
+		t.Run(name, func(t *testing.T) {
+			sr := new(oteltest.SpanRecorder)
+			otel.SetTracerProvider(oteltest.NewTracerProvider(oteltest.WithSpanRecorder(sr)))
+			groupClaim := &testConsumerGroupClaim{
+				messageChan: make(chan *sarama.ConsumerMessage, 1),
+			}
+			groupClaim.messageChan <- &sarama.ConsumerMessage{Value: []byte("!@#")}
+			close(groupClaim.messageChan)
+			require.NoError(t, newHandler().ConsumeClaim(testConsumerGroupSession{}, groupClaim))
+
+			// The operation of the skipped message is ended with the unmarshal error.
+			spans := sr.Completed()
+			require.Len(t, spans, 1)
+			assert.Equal(t, codes.Error, spans[0].StatusCode())
+		})
+	}
+}
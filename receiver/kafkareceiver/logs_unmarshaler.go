@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+const (
+	rawEncoding        = "raw"
+	textEncoding       = "text"
+	textEncodingPrefix = textEncoding + "_"
+	jsonEncoding       = "json"
+
+	attributeKafkaTopic     = "kafka.topic"
+	attributeKafkaPartition = "kafka.partition"
+	attributeKafkaOffset    = "kafka.offset"
+	attributeKafkaKey       = "kafka.key"
+	attributeKafkaTimestamp = "kafka.timestamp"
+)
+
+var errJSONTrailingData = errors.New("unexpected data after the JSON value")
+
+// rawLogsUnmarshaler sets the message as the bytes body of a single log record.
+type rawLogsUnmarshaler struct{}
+
+var _ LogsMessageUnmarshaler = rawLogsUnmarshaler{}
+
+func (r rawLogsUnmarshaler) Unmarshal(buf []byte) (pdata.Logs, error) {
+	return r.UnmarshalMessage(&sarama.ConsumerMessage{Value: buf})
+}
+
+func (r rawLogsUnmarshaler) UnmarshalMessage(message *sarama.ConsumerMessage) (pdata.Logs, error) {
+	logs, lr := newMessageLogRecord(message)
+	lr.Body().SetBytesVal(message.Value)
+	return logs, nil
+}
+
+func (r rawLogsUnmarshaler) Encoding() string {
+	return rawEncoding
+}
+
+// textLogsUnmarshaler decodes the message with a charset and sets it as the
+// string body of a single log record.
+type textLogsUnmarshaler struct {
+	encoding string
+	charset  encoding.Encoding
+}
+
+var _ LogsMessageUnmarshaler = (*textLogsUnmarshaler)(nil)
+
+// newTextLogsUnmarshaler creates an unmarshaler for the "text" and "text_<charset>" encodings.
+// The charset is any name or label of the WHATWG Encoding Standard, "text" being UTF-8.
+func newTextLogsUnmarshaler(enc string) (*textLogsUnmarshaler, error) {
+	charset := "utf-8"
+	if enc != textEncoding {
+		charset = strings.TrimPrefix(enc, textEncodingPrefix)
+	}
+	e, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported charset %q: %w", charset, err)
+	}
+	return &textLogsUnmarshaler{encoding: enc, charset: e}, nil
+}
+
+func (t *textLogsUnmarshaler) Unmarshal(buf []byte) (pdata.Logs, error) {
+	return t.UnmarshalMessage(&sarama.ConsumerMessage{Value: buf})
+}
+
+func (t *textLogsUnmarshaler) UnmarshalMessage(message *sarama.ConsumerMessage) (pdata.Logs, error) {
+	// Decoders are stateful, and the partitions are consumed concurrently.
+	text, err := t.charset.NewDecoder().Bytes(message.Value)
+	if err != nil {
+		return pdata.NewLogs(), err
+	}
+	logs, lr := newMessageLogRecord(message)
+	lr.Body().SetStringVal(string(text))
+	return logs, nil
+}
+
+func (t *textLogsUnmarshaler) Encoding() string {
+	return t.encoding
+}
+
+// jsonLogsUnmarshaler parses the message as JSON and sets the parsed value
+// as the body of a single log record.
+type jsonLogsUnmarshaler struct{}
+
+var _ LogsMessageUnmarshaler = jsonLogsUnmarshaler{}
+
+func (j jsonLogsUnmarshaler) Unmarshal(buf []byte) (pdata.Logs, error) {
+	return j.UnmarshalMessage(&sarama.ConsumerMessage{Value: buf})
+}
+
+func (j jsonLogsUnmarshaler) UnmarshalMessage(message *sarama.ConsumerMessage) (pdata.Logs, error) {
+	dec := json.NewDecoder(bytes.NewReader(message.Value))
+	// Keep the precision of integers.
+	dec.UseNumber()
+	var body interface{}
+	if err := dec.Decode(&body); err != nil {
+		return pdata.NewLogs(), err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return pdata.NewLogs(), errJSONTrailingData
+	}
+	logs, lr := newMessageLogRecord(message)
+	jsonToAttributeValue(body).CopyTo(lr.Body())
+	return logs, nil
+}
+
+func (j jsonLogsUnmarshaler) Encoding() string {
+	return jsonEncoding
+}
+
+// newMessageLogRecord creates logs with a single log record holding the metadata of the message.
+func newMessageLogRecord(message *sarama.ConsumerMessage) (pdata.Logs, pdata.LogRecord) {
+	logs := pdata.NewLogs()
+	lr := logs.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	attrs := lr.Attributes()
+	if message.Topic != "" {
+		attrs.InsertString(attributeKafkaTopic, message.Topic)
+		attrs.InsertInt(attributeKafkaPartition, int64(message.Partition))
+		attrs.InsertInt(attributeKafkaOffset, message.Offset)
+	}
+	if message.Key != nil {
+		attrs.InsertString(attributeKafkaKey, string(message.Key))
+	}
+	if !message.Timestamp.IsZero() {
+		lr.SetTimestamp(pdata.TimestampFromTime(message.Timestamp))
+		attrs.InsertString(attributeKafkaTimestamp, message.Timestamp.UTC().Format(time.RFC3339Nano))
+	}
+	return logs, lr
+}
+
+func jsonToAttributeValue(v interface{}) pdata.AttributeValue {
+	switch val := v.(type) {
+	case string:
+		return pdata.NewAttributeValueString(val)
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return pdata.NewAttributeValueInt(i)
+		}
+		f, _ := val.Float64()
+		return pdata.NewAttributeValueDouble(f)
+	case bool:
+		return pdata.NewAttributeValueBool(val)
+	case map[string]interface{}:
+		av := pdata.NewAttributeValueMap()
+		m := av.MapVal()
+		m.EnsureCapacity(len(val))
+		for k, item := range val {
+			m.Insert(k, jsonToAttributeValue(item))
+		}
+		return av
+	case []interface{}:
+		av := pdata.NewAttributeValueArray()
+		arr := av.ArrayVal()
+		arr.EnsureCapacity(len(val))
+		for _, item := range val {
+			jsonToAttributeValue(item).CopyTo(arr.AppendEmpty())
+		}
+		return av
+	default:
+		return pdata.NewAttributeValueNull()
+	}
+}
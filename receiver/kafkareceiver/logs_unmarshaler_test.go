@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func testMessage(value []byte) *sarama.ConsumerMessage {
+	return &sarama.ConsumerMessage{
+		Topic:     "logs",
+		Partition: 3,
+		Offset:    42,
+		Key:       []byte("key"),
+		Timestamp: time.Date(2021, 8, 30, 10, 0, 0, 5, time.UTC),
+		Value:     value,
+	}
+}
+
+func singleLogRecord(t *testing.T, logs pdata.Logs) pdata.LogRecord {
+	require.Equal(t, 1, logs.LogRecordCount())
+	return logs.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+}
+
+func TestMessageLogRecordMetadata(t *testing.T) {
+	logs, err := rawLogsUnmarshaler{}.UnmarshalMessage(testMessage([]byte("line")))
+	require.NoError(t, err)
+	lr := singleLogRecord(t, logs)
+	assert.Equal(t, pdata.TimestampFromTime(time.Date(2021, 8, 30, 10, 0, 0, 5, time.UTC)), lr.Timestamp())
+
+	expected := pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"kafka.topic":     pdata.NewAttributeValueString("logs"),
+		"kafka.partition": pdata.NewAttributeValueInt(3),
+		"kafka.offset":    pdata.NewAttributeValueInt(42),
+		"kafka.key":       pdata.NewAttributeValueString("key"),
+		"kafka.timestamp": pdata.NewAttributeValueString("2021-08-30T10:00:00.000000005Z"),
+	})
+	assert.Equal(t, expected.Sort(), lr.Attributes().Sort())
+}
+
+func TestRawLogsUnmarshaler(t *testing.T) {
+	u := rawLogsUnmarshaler{}
+	assert.Equal(t, "raw", u.Encoding())
+	logs, err := u.Unmarshal([]byte{0xff, 0x00})
+	require.NoError(t, err)
+	lr := singleLogRecord(t, logs)
+	assert.Equal(t, pdata.AttributeValueTypeBytes, lr.Body().Type())
+	assert.Equal(t, []byte{0xff, 0x00}, lr.Body().BytesVal())
+	assert.Equal(t, 0, lr.Attributes().Len())
+	assert.Equal(t, pdata.Timestamp(0), lr.Timestamp())
+}
+
+func TestTextLogsUnmarshaler(t *testing.T) {
+	tests := []struct {
+		encoding string
+		value    []byte
+		expected string
+	}{
+		{
+			encoding: "text",
+			value:    []byte("héllo"),
+			expected: "héllo",
+		},
+		{
+			encoding: "text_utf-8",
+			value:    []byte("héllo"),
+			expected: "héllo",
+		},
+		{
+			encoding: "text_iso-8859-1",
+			value:    []byte{'h', 0xe9, 'l', 'l', 'o'},
+			expected: "héllo",
+		},
+		{
+			encoding: "text_shift_jis",
+			value:    []byte{0x82, 0xb1, 0x82, 0xf1},
+			expected: "こん",
+		},
+		{
+			encoding: "text_utf-16le",
+			value:    []byte{'h', 0, 'i', 0},
+			expected: "hi",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			u, err := newTextLogsUnmarshaler(tt.encoding)
+			require.NoError(t, err)
+			assert.Equal(t, tt.encoding, u.Encoding())
+			logs, err := u.UnmarshalMessage(testMessage(tt.value))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, singleLogRecord(t, logs).Body().StringVal())
+		})
+	}
+}
+
+func TestTextLogsUnmarshaler_unknownCharset(t *testing.T) {
+	_, err := newTextLogsUnmarshaler("text_klingon")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported charset "klingon"`)
+}
+
+func TestJSONLogsUnmarshaler(t *testing.T) {
+	u := jsonLogsUnmarshaler{}
+	assert.Equal(t, "json", u.Encoding())
+	logs, err := u.UnmarshalMessage(testMessage([]byte(`{"msg":"hello","level":"info","count":9007199254740993,"ratio":0.5,"ok":true,"tags":["a",1],"nested":{"k":null}}`)))
+	require.NoError(t, err)
+	lr := singleLogRecord(t, logs)
+	require.Equal(t, pdata.AttributeValueTypeMap, lr.Body().Type())
+
+	nested := pdata.NewAttributeValueMap()
+	nested.MapVal().InsertNull("k")
+	tags := pdata.NewAttributeValueArray()
+	pdata.NewAttributeValueString("a").CopyTo(tags.ArrayVal().AppendEmpty())
+	pdata.NewAttributeValueInt(1).CopyTo(tags.ArrayVal().AppendEmpty())
+	expected := pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"msg":    pdata.NewAttributeValueString("hello"),
+		"level":  pdata.NewAttributeValueString("info"),
+		"count":  pdata.NewAttributeValueInt(9007199254740993),
+		"ratio":  pdata.NewAttributeValueDouble(0.5),
+		"ok":     pdata.NewAttributeValueBool(true),
+		"tags":   tags,
+		"nested": nested,
+	})
+	assert.Equal(t, expected.Sort(), lr.Body().MapVal().Sort())
+	v, ok := lr.Attributes().Get("kafka.topic")
+	require.True(t, ok)
+	assert.Equal(t, "logs", v.StringVal())
+}
+
+func TestJSONLogsUnmarshaler_scalar(t *testing.T) {
+	logs, err := jsonLogsUnmarshaler{}.Unmarshal([]byte(`"just a string"`))
+	require.NoError(t, err)
+	assert.Equal(t, "just a string", singleLogRecord(t, logs).Body().StringVal())
+}
+
+func TestJSONLogsUnmarshaler_error(t *testing.T) {
+	_, err := jsonLogsUnmarshaler{}.Unmarshal([]byte(`{"msg":`))
+	assert.Error(t, err)
+	_, err = jsonLogsUnmarshaler{}.Unmarshal([]byte(`{"msg":"a"} {"msg":"b"}`))
+	assert.Equal(t, errJSONTrailingData, err)
+}
+
+func TestCreateLogsReceiver_textCharset(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ProtocolVersion = "2.0.0"
+	// disable contacting broker at startup
+	cfg.Metadata.Full = false
+	f := kafkaReceiverFactory{logsUnmarshalers: defaultLogsUnmarshalers()}
+
+	cfg.Encoding = "text_windows-1252"
+	r, err := f.createLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "text_windows-1252", r.(*kafkaLogsConsumer).unmarshaler.Encoding())
+
+	cfg.Encoding = "text_klingon"
+	r, err = f.createLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, nil)
+	require.Error(t, err)
+	assert.Nil(t, r)
+}
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver
+
+import (
+	"context"
+
+	"github.com/Shopify/sarama"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+const headerAttributePrefix = "kafka.header."
+
+// messageHandling controls how the claimed messages are marked and enriched.
+type messageHandling struct {
+	// markAfterConsume marks the messages only once the next consumer
+	// successfully processed them, providing at-least-once delivery.
+	markAfterConsume bool
+	// haltOnUnmarshalError stops consuming the partition on a message that
+	// cannot be unmarshaled, instead of skipping it.
+	haltOnUnmarshalError bool
+	// headers are the message headers added as resource attributes.
+	headers []string
+}
+
+func newMessageHandling(config Config) messageHandling {
+	return messageHandling{
+		markAfterConsume:     config.DeliveryGuarantee == atLeastOnce,
+		haltOnUnmarshalError: config.OnUnmarshalError == unmarshalErrorHalt,
+		headers:              config.HeaderExtraction.Headers,
+	}
+}
+
+// received is called when a message is claimed, before it is unmarshaled.
+func (h messageHandling) received(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage) {
+	if !h.markAfterConsume {
+		session.MarkMessage(message, "")
+	}
+}
+
+// consumed is called once the message was successfully processed by the next consumer.
+func (h messageHandling) consumed(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage) {
+	if h.markAfterConsume {
+		session.MarkMessage(message, "")
+	}
+}
+
+// unmarshalFailed records a message that cannot be unmarshaled, and returns the error
+// halting the claim, or nil when the message is skipped.
+func (h messageHandling) unmarshalFailed(ctx context.Context, session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage, statsTags []tag.Mutator, logger *zap.Logger, err error) error {
+	logger.Error("failed to unmarshal message",
+		zap.Error(err),
+		zap.String("topic", message.Topic),
+		zap.Int32("partition", message.Partition),
+		zap.Int64("offset", message.Offset))
+	_ = stats.RecordWithTags(ctx, statsTags, statUnmarshalFailedMessages.M(1))
+	if h.haltOnUnmarshalError {
+		return err
+	}
+	h.consumed(session, message)
+	return nil
+}
+
+// headerAttributes returns the resource attributes for the configured headers
+// found in the message.
+func (h messageHandling) headerAttributes(message *sarama.ConsumerMessage) map[string]string {
+	if len(h.headers) == 0 {
+		return nil
+	}
+	attrs := map[string]string{}
+	for _, key := range h.headers {
+		for _, header := range message.Headers {
+			if header != nil && string(header.Key) == key {
+				attrs[headerAttributePrefix+key] = string(header.Value)
+				break
+			}
+		}
+	}
+	return attrs
+}
+
+func addResourceAttributes(resource pdata.Resource, attrs map[string]string) {
+	for k, v := range attrs {
+		resource.Attributes().UpsertString(k, v)
+	}
+}
+
+func (h messageHandling) addTracesHeaders(traces pdata.Traces, message *sarama.ConsumerMessage) {
+	attrs := h.headerAttributes(message)
+	if len(attrs) == 0 {
+		return
+	}
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		addResourceAttributes(rss.At(i).Resource(), attrs)
+	}
+}
+
+func (h messageHandling) addMetricsHeaders(metrics pdata.Metrics, message *sarama.ConsumerMessage) {
+	attrs := h.headerAttributes(message)
+	if len(attrs) == 0 {
+		return
+	}
+	rms := metrics.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		addResourceAttributes(rms.At(i).Resource(), attrs)
+	}
+}
+
+func (h messageHandling) addLogsHeaders(logs pdata.Logs, message *sarama.ConsumerMessage) {
+	attrs := h.headerAttributes(message)
+	if len(attrs) == 0 {
+		return
+	}
+	rls := logs.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		addResourceAttributes(rls.At(i).Resource(), attrs)
+	}
+}
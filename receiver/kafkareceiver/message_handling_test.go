@@ -0,0 +1,221 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+func TestNewMessageHandling(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, messageHandling{}, newMessageHandling(*cfg))
+
+	cfg.DeliveryGuarantee = atLeastOnce
+	cfg.OnUnmarshalError = unmarshalErrorHalt
+	cfg.HeaderExtraction.Headers = []string{"tenant"}
+	assert.Equal(t, messageHandling{
+		markAfterConsume:     true,
+		haltOnUnmarshalError: true,
+		headers:              []string{"tenant"},
+	}, newMessageHandling(*cfg))
+}
+
+func TestNewSaramaConfig_initialOffset(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	c, err := newSaramaConfig(*cfg)
+	require.NoError(t, err)
+	assert.Equal(t, sarama.OffsetNewest, c.Consumer.Offsets.Initial)
+
+	cfg.InitialOffset = offsetEarliest
+	c, err = newSaramaConfig(*cfg)
+	require.NoError(t, err)
+	assert.Equal(t, sarama.OffsetOldest, c.Consumer.Offsets.Initial)
+}
+
+func TestHeaderAttributes(t *testing.T) {
+	h := messageHandling{headers: []string{"tenant", "missing"}}
+	message := &sarama.ConsumerMessage{
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte("other"), Value: []byte("ignored")},
+			{Key: []byte("tenant"), Value: []byte("acme")},
+		},
+	}
+	assert.Equal(t, map[string]string{"kafka.header.tenant": "acme"}, h.headerAttributes(message))
+	assert.Nil(t, messageHandling{}.headerAttributes(message))
+}
+
+func marshalTestTraces(t *testing.T) []byte {
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().InsertString("service.name", "svc")
+	bts, err := otlp.NewProtobufTracesMarshaler().MarshalTraces(td)
+	require.NoError(t, err)
+	return bts
+}
+
+func consumeTestMessages(t *testing.T, c *tracesConsumerGroupHandler, messages ...*sarama.ConsumerMessage) ([]int64, error) {
+	var marked []int64
+	groupClaim := testConsumerGroupClaim{
+		messageChan: make(chan *sarama.ConsumerMessage, len(messages)),
+	}
+	for _, message := range messages {
+		groupClaim.messageChan <- message
+	}
+	close(groupClaim.messageChan)
+
+	err := c.ConsumeClaim(testConsumerGroupSession{marked: &marked}, groupClaim)
+	return marked, err
+}
+
+func TestTracesConsumerGroupHandler_skipUnmarshalError(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	sink := new(consumertest.TracesSink)
+	c := &tracesConsumerGroupHandler{
+		unmarshaler:  newPdataTracesUnmarshaler(otlp.NewProtobufTracesUnmarshaler(), defaultEncoding),
+		logger:       zap.NewNop(),
+		ready:        make(chan bool),
+		nextConsumer: sink,
+		handling:     messageHandling{markAfterConsume: true},
+		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{}),
+	}
+
+	marked, err := consumeTestMessages(t, c,
+		&sarama.ConsumerMessage{Offset: 1, Value: []byte("!@#")},
+		&sarama.ConsumerMessage{Offset: 2, Value: marshalTestTraces(t)})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, marked)
+	assert.Len(t, sink.AllTraces(), 1)
+
+	viewData, err := view.RetrieveData(statUnmarshalFailedMessages.Name())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(viewData))
+	assert.Equal(t, float64(1), viewData[0].Data.(*view.SumData).Value)
+}
+
+func TestTracesConsumerGroupHandler_atLeastOnce(t *testing.T) {
+	c := &tracesConsumerGroupHandler{
+		unmarshaler:  newPdataTracesUnmarshaler(otlp.NewProtobufTracesUnmarshaler(), defaultEncoding),
+		logger:       zap.NewNop(),
+		ready:        make(chan bool),
+		nextConsumer: consumertest.NewErr(errors.New("failed to consume")),
+		handling:     messageHandling{markAfterConsume: true},
+		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{}),
+	}
+	marked, err := consumeTestMessages(t, c, &sarama.ConsumerMessage{Offset: 1, Value: marshalTestTraces(t)})
+	assert.EqualError(t, err, "failed to consume")
+	assert.Empty(t, marked)
+
+	c.handling.markAfterConsume = false
+	marked, err = consumeTestMessages(t, c, &sarama.ConsumerMessage{Offset: 1, Value: marshalTestTraces(t)})
+	assert.EqualError(t, err, "failed to consume")
+	assert.Equal(t, []int64{1}, marked)
+}
+
+func TestTracesConsumerGroupHandler_headers(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	c := &tracesConsumerGroupHandler{
+		unmarshaler:  newPdataTracesUnmarshaler(otlp.NewProtobufTracesUnmarshaler(), defaultEncoding),
+		logger:       zap.NewNop(),
+		ready:        make(chan bool),
+		nextConsumer: sink,
+		handling:     messageHandling{headers: []string{"tenant"}},
+		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{}),
+	}
+	_, err := consumeTestMessages(t, c, &sarama.ConsumerMessage{
+		Value:   marshalTestTraces(t),
+		Headers: []*sarama.RecordHeader{{Key: []byte("tenant"), Value: []byte("acme")}},
+	})
+	require.NoError(t, err)
+	require.Len(t, sink.AllTraces(), 1)
+	attrs := sink.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes()
+	v, ok := attrs.Get("kafka.header.tenant")
+	require.True(t, ok)
+	assert.Equal(t, "acme", v.StringVal())
+	v, ok = attrs.Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "svc", v.StringVal())
+}
+
+func TestMetricsConsumerGroupHandler_headers(t *testing.T) {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().AppendEmpty()
+	bts, err := otlp.NewProtobufMetricsMarshaler().MarshalMetrics(md)
+	require.NoError(t, err)
+
+	sink := new(consumertest.MetricsSink)
+	c := &metricsConsumerGroupHandler{
+		unmarshaler:  newPdataMetricsUnmarshaler(otlp.NewProtobufMetricsUnmarshaler(), defaultEncoding),
+		logger:       zap.NewNop(),
+		ready:        make(chan bool),
+		nextConsumer: sink,
+		handling:     messageHandling{headers: []string{"tenant"}},
+		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{}),
+	}
+	groupClaim := testConsumerGroupClaim{messageChan: make(chan *sarama.ConsumerMessage, 1)}
+	groupClaim.messageChan <- &sarama.ConsumerMessage{
+		Value:   bts,
+		Headers: []*sarama.RecordHeader{{Key: []byte("tenant"), Value: []byte("acme")}},
+	}
+	close(groupClaim.messageChan)
+	require.NoError(t, c.ConsumeClaim(testConsumerGroupSession{}, groupClaim))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	v, ok := sink.AllMetrics()[0].ResourceMetrics().At(0).Resource().Attributes().Get("kafka.header.tenant")
+	require.True(t, ok)
+	assert.Equal(t, "acme", v.StringVal())
+}
+
+func TestLogsConsumerGroupHandler_headers(t *testing.T) {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().AppendEmpty()
+	bts, err := otlp.NewProtobufLogsMarshaler().MarshalLogs(ld)
+	require.NoError(t, err)
+
+	sink := new(consumertest.LogsSink)
+	c := &logsConsumerGroupHandler{
+		unmarshaler:  newPdataLogsUnmarshaler(otlp.NewProtobufLogsUnmarshaler(), defaultEncoding),
+		logger:       zap.NewNop(),
+		ready:        make(chan bool),
+		nextConsumer: sink,
+		handling:     messageHandling{headers: []string{"tenant"}},
+		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{}),
+	}
+	groupClaim := testConsumerGroupClaim{messageChan: make(chan *sarama.ConsumerMessage, 1)}
+	groupClaim.messageChan <- &sarama.ConsumerMessage{
+		Value:   bts,
+		Headers: []*sarama.RecordHeader{{Key: []byte("tenant"), Value: []byte("acme")}},
+	}
+	close(groupClaim.messageChan)
+	require.NoError(t, c.ConsumeClaim(testConsumerGroupSession{}, groupClaim))
+
+	require.Len(t, sink.AllLogs(), 1)
+	v, ok := sink.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().Get("kafka.header.tenant")
+	require.True(t, ok)
+	assert.Equal(t, "acme", v.StringVal())
+}
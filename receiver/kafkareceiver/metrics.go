@@ -29,6 +29,10 @@ var (
 
 	statPartitionStart = stats.Int64("kafka_receiver_partition_start", "Number of started partitions", stats.UnitDimensionless)
 	statPartitionClose = stats.Int64("kafka_receiver_partition_close", "Number of finished partitions", stats.UnitDimensionless)
+
+	statUnmarshalFailedMessages = stats.Int64("kafka_receiver_unmarshal_failed_messages", "Number of messages that failed to be unmarshaled", stats.UnitDimensionless)
+	statRebalanceCount          = stats.Int64("kafka_receiver_rebalances", "Number of consumer group rebalances", stats.UnitDimensionless)
+	statOffsetCommitCount       = stats.Int64("kafka_receiver_offset_commits", "Number of offset commits made when partitions are released", stats.UnitDimensionless)
 )
 
 // MetricViews return metric views for Kafka receiver.
@@ -75,11 +79,38 @@ func MetricViews() []*view.View {
 		Aggregation: view.Sum(),
 	}
 
+	countUnmarshalFailedMessages := &view.View{
+		Name:        statUnmarshalFailedMessages.Name(),
+		Measure:     statUnmarshalFailedMessages,
+		Description: statUnmarshalFailedMessages.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	countRebalances := &view.View{
+		Name:        statRebalanceCount.Name(),
+		Measure:     statRebalanceCount,
+		Description: statRebalanceCount.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	countOffsetCommits := &view.View{
+		Name:        statOffsetCommitCount.Name(),
+		Measure:     statOffsetCommitCount,
+		Description: statOffsetCommitCount.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Sum(),
+	}
+
 	return []*view.View{
 		countMessages,
 		lastValueOffset,
 		lastValueOffsetLag,
 		countPartitionStart,
 		countPartitionClose,
+		countUnmarshalFailedMessages,
+		countRebalances,
+		countOffsetCommits,
 	}
 }
@@ -28,6 +28,9 @@ func TestMetrics(t *testing.T) {
 		"kafka_receiver_offset_lag",
 		"kafka_receiver_partition_start",
 		"kafka_receiver_partition_close",
+		"kafka_receiver_unmarshal_failed_messages",
+		"kafka_receiver_rebalances",
+		"kafka_receiver_offset_commits",
 	}
 	for i, viewName := range viewNames {
 		assert.Equal(t, viewName, metricViews[i].Name)
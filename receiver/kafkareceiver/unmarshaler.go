@@ -15,6 +15,9 @@
 package kafkareceiver
 
 import (
+	"github.com/Shopify/sarama"
+	"golang.org/x/text/encoding/unicode"
+
 	"go.opentelemetry.io/collector/model/otlp"
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.opentelemetry.io/collector/translator/trace/zipkinv1"
@@ -48,6 +51,15 @@ type LogsUnmarshaler interface {
 	Encoding() string
 }
 
+// LogsMessageUnmarshaler is an optional interface implemented by the LogsUnmarshaler
+// that also need the metadata of the Kafka message, such as its key or timestamp.
+type LogsMessageUnmarshaler interface {
+	LogsUnmarshaler
+
+	// UnmarshalMessage deserializes the Kafka message into logs.
+	UnmarshalMessage(*sarama.ConsumerMessage) (pdata.Logs, error)
+}
+
 // defaultTracesUnmarshalers returns map of supported encodings with TracesUnmarshaler.
 func defaultTracesUnmarshalers() map[string]TracesUnmarshaler {
 	otlpPb := newPdataTracesUnmarshaler(otlp.NewProtobufTracesUnmarshaler(), defaultEncoding)
@@ -75,7 +87,13 @@ func defaultMetricsUnmarshalers() map[string]MetricsUnmarshaler {
 
 func defaultLogsUnmarshalers() map[string]LogsUnmarshaler {
 	otlpPb := newPdataLogsUnmarshaler(otlp.NewProtobufLogsUnmarshaler(), defaultEncoding)
+	raw := rawLogsUnmarshaler{}
+	text := &textLogsUnmarshaler{encoding: textEncoding, charset: unicode.UTF8}
+	json := jsonLogsUnmarshaler{}
 	return map[string]LogsUnmarshaler{
 		otlpPb.Encoding(): otlpPb,
+		raw.Encoding():    raw,
+		text.Encoding():   text,
+		json.Encoding():   json,
 	}
 }
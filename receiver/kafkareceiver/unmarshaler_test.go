@@ -15,10 +15,13 @@
 package kafkareceiver
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
 )
 
 func TestDefaultTracesUnMarshaler(t *testing.T) {
@@ -59,6 +62,9 @@ func TestDefaultMetricsUnMarshaler(t *testing.T) {
 func TestDefaultLogsUnMarshaler(t *testing.T) {
 	expectedEncodings := []string{
 		"otlp_proto",
+		"raw",
+		"text",
+		"json",
 	}
 	marshalers := defaultLogsUnmarshalers()
 	assert.Equal(t, len(expectedEncodings), len(marshalers))
@@ -70,3 +76,25 @@ func TestDefaultLogsUnMarshaler(t *testing.T) {
 		})
 	}
 }
+
+func TestFactoryWithCustomUnmarshalers(t *testing.T) {
+	f := NewFactory(
+		WithTracesUnmarshalers(customTracesUnmarshaler{}),
+		WithMetricsUnmarshalers(customMetricsUnmarshaler{}),
+		WithLogsUnmarshalers(customLogsUnmarshaler{}),
+	)
+	cfg := createDefaultConfig().(*Config)
+	// disable contacting broker
+	cfg.Metadata.Full = false
+	cfg.ProtocolVersion = "2.0.0"
+	cfg.Encoding = "custom"
+	tr, err := f.CreateTracesReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, tr)
+	mr, err := f.CreateMetricsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, mr)
+	lr, err := f.CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, lr)
+}
@@ -29,6 +29,7 @@ import (
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
 	"go.opentelemetry.io/collector/testutil"
 )
 
@@ -313,3 +314,16 @@ func TestCreateLogReceiver(t *testing.T) {
 		})
 	}
 }
+
+func TestConformance(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.HTTP.Endpoint = testutil.GetAvailableLocalAddress(t)
+
+	sender := otlpexporter.NewFactory()
+	senderCfg := sender.CreateDefaultConfig().(*otlpexporter.Config)
+	senderCfg.GRPCClientSettings.Endpoint = cfg.GRPC.NetAddr.Endpoint
+	senderCfg.GRPCClientSettings.TLSSetting.Insecure = true
+	componenttest.VerifyReceiverConformance(t, factory, cfg, sender, senderCfg)
+}
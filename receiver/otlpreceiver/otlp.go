@@ -26,6 +26,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
@@ -147,9 +148,15 @@ func (r *otlpReceiver) startProtocolServers(host component.Host) error {
 		}
 	}
 	if r.cfg.HTTP != nil {
+		var authenticator configauth.ServerAuthenticator
+		authenticator, err = r.cfg.HTTP.ServerAuthenticator(host.GetExtensions())
+		if err != nil {
+			return err
+		}
 		r.serverHTTP = r.cfg.HTTP.ToServer(
 			r.httpMux,
 			confighttp.WithErrorHandler(errorHandler),
+			confighttp.WithAuthenticator(authenticator),
 		)
 		err = r.startHTTPServer(r.cfg.HTTP, host)
 		if err != nil {
@@ -39,6 +39,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/confignet"
@@ -601,6 +602,62 @@ func TestHTTPInvalidTLSCredentials(t *testing.T) {
 		`failed to load TLS config: for auth via TLS, either both certificate and key must be supplied, or neither`)
 }
 
+// extensionsHost is a component.Host providing the given extensions.
+type extensionsHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *extensionsHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func TestHTTPAuthentication(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.HTTP.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.HTTP.Auth = &configauth.Authentication{AuthenticatorName: "mock"}
+	cfg.GRPC = nil
+	host := &extensionsHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{
+			config.NewID("mock"): &configauth.MockAuthenticator{
+				AuthenticateFunc: func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+					if len(headers["Authorization"]) == 0 || headers["Authorization"][0] != "Bearer secret" {
+						return ctx, errors.New("invalid bearer token")
+					}
+					return ctx, nil
+				},
+			},
+		},
+	}
+
+	sink := new(consumertest.TracesSink)
+	r := newReceiver(t, factory, cfg, sink, nil)
+	require.NoError(t, r.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	body, err := otlp.NewProtobufTracesMarshaler().MarshalTraces(testdata.GenerateTracesOneSpan())
+	require.NoError(t, err)
+	url := fmt.Sprintf("http://%s/v1/traces", cfg.HTTP.Endpoint)
+
+	resp, err := http.Post(url, "application/x-protobuf", bytes.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, 0, sink.SpanCount())
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 1, sink.SpanCount())
+}
+
 func newGRPCReceiver(t *testing.T, name string, endpoint string, tc consumer.Traces, mc consumer.Metrics) component.Component {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig().(*Config)
@@ -163,7 +163,7 @@ func (mg *metricGroupPdata) toSummaryPoint(orderedLabelKeys []string, dest *pdat
 	for _, p := range mg.complexValue {
 		quantile := quantileValues.AppendEmpty()
 		quantile.SetValue(p.value)
-		quantile.SetQuantile(p.boundary * 100)
+		quantile.SetQuantile(p.boundary)
 	}
 
 	// Based on the summary description from https://prometheus.io/docs/concepts/metric_types/#summary
@@ -274,11 +274,16 @@ func (mf *metricFamilyPdata) getGroups() []*metricGroupPdata {
 
 func (mf *metricFamilyPdata) ToMetricPdata(metrics *pdata.MetricSlice) (int, int) {
 	metric := pdata.NewMetric()
+	metric.SetName(mf.name)
+	metric.SetDescription(mf.metadata.Help)
+	metric.SetUnit(mf.metadata.Unit)
 	pointCount := 0
 
 	switch mf.mtype {
 	case pdata.MetricDataTypeHistogram:
+		metric.SetDataType(pdata.MetricDataTypeHistogram)
 		histogram := metric.Histogram()
+		histogram.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		hdpL := histogram.DataPoints()
 		for _, mg := range mf.getGroups() {
 			if !mg.toDistributionPoint(mf.labelKeysOrdered, &hdpL) {
@@ -288,6 +293,7 @@ func (mf *metricFamilyPdata) ToMetricPdata(metrics *pdata.MetricSlice) (int, int
 		pointCount = hdpL.Len()
 
 	case pdata.MetricDataTypeSummary:
+		metric.SetDataType(pdata.MetricDataTypeSummary)
 		summary := metric.Summary()
 		sdpL := summary.DataPoints()
 		for _, mg := range mf.getGroups() {
@@ -298,7 +304,10 @@ func (mf *metricFamilyPdata) ToMetricPdata(metrics *pdata.MetricSlice) (int, int
 		pointCount = sdpL.Len()
 
 	case pdata.MetricDataTypeSum:
+		metric.SetDataType(pdata.MetricDataTypeSum)
 		sum := metric.Sum()
+		sum.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		sum.SetIsMonotonic(true)
 		sdpL := sum.DataPoints()
 		for _, mg := range mf.getGroups() {
 			if !mg.toNumberDataPoint(mf.labelKeysOrdered, &sdpL) {
@@ -308,6 +317,7 @@ func (mf *metricFamilyPdata) ToMetricPdata(metrics *pdata.MetricSlice) (int, int
 		pointCount = sdpL.Len()
 
 	default:
+		metric.SetDataType(pdata.MetricDataTypeGauge)
 		gauge := metric.Gauge()
 		gdpL := gauge.DataPoints()
 		for _, mg := range mf.getGroups() {
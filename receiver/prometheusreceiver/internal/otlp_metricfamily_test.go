@@ -332,16 +332,16 @@ func TestMetricGroupData_toSummaryUnitTest(t *testing.T) {
 				qn0.SetQuantile(0)
 				qn0.SetValue(8)
 				qn50 := qtL.AppendEmpty()
-				qn50.SetQuantile(50)
+				qn50.SetQuantile(.50)
 				qn50.SetValue(27)
 				qn75 := qtL.AppendEmpty()
-				qn75.SetQuantile(75)
+				qn75.SetQuantile(.75)
 				qn75.SetValue(33.7)
 				qn90 := qtL.AppendEmpty()
-				qn90.SetQuantile(90)
+				qn90.SetQuantile(.90)
 				qn90.SetValue(56)
 				qn99 := qtL.AppendEmpty()
-				qn99.SetQuantile(99)
+				qn99.SetQuantile(.99)
 				qn99.SetValue(82)
 				point.SetTimestamp(14 * 1e6) // the time in milliseconds -> nanoseconds.
 				point.SetStartTimestamp(14 * 1e6)
@@ -445,7 +445,8 @@ func TestMetricGroupData_toSummaryPointEquivalence(t *testing.T) {
 			require.Equal(t, len(ocQuantiles), pdataQuantiles.Len())
 			for i, ocQuantile := range ocQuantiles {
 				pdataQuantile := pdataQuantiles.At(i)
-				require.Equal(t, ocQuantile.Percentile, pdataQuantile.Quantile(), "The quantile percentiles must match")
+				// OpenCensus reports percentiles, OTLP reports quantiles in the [0, 1] range.
+				require.Equal(t, ocQuantile.Percentile/100, pdataQuantile.Quantile(), "The quantile percentiles must match")
 				require.Equal(t, ocQuantile.Value, pdataQuantile.Value(), "The quantile values must match")
 			}
 		})
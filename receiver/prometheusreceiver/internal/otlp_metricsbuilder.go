@@ -110,10 +110,6 @@ func newMetricBuilderPdata(mc MetadataCache, useStartTimeMetric bool, startTimeM
 	}
 }
 
-// This code is used in follow-up changes but golangci-lint is so pedantic.
-var _ = newMetricBuilderPdata
-var _ = (*metricBuilderPdata)(nil).AddDataPoint
-
 // AddDataPoint is for feeding prometheus data complexValue in its processing order
 func (b *metricBuilderPdata) AddDataPoint(ls labels.Labels, t int64, v float64) (rerr error) {
 	// Any datapoint with duplicate labels MUST be rejected per:
@@ -181,3 +177,23 @@ func (b *metricBuilderPdata) AddDataPoint(ls labels.Labels, t int64, v float64)
 
 	return b.currentMf.Add(metricName, ls, t, v)
 }
+
+// Build returns the pdata.MetricSlice built from all the added data points, along with the
+// number of time series and dropped time series. The only error returned is errNoDataToBuild.
+func (b *metricBuilderPdata) Build() (pdata.MetricSlice, int, int, error) {
+	if !b.hasData {
+		if b.hasInternalMetric {
+			return pdata.NewMetricSlice(), 0, 0, nil
+		}
+		return b.metrics, 0, 0, errNoDataToBuild
+	}
+
+	if b.currentMf != nil {
+		ts, dts := b.currentMf.ToMetricPdata(&b.metrics)
+		b.numTimeseries += ts
+		b.droppedTimeseries += dts
+		b.currentMf = nil
+	}
+
+	return b.metrics, b.numTimeseries, b.droppedTimeseries, nil
+}
@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/pkg/textparse"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/prometheus/prometheus/scrape"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// remoteWriteMetadata is a MetadataCache built from the metadata sent in a
+// remote-write request, completed with types inferred from the series names.
+type remoteWriteMetadata map[string]scrape.MetricMetadata
+
+var _ MetadataCache = (remoteWriteMetadata)(nil)
+
+func (m remoteWriteMetadata) Metadata(metricName string) (scrape.MetricMetadata, bool) {
+	md, ok := m[metricName]
+	return md, ok
+}
+
+func (m remoteWriteMetadata) SharedLabels() labels.Labels {
+	return nil
+}
+
+func convPrompbMetricType(t prompb.MetricMetadata_MetricType) textparse.MetricType {
+	switch t {
+	case prompb.MetricMetadata_COUNTER:
+		return textparse.MetricTypeCounter
+	case prompb.MetricMetadata_GAUGE:
+		return textparse.MetricTypeGauge
+	case prompb.MetricMetadata_HISTOGRAM:
+		return textparse.MetricTypeHistogram
+	case prompb.MetricMetadata_GAUGEHISTOGRAM:
+		return textparse.MetricTypeGaugeHistogram
+	case prompb.MetricMetadata_SUMMARY:
+		return textparse.MetricTypeSummary
+	case prompb.MetricMetadata_INFO:
+		return textparse.MetricTypeInfo
+	case prompb.MetricMetadata_STATESET:
+		return textparse.MetricTypeStateset
+	default:
+		return textparse.MetricTypeUnknown
+	}
+}
+
+// newRemoteWriteMetadata uses the metadata of the request when available, and
+// otherwise infers the type of a family from the names and labels of its series:
+// "_bucket" series with an "le" label are histograms, series with a "quantile"
+// label are summaries and "_total" series are counters.
+func newRemoteWriteMetadata(req *prompb.WriteRequest, series []labels.Labels) remoteWriteMetadata {
+	mc := make(remoteWriteMetadata)
+	for _, md := range req.Metadata {
+		mc[md.MetricFamilyName] = scrape.MetricMetadata{
+			Metric: md.MetricFamilyName,
+			Type:   convPrompbMetricType(md.Type),
+			Help:   md.Help,
+			Unit:   md.Unit,
+		}
+	}
+
+	infer := func(family string, mtype textparse.MetricType) {
+		if _, ok := mc[family]; !ok {
+			mc[family] = scrape.MetricMetadata{Metric: family, Type: mtype}
+		}
+	}
+	for _, ls := range series {
+		name := ls.Get(model.MetricNameLabel)
+		switch {
+		case strings.HasSuffix(name, metricsSuffixBucket) && ls.Has(model.BucketLabel):
+			infer(strings.TrimSuffix(name, metricsSuffixBucket), textparse.MetricTypeHistogram)
+		case ls.Has(model.QuantileLabel):
+			infer(name, textparse.MetricTypeSummary)
+		case strings.HasSuffix(name, metricSuffixTotal):
+			infer(name, textparse.MetricTypeCounter)
+		}
+	}
+	return mc
+}
+
+type remoteWriteSample struct {
+	ls labels.Labels
+	v  float64
+}
+
+type remoteWriteTarget struct {
+	job      string
+	instance string
+	// samples groups the samples of the target by their timestamp.
+	samples map[int64][]remoteWriteSample
+}
+
+// RemoteWriteToMetrics converts a Prometheus remote-write request into pdata.Metrics.
+// The series are grouped into one resource per job and instance labels, and each
+// timestamp is translated like a single scrape of that target. It returns the number
+// of dropped time series together with the metrics.
+func RemoteWriteToMetrics(req *prompb.WriteRequest, logger *zap.Logger) (pdata.Metrics, int) {
+	md := pdata.NewMetrics()
+
+	series := make([]labels.Labels, len(req.Timeseries))
+	for i, ts := range req.Timeseries {
+		ls := make(labels.Labels, 0, len(ts.Labels))
+		for _, l := range ts.Labels {
+			ls = append(ls, labels.Label{Name: l.Name, Value: l.Value})
+		}
+		sort.Sort(ls)
+		series[i] = ls
+	}
+	mc := newRemoteWriteMetadata(req, series)
+
+	var targets []*remoteWriteTarget
+	targetsByKey := make(map[string]*remoteWriteTarget)
+	for i, ts := range req.Timeseries {
+		ls := series[i]
+		job, instance := ls.Get(model.JobLabel), ls.Get(model.InstanceLabel)
+		key := job + "\xff" + instance
+		target, ok := targetsByKey[key]
+		if !ok {
+			target = &remoteWriteTarget{job: job, instance: instance, samples: make(map[int64][]remoteWriteSample)}
+			targetsByKey[key] = target
+			targets = append(targets, target)
+		}
+		for _, s := range ts.Samples {
+			target.samples[s.Timestamp] = append(target.samples[s.Timestamp], remoteWriteSample{ls: ls, v: s.Value})
+		}
+	}
+
+	dropped := 0
+	for _, target := range targets {
+		rm := md.ResourceMetrics().AppendEmpty()
+		if target.job != "" || target.instance != "" {
+			createNodeAndResourcePdata(target.job, target.instance, "").CopyTo(rm.Resource())
+			rm.Resource().Attributes().Delete(schemeAttr)
+		}
+		metrics := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+
+		timestamps := make([]int64, 0, len(target.samples))
+		for t := range target.samples {
+			timestamps = append(timestamps, t)
+		}
+		sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+
+		for _, t := range timestamps {
+			samples := target.samples[t]
+			// The builder expects the series of a family to be consecutive.
+			sort.SliceStable(samples, func(i, j int) bool {
+				ni, nj := samples[i].ls.Get(model.MetricNameLabel), samples[j].ls.Get(model.MetricNameLabel)
+				if fi, fj := normalizeMetricName(ni), normalizeMetricName(nj); fi != fj {
+					return fi < fj
+				}
+				return ni < nj
+			})
+
+			b := newMetricBuilderPdata(mc, false, "", logger, newStalenessStore())
+			for _, s := range samples {
+				if err := b.AddDataPoint(s.ls, t, s.v); err != nil {
+					logger.Debug("Failed to add remote-write sample", zap.Error(err))
+				}
+			}
+			ms, _, dts, err := b.Build()
+			if err != nil {
+				dropped += len(samples)
+				continue
+			}
+			dropped += dts
+			ms.MoveAndAppendTo(metrics)
+		}
+	}
+	return md, dropped
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func newSeries(value float64, timestamp int64, kvs ...string) prompb.TimeSeries {
+	ts := prompb.TimeSeries{Samples: []prompb.Sample{{Value: value, Timestamp: timestamp}}}
+	for i := 0; i < len(kvs); i += 2 {
+		ts.Labels = append(ts.Labels, prompb.Label{Name: kvs[i], Value: kvs[i+1]})
+	}
+	return ts
+}
+
+func TestRemoteWriteToMetricsInferredTypes(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			newSeries(2, 1000, "__name__", "latency_bucket", "le", "0.5", "job", "app", "instance", "host:80"),
+			newSeries(5, 1000, "__name__", "latency_bucket", "le", "+Inf", "job", "app", "instance", "host:80"),
+			newSeries(5, 1000, "__name__", "latency_count", "job", "app", "instance", "host:80"),
+			newSeries(1.5, 1000, "__name__", "latency_sum", "job", "app", "instance", "host:80"),
+			newSeries(0.1, 1000, "__name__", "rpc", "quantile", "0.5", "job", "app", "instance", "host:80"),
+			newSeries(3, 1000, "__name__", "rpc_count", "job", "app", "instance", "host:80"),
+			newSeries(0.4, 1000, "__name__", "rpc_sum", "job", "app", "instance", "host:80"),
+			newSeries(7, 1000, "__name__", "queue_size", "job", "other"),
+		},
+	}
+
+	md, dropped := RemoteWriteToMetrics(req, zap.NewNop())
+	assert.Equal(t, 0, dropped)
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+
+	rm := md.ResourceMetrics().At(0)
+	port, ok := rm.Resource().Attributes().Get(portAttr)
+	require.True(t, ok)
+	assert.Equal(t, "80", port.StringVal())
+	_, ok = rm.Resource().Attributes().Get(schemeAttr)
+	assert.False(t, ok)
+
+	metrics := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+
+	histogram := metrics.At(0)
+	assert.Equal(t, "latency", histogram.Name())
+	require.Equal(t, pdata.MetricDataTypeHistogram, histogram.DataType())
+	hdp := histogram.Histogram().DataPoints().At(0)
+	assert.Equal(t, []float64{0.5}, hdp.ExplicitBounds())
+	assert.Equal(t, []uint64{2, 3}, hdp.BucketCounts())
+	assert.Equal(t, uint64(5), hdp.Count())
+	assert.Equal(t, 1.5, hdp.Sum())
+
+	summary := metrics.At(1)
+	assert.Equal(t, "rpc", summary.Name())
+	require.Equal(t, pdata.MetricDataTypeSummary, summary.DataType())
+	sdp := summary.Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(3), sdp.Count())
+	assert.Equal(t, 0.5, sdp.QuantileValues().At(0).Quantile())
+	assert.Equal(t, 0.1, sdp.QuantileValues().At(0).Value())
+
+	rm = md.ResourceMetrics().At(1)
+	job, ok := rm.Resource().Attributes().Get(jobAttr)
+	require.True(t, ok)
+	assert.Equal(t, "other", job.StringVal())
+	gauge := rm.InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	require.Equal(t, pdata.MetricDataTypeGauge, gauge.DataType())
+	assert.Equal(t, 7.0, gauge.Gauge().DataPoints().At(0).DoubleVal())
+}
+
+func TestRemoteWriteToMetricsWithMetadata(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			newSeries(1, 1000, "__name__", "jobs_done"),
+			newSeries(2, 2000, "__name__", "jobs_done"),
+		},
+		Metadata: []prompb.MetricMetadata{
+			{MetricFamilyName: "jobs_done", Type: prompb.MetricMetadata_COUNTER, Help: "Finished jobs", Unit: "1"},
+		},
+	}
+
+	md, dropped := RemoteWriteToMetrics(req, zap.NewNop())
+	assert.Equal(t, 0, dropped)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	assert.Equal(t, 0, md.ResourceMetrics().At(0).Resource().Attributes().Len())
+
+	// Each timestamp is converted separately.
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	for i, want := range []float64{1, 2} {
+		metric := metrics.At(i)
+		assert.Equal(t, "jobs_done", metric.Name())
+		assert.Equal(t, "Finished jobs", metric.Description())
+		assert.Equal(t, "1", metric.Unit())
+		require.Equal(t, pdata.MetricDataTypeSum, metric.DataType())
+		assert.True(t, metric.Sum().IsMonotonic())
+		assert.Equal(t, pdata.AggregationTemporalityCumulative, metric.Sum().AggregationTemporality())
+		dp := metric.Sum().DataPoints().At(0)
+		assert.Equal(t, want, dp.DoubleVal())
+		assert.Equal(t, pdata.Timestamp(int64(want)*1e9), dp.Timestamp())
+	}
+}
+
+func TestRemoteWriteToMetricsDropped(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			newSeries(1, 1000, "job", "app"),
+		},
+	}
+
+	md, dropped := RemoteWriteToMetrics(req, zap.NewNop())
+	assert.Equal(t, 1, dropped)
+	assert.Equal(t, 0, md.DataPointCount())
+}
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver
+
+import (
+	"github.com/prometheus/prometheus/prompb"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/prometheusreceiver/internal"
+)
+
+// RemoteWriteToMetrics converts a Prometheus remote-write request into pdata.Metrics using
+// the same translation as the scrape path of this receiver. Series are grouped into one
+// resource per "job" and "instance" labels. The number of dropped time series is returned
+// together with the metrics.
+func RemoteWriteToMetrics(req *prompb.WriteRequest, logger *zap.Logger) (pdata.Metrics, int) {
+	return internal.RemoteWriteToMetrics(req, logger)
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config defines configuration for Prometheus remote-write receiver.
+type Config struct {
+	config.ReceiverSettings       `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// MaxRequestBodySize is the maximum size in bytes of the compressed body of a request,
+	// larger requests are rejected with http.StatusRequestEntityTooLarge.
+	MaxRequestBodySize int64 `mapstructure:"max_request_body_size"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.MaxRequestBodySize <= 0 {
+		return errors.New("max_request_body_size must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+// This file implements factory for Prometheus remote-write receiver.
+
+const (
+	typeStr = "prometheusremotewrite"
+
+	defaultBindEndpoint = "0.0.0.0:19291"
+
+	defaultMaxRequestBodySize = 10 * 1024 * 1024
+)
+
+// NewFactory creates a new Prometheus remote-write receiver factory.
+func NewFactory() component.ReceiverFactory {
+	return receiverhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiverhelper.WithMetrics(createMetricsReceiver),
+	)
+}
+
+// createDefaultConfig creates the default configuration for Prometheus remote-write receiver.
+func createDefaultConfig() config.Receiver {
+	return &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewID(typeStr)),
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: defaultBindEndpoint,
+		},
+		MaxRequestBodySize: defaultMaxRequestBodySize,
+	}
+}
+
+// createMetricsReceiver creates a metrics receiver based on provided config.
+func createMetricsReceiver(
+	_ context.Context,
+	set component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Metrics,
+) (component.MetricsReceiver, error) {
+	rCfg := cfg.(*Config)
+	return newReceiver(rCfg, set, nextConsumer)
+}
@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/receiver/prometheusreceiver"
+)
+
+const (
+	writePath = "/api/v1/write"
+
+	receiverTransport = "http"
+	receiverFormat    = "prometheus_remote_write"
+)
+
+var (
+	errRequestBodyTooLarge = errors.New("request body too large")
+	errInvalidTimeSeries   = errors.New("invalid time series")
+)
+
+// remoteWriteReceiver receives Prometheus remote-write requests over HTTP.
+type remoteWriteReceiver struct {
+	logger       *zap.Logger
+	config       *Config
+	nextConsumer consumer.Metrics
+	obsrecv      *obsreport.Receiver
+
+	server     *http.Server
+	shutdownWG sync.WaitGroup
+}
+
+var _ http.Handler = (*remoteWriteReceiver)(nil)
+
+// newReceiver creates a new prometheusremotewritereceiver.remoteWriteReceiver reference.
+func newReceiver(config *Config, set component.ReceiverCreateSettings, nextConsumer consumer.Metrics) (*remoteWriteReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+
+	return &remoteWriteReceiver{
+		logger:       set.Logger,
+		config:       config,
+		nextConsumer: nextConsumer,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID: config.ID(),
+			Transport:  receiverTransport,
+		}),
+	}, nil
+}
+
+// Start spins up the receiver's HTTP server.
+func (r *remoteWriteReceiver) Start(_ context.Context, host component.Host) error {
+	if host == nil {
+		return errors.New("nil host")
+	}
+
+	authenticator, err := r.config.HTTPServerSettings.ServerAuthenticator(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+
+	listener, err := r.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(writePath, r)
+	r.server = r.config.HTTPServerSettings.ToServer(mux, confighttp.WithAuthenticator(authenticator))
+
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+
+		if errHTTP := r.server.Serve(listener); errHTTP != http.ErrServerClosed {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+
+	return nil
+}
+
+// Shutdown stops the HTTP server.
+func (r *remoteWriteReceiver) Shutdown(context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	err := r.server.Close()
+	r.shutdownWG.Wait()
+	return err
+}
+
+// ServeHTTP decodes a snappy compressed prompb.WriteRequest and sends the
+// converted metrics to the next consumer. The status codes follow the
+// remote-write protocol: 4xx responses are not retried by the sender, 5xx
+// responses are retried, so only errors that can succeed later map to 5xx.
+func (r *remoteWriteReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, fmt.Sprintf("method %s not allowed", req.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := req.Context()
+	if c, ok := client.FromHTTP(req); ok {
+		ctx = client.NewContext(ctx, c)
+	}
+	ctx = r.obsrecv.StartMetricsOp(ctx)
+
+	wr, err := decodeWriteRequest(w, req, r.config.MaxRequestBodySize)
+	if err != nil {
+		r.obsrecv.EndMetricsOp(ctx, receiverFormat, 0, err)
+		status := http.StatusBadRequest
+		if errors.Is(err, errRequestBodyTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	md, dropped := prometheusreceiver.RemoteWriteToMetrics(wr, r.logger)
+	if dropped > 0 {
+		// Record the dropped points as refused, in an operation of their own since the
+		// other points of the request may still be accepted.
+		r.logger.Debug("Dropped invalid remote-write time series", zap.Int("count", dropped))
+		droppedCtx := r.obsrecv.StartMetricsOp(ctx)
+		r.obsrecv.EndMetricsOp(droppedCtx, receiverFormat, dropped, errInvalidTimeSeries)
+	}
+
+	numPoints := md.DataPointCount()
+	if numPoints == 0 {
+		r.obsrecv.EndMetricsOp(ctx, receiverFormat, 0, nil)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	consumerErr := r.nextConsumer.ConsumeMetrics(ctx, md)
+	r.obsrecv.EndMetricsOp(ctx, receiverFormat, numPoints, consumerErr)
+
+	if consumerErr != nil {
+		if consumererror.IsPermanent(consumerErr) {
+			http.Error(w, consumerErr.Error(), http.StatusBadRequest)
+			return
+		}
+		// Transient error, e.g. the memory limiter refused the data; ask the
+		// sender to back off and retry.
+		http.Error(w, consumerErr.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeWriteRequest(w http.ResponseWriter, req *http.Request, maxBodySize int64) (*prompb.WriteRequest, error) {
+	body := http.MaxBytesReader(w, req.Body, maxBodySize)
+	compressed, err := ioutil.ReadAll(body)
+	_ = body.Close()
+	if err != nil {
+		// The reader fails past the limit, after returning all the bytes up to it.
+		if int64(len(compressed)) >= maxBodySize {
+			return nil, fmt.Errorf("%w, the limit is %d bytes", errRequestBodyTooLarge, maxBodySize)
+		}
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress request body: %w", err)
+	}
+
+	wr := &prompb.WriteRequest{}
+	if err = proto.Unmarshal(data, wr); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal write request: %w", err)
+	}
+	return wr, nil
+}
@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+	"go.opentelemetry.io/collector/testutil"
+)
+
+func newTestWriteRequest() *prompb.WriteRequest {
+	return &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "http_requests_total"},
+					{Name: "code", Value: "200"},
+					{Name: "instance", Value: "localhost:8080"},
+					{Name: "job", Value: "app"},
+				},
+				Samples: []prompb.Sample{{Value: 42, Timestamp: 1000}},
+			},
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "temperature"},
+					{Name: "instance", Value: "localhost:8080"},
+					{Name: "job", Value: "app"},
+				},
+				Samples: []prompb.Sample{{Value: 21.5, Timestamp: 1000}},
+			},
+		},
+	}
+}
+
+func startReceiver(t *testing.T, next consumer.Metrics) string {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	return startReceiverWithConfig(t, cfg, componenttest.NewNopHost(), next)
+}
+
+func startReceiverWithConfig(t *testing.T, cfg *Config, host component.Host, next consumer.Metrics) string {
+	r, err := newReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), next)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), host))
+	t.Cleanup(func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	})
+	return "http://" + cfg.Endpoint + writePath
+}
+
+func postWriteRequest(t *testing.T, url string, wr *prompb.WriteRequest) *http.Response {
+	data, err := proto.Marshal(wr)
+	require.NoError(t, err)
+	resp, err := http.Post(url, "application/x-protobuf", bytes.NewReader(snappy.Encode(nil, data)))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp
+}
+
+func TestReceiveWriteRequest(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink)
+
+	resp := postWriteRequest(t, url, newTestWriteRequest())
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	mds := sink.AllMetrics()
+	require.Len(t, mds, 1)
+	require.Equal(t, 1, mds[0].ResourceMetrics().Len())
+	rm := mds[0].ResourceMetrics().At(0)
+
+	job, ok := rm.Resource().Attributes().Get("job")
+	require.True(t, ok)
+	assert.Equal(t, "app", job.StringVal())
+	instance, ok := rm.Resource().Attributes().Get("instance")
+	require.True(t, ok)
+	assert.Equal(t, "localhost:8080", instance.StringVal())
+
+	metrics := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "http_requests_total", metrics.At(0).Name())
+	require.Equal(t, pdata.MetricDataTypeSum, metrics.At(0).DataType())
+	assert.Equal(t, 42.0, metrics.At(0).Sum().DataPoints().At(0).DoubleVal())
+	assert.Equal(t, "temperature", metrics.At(1).Name())
+	require.Equal(t, pdata.MetricDataTypeGauge, metrics.At(1).DataType())
+	assert.Equal(t, 21.5, metrics.At(1).Gauge().DataPoints().At(0).DoubleVal())
+}
+
+func TestReceiveStatusCodes(t *testing.T) {
+	tests := []struct {
+		name       string
+		next       consumer.Metrics
+		wantStatus int
+	}{
+		{
+			name:       "transient error",
+			next:       consumertest.NewErr(errors.New("memory limit")),
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "permanent error",
+			next:       consumertest.NewErr(consumererror.Permanent(errors.New("invalid"))),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := startReceiver(t, tt.next)
+			resp := postWriteRequest(t, url, newTestWriteRequest())
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+// extensionsHost is a component.Host providing the given extensions.
+type extensionsHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *extensionsHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func TestReceiveAuthentication(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.Auth = &configauth.Authentication{AuthenticatorName: "mock"}
+	host := &extensionsHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{
+			config.NewID("mock"): &configauth.MockAuthenticator{
+				AuthenticateFunc: func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+					return ctx, errors.New("not authenticated")
+				},
+			},
+		},
+	}
+	sink := new(consumertest.MetricsSink)
+	url := startReceiverWithConfig(t, cfg, host, sink)
+
+	resp := postWriteRequest(t, url, newTestWriteRequest())
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Empty(t, sink.AllMetrics())
+}
+
+func TestStartUnknownAuthenticator(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.Auth = &configauth.Authentication{AuthenticatorName: "missing"}
+
+	r, err := newReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.EqualError(t, r.Start(context.Background(), componenttest.NewNopHost()), `failed to resolve authenticator "missing": authenticator not found`)
+}
+
+func TestReceiveDroppedSeries(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink)
+
+	// A series without a metric name cannot be converted.
+	wr := newTestWriteRequest()
+	wr.Timeseries = append(wr.Timeseries, prompb.TimeSeries{
+		Labels:  []prompb.Label{{Name: "job", Value: "app"}},
+		Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}},
+	})
+	resp := postWriteRequest(t, url, wr)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	assert.Equal(t, 2, sink.DataPointCount())
+	obsreporttest.CheckReceiverMetrics(t, config.NewID(typeStr), receiverTransport, 2, 1)
+}
+
+func TestReceiveRequestBodyTooLarge(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.MaxRequestBodySize = 16
+	sink := new(consumertest.MetricsSink)
+	url := startReceiverWithConfig(t, cfg, componenttest.NewNopHost(), sink)
+
+	resp := postWriteRequest(t, url, newTestWriteRequest())
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	assert.Empty(t, sink.AllMetrics())
+
+	// A body of exactly the maximum size is read.
+	resp, err := http.Post(url, "application/x-protobuf", bytes.NewReader(make([]byte, 16)))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestReceiveInvalidRequest(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink)
+
+	resp, err := http.Post(url, "application/x-protobuf", bytes.NewReader([]byte("not snappy")))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Get(url)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	assert.Len(t, sink.AllMetrics(), 0)
+}
@@ -0,0 +1,344 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+const (
+	instrumentationLibraryName = "otelcol/statsdreceiver"
+	metricTypeAttributeKey     = "metric_type"
+)
+
+var (
+	defaultExplicitBounds = []float64{1, 2, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+	defaultQuantiles      = []float64{0.5, 0.9, 0.95, 0.99}
+)
+
+// series holds the state aggregated for a single name, type and label set
+// during a flush interval.
+type series struct {
+	name       string
+	metricType statsDMetricType
+	labels     []label
+
+	sum     float64
+	gauge   float64
+	members map[string]struct{}
+	samples []sample
+}
+
+// sample is a single timer, histogram or distribution observation. The weight
+// is the inverse of the sample rate.
+type sample struct {
+	value  float64
+	weight float64
+}
+
+// aggregator aggregates parsed StatsD metrics between flushes. It is not safe
+// for concurrent use.
+type aggregator struct {
+	enableMetricType   bool
+	isMonotonicCounter bool
+	observers          map[statsDMetricType]TimerHistogramMapping
+
+	lastFlush time.Time
+	series    map[string]*series
+	// order keeps the flush output stable across runs.
+	order []string
+	// gauges keeps the last value of each gauge across flushes so that
+	// relative updates ("+N"/"-N") apply to the previous value.
+	gauges map[string]*gaugeValue
+	// gaugeExpiration is the number of flushes without update after which
+	// a gauge is removed from gauges, zero means never.
+	gaugeExpiration int
+}
+
+// gaugeValue is the last value of a gauge, and the number of flushes since it
+// was last updated.
+type gaugeValue struct {
+	value       float64
+	idleFlushes int
+}
+
+func newAggregator(cfg *Config, now time.Time) *aggregator {
+	observers := make(map[statsDMetricType]TimerHistogramMapping)
+	for _, m := range cfg.TimerHistogramMapping {
+		switch m.StatsDType {
+		case statsDTypeTimer:
+			observers[timerType] = m
+		case statsDTypeHistogram:
+			observers[histogramType] = m
+		case statsDTypeDistribution:
+			observers[distributionType] = m
+		}
+	}
+	return &aggregator{
+		enableMetricType:   cfg.EnableMetricType,
+		isMonotonicCounter: cfg.IsMonotonicCounter,
+		observers:          observers,
+		lastFlush:          now,
+		series:             make(map[string]*series),
+		gauges:             make(map[string]*gaugeValue),
+		gaugeExpiration:    cfg.GaugeExpirationFlushes,
+	}
+}
+
+func seriesKey(m statsDMetric) string {
+	var b strings.Builder
+	b.WriteString(string(m.metricType))
+	b.WriteByte('|')
+	b.WriteString(m.name)
+	for _, l := range m.labels {
+		b.WriteByte('|')
+		b.WriteString(l.key)
+		b.WriteByte('=')
+		b.WriteString(l.value)
+	}
+	return b.String()
+}
+
+// aggregate adds the parsed metric to the current flush interval.
+func (a *aggregator) aggregate(m statsDMetric) {
+	key := seriesKey(m)
+	s, ok := a.series[key]
+	if !ok {
+		s = &series{
+			name:       m.name,
+			metricType: m.metricType,
+			labels:     m.labels,
+		}
+		a.series[key] = s
+		a.order = append(a.order, key)
+	}
+
+	switch m.metricType {
+	case counterType:
+		s.sum += m.value / m.sampleRate
+	case gaugeType:
+		g, ok := a.gauges[key]
+		if !ok {
+			g = &gaugeValue{}
+			a.gauges[key] = g
+		}
+		if m.relative {
+			g.value += m.value
+		} else {
+			g.value = m.value
+		}
+		g.idleFlushes = 0
+		s.gauge = g.value
+	case setType:
+		if s.members == nil {
+			s.members = make(map[string]struct{})
+		}
+		s.members[m.rawValue] = struct{}{}
+	case timerType, histogramType, distributionType:
+		s.samples = append(s.samples, sample{value: m.value, weight: 1 / m.sampleRate})
+	}
+}
+
+// flush converts everything aggregated since the previous flush into
+// pdata.Metrics and resets the interval state.
+func (a *aggregator) flush(now time.Time) pdata.Metrics {
+	a.expireGauges()
+
+	md := pdata.NewMetrics()
+	if len(a.order) == 0 {
+		a.lastFlush = now
+		return md
+	}
+
+	ilm := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName(instrumentationLibraryName)
+
+	start := pdata.TimestampFromTime(a.lastFlush)
+	ts := pdata.TimestampFromTime(now)
+
+	metrics := make(map[string]pdata.Metric)
+	for _, key := range a.order {
+		s := a.series[key]
+		observer := a.observers[s.metricType]
+
+		metricKey := string(s.metricType) + "|" + s.name
+		metric, ok := metrics[metricKey]
+		if !ok {
+			metric = ilm.Metrics().AppendEmpty()
+			metric.SetName(s.name)
+			a.initMetric(metric, s.metricType, observer)
+			metrics[metricKey] = metric
+		}
+
+		switch metric.DataType() {
+		case pdata.MetricDataTypeSum:
+			dp := metric.Sum().DataPoints().AppendEmpty()
+			dp.SetStartTimestamp(start)
+			dp.SetTimestamp(ts)
+			dp.SetIntVal(int64(math.Round(s.sum)))
+			a.setAttributes(dp.Attributes(), s)
+		case pdata.MetricDataTypeGauge:
+			dp := metric.Gauge().DataPoints().AppendEmpty()
+			dp.SetTimestamp(ts)
+			switch s.metricType {
+			case setType:
+				dp.SetIntVal(int64(len(s.members)))
+			case gaugeType:
+				dp.SetDoubleVal(s.gauge)
+			default:
+				dp.SetDoubleVal(s.samples[len(s.samples)-1].value)
+			}
+			a.setAttributes(dp.Attributes(), s)
+		case pdata.MetricDataTypeHistogram:
+			dp := metric.Histogram().DataPoints().AppendEmpty()
+			dp.SetStartTimestamp(start)
+			dp.SetTimestamp(ts)
+			fillHistogramDataPoint(dp, s.samples, observer.ExplicitBounds)
+			a.setAttributes(dp.Attributes(), s)
+		case pdata.MetricDataTypeSummary:
+			dp := metric.Summary().DataPoints().AppendEmpty()
+			dp.SetStartTimestamp(start)
+			dp.SetTimestamp(ts)
+			fillSummaryDataPoint(dp, s.samples, observer.Quantiles)
+			a.setAttributes(dp.Attributes(), s)
+		}
+	}
+
+	a.series = make(map[string]*series)
+	a.order = a.order[:0]
+	a.lastFlush = now
+	return md
+}
+
+// expireGauges removes the gauges that were not updated during the last
+// gaugeExpiration flush intervals, including the current one.
+func (a *aggregator) expireGauges() {
+	if a.gaugeExpiration == 0 {
+		return
+	}
+	for key, g := range a.gauges {
+		if _, updated := a.series[key]; updated {
+			continue
+		}
+		g.idleFlushes++
+		if g.idleFlushes >= a.gaugeExpiration {
+			delete(a.gauges, key)
+		}
+	}
+}
+
+func (a *aggregator) initMetric(metric pdata.Metric, metricType statsDMetricType, observer TimerHistogramMapping) {
+	switch metricType {
+	case counterType:
+		metric.SetDataType(pdata.MetricDataTypeSum)
+		metric.Sum().SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		metric.Sum().SetIsMonotonic(a.isMonotonicCounter)
+	case gaugeType, setType:
+		metric.SetDataType(pdata.MetricDataTypeGauge)
+	default:
+		switch observer.ObserverType {
+		case observerTypeHistogram:
+			metric.SetDataType(pdata.MetricDataTypeHistogram)
+			metric.Histogram().SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		case observerTypeSummary:
+			metric.SetDataType(pdata.MetricDataTypeSummary)
+		default:
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		}
+		if metricType == timerType {
+			metric.SetUnit("ms")
+		}
+	}
+}
+
+func (a *aggregator) setAttributes(attrs pdata.AttributeMap, s *series) {
+	attrs.EnsureCapacity(len(s.labels) + 1)
+	for _, l := range s.labels {
+		attrs.UpsertString(l.key, l.value)
+	}
+	if a.enableMetricType {
+		attrs.UpsertString(metricTypeAttributeKey, s.metricType.attributeName())
+	}
+}
+
+func fillHistogramDataPoint(dp pdata.HistogramDataPoint, samples []sample, bounds []float64) {
+	if len(bounds) == 0 {
+		bounds = defaultExplicitBounds
+	}
+	counts := make([]float64, len(bounds)+1)
+	var count, sum float64
+	for _, smp := range samples {
+		counts[sort.SearchFloat64s(bounds, smp.value)] += smp.weight
+		count += smp.weight
+		sum += smp.value * smp.weight
+	}
+
+	bucketCounts := make([]uint64, len(counts))
+	for i, c := range counts {
+		bucketCounts[i] = uint64(math.Round(c))
+	}
+	dp.SetExplicitBounds(append([]float64(nil), bounds...))
+	dp.SetBucketCounts(bucketCounts)
+	dp.SetCount(uint64(math.Round(count)))
+	dp.SetSum(sum)
+}
+
+func fillSummaryDataPoint(dp pdata.SummaryDataPoint, samples []sample, quantiles []float64) {
+	if len(quantiles) == 0 {
+		quantiles = defaultQuantiles
+	}
+	sorted := append([]sample(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].value < sorted[j].value
+	})
+
+	var count, sum float64
+	for _, smp := range sorted {
+		count += smp.weight
+		sum += smp.value * smp.weight
+	}
+	dp.SetCount(uint64(math.Round(count)))
+	dp.SetSum(sum)
+
+	qvs := dp.QuantileValues()
+	qvs.EnsureCapacity(len(quantiles))
+	for _, q := range quantiles {
+		qv := qvs.AppendEmpty()
+		qv.SetQuantile(q)
+		qv.SetValue(weightedQuantile(sorted, count, q))
+	}
+}
+
+// weightedQuantile returns the smallest value whose cumulative weight reaches
+// q of the total weight. The samples must be sorted by value.
+func weightedQuantile(sorted []sample, total float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	target := q * total
+	var cum float64
+	for _, smp := range sorted {
+		cum += smp.weight
+		if cum >= target {
+			return smp.value
+		}
+	}
+	return sorted[len(sorted)-1].value
+}
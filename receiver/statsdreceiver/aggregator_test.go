@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func aggregateLines(t *testing.T, a *aggregator, lines ...string) {
+	for _, line := range lines {
+		m, err := parseMessageToMetric(line)
+		require.NoError(t, err)
+		a.aggregate(m)
+	}
+}
+
+func TestAggregatorCounter(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.IsMonotonicCounter = true
+	cfg.EnableMetricType = true
+	start := time.Unix(100, 0)
+	a := newAggregator(cfg, start)
+
+	aggregateLines(t, a, "requests:1|c|#code:200", "requests:2|c|@0.5|#code:200", "requests:1|c|#code:500")
+	end := start.Add(time.Minute)
+	md := a.flush(end)
+
+	require.Equal(t, 1, md.MetricCount())
+	ilm := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0)
+	assert.Equal(t, instrumentationLibraryName, ilm.InstrumentationLibrary().Name())
+	metric := ilm.Metrics().At(0)
+	assert.Equal(t, "requests", metric.Name())
+	require.Equal(t, pdata.MetricDataTypeSum, metric.DataType())
+	assert.Equal(t, pdata.AggregationTemporalityDelta, metric.Sum().AggregationTemporality())
+	assert.True(t, metric.Sum().IsMonotonic())
+
+	dps := metric.Sum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	assert.Equal(t, int64(5), dps.At(0).IntVal())
+	assert.Equal(t, pdata.TimestampFromTime(start), dps.At(0).StartTimestamp())
+	assert.Equal(t, pdata.TimestampFromTime(end), dps.At(0).Timestamp())
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"code":        pdata.NewAttributeValueString("200"),
+		"metric_type": pdata.NewAttributeValueString("counter"),
+	}).Sort(), dps.At(0).Attributes().Sort())
+	assert.Equal(t, int64(1), dps.At(1).IntVal())
+
+	// The interval state is reset after a flush.
+	assert.Equal(t, 0, a.flush(end.Add(time.Minute)).MetricCount())
+}
+
+func TestAggregatorGaugeAndSet(t *testing.T) {
+	a := newAggregator(createDefaultConfig().(*Config), time.Now())
+
+	aggregateLines(t, a, "temp:10|g", "temp:+5|g", "users:a|s", "users:b|s", "users:a|s")
+	md := a.flush(time.Now())
+	require.Equal(t, 2, md.MetricCount())
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+
+	require.Equal(t, pdata.MetricDataTypeGauge, metrics.At(0).DataType())
+	assert.Equal(t, 15.0, metrics.At(0).Gauge().DataPoints().At(0).DoubleVal())
+	require.Equal(t, pdata.MetricDataTypeGauge, metrics.At(1).DataType())
+	assert.Equal(t, int64(2), metrics.At(1).Gauge().DataPoints().At(0).IntVal())
+
+	// Relative gauge updates apply to the value from the previous interval.
+	aggregateLines(t, a, "temp:-3|g")
+	md = a.flush(time.Now())
+	require.Equal(t, 1, md.MetricCount())
+	metric := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, 12.0, metric.Gauge().DataPoints().At(0).DoubleVal())
+}
+
+func TestAggregatorTimerMappings(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TimerHistogramMapping = []TimerHistogramMapping{
+		{StatsDType: statsDTypeTimer, ObserverType: observerTypeHistogram, ExplicitBounds: []float64{10, 100}},
+		{StatsDType: statsDTypeHistogram, ObserverType: observerTypeSummary, Quantiles: []float64{0, 0.5, 1}},
+	}
+	a := newAggregator(cfg, time.Now())
+
+	aggregateLines(t, a,
+		"latency:5|ms", "latency:50|ms|@0.5", "latency:500|ms",
+		"size:1|h", "size:2|h", "size:3|h", "size:4|h",
+		"dist:7|d", "dist:9|d",
+	)
+	md := a.flush(time.Now())
+	require.Equal(t, 3, md.MetricCount())
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+
+	latency := metrics.At(0)
+	require.Equal(t, pdata.MetricDataTypeHistogram, latency.DataType())
+	assert.Equal(t, "ms", latency.Unit())
+	hdp := latency.Histogram().DataPoints().At(0)
+	assert.Equal(t, []float64{10, 100}, hdp.ExplicitBounds())
+	assert.Equal(t, []uint64{1, 2, 1}, hdp.BucketCounts())
+	assert.Equal(t, uint64(4), hdp.Count())
+	assert.Equal(t, 605.0, hdp.Sum())
+
+	size := metrics.At(1)
+	require.Equal(t, pdata.MetricDataTypeSummary, size.DataType())
+	sdp := size.Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(4), sdp.Count())
+	assert.Equal(t, 10.0, sdp.Sum())
+	require.Equal(t, 3, sdp.QuantileValues().Len())
+	assert.Equal(t, 1.0, sdp.QuantileValues().At(0).Value())
+	assert.Equal(t, 2.0, sdp.QuantileValues().At(1).Value())
+	assert.Equal(t, 4.0, sdp.QuantileValues().At(2).Value())
+
+	dist := metrics.At(2)
+	require.Equal(t, pdata.MetricDataTypeGauge, dist.DataType())
+	assert.Equal(t, 9.0, dist.Gauge().DataPoints().At(0).DoubleVal())
+}
+
+func TestAggregatorGaugeExpiration(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.GaugeExpirationFlushes = 2
+	a := newAggregator(cfg, time.Now())
+
+	aggregateLines(t, a, "temp:10|g", "load:1|g")
+	a.flush(time.Now())
+	// An update keeps the gauge, an idle flush does not expire it yet.
+	aggregateLines(t, a, "load:+1|g")
+	a.flush(time.Now())
+	assert.Len(t, a.gauges, 2)
+
+	a.flush(time.Now())
+	assert.Len(t, a.gauges, 1)
+	assert.Contains(t, a.gauges, seriesKey(statsDMetric{name: "load", metricType: gaugeType}))
+
+	// A relative update of an expired gauge applies to zero.
+	aggregateLines(t, a, "temp:+5|g", "load:+1|g")
+	md := a.flush(time.Now())
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, 5.0, metrics.At(0).Gauge().DataPoints().At(0).DoubleVal())
+	assert.Equal(t, 3.0, metrics.At(1).Gauge().DataPoints().At(0).DoubleVal())
+
+	cfg.GaugeExpirationFlushes = 0
+	a = newAggregator(cfg, time.Now())
+	aggregateLines(t, a, "temp:10|g")
+	for i := 0; i < 100; i++ {
+		a.flush(time.Now())
+	}
+	assert.Len(t, a.gauges, 1)
+}
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+)
+
+// Supported StatsD types that can be mapped through TimerHistogramMapping.
+const (
+	statsDTypeTimer        = "timer"
+	statsDTypeHistogram    = "histogram"
+	statsDTypeDistribution = "distribution"
+)
+
+// Supported aggregations for timer, histogram and distribution values.
+const (
+	observerTypeGauge     = "gauge"
+	observerTypeHistogram = "histogram"
+	observerTypeSummary   = "summary"
+)
+
+// TimerHistogramMapping defines how observations of a StatsD type are
+// aggregated during a flush interval.
+type TimerHistogramMapping struct {
+	// StatsDType is one of "timer", "histogram" or "distribution".
+	StatsDType string `mapstructure:"statsd_type"`
+	// ObserverType is one of "gauge" (last value), "histogram" or "summary".
+	ObserverType string `mapstructure:"observer_type"`
+	// ExplicitBounds are the bucket boundaries used when ObserverType is
+	// "histogram". If empty the default boundaries are used.
+	ExplicitBounds []float64 `mapstructure:"explicit_bounds"`
+	// Quantiles are the quantiles reported when ObserverType is "summary".
+	// If empty the default quantiles are used.
+	Quantiles []float64 `mapstructure:"quantiles"`
+}
+
+// Config defines configuration for StatsD receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	confignet.NetAddr       `mapstructure:",squash"`
+
+	// AggregationInterval is the interval at which the aggregated metrics are
+	// flushed to the next consumer (default 60s).
+	AggregationInterval time.Duration `mapstructure:"aggregation_interval"`
+
+	// EnableMetricType adds a "metric_type" attribute with the StatsD type to
+	// every data point.
+	EnableMetricType bool `mapstructure:"enable_metric_type"`
+
+	// IsMonotonicCounter marks the Sum generated from counters as monotonic.
+	IsMonotonicCounter bool `mapstructure:"is_monotonic_counter"`
+
+	// GaugeExpirationFlushes is the number of flushes after which the last value
+	// of a gauge that is not updated anymore is forgotten, relative updates then
+	// apply to zero. Zero keeps the values forever (default 10).
+	GaugeExpirationFlushes int `mapstructure:"gauge_expiration_flushes"`
+
+	// TimerHistogramMapping configures the aggregation for each of the
+	// timer, histogram and distribution StatsD types. Types without a mapping
+	// are reported as a gauge with the last observed value.
+	TimerHistogramMapping []TimerHistogramMapping `mapstructure:"timer_histogram_mapping"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	switch cfg.Transport {
+	case "udp", "udp4", "udp6":
+	default:
+		return fmt.Errorf("unsupported transport %q, must be one of udp, udp4 or udp6", cfg.Transport)
+	}
+	if cfg.AggregationInterval <= 0 {
+		return errors.New("aggregation_interval must be positive")
+	}
+	if cfg.GaugeExpirationFlushes < 0 {
+		return errors.New("gauge_expiration_flushes must not be negative")
+	}
+
+	seen := make(map[string]bool)
+	for _, m := range cfg.TimerHistogramMapping {
+		switch m.StatsDType {
+		case statsDTypeTimer, statsDTypeHistogram, statsDTypeDistribution:
+		default:
+			return fmt.Errorf("unsupported statsd_type %q in timer_histogram_mapping", m.StatsDType)
+		}
+		if seen[m.StatsDType] {
+			return fmt.Errorf("duplicate statsd_type %q in timer_histogram_mapping", m.StatsDType)
+		}
+		seen[m.StatsDType] = true
+
+		switch m.ObserverType {
+		case observerTypeGauge, observerTypeHistogram, observerTypeSummary:
+		default:
+			return fmt.Errorf("unsupported observer_type %q for statsd_type %q", m.ObserverType, m.StatsDType)
+		}
+		for i := 1; i < len(m.ExplicitBounds); i++ {
+			if m.ExplicitBounds[i] <= m.ExplicitBounds[i-1] {
+				return fmt.Errorf("explicit_bounds for statsd_type %q must be strictly increasing", m.StatsDType)
+			}
+		}
+		for _, q := range m.Quantiles {
+			if q < 0 || q > 1 {
+				return fmt.Errorf("quantiles for statsd_type %q must be between 0 and 1", m.StatsDType)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	r0 := cfg.Receivers[config.NewID(typeStr)]
+	assert.Equal(t, factory.CreateDefaultConfig(), r0)
+
+	r1 := cfg.Receivers[config.NewIDWithName(typeStr, "receiver_settings")].(*Config)
+	assert.Equal(t,
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewIDWithName(typeStr, "receiver_settings")),
+			NetAddr: confignet.NetAddr{
+				Endpoint:  "localhost:12345",
+				Transport: "udp6",
+			},
+			AggregationInterval:    70 * time.Second,
+			EnableMetricType:       true,
+			IsMonotonicCounter:     true,
+			GaugeExpirationFlushes: 3,
+			TimerHistogramMapping: []TimerHistogramMapping{
+				{StatsDType: "timer", ObserverType: "histogram", ExplicitBounds: []float64{10, 100, 1000}},
+				{StatsDType: "histogram", ObserverType: "summary", Quantiles: []float64{0.5, 0.99}},
+			},
+		},
+		r1)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		errMsg string
+	}{
+		{
+			name:   "empty endpoint",
+			modify: func(cfg *Config) { cfg.Endpoint = "" },
+			errMsg: "endpoint must be specified",
+		},
+		{
+			name:   "tcp transport",
+			modify: func(cfg *Config) { cfg.Transport = "tcp" },
+			errMsg: `unsupported transport "tcp", must be one of udp, udp4 or udp6`,
+		},
+		{
+			name:   "zero interval",
+			modify: func(cfg *Config) { cfg.AggregationInterval = 0 },
+			errMsg: "aggregation_interval must be positive",
+		},
+		{
+			name:   "negative gauge expiration",
+			modify: func(cfg *Config) { cfg.GaugeExpirationFlushes = -1 },
+			errMsg: "gauge_expiration_flushes must not be negative",
+		},
+		{
+			name: "unknown statsd type",
+			modify: func(cfg *Config) {
+				cfg.TimerHistogramMapping = []TimerHistogramMapping{{StatsDType: "counter", ObserverType: "gauge"}}
+			},
+			errMsg: `unsupported statsd_type "counter" in timer_histogram_mapping`,
+		},
+		{
+			name: "duplicate statsd type",
+			modify: func(cfg *Config) {
+				cfg.TimerHistogramMapping = []TimerHistogramMapping{
+					{StatsDType: "timer", ObserverType: "gauge"},
+					{StatsDType: "timer", ObserverType: "summary"},
+				}
+			},
+			errMsg: `duplicate statsd_type "timer" in timer_histogram_mapping`,
+		},
+		{
+			name: "unknown observer type",
+			modify: func(cfg *Config) {
+				cfg.TimerHistogramMapping = []TimerHistogramMapping{{StatsDType: "timer", ObserverType: "sum"}}
+			},
+			errMsg: `unsupported observer_type "sum" for statsd_type "timer"`,
+		},
+		{
+			name: "unsorted bounds",
+			modify: func(cfg *Config) {
+				cfg.TimerHistogramMapping = []TimerHistogramMapping{{StatsDType: "timer", ObserverType: "histogram", ExplicitBounds: []float64{2, 1}}}
+			},
+			errMsg: `explicit_bounds for statsd_type "timer" must be strictly increasing`,
+		},
+		{
+			name: "invalid quantile",
+			modify: func(cfg *Config) {
+				cfg.TimerHistogramMapping = []TimerHistogramMapping{{StatsDType: "timer", ObserverType: "summary", Quantiles: []float64{1.5}}}
+			},
+			errMsg: `quantiles for statsd_type "timer" must be between 0 and 1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.errMsg)
+		})
+	}
+}
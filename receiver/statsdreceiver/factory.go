@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+// This file implements factory for StatsD receiver.
+
+const (
+	typeStr = "statsd"
+
+	defaultBindEndpoint        = "localhost:8125"
+	defaultTransport           = "udp"
+	defaultAggregationInterval = 60 * time.Second
+	defaultGaugeExpiration     = 10
+)
+
+// NewFactory creates a factory for the StatsD receiver.
+func NewFactory() component.ReceiverFactory {
+	return receiverhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiverhelper.WithMetrics(createMetricsReceiver),
+	)
+}
+
+// createDefaultConfig creates the default configuration for StatsD receiver.
+func createDefaultConfig() config.Receiver {
+	return &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewID(typeStr)),
+		NetAddr: confignet.NetAddr{
+			Endpoint:  defaultBindEndpoint,
+			Transport: defaultTransport,
+		},
+		AggregationInterval:    defaultAggregationInterval,
+		GaugeExpirationFlushes: defaultGaugeExpiration,
+	}
+}
+
+// createMetricsReceiver creates a metrics receiver based on provided config.
+func createMetricsReceiver(
+	_ context.Context,
+	set component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Metrics,
+) (component.MetricsReceiver, error) {
+	rCfg := cfg.(*Config)
+	return newReceiver(set, rCfg, nextConsumer)
+}
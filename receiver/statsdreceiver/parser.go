@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type statsDMetricType string
+
+const (
+	counterType      statsDMetricType = "c"
+	gaugeType        statsDMetricType = "g"
+	timerType        statsDMetricType = "ms"
+	histogramType    statsDMetricType = "h"
+	distributionType statsDMetricType = "d"
+	setType          statsDMetricType = "s"
+)
+
+// attributeName returns the value used for the "metric_type" attribute.
+func (t statsDMetricType) attributeName() string {
+	switch t {
+	case counterType:
+		return "counter"
+	case gaugeType:
+		return "gauge"
+	case timerType:
+		return "timing"
+	case histogramType:
+		return "histogram"
+	case distributionType:
+		return "distribution"
+	case setType:
+		return "set"
+	}
+	return string(t)
+}
+
+// label is a single DogStatsD tag.
+type label struct {
+	key   string
+	value string
+}
+
+// statsDMetric is the parsed representation of a single StatsD line.
+type statsDMetric struct {
+	name       string
+	metricType statsDMetricType
+	value      float64
+	// rawValue is the unparsed value, used as the member of a set.
+	rawValue string
+	// relative is true for gauges whose value has an explicit sign.
+	relative   bool
+	sampleRate float64
+	// labels are sorted by key.
+	labels []label
+}
+
+var errEmptyName = errors.New("empty metric name")
+
+// parseMessageToMetric parses a line in the StatsD format with the DogStatsD
+// extensions: <name>:<value>|<type>[|@<sample_rate>][|#<tag>[:<value>],...]
+func parseMessageToMetric(line string) (statsDMetric, error) {
+	result := statsDMetric{sampleRate: 1}
+
+	parts := strings.Split(line, "|")
+	if len(parts) < 2 {
+		return result, fmt.Errorf("invalid message format: %q", line)
+	}
+
+	sep := strings.LastIndexByte(parts[0], ':')
+	if sep < 0 {
+		return result, fmt.Errorf("invalid <name>:<value> format: %q", parts[0])
+	}
+	result.name = parts[0][:sep]
+	if result.name == "" {
+		return result, errEmptyName
+	}
+	result.rawValue = parts[0][sep+1:]
+	if result.rawValue == "" {
+		return result, fmt.Errorf("empty metric value: %q", line)
+	}
+
+	switch t := statsDMetricType(parts[1]); t {
+	case counterType, gaugeType, timerType, histogramType, distributionType, setType:
+		result.metricType = t
+	default:
+		return result, fmt.Errorf("unsupported metric type %q", parts[1])
+	}
+
+	if result.metricType != setType {
+		f, err := strconv.ParseFloat(result.rawValue, 64)
+		if err != nil {
+			return result, fmt.Errorf("parse metric value %q: %w", result.rawValue, err)
+		}
+		result.value = f
+		if result.metricType == gaugeType && (result.rawValue[0] == '+' || result.rawValue[0] == '-') {
+			result.relative = true
+		}
+	}
+
+	for _, part := range parts[2:] {
+		switch {
+		case strings.HasPrefix(part, "@"):
+			rate, err := strconv.ParseFloat(part[1:], 64)
+			if err != nil {
+				return result, fmt.Errorf("parse sample rate %q: %w", part, err)
+			}
+			if rate <= 0 || rate > 1 {
+				return result, fmt.Errorf("sample rate %q must be in (0, 1]", part)
+			}
+			result.sampleRate = rate
+		case strings.HasPrefix(part, "#"):
+			labels, err := parseTags(part[1:])
+			if err != nil {
+				return result, err
+			}
+			result.labels = labels
+		default:
+			// Ignore unknown extensions, e.g. DogStatsD container ID ("c:") or
+			// timestamp ("T"), to remain compatible with newer clients.
+		}
+	}
+
+	return result, nil
+}
+
+func parseTags(s string) ([]label, error) {
+	if s == "" {
+		return nil, nil
+	}
+	tags := strings.Split(s, ",")
+	labels := make([]label, 0, len(tags))
+	for _, tag := range tags {
+		if tag == "" {
+			continue
+		}
+		kv := strings.SplitN(tag, ":", 2)
+		if kv[0] == "" {
+			return nil, fmt.Errorf("invalid tag %q: empty key", tag)
+		}
+		l := label{key: kv[0]}
+		if len(kv) == 2 {
+			l.value = kv[1]
+		}
+		labels = append(labels, l)
+	}
+	sort.SliceStable(labels, func(i, j int) bool {
+		return labels[i].key < labels[j].key
+	})
+	return labels, nil
+}
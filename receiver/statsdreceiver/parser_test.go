@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMessageToMetric(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    statsDMetric
+		wantErr string
+	}{
+		{
+			name:  "counter",
+			input: "test.metric:42|c",
+			want:  statsDMetric{name: "test.metric", metricType: counterType, value: 42, rawValue: "42", sampleRate: 1},
+		},
+		{
+			name:  "counter with sample rate and tags",
+			input: "test.metric:42|c|@0.1|#key:value,foo:bar",
+			want: statsDMetric{
+				name: "test.metric", metricType: counterType, value: 42, rawValue: "42", sampleRate: 0.1,
+				labels: []label{{key: "foo", value: "bar"}, {key: "key", value: "value"}},
+			},
+		},
+		{
+			name:  "gauge",
+			input: "test.gauge:1.5|g",
+			want:  statsDMetric{name: "test.gauge", metricType: gaugeType, value: 1.5, rawValue: "1.5", sampleRate: 1},
+		},
+		{
+			name:  "relative gauge",
+			input: "test.gauge:-3|g",
+			want:  statsDMetric{name: "test.gauge", metricType: gaugeType, value: -3, rawValue: "-3", relative: true, sampleRate: 1},
+		},
+		{
+			name:  "timer",
+			input: "test.timer:320|ms|#env",
+			want: statsDMetric{
+				name: "test.timer", metricType: timerType, value: 320, rawValue: "320", sampleRate: 1,
+				labels: []label{{key: "env"}},
+			},
+		},
+		{
+			name:  "set",
+			input: "test.set:user-1|s",
+			want:  statsDMetric{name: "test.set", metricType: setType, rawValue: "user-1", sampleRate: 1},
+		},
+		{
+			name:  "name with colon",
+			input: "http:latency:5|h",
+			want:  statsDMetric{name: "http:latency", metricType: histogramType, value: 5, rawValue: "5", sampleRate: 1},
+		},
+		{
+			name:  "unknown extension ignored",
+			input: "test.dist:5|d|c:abc123",
+			want:  statsDMetric{name: "test.dist", metricType: distributionType, value: 5, rawValue: "5", sampleRate: 1},
+		},
+		{
+			name:    "missing type",
+			input:   "test.metric:42",
+			wantErr: `invalid message format: "test.metric:42"`,
+		},
+		{
+			name:    "missing value",
+			input:   "test.metric|c",
+			wantErr: `invalid <name>:<value> format: "test.metric"`,
+		},
+		{
+			name:    "empty name",
+			input:   ":42|c",
+			wantErr: "empty metric name",
+		},
+		{
+			name:    "empty value",
+			input:   "test.metric:|c",
+			wantErr: `empty metric value: "test.metric:|c"`,
+		},
+		{
+			name:    "unsupported type",
+			input:   "test.metric:42|x",
+			wantErr: `unsupported metric type "x"`,
+		},
+		{
+			name:    "invalid value",
+			input:   "test.metric:abc|c",
+			wantErr: `parse metric value "abc": strconv.ParseFloat: parsing "abc": invalid syntax`,
+		},
+		{
+			name:    "invalid sample rate",
+			input:   "test.metric:1|c|@2",
+			wantErr: `sample rate "@2" must be in (0, 1]`,
+		},
+		{
+			name:    "invalid tag",
+			input:   "test.metric:1|c|#:value",
+			wantErr: `invalid tag ":value": empty key`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMessageToMetric(tt.input)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const (
+	receiverFormat = "statsd"
+
+	// maxPacketSize is the largest UDP payload that can be received.
+	maxPacketSize = 65_527
+)
+
+// statsdReceiver implements the component.MetricsReceiver for StatsD protocol.
+type statsdReceiver struct {
+	logger       *zap.Logger
+	config       *Config
+	nextConsumer consumer.Metrics
+	obsrecv      *obsreport.Receiver
+
+	mu         sync.Mutex
+	aggregator *aggregator
+
+	conn       net.PacketConn
+	stopCh     chan struct{}
+	shutdownWG sync.WaitGroup
+}
+
+var _ component.MetricsReceiver = (*statsdReceiver)(nil)
+
+// newReceiver creates the StatsD receiver with the given configuration.
+func newReceiver(
+	set component.ReceiverCreateSettings,
+	config *Config,
+	nextConsumer consumer.Metrics,
+) (*statsdReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+
+	return &statsdReceiver{
+		logger:       set.Logger,
+		config:       config,
+		nextConsumer: nextConsumer,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID: config.ID(),
+			Transport:  config.Transport,
+		}),
+		aggregator: newAggregator(config, time.Now()),
+		stopCh:     make(chan struct{}),
+	}, nil
+}
+
+// Start starts listening for StatsD packets and flushing the aggregated metrics.
+func (r *statsdReceiver) Start(_ context.Context, host component.Host) error {
+	conn, err := net.ListenPacket(r.config.Transport, r.config.Endpoint)
+	if err != nil {
+		return err
+	}
+	r.conn = conn
+
+	r.shutdownWG.Add(2)
+	go func() {
+		defer r.shutdownWG.Done()
+		r.readPackets(host)
+	}()
+	go func() {
+		defer r.shutdownWG.Done()
+		r.flushLoop()
+	}()
+	return nil
+}
+
+// Shutdown stops the receiver and flushes what was aggregated so far.
+func (r *statsdReceiver) Shutdown(ctx context.Context) error {
+	if r.conn == nil {
+		return nil
+	}
+	close(r.stopCh)
+	err := r.conn.Close()
+	r.shutdownWG.Wait()
+	r.conn = nil
+
+	r.flush(ctx)
+	return err
+}
+
+func (r *statsdReceiver) readPackets(host component.Host) {
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, _, err := r.conn.ReadFrom(buf)
+		if n > 0 {
+			r.handlePacket(buf[:n])
+		}
+		if err != nil {
+			select {
+			case <-r.stopCh:
+				return
+			default:
+			}
+			host.ReportFatalError(err)
+			return
+		}
+	}
+}
+
+// handlePacket parses all lines of a packet and adds them to the current
+// aggregation. Lines that cannot be parsed are reported as refused.
+func (r *statsdReceiver) handlePacket(packet []byte) {
+	var errs []error
+	r.mu.Lock()
+	for _, line := range bytes.Split(packet, []byte{'\n'}) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		m, err := parseMessageToMetric(string(line))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		r.aggregator.aggregate(m)
+	}
+	r.mu.Unlock()
+
+	if len(errs) > 0 {
+		err := consumererror.Combine(errs)
+		ctx := r.obsrecv.StartMetricsOp(context.Background())
+		r.obsrecv.EndMetricsOp(ctx, receiverFormat, len(errs), err)
+		r.logger.Debug("Failed to parse StatsD lines", zap.Int("count", len(errs)), zap.Error(err))
+	}
+}
+
+func (r *statsdReceiver) flushLoop() {
+	ticker := time.NewTicker(r.config.AggregationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.flush(context.Background())
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+func (r *statsdReceiver) flush(ctx context.Context) {
+	r.mu.Lock()
+	md := r.aggregator.flush(time.Now())
+	r.mu.Unlock()
+
+	numPoints := md.DataPointCount()
+	if numPoints == 0 {
+		return
+	}
+
+	ctx = r.obsrecv.StartMetricsOp(ctx)
+	err := r.nextConsumer.ConsumeMetrics(ctx, md)
+	r.obsrecv.EndMetricsOp(ctx, receiverFormat, numPoints, err)
+	if err != nil {
+		r.logger.Error("Failed to send aggregated StatsD metrics", zap.Int("data_points", numPoints), zap.Error(err))
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+	"go.opentelemetry.io/collector/testutil"
+)
+
+func TestReceiveAndFlush(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.AggregationInterval = 50 * time.Millisecond
+
+	sink := new(consumertest.MetricsSink)
+	r, err := newReceiver(componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	}()
+
+	conn, err := net.Dial("udp", cfg.Endpoint)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("requests:1|c\nrequests:2|c\ninvalid\ntemp:3|g"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return sink.DataPointCount() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	obsreporttest.CheckReceiverMetrics(t, config.NewID(typeStr), "udp", 2, 1)
+}
+
+func TestShutdownFlushes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+
+	sink := new(consumertest.MetricsSink)
+	r, err := newReceiver(componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	// Shutdown without Start is a no-op.
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	r.handlePacket([]byte("requests:1|c"))
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.Equal(t, 1, sink.DataPointCount())
+}
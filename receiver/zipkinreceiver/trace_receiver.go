@@ -30,6 +30,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.opentelemetry.io/collector/obsreport"
@@ -92,9 +93,13 @@ func (zr *zipkinReceiver) Start(_ context.Context, host component.Host) error {
 	}
 
 	zr.host = host
-	zr.server = zr.config.HTTPServerSettings.ToServer(zr)
+	authenticator, err := zr.config.HTTPServerSettings.ServerAuthenticator(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	zr.server = zr.config.HTTPServerSettings.ToServer(zr, confighttp.WithAuthenticator(authenticator))
 	var listener net.Listener
-	listener, err := zr.config.HTTPServerSettings.ToListener()
+	listener, err = zr.config.HTTPServerSettings.ToListener()
 	if err != nil {
 		return err
 	}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"reflect"
 	"runtime"
 	"syscall"
 
@@ -34,6 +35,7 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configcheck"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/config/configunmarshaler"
@@ -73,6 +75,12 @@ type Collector struct {
 	rootCmd *cobra.Command
 	logger  *zap.Logger
 
+	// loggingOptions, logsCfg and closeLogger are used to recreate the logger when the
+	// service::telemetry::logs configuration changes.
+	loggingOptions []zap.Option
+	logsCfg        config.ServiceTelemetryLogs
+	closeLogger    func()
+
 	tracerProvider      trace.TracerProvider
 	zPagesSpanProcessor *zpages.SpanProcessor
 
@@ -94,6 +102,13 @@ type Collector struct {
 
 	// asyncErrorChannel is used to signal a fatal error from any component.
 	asyncErrorChannel chan error
+
+	// configUpdatedChannel is used to signal that the configuration changed and the
+	// service must be reloaded.
+	configUpdatedChannel chan struct{}
+
+	// watcherDone is closed when the goroutine watching the configuration for updates exits.
+	watcherDone chan struct{}
 }
 
 // New creates and returns a new instance of Collector.
@@ -108,6 +123,7 @@ func New(set CollectorSettings) (*Collector, error) {
 		stateChannel:      make(chan State, Closed+1),
 		parserProvider:    set.ParserProvider,
 		configUnmarshaler: set.ConfigUnmarshaler,
+		loggingOptions:    set.LoggingOptions,
 		// We use a negative in the settings not to break the existing
 		// behavior. Internally, allowGracefulShutodwn is more readable.
 		allowGracefulShutodwn: !set.DisableGracefulShutdown,
@@ -128,7 +144,7 @@ func New(set CollectorSettings) (*Collector, error) {
 		Version: set.BuildInfo.Version,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var err error
-			if col.logger, err = newLogger(set.LoggingOptions); err != nil {
+			if col.logger, col.closeLogger, err = newLogger(col.logsCfg, col.loggingOptions); err != nil {
 				return fmt.Errorf("failed to get logger: %w", err)
 			}
 
@@ -157,7 +173,9 @@ func New(set CollectorSettings) (*Collector, error) {
 	for _, addFlags := range addFlagsFns {
 		addFlags(flagSet)
 	}
-	rootCmd.Flags().AddGoFlagSet(flagSet)
+	// The flags are persistent so the subcommands can load the configuration.
+	rootCmd.PersistentFlags().AddGoFlagSet(flagSet)
+	rootCmd.AddCommand(newValidateCommand(col), newPrintConfigCommand(col))
 	col.rootCmd = rootCmd
 
 	return col, nil
@@ -205,7 +223,16 @@ func (col *Collector) Shutdown() {
 func (col *Collector) setupTelemetry(ballastSizeBytes uint64) error {
 	col.logger.Info("Setting up own telemetry...")
 
-	err := collectorTelemetry.init(col.asyncErrorChannel, ballastSizeBytes, col.logger)
+	err := collectorTelemetry.init(telemetrySettings{
+		asyncErrorChannel: col.asyncErrorChannel,
+		ballastSizeBytes:  ballastSizeBytes,
+		logger:            col.logger,
+		buildInfo:         col.info,
+		config:            col.service.config,
+		factories:         col.factories.Exporters,
+		tracerProvider:    col.tracerProvider,
+		host:              col.service,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to initialize telemetry: %w", err)
 	}
@@ -225,13 +252,24 @@ func (col *Collector) runAndWaitForShutdownEvent() {
 
 	col.shutdownChan = make(chan struct{})
 	col.stateChannel <- Running
-	select {
-	case err := <-col.asyncErrorChannel:
-		col.logger.Error("Asynchronous error received, terminating process", zap.Error(err))
-	case s := <-col.signalsChannel:
-		col.logger.Info("Received signal from OS", zap.String("signal", s.String()))
-	case <-col.shutdownChan:
-		col.logger.Info("Received shutdown request")
+LOOP:
+	for {
+		select {
+		case <-col.configUpdatedChannel:
+			if err := col.reloadService(context.Background()); err != nil {
+				col.logger.Error("Failed to reload the configuration, terminating process", zap.Error(err))
+				break LOOP
+			}
+		case err := <-col.asyncErrorChannel:
+			col.logger.Error("Asynchronous error received, terminating process", zap.Error(err))
+			break LOOP
+		case s := <-col.signalsChannel:
+			col.logger.Info("Received signal from OS", zap.String("signal", s.String()))
+			break LOOP
+		case <-col.shutdownChan:
+			col.logger.Info("Received shutdown request")
+			break LOOP
+		}
 	}
 	col.stateChannel <- Closing
 }
@@ -255,6 +293,10 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	if err = col.applyLogsConfig(cfg.Service.Telemetry.Logs); err != nil {
+		return fmt.Errorf("cannot apply the logs configuration: %w", err)
+	}
+
 	col.logger.Info("Applying configuration...")
 
 	service, err := newService(&svcSettings{
@@ -277,21 +319,25 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 
 	col.service = service
 
-	// If provider is watchable start a goroutine watching for updates.
+	// If provider is watchable start a goroutine watching for updates. The service is
+	// reloaded by runAndWaitForShutdownEvent, after the goroutine exited.
 	if watchable, ok := col.parserProvider.(parserprovider.Watchable); ok {
+		watcherDone := make(chan struct{})
+		col.watcherDone = watcherDone
+		logger := col.logger
 		go func() {
+			defer close(watcherDone)
 			err := watchable.WatchForUpdate()
 			switch {
 			// TODO: Move configsource.ErrSessionClosed to providerparser package to avoid depending on configsource.
 			case errors.Is(err, configsource.ErrSessionClosed):
 				// This is the case of shutdown of the whole collector server, nothing to do.
-				col.logger.Info("Config WatchForUpdate closed", zap.Error(err))
-				return
+				logger.Info("Config WatchForUpdate closed", zap.Error(err))
 			default:
-				col.logger.Warn("Config WatchForUpdated exited", zap.Error(err))
-				if err := col.reloadService(context.Background()); err != nil {
-					col.asyncErrorChannel <- err
-				}
+				logger.Warn("Config WatchForUpdated exited", zap.Error(err))
+				// The channel is buffered, and the previous update was consumed before
+				// this goroutine started, so this does not block.
+				col.configUpdatedChannel <- struct{}{}
 			}
 		}()
 	}
@@ -299,6 +345,36 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 	return nil
 }
 
+// waitForConfigWatcher waits for the goroutine watching the configuration for updates to
+// exit. The parser provider must be closed first, WatchForUpdate returns once its session
+// is closed.
+func (col *Collector) waitForConfigWatcher() {
+	if col.watcherDone != nil {
+		<-col.watcherDone
+		col.watcherDone = nil
+	}
+}
+
+// applyLogsConfig replaces the logger of the collector if the logs configuration changed.
+// The components created afterwards use the new logger.
+func (col *Collector) applyLogsConfig(logsCfg config.ServiceTelemetryLogs) error {
+	if reflect.DeepEqual(col.logsCfg, logsCfg) {
+		return nil
+	}
+
+	logger, closeLogger, err := newLogger(logsCfg, col.loggingOptions)
+	if err != nil {
+		return err
+	}
+	col.logger.Info("Switching to the logger of the logs configuration")
+
+	// Syncing the standard streams may fail, there is nothing to do about it.
+	_ = col.logger.Sync()
+	col.closeLogger()
+	col.logger, col.closeLogger, col.logsCfg = logger, closeLogger, logsCfg
+	return nil
+}
+
 func (col *Collector) execute(ctx context.Context) error {
 	col.logger.Info("Starting "+col.info.Command+"...",
 		zap.String("Version", col.info.Version),
@@ -313,6 +389,7 @@ func (col *Collector) execute(ctx context.Context) error {
 	}
 
 	col.asyncErrorChannel = make(chan error)
+	col.configUpdatedChannel = make(chan struct{}, 1)
 
 	err := col.setupConfigurationComponents(ctx)
 	if err != nil {
@@ -337,6 +414,8 @@ func (col *Collector) execute(ctx context.Context) error {
 	if closable, ok := col.parserProvider.(parserprovider.Closeable); ok {
 		if err := closable.Close(ctx); err != nil {
 			errs = append(errs, fmt.Errorf("failed to close config: %w", err))
+		} else {
+			col.waitForConfigWatcher()
 		}
 	}
 
@@ -351,6 +430,7 @@ func (col *Collector) execute(ctx context.Context) error {
 	}
 
 	col.logger.Info("Shutdown complete.")
+	_ = col.logger.Sync()
 	col.stateChannel <- Closed
 	close(col.stateChannel)
 
@@ -365,6 +445,13 @@ func (col *Collector) reloadService(ctx context.Context) error {
 		if err := closeable.Close(ctx); err != nil {
 			return fmt.Errorf("failed close current config provider: %w", err)
 		}
+		col.waitForConfigWatcher()
+	}
+
+	// The telemetry exporters are created from the retiring config, and started with the
+	// retiring service as host: push the last telemetry before the service shuts down.
+	if err := collectorTelemetry.shutdown(); err != nil {
+		return fmt.Errorf("failed to shutdown collector telemetry: %w", err)
 	}
 
 	if col.service != nil {
@@ -379,7 +466,7 @@ func (col *Collector) reloadService(ctx context.Context) error {
 		return fmt.Errorf("failed to setup configuration components: %w", err)
 	}
 
-	return nil
+	return col.setupTelemetry(col.getBallastSize())
 }
 
 func (col *Collector) getBallastSize() uint64 {
@@ -36,6 +36,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configparser"
 	"go.opentelemetry.io/collector/config/configunmarshaler"
+	"go.opentelemetry.io/collector/config/experimental/configsource"
 	"go.opentelemetry.io/collector/service/defaultcomponents"
 	"go.opentelemetry.io/collector/service/internal/builder"
 	"go.opentelemetry.io/collector/service/parserprovider"
@@ -95,9 +96,10 @@ func TestCollector_Start(t *testing.T) {
 
 	assertZPages(t)
 
-	// Trigger another configuration load.
+	// Trigger another configuration load, the telemetry is set up again.
 	require.NoError(t, col.reloadService(context.Background()))
 	require.True(t, isCollectorAvailable(t, "http://"+healthCheckEndpoint))
+	assertMetrics(t, testPrefix, metricsPort, mandatoryLabels)
 
 	col.signalsChannel <- syscall.SIGTERM
 	<-colDone
@@ -107,7 +109,7 @@ func TestCollector_Start(t *testing.T) {
 
 type mockColTelemetry struct{}
 
-func (tel *mockColTelemetry) init(chan<- error, uint64, *zap.Logger) error {
+func (tel *mockColTelemetry) init(telemetrySettings) error {
 	return nil
 }
 
@@ -115,6 +117,53 @@ func (tel *mockColTelemetry) shutdown() error {
 	return errors.New("err1")
 }
 
+// recordingColTelemetry records the settings the telemetry is initialized from.
+type recordingColTelemetry struct {
+	inits     []telemetrySettings
+	shutdowns int
+}
+
+func (tel *recordingColTelemetry) init(set telemetrySettings) error {
+	tel.inits = append(tel.inits, set)
+	return nil
+}
+
+func (tel *recordingColTelemetry) shutdown() error {
+	tel.shutdowns++
+	return nil
+}
+
+func TestCollector_reloadServiceSetsUpTelemetry(t *testing.T) {
+	preservedAppTelemetry := collectorTelemetry
+	tel := &recordingColTelemetry{}
+	collectorTelemetry = tel
+	defer func() { collectorTelemetry = preservedAppTelemetry }()
+
+	factories, err := defaultcomponents.Components()
+	require.NoError(t, err)
+	col := Collector{
+		logger:            zap.NewNop(),
+		tracerProvider:    trace.NewNoopTracerProvider(),
+		parserProvider:    new(minimalParserLoader),
+		configUnmarshaler: configunmarshaler.NewDefault(),
+		factories:         factories,
+	}
+	require.NoError(t, col.setupConfigurationComponents(context.Background()))
+	require.NoError(t, col.setupTelemetry(0))
+	retiringService := col.service
+
+	require.NoError(t, col.reloadService(context.Background()))
+	t.Cleanup(func() { assert.NoError(t, col.service.Shutdown(context.Background())) })
+
+	// The telemetry exporters are created again from the new config, and started with the new service.
+	assert.Equal(t, 1, tel.shutdowns)
+	require.Len(t, tel.inits, 2)
+	assert.Same(t, retiringService, tel.inits[0].host)
+	assert.Same(t, col.service, tel.inits[1].host)
+	assert.Same(t, col.service.config, tel.inits[1].config)
+	assert.NotSame(t, tel.inits[0].config, tel.inits[1].config)
+}
+
 func TestCollector_ReportError(t *testing.T) {
 	// use a mock AppTelemetry struct to return an error on shutdown
 	preservedAppTelemetry := collectorTelemetry
@@ -174,6 +223,39 @@ func TestCollector_StartAsGoRoutine(t *testing.T) {
 	assert.Equal(t, Closed, <-col.GetStateChannel())
 }
 
+func TestCollector_ReloadOnConfigUpdate(t *testing.T) {
+	factories, err := defaultcomponents.Components()
+	require.NoError(t, err)
+
+	parserProvider := newWatchableParserLoader()
+	set := CollectorSettings{
+		BuildInfo:      component.DefaultBuildInfo(),
+		Factories:      factories,
+		ParserProvider: parserProvider,
+	}
+	col, err := New(set)
+	require.NoError(t, err)
+
+	colDone := make(chan struct{})
+	go func() {
+		defer close(colDone)
+		assert.NoError(t, col.Run())
+	}()
+
+	assert.Equal(t, Starting, <-col.GetStateChannel())
+	assert.Equal(t, Running, <-col.GetStateChannel())
+	<-parserProvider.loaded
+
+	// The update makes the watcher exit, and the service is reloaded from a new session.
+	parserProvider.updates <- errors.New("config updated")
+	<-parserProvider.loaded
+
+	col.Shutdown()
+	<-colDone
+	assert.Equal(t, Closing, <-col.GetStateChannel())
+	assert.Equal(t, Closed, <-col.GetStateChannel())
+}
+
 // isCollectorAvailable checks if the healthcheck server at the given endpoint is
 // returning `available`.
 func isCollectorAvailable(t *testing.T, healthCheckEndPoint string) bool {
@@ -340,8 +422,46 @@ func TestCollector_reloadService(t *testing.T) {
 				return
 			}
 
-			// If successful need to shutdown active service.
+			// If successful need to shutdown active service and telemetry.
 			assert.NoError(t, col.service.Shutdown(ctx))
+			assert.NoError(t, collectorTelemetry.shutdown())
 		})
 	}
 }
+
+// watchableParserLoader is a minimalParserLoader whose sessions are watched for updates.
+type watchableParserLoader struct {
+	minimalParserLoader
+	// updates is used to end the current session with an update.
+	updates chan error
+	// loaded receives a value for every session started.
+	loaded chan struct{}
+	closed chan struct{}
+}
+
+func newWatchableParserLoader() *watchableParserLoader {
+	return &watchableParserLoader{
+		updates: make(chan error),
+		loaded:  make(chan struct{}, 1),
+	}
+}
+
+func (p *watchableParserLoader) Get() (*configparser.Parser, error) {
+	p.closed = make(chan struct{})
+	p.loaded <- struct{}{}
+	return p.minimalParserLoader.Get()
+}
+
+func (p *watchableParserLoader) WatchForUpdate() error {
+	select {
+	case err := <-p.updates:
+		return err
+	case <-p.closed:
+		return configsource.ErrSessionClosed
+	}
+}
+
+func (p *watchableParserLoader) Close(context.Context) error {
+	close(p.closed)
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/internal/configprinter"
+	"go.opentelemetry.io/collector/service/parserprovider"
+)
+
+// newValidateCommand returns the command that validates the configuration without
+// running the collector.
+func newValidateCommand(col *Collector) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Validates the configuration without running the collector",
+		Long: "Loads, unmarshals and validates the configuration and builds its components without starting them." +
+			" All the errors found are listed and the command exits with a non-zero code if the configuration is invalid.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := col.loadConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if errs := cfg.ValidateAll(); len(errs) > 0 {
+				return validationError(errs)
+			}
+
+			// Build the components, without starting them, to report the errors only found
+			// when creating them. They are shut down right after since some of them acquire
+			// resources when created.
+			srv, err := newService(&svcSettings{
+				BuildInfo:         col.info,
+				Factories:         col.factories,
+				Config:            cfg,
+				Logger:            zap.NewNop(),
+				TracerProvider:    trace.NewNoopTracerProvider(),
+				AsyncErrorChannel: make(chan error),
+			})
+			if err != nil {
+				return validationError([]error{err})
+			}
+			if err = srv.Shutdown(cmd.Context()); err != nil {
+				return fmt.Errorf("failed to shutdown the components built for the validation: %w", err)
+			}
+
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), "The configuration is valid.")
+			return err
+		},
+	}
+}
+
+// newPrintConfigCommand returns the command that prints the effective configuration.
+func newPrintConfigCommand(col *Collector) *cobra.Command {
+	return &cobra.Command{
+		Use:   "print-config",
+		Short: "Prints the effective configuration",
+		Long: "Prints the fully resolved configuration, with the --set flags, the environment variables and the" +
+			" defaults of the components applied. The values of the settings that look like secrets are redacted.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := col.loadConfig(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			out, err := configprinter.Marshal(cfg)
+			if err != nil {
+				return fmt.Errorf("cannot marshal configuration: %w", err)
+			}
+			_, err = cmd.OutOrStdout().Write(out)
+			return err
+		},
+	}
+}
+
+// loadConfig loads and unmarshals the configuration, the parser provider is closed
+// once the configuration is loaded.
+func (col *Collector) loadConfig(ctx context.Context) (*config.Config, error) {
+	cp, err := col.parserProvider.Get()
+	if err != nil {
+		return nil, fmt.Errorf("cannot load configuration's parser: %w", err)
+	}
+	if closeable, ok := col.parserProvider.(parserprovider.Closeable); ok {
+		defer func() {
+			_ = closeable.Close(ctx)
+		}()
+	}
+
+	cfg, err := col.configUnmarshaler.Unmarshal(cp, col.factories)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// validationError returns an error listing all the given errors.
+func validationError(errs []error) error {
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	sort.Strings(msgs)
+	return fmt.Errorf("invalid configuration, %d error(s) found:\n  - %s", len(msgs), strings.Join(msgs, "\n  - "))
+}
@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.opentelemetry.io/collector/service/defaultcomponents"
+)
+
+func runCommand(t *testing.T, args ...string) (string, error) {
+	factories, err := defaultcomponents.Components()
+	require.NoError(t, err)
+	return runCommandWithFactories(t, factories, args...)
+}
+
+func runCommandWithFactories(t *testing.T, factories component.Factories, args ...string) (string, error) {
+	col, err := New(CollectorSettings{BuildInfo: component.DefaultBuildInfo(), Factories: factories})
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	col.rootCmd.SetOut(out)
+	col.rootCmd.SetErr(&bytes.Buffer{})
+	col.rootCmd.SetArgs(args)
+	err = col.Run()
+	return out.String(), err
+}
+
+func TestValidateCommand(t *testing.T) {
+	out, err := runCommand(t, "validate", "--config=testdata/otelcol-config-minimal.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "The configuration is valid.\n", out)
+}
+
+func TestValidateCommand_Invalid(t *testing.T) {
+	_, err := runCommand(t, "validate", "--config=testdata/otelcol-config-invalid.yaml")
+	require.Error(t, err)
+	assert.Equal(t, "invalid configuration, 2 error(s) found:\n"+
+		"  - pipeline \"traces\" references processor \"batch\" which does not exist\n"+
+		"  - service references extension \"health_check\" which does not exist", err.Error())
+
+	_, err = runCommand(t, "validate", "--config=testdata/not_found.yaml")
+	assert.Error(t, err)
+}
+
+// shutdownCountingExporter counts the calls to Shutdown.
+type shutdownCountingExporter struct {
+	component.TracesExporter
+	shutdowns *int
+}
+
+func (e shutdownCountingExporter) Shutdown(ctx context.Context) error {
+	*e.shutdowns++
+	return e.TracesExporter.Shutdown(ctx)
+}
+
+func TestValidateCommand_ShutsDownComponents(t *testing.T) {
+	shutdowns := 0
+	factories, err := defaultcomponents.Components()
+	require.NoError(t, err)
+	factories.Exporters["counting"] = exporterhelper.NewFactory(
+		"counting",
+		func() config.Exporter {
+			cfg := config.NewExporterSettings(config.NewID("counting"))
+			return &cfg
+		},
+		exporterhelper.WithTraces(func(ctx context.Context, set component.ExporterCreateSettings, cfg config.Exporter) (component.TracesExporter, error) {
+			exp, err := componenttest.NewNopExporterFactory().CreateTracesExporter(ctx, set, cfg)
+			return shutdownCountingExporter{TracesExporter: exp, shutdowns: &shutdowns}, err
+		}))
+	factories.Receivers["failing"] = receiverhelper.NewFactory(
+		"failing",
+		func() config.Receiver {
+			cfg := config.NewReceiverSettings(config.NewID("failing"))
+			return &cfg
+		},
+		receiverhelper.WithTraces(func(context.Context, component.ReceiverCreateSettings, config.Receiver, consumer.Traces) (component.TracesReceiver, error) {
+			return nil, errors.New("cannot create the receiver")
+		}))
+
+	writeConfig := func(receiver, receiverCfg string) string {
+		cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, ioutil.WriteFile(cfgPath, []byte(`
+receivers:
+  `+receiver+`:`+receiverCfg+`
+exporters:
+  counting:
+service:
+  pipelines:
+    traces:
+      receivers: [`+receiver+`]
+      exporters: [counting]
+`), 0600))
+		return cfgPath
+	}
+
+	_, err = runCommandWithFactories(t, factories, "validate", "--config="+writeConfig("otlp", "\n    protocols:\n      grpc:"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, shutdowns)
+
+	// The exporter is built before the receiver fails to be created.
+	_, err = runCommandWithFactories(t, factories, "validate", "--config="+writeConfig("failing", ""))
+	require.Error(t, err)
+	assert.Equal(t, 2, shutdowns)
+}
+
+func TestPrintConfigCommand(t *testing.T) {
+	out, err := runCommand(t, "print-config",
+		"--config=testdata/otelcol-config-secrets.yaml",
+		"--set=exporters.otlphttp.timeout=5s")
+	require.NoError(t, err)
+
+	var printed map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(out), &printed))
+
+	exporters := printed["exporters"].(map[interface{}]interface{})
+	otlphttp := exporters["otlphttp"].(map[interface{}]interface{})
+	// Values from the file and the --set flags.
+	assert.Equal(t, "https://localhost:4318", otlphttp["endpoint"])
+	assert.Equal(t, "5s", otlphttp["timeout"])
+	// Defaults of the component.
+	assert.Contains(t, otlphttp, "retry_on_failure")
+	assert.Contains(t, otlphttp, "sending_queue")
+	// Secrets are redacted, the values of the headers are opaque.
+	assert.Equal(t, map[interface{}]interface{}{
+		"Authorization": "[REDACTED]",
+		"X-Scope-OrgID": "[REDACTED]",
+	}, otlphttp["headers"])
+	assert.NotContains(t, out, "s3cr3t")
+
+	service := printed["service"].(map[interface{}]interface{})
+	pipelines := service["pipelines"].(map[interface{}]interface{})
+	assert.Equal(t, map[interface{}]interface{}{
+		"receivers":  []interface{}{"otlp"},
+		"processors": []interface{}{},
+		"exporters":  []interface{}{"otlphttp"},
+	}, pipelines["traces"])
+}
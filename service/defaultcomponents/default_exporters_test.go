@@ -32,6 +32,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/fileexporter"
 	"go.opentelemetry.io/collector/exporter/jaegerexporter"
 	"go.opentelemetry.io/collector/exporter/kafkaexporter"
+	"go.opentelemetry.io/collector/exporter/loadbalancingexporter"
 	"go.opentelemetry.io/collector/exporter/opencensusexporter"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
 	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
@@ -81,6 +82,14 @@ func TestDefaultExporters(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			exporter: "loadbalancing",
+			getConfigFn: func() config.Exporter {
+				cfg := expFactories["loadbalancing"].CreateDefaultConfig().(*loadbalancingexporter.Config)
+				cfg.Resolver.Static = &loadbalancingexporter.StaticResolver{Hostnames: []string{endpoint}}
+				return cfg
+			},
+		},
 		{
 			exporter:      "logging",
 			skipLifecycle: runtime.GOOS == "darwin", // TODO: investigate why this fails on darwin.
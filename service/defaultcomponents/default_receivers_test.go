@@ -71,6 +71,12 @@ func TestDefaultReceivers(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			receiver: "prometheusremotewrite",
+		},
+		{
+			receiver: "statsd",
+		},
 		{
 			receiver: "zipkin",
 		},
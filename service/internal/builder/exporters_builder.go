@@ -167,11 +167,14 @@ func BuildExporters(
 
 		factory, exists := factories[expID.Type()]
 		if !exists || factory == nil {
+			// Release the exporters already built, none of them was started.
+			_ = exporters.ShutdownAll(context.Background())
 			return nil, fmt.Errorf("exporter factory not found for type: %s", expID.Type())
 		}
 
 		exp, err := buildExporter(context.Background(), factory, set, expCfg, exporterInputDataTypes[expID])
 		if err != nil {
+			_ = exporters.ShutdownAll(context.Background())
 			return nil, err
 		}
 
@@ -207,9 +210,44 @@ func calcExportersRequiredDataTypes(cfg *config.Config) exportersRequiredDataTyp
 			result[expID][pipeline.InputType] = dataTypeRequirement{pipeline}
 		}
 	}
+
+	// The dead_letter exporters receive the data types of the exporters using them, whether
+	// or not they are used in a pipeline themselves. The data types are propagated until
+	// nothing changes, so that they reach the end of dead_letter chains, cycles included.
+	for changed := true; changed; {
+		changed = false
+		for expID, requirements := range result {
+			dlCfg, ok := cfg.Exporters[expID].(deadLetterConfig)
+			if !ok {
+				continue
+			}
+			dlID, ok := dlCfg.DeadLetterExporterID()
+			if !ok || dlID == expID {
+				continue
+			}
+			if _, ok := cfg.Exporters[dlID]; !ok {
+				continue
+			}
+			if _, ok := result[dlID]; !ok {
+				result[dlID] = make(dataTypeRequirements)
+			}
+			for dataType, requirement := range requirements {
+				if _, ok := result[dlID][dataType]; !ok {
+					result[dlID][dataType] = requirement
+					changed = true
+				}
+			}
+		}
+	}
 	return result
 }
 
+// deadLetterConfig is implemented by the configurations of the exporters sending the data
+// they fail to export to another exporter, e.g. through exporterhelper.DeadLetterSettings.
+type deadLetterConfig interface {
+	DeadLetterExporterID() (config.ComponentID, bool)
+}
+
 func buildExporter(
 	ctx context.Context,
 	factory component.ExporterFactory,
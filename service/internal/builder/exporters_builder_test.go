@@ -30,6 +30,7 @@ import (
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configtest"
 	"go.opentelemetry.io/collector/exporter/opencensusexporter"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
 	"go.opentelemetry.io/collector/internal/testcomponents"
 )
 
@@ -106,6 +107,115 @@ func TestBuildExporters(t *testing.T) {
 	// TODO: once we have an exporter that supports metrics data type test it too.
 }
 
+func TestBuildExporters_DeadLetterExporter(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+	otlpFactory := otlpexporter.NewFactory()
+	factories.Exporters[otlpFactory.Type()] = otlpFactory
+
+	otlpCfg := otlpFactory.CreateDefaultConfig().(*otlpexporter.Config)
+	otlpCfg.Endpoint = "localhost:4317"
+	otlpCfg.DeadLetterSettings.Exporter = "exampleexporter"
+	cfg := &config.Config{
+		Exporters: map[config.ComponentID]config.Exporter{
+			otlpCfg.ID():                    otlpCfg,
+			config.NewID("exampleexporter"): testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+		},
+		Service: config.Service{
+			Pipelines: map[string]*config.Pipeline{
+				"logs": {
+					Name:      "logs",
+					InputType: config.LogsDataType,
+					Exporters: []config.ComponentID{otlpCfg.ID()},
+				},
+			},
+		},
+	}
+
+	exporters, err := BuildExporters(zap.NewNop(), trace.NewNoopTracerProvider(), component.DefaultBuildInfo(), cfg, factories.Exporters)
+	require.NoError(t, err)
+
+	// The dead_letter exporter is created for the data type of the exporter using it.
+	dl := exporters[config.NewID("exampleexporter")]
+	require.NotNil(t, dl)
+	assert.NotNil(t, dl.getLogExporter())
+	assert.Nil(t, dl.getTracesExporter())
+	assert.Nil(t, dl.getMetricExporter())
+
+	host := &exportersHost{Host: componenttest.NewNopHost(), exporters: exporters.ToMapByDataType()}
+	require.NoError(t, exporters.StartAll(context.Background(), host))
+	assert.NoError(t, exporters.ShutdownAll(context.Background()))
+}
+
+func TestBuildExporters_DeadLetterExporterChain(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+	otlpFactory := otlpexporter.NewFactory()
+	factories.Exporters[otlpFactory.Type()] = otlpFactory
+
+	// otlp/a -> otlp/b -> exampleexporter, and otlp/c <-> otlp/d.
+	newOTLPConfig := func(name string, deadLetter string) *otlpexporter.Config {
+		otlpCfg := otlpFactory.CreateDefaultConfig().(*otlpexporter.Config)
+		otlpCfg.SetIDName(name)
+		otlpCfg.Endpoint = "localhost:4317"
+		otlpCfg.DeadLetterSettings.Exporter = deadLetter
+		return otlpCfg
+	}
+	exporterCfgs := map[config.ComponentID]config.Exporter{
+		config.NewID("exampleexporter"): testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+	}
+	for _, otlpCfg := range []*otlpexporter.Config{
+		newOTLPConfig("a", "otlp/b"),
+		newOTLPConfig("b", "exampleexporter"),
+		newOTLPConfig("c", "otlp/d"),
+		newOTLPConfig("d", "otlp/c"),
+	} {
+		exporterCfgs[otlpCfg.ID()] = otlpCfg
+	}
+	cfg := &config.Config{
+		Exporters: exporterCfgs,
+		Service: config.Service{
+			Pipelines: map[string]*config.Pipeline{
+				"logs": {
+					Name:      "logs",
+					InputType: config.LogsDataType,
+					Exporters: []config.ComponentID{config.NewIDWithName("otlp", "a"), config.NewIDWithName("otlp", "c")},
+				},
+			},
+		},
+	}
+
+	// The result must not depend on the map iteration order.
+	for i := 0; i < 20; i++ {
+		result := calcExportersRequiredDataTypes(cfg)
+		for _, id := range []config.ComponentID{
+			config.NewIDWithName("otlp", "b"),
+			config.NewID("exampleexporter"),
+			config.NewIDWithName("otlp", "d"),
+		} {
+			assert.Contains(t, result[id], config.LogsDataType, "exporter %v", id)
+		}
+	}
+
+	exporters, err := BuildExporters(zap.NewNop(), trace.NewNoopTracerProvider(), component.DefaultBuildInfo(), cfg, factories.Exporters)
+	require.NoError(t, err)
+	assert.NotNil(t, exporters[config.NewID("exampleexporter")].getLogExporter())
+
+	host := &exportersHost{Host: componenttest.NewNopHost(), exporters: exporters.ToMapByDataType()}
+	require.NoError(t, exporters.StartAll(context.Background(), host))
+	assert.NoError(t, exporters.ShutdownAll(context.Background()))
+}
+
+// exportersHost is a component.Host returning the given exporters.
+type exportersHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (h *exportersHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return h.exporters
+}
+
 func TestBuildExporters_BuildLogs(t *testing.T) {
 	factories, err := testcomponents.ExampleComponents()
 	assert.Nil(t, err)
@@ -123,11 +123,14 @@ func BuildExtensions(
 	for _, extID := range config.Service.Extensions {
 		extCfg, existsCfg := config.Extensions[extID]
 		if !existsCfg {
+			// Release the extensions already built, none of them was started.
+			_ = extensions.ShutdownAll(context.Background())
 			return nil, fmt.Errorf("extension %q is not configured", extID)
 		}
 
 		factory, existsFactory := factories[extID.Type()]
 		if !existsFactory {
+			_ = extensions.ShutdownAll(context.Background())
 			return nil, fmt.Errorf("extension factory for type %q is not configured", extID.Type())
 		}
 
@@ -140,6 +143,7 @@ func BuildExtensions(
 		}
 		ext, err := buildExtension(context.Background(), factory, set, extCfg)
 		if err != nil {
+			_ = extensions.ShutdownAll(context.Background())
 			return nil, err
 		}
 
@@ -22,6 +22,7 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenthelper"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
@@ -42,6 +43,9 @@ type builtPipeline struct {
 	MutatesData bool
 
 	processors []component.Processor
+
+	// fanout sends the data to the exporters of the pipeline.
+	fanout component.Component
 }
 
 // BuiltPipelines is a map of build pipelines created from pipeline configs.
@@ -51,6 +55,10 @@ func (bps BuiltPipelines) StartProcessors(ctx context.Context, host component.Ho
 	for _, bp := range bps {
 		bp.logger.Info("Pipeline is starting...")
 		hostWrapper := newHostWrapper(host, bp.logger)
+		// Start the fanout to the exporters first, it is the end of the pipeline.
+		if err := bp.fanout.Start(ctx, hostWrapper); err != nil {
+			return err
+		}
 		// Start in reverse order, starting from the back of processors pipeline.
 		// This is important so that processors that are earlier in the pipeline and
 		// reference processors that are later in the pipeline do not start sending
@@ -74,6 +82,10 @@ func (bps BuiltPipelines) ShutdownProcessors(ctx context.Context) error {
 				errs = append(errs, err)
 			}
 		}
+		// Shutdown the fanout last, the processors may send their pending data while shutting down.
+		if err := bp.fanout.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
 		bp.logger.Info("Pipeline is shutdown.")
 	}
 
@@ -125,14 +137,18 @@ func (pb *pipelinesBuilder) buildPipeline(ctx context.Context, pipelineCfg *conf
 	var tc consumer.Traces
 	var mc consumer.Metrics
 	var lc consumer.Logs
+	var fanout component.Component
+
+	pipelineLogger := pb.logger.With(zap.String("pipeline_name", pipelineCfg.Name),
+		zap.String("pipeline_datatype", string(pipelineCfg.InputType)))
 
 	switch pipelineCfg.InputType {
 	case config.TracesDataType:
-		tc = pb.buildFanoutExportersTracesConsumer(pipelineCfg.Exporters)
+		tc, fanout = pb.buildFanoutExportersTracesConsumer(pipelineLogger, pipelineCfg)
 	case config.MetricsDataType:
-		mc = pb.buildFanoutExportersMetricsConsumer(pipelineCfg.Exporters)
+		mc, fanout = pb.buildFanoutExportersMetricsConsumer(pipelineLogger, pipelineCfg)
 	case config.LogsDataType:
-		lc = pb.buildFanoutExportersLogsConsumer(pipelineCfg.Exporters)
+		lc, fanout = pb.buildFanoutExportersLogsConsumer(pipelineLogger, pipelineCfg)
 	}
 
 	mutatesConsumedData := false
@@ -209,8 +225,6 @@ func (pb *pipelinesBuilder) buildPipeline(ctx context.Context, pipelineCfg *conf
 		}
 	}
 
-	pipelineLogger := pb.logger.With(zap.String("pipeline_name", pipelineCfg.Name),
-		zap.String("pipeline_datatype", string(pipelineCfg.InputType)))
 	pipelineLogger.Info("Pipeline was built.")
 
 	bp := &builtPipeline{
@@ -220,6 +234,7 @@ func (pb *pipelinesBuilder) buildPipeline(ctx context.Context, pipelineCfg *conf
 		lc,
 		mutatesConsumedData,
 		processors,
+		fanout,
 	}
 
 	return bp, nil
@@ -236,38 +251,61 @@ func (pb *pipelinesBuilder) getBuiltExportersByIDs(exporterIDs []config.Componen
 	return result
 }
 
-func (pb *pipelinesBuilder) buildFanoutExportersTracesConsumer(exporterIDs []config.ComponentID) consumer.Traces {
-	builtExporters := pb.getBuiltExportersByIDs(exporterIDs)
+func (pb *pipelinesBuilder) buildFanoutExportersTracesConsumer(logger *zap.Logger, pipelineCfg *config.Pipeline) (consumer.Traces, component.Component) {
+	builtExporters := pb.getBuiltExportersByIDs(pipelineCfg.Exporters)
 
 	var exporters []consumer.Traces
 	for _, builtExp := range builtExporters {
 		exporters = append(exporters, builtExp.getTracesExporter())
 	}
 
+	if usesExporterDirectly(pipelineCfg) {
+		return exporters[0], componenthelper.New()
+	}
+
 	// Create a junction point that fans out to all exporters.
-	return fanoutconsumer.NewTraces(exporters)
+	fanout := fanoutconsumer.NewExportersTraces(logger, pipelineCfg, exporters)
+	return fanout, fanout
 }
 
-func (pb *pipelinesBuilder) buildFanoutExportersMetricsConsumer(exporterIDs []config.ComponentID) consumer.Metrics {
-	builtExporters := pb.getBuiltExportersByIDs(exporterIDs)
+func (pb *pipelinesBuilder) buildFanoutExportersMetricsConsumer(logger *zap.Logger, pipelineCfg *config.Pipeline) (consumer.Metrics, component.Component) {
+	builtExporters := pb.getBuiltExportersByIDs(pipelineCfg.Exporters)
 
 	var exporters []consumer.Metrics
 	for _, builtExp := range builtExporters {
 		exporters = append(exporters, builtExp.getMetricExporter())
 	}
 
+	if usesExporterDirectly(pipelineCfg) {
+		return exporters[0], componenthelper.New()
+	}
+
 	// Create a junction point that fans out to all exporters.
-	return fanoutconsumer.NewMetrics(exporters)
+	fanout := fanoutconsumer.NewExportersMetrics(logger, pipelineCfg, exporters)
+	return fanout, fanout
 }
 
-func (pb *pipelinesBuilder) buildFanoutExportersLogsConsumer(exporterIDs []config.ComponentID) consumer.Logs {
-	builtExporters := pb.getBuiltExportersByIDs(exporterIDs)
+func (pb *pipelinesBuilder) buildFanoutExportersLogsConsumer(logger *zap.Logger, pipelineCfg *config.Pipeline) (consumer.Logs, component.Component) {
+	builtExporters := pb.getBuiltExportersByIDs(pipelineCfg.Exporters)
 
 	exporters := make([]consumer.Logs, len(builtExporters))
 	for i, builtExp := range builtExporters {
 		exporters[i] = builtExp.getLogExporter()
 	}
 
+	if usesExporterDirectly(pipelineCfg) {
+		return exporters[0], componenthelper.New()
+	}
+
 	// Create a junction point that fans out to all exporters.
-	return fanoutconsumer.NewLogs(exporters)
+	fanout := fanoutconsumer.NewExportersLogs(logger, pipelineCfg, exporters)
+	return fanout, fanout
+}
+
+// usesExporterDirectly returns true if the pipeline sends the data to its only exporter without
+// going through a fanout, which is the case when the pipeline does not configure its fanout.
+func usesExporterDirectly(pipelineCfg *config.Pipeline) bool {
+	return len(pipelineCfg.Exporters) == 1 &&
+		(pipelineCfg.Fanout.Mode == "" || pipelineCfg.Fanout.Mode == config.FanoutModeSync) &&
+		len(pipelineCfg.Fanout.BestEffort) == 0
 }
@@ -268,3 +268,54 @@ func TestBuildPipelines_NotSupportedDataType(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildPipelines_ExporterWithoutFanout(t *testing.T) {
+	factories := createTestFactories()
+	exporterID := config.NewID(testcomponents.ExampleExporterFactory.Type())
+
+	tests := []struct {
+		name   string
+		fanout config.FanoutSettings
+		direct bool
+	}{
+		{
+			name:   "not configured",
+			direct: true,
+		},
+		{
+			name:   "sync",
+			fanout: config.FanoutSettings{Mode: config.FanoutModeSync},
+			direct: true,
+		},
+		{
+			name:   "async",
+			fanout: config.FanoutSettings{Mode: config.FanoutModeAsync},
+		},
+		{
+			name:   "best effort",
+			fanout: config.FanoutSettings{BestEffort: []config.ComponentID{exporterID}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := createExampleConfig("logs")
+			pipelineCfg := cfg.Service.Pipelines["logs"]
+			pipelineCfg.Processors = nil
+			pipelineCfg.Fanout = test.fanout
+
+			allExporters, err := BuildExporters(zap.NewNop(), trace.NewNoopTracerProvider(), component.DefaultBuildInfo(), cfg, factories.Exporters)
+			require.NoError(t, err)
+			pipelineProcessors, err := BuildPipelines(zap.NewNop(), trace.NewNoopTracerProvider(), component.DefaultBuildInfo(), cfg, allExporters, factories.Processors)
+			require.NoError(t, err)
+
+			bp := pipelineProcessors[pipelineCfg]
+			require.NotNil(t, bp)
+			if test.direct {
+				assert.Equal(t, allExporters[exporterID].getLogExporter(), bp.firstLC)
+			} else {
+				assert.NotEqual(t, allExporters[exporterID].getLogExporter(), bp.firstLC)
+			}
+		})
+	}
+}
@@ -110,6 +110,8 @@ func BuildReceivers(
 				set.Logger.Info("Ignoring receiver as it is not used by any pipeline")
 				continue
 			}
+			// Release the receivers already built, none of them was started.
+			_ = receivers.ShutdownAll(context.Background())
 			return nil, err
 		}
 		receivers[recvID] = rcv
@@ -0,0 +1,288 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Package configprinter marshals a config.Config, with its defaults applied, back to YAML
+// redacting the configopaque.String values and the values that look like secrets.
+package configprinter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+// RedactedValue replaces the values of the settings that look like secrets.
+const RedactedValue = "[REDACTED]"
+
+// sensitiveNames are the substrings that identify a setting, or a key of a map setting
+// like "headers", holding a secret. The names are compared in lower case and without
+// '_' and '-'.
+var sensitiveNames = []string{
+	"password",
+	"secret",
+	"token",
+	"apikey",
+	"authorization",
+	"credential",
+	"privatekey",
+}
+
+// Marshal returns the YAML representation of the given config.
+func Marshal(cfg *config.Config) ([]byte, error) {
+	out := map[string]interface{}{
+		"receivers":  componentsToMap(cfg.Receivers),
+		"processors": componentsToMap(cfg.Processors),
+		"exporters":  componentsToMap(cfg.Exporters),
+		"extensions": componentsToMap(cfg.Extensions),
+		"service":    serviceToMap(&cfg.Service),
+	}
+	return yaml.Marshal(out)
+}
+
+func componentsToMap(components interface{}) map[string]interface{} {
+	v := reflect.ValueOf(components)
+	out := make(map[string]interface{}, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		out[fmt.Sprint(iter.Key().Interface())] = toValue(iter.Value())
+	}
+	return out
+}
+
+func serviceToMap(srv *config.Service) map[string]interface{} {
+	pipelines := make(map[string]interface{}, len(srv.Pipelines))
+	for name, pipeline := range srv.Pipelines {
+		p := map[string]interface{}{
+			"receivers":  idsToStrings(pipeline.Receivers),
+			"processors": idsToStrings(pipeline.Processors),
+			"exporters":  idsToStrings(pipeline.Exporters),
+		}
+		if pipeline.Fanout.Mode != "" || len(pipeline.Fanout.BestEffort) > 0 || pipeline.Fanout.QueueSize != 0 {
+			p["fanout"] = map[string]interface{}{
+				"mode":        string(pipeline.Fanout.Mode),
+				"best_effort": idsToStrings(pipeline.Fanout.BestEffort),
+				"queue_size":  pipeline.Fanout.QueueSize,
+			}
+		}
+		pipelines[name] = p
+	}
+	out := map[string]interface{}{
+		"extensions": idsToStrings(srv.Extensions),
+		"pipelines":  pipelines,
+	}
+	if telemetry := telemetryToMap(&srv.Telemetry); len(telemetry) > 0 {
+		out["telemetry"] = telemetry
+	}
+	return out
+}
+
+func telemetryToMap(telemetry *config.ServiceTelemetry) map[string]interface{} {
+	out := make(map[string]interface{})
+	if logs := telemetry.Logs; !reflect.DeepEqual(logs, config.ServiceTelemetryLogs{}) {
+		out["logs"] = logsToMap(&logs)
+	}
+	if metrics := telemetry.Metrics; !reflect.DeepEqual(metrics, config.ServiceTelemetryMetrics{}) {
+		m := map[string]interface{}{
+			"exporters": idsToStrings(metrics.Exporters),
+			"interval":  metrics.Interval.String(),
+		}
+		if metrics.Address != nil {
+			m["address"] = *metrics.Address
+		}
+		out["metrics"] = m
+	}
+	if len(telemetry.Traces.Exporters) > 0 {
+		out["traces"] = map[string]interface{}{
+			"exporters": idsToStrings(telemetry.Traces.Exporters),
+		}
+	}
+	if len(telemetry.Resource) > 0 {
+		out["resource"] = toValue(reflect.ValueOf(telemetry.Resource))
+	}
+	return out
+}
+
+func logsToMap(logs *config.ServiceTelemetryLogs) map[string]interface{} {
+	out := map[string]interface{}{
+		"level":              logs.Level,
+		"development":        logs.Development,
+		"encoding":           logs.Encoding,
+		"output_paths":       logs.OutputPaths,
+		"error_output_paths": logs.ErrorOutputPaths,
+		"initial_fields":     toValue(reflect.ValueOf(logs.InitialFields)),
+	}
+	if logs.Rotation != nil {
+		out["rotation"] = map[string]interface{}{
+			"max_size_mib": logs.Rotation.MaxSizeMiB,
+			"max_backups":  logs.Rotation.MaxBackups,
+		}
+	}
+	if logs.Sampling != nil {
+		out["sampling"] = map[string]interface{}{
+			"initial":    logs.Sampling.Initial,
+			"thereafter": logs.Sampling.Thereafter,
+		}
+	}
+	return out
+}
+
+func idsToStrings(ids []config.ComponentID) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, id.String())
+	}
+	return out
+}
+
+// toValue converts the given value to the YAML friendly representation used in the
+// configuration, using the mapstructure tags for the names of the struct fields.
+func toValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch val := v.Interface().(type) {
+	case time.Duration:
+		return val.String()
+	case config.ComponentID:
+		return val.String()
+	case configopaque.String:
+		return RedactedValue
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return toValue(v.Elem())
+	case reflect.Struct:
+		out := make(map[string]interface{})
+		structToMap(v, out)
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		out := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := fmt.Sprint(iter.Key().Interface())
+			if isSensitive(key) {
+				out[key] = RedactedValue
+				continue
+			}
+			out[key] = toValue(iter.Value())
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// Binary data, e.g.: certificates, are printed as a string.
+			return string(v.Bytes())
+		}
+		out := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out = append(out, toValue(v.Index(i)))
+		}
+		return out
+	default:
+		return v.Interface()
+	}
+}
+
+func structToMap(v reflect.Value, out map[string]interface{}) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			// Unexported field.
+			continue
+		}
+
+		name, squash := parseTag(field)
+		if name == "-" {
+			continue
+		}
+
+		fv := v.Field(i)
+		if !isSetting(fv) {
+			continue
+		}
+		if squash {
+			for fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					break
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				structToMap(fv, out)
+			}
+			continue
+		}
+
+		if isSensitive(name) && !fv.IsZero() {
+			out[name] = RedactedValue
+			continue
+		}
+		out[name] = toValue(fv)
+	}
+}
+
+// isSetting returns false for the fields that can't be set in the configuration, e.g.: functions
+// used to customize the component from code.
+func isSetting(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return false
+	}
+	return true
+}
+
+// parseTag returns the name of the field in the configuration and if the field is squashed.
+func parseTag(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("mapstructure")
+	parts := strings.Split(tag, ",")
+	squash := false
+	for _, opt := range parts[1:] {
+		if opt == "squash" {
+			squash = true
+		}
+	}
+	if field.Anonymous && parts[0] == "" {
+		squash = true
+	}
+	if parts[0] == "" {
+		return strings.ToLower(field.Name), squash
+	}
+	return parts[0], squash
+}
+
+func isSensitive(name string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+	for _, sensitive := range sensitiveNames {
+		if strings.Contains(normalized, sensitive) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package configprinter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+type Settings struct {
+	Endpoint string        `mapstructure:"endpoint"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+	Password string        `mapstructure:"password"`
+	APIKey   string        `mapstructure:"api_key"`
+}
+
+type testExporterConfig struct {
+	config.ExporterSettings `mapstructure:",squash"`
+	Settings                `mapstructure:",squash"`
+	Headers                 map[string]string `mapstructure:"headers"`
+	Nested                  *Settings         `mapstructure:"nested"`
+	Ignored                 string            `mapstructure:"-"`
+	NoTag                   []int
+	CustomFn                func() error
+	Opaque                  configopaque.String `mapstructure:"opaque"`
+}
+
+func TestMarshal(t *testing.T) {
+	cfg := &config.Config{
+		Exporters: config.Exporters{
+			config.NewIDWithName("test", "1"): &testExporterConfig{
+				ExporterSettings: config.NewExporterSettings(config.NewIDWithName("test", "1")),
+				Settings: Settings{
+					Endpoint: "localhost:4317",
+					Timeout:  5 * time.Second,
+					Password: "s3cr3t",
+				},
+				Headers: map[string]string{"authorization": "Bearer s3cr3t", "x-tenant": "tenant"},
+				Ignored: "ignored",
+				NoTag:   []int{1, 2},
+				Opaque:  "s3cr3t",
+			},
+		},
+		Service: config.Service{
+			Telemetry: config.ServiceTelemetry{
+				Logs: config.ServiceTelemetryLogs{
+					Level:         "debug",
+					OutputPaths:   []string{"stdout"},
+					Rotation:      &config.ServiceTelemetryLogsRotation{MaxSizeMiB: 10},
+					InitialFields: map[string]interface{}{"service": "otelcol", "token": "s3cr3t"},
+				},
+				Metrics: config.ServiceTelemetryMetrics{
+					Exporters: []config.ComponentID{config.NewIDWithName("test", "1")},
+				},
+				Traces: config.ServiceTelemetryTraces{
+					Exporters: []config.ComponentID{config.NewIDWithName("test", "1")},
+				},
+				Resource: map[string]string{"deployment.environment": "test"},
+			},
+			Extensions: []config.ComponentID{config.NewID("ext")},
+			Pipelines: config.Pipelines{
+				"traces": &config.Pipeline{
+					Receivers: []config.ComponentID{config.NewID("rcv")},
+					Exporters: []config.ComponentID{config.NewIDWithName("test", "1")},
+					Fanout: config.FanoutSettings{
+						Mode:       config.FanoutModeAsync,
+						BestEffort: []config.ComponentID{config.NewIDWithName("test", "1")},
+					},
+				},
+			},
+		},
+	}
+
+	out, err := Marshal(cfg)
+	require.NoError(t, err)
+
+	var printed map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(out, &printed))
+	assert.Equal(t, map[string]interface{}{
+		"receivers":  map[interface{}]interface{}{},
+		"processors": map[interface{}]interface{}{},
+		"extensions": map[interface{}]interface{}{},
+		"exporters": map[interface{}]interface{}{
+			"test/1": map[interface{}]interface{}{
+				"endpoint": "localhost:4317",
+				"timeout":  "5s",
+				"password": RedactedValue,
+				"api_key":  "",
+				"headers": map[interface{}]interface{}{
+					"authorization": RedactedValue,
+					"x-tenant":      "tenant",
+				},
+				"nested": nil,
+				"notag":  []interface{}{1, 2},
+				"opaque": RedactedValue,
+			},
+		},
+		"service": map[interface{}]interface{}{
+			"telemetry": map[interface{}]interface{}{
+				"logs": map[interface{}]interface{}{
+					"level":              "debug",
+					"development":        false,
+					"encoding":           "",
+					"output_paths":       []interface{}{"stdout"},
+					"error_output_paths": []interface{}{},
+					"rotation": map[interface{}]interface{}{
+						"max_size_mib": 10,
+						"max_backups":  0,
+					},
+					"initial_fields": map[interface{}]interface{}{
+						"service": "otelcol",
+						"token":   RedactedValue,
+					},
+				},
+				"metrics": map[interface{}]interface{}{
+					"exporters": []interface{}{"test/1"},
+					"interval":  "0s",
+				},
+				"traces": map[interface{}]interface{}{
+					"exporters": []interface{}{"test/1"},
+				},
+				"resource": map[interface{}]interface{}{
+					"deployment.environment": "test",
+				},
+			},
+			"extensions": []interface{}{"ext"},
+			"pipelines": map[interface{}]interface{}{
+				"traces": map[interface{}]interface{}{
+					"receivers":  []interface{}{"rcv"},
+					"processors": []interface{}{},
+					"exporters":  []interface{}{"test/1"},
+					"fanout": map[interface{}]interface{}{
+						"mode":        "async",
+						"best_effort": []interface{}{"test/1"},
+						"queue_size":  0,
+					},
+				},
+			},
+		},
+	}, printed)
+}
@@ -26,6 +26,7 @@ import (
 
 	"gopkg.in/yaml.v2"
 
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configparser"
 	"go.opentelemetry.io/collector/config/experimental/configsource"
 	"go.opentelemetry.io/collector/consumer/consumererror"
@@ -38,6 +39,9 @@ const (
 	// configSourceNameDelimChar is the char used to terminate the name of config source
 	// when it is used to retrieve values to inject in the configuration.
 	configSourceNameDelimChar = ':'
+	// configSourcesKey is the key of the section used to declare the config sources
+	// available to the configuration.
+	configSourcesKey = "config_sources"
 )
 
 // private error types to help with testability
@@ -46,7 +50,16 @@ type (
 )
 
 // Manager is used to inject data from config sources into a configuration and also
-// to monitor for updates on the items injected into the configuration.  All methods
+// to monitor for updates on the items injected into the configuration. The config sources
+// available to the configuration are declared in its "config_sources" section, the keys
+// of the section are the names used to reference them:
+//
+//    config_sources:
+//      env:
+//      file/secrets:
+//
+// Config sources with a name, e.g.: "file/secrets", must be referenced with the bracketed
+// syntax, e.g.: "${file/secrets:/etc/secret.txt}". All methods
 // of a Manager must be called only once and have an expected sequence:
 //
 // 1. NewManager to create a new instance;
@@ -175,25 +188,33 @@ type Manager struct {
 
 // NewManager creates a new instance of a Manager to be used to inject data from
 // ConfigSource objects into a configuration and watch for updates on the injected
-// data.
-func NewManager(_ *configparser.Parser) (*Manager, error) {
-	// TODO: Config sources should be extracted for the config itself, need Factories for that.
+// data. The ConfigSource objects are created, using the given factories, from the
+// "config_sources" section of the parser.
+func NewManager(ctx context.Context, parser *configparser.Parser, factories configsource.Factories) (*Manager, error) {
+	configSources, err := loadConfigSources(ctx, parser, factories)
+	if err != nil {
+		return nil, err
+	}
 
 	return &Manager{
-		// TODO: Temporarily tests should set their config sources per their needs.
-		sessions:   make(map[string]configsource.Session),
-		watchingCh: make(chan struct{}),
-		closeCh:    make(chan struct{}),
+		configSources: configSources,
+		sessions:      make(map[string]configsource.Session),
+		watchingCh:    make(chan struct{}),
+		closeCh:       make(chan struct{}),
 	}, nil
 }
 
 // Resolve inspects the given config.Parser and resolves all config sources referenced
-// in the configuration, returning a config.Parser fully resolved. This must be called only
+// in the configuration, returning a config.Parser fully resolved. The "config_sources"
+// section is not part of the resolved configuration. This must be called only
 // once per lifetime of a Manager object.
 func (m *Manager) Resolve(ctx context.Context, parser *configparser.Parser) (*configparser.Parser, error) {
 	res := configparser.NewParser()
 	allKeys := parser.AllKeys()
 	for _, k := range allKeys {
+		if k == configSourcesKey || strings.HasPrefix(k, configSourcesKey+configparser.KeyDelimiter) {
+			continue
+		}
 		value, err := m.expandStringValues(ctx, parser.Get(k))
 		if err != nil {
 			// Call RetrieveEnd for all sessions used so far but don't record any errors.
@@ -395,8 +416,10 @@ func (m *Manager) expandString(ctx context.Context, s string) (interface{}, erro
 				// Allow for some spaces.
 				expandableContent = strings.Trim(expandableContent, " ")
 				if len(expandableContent) > 1 && strings.Contains(expandableContent, string(configSourceNameDelimChar)) {
-					// Bracket expandableContent contains ':' treating it as a config source.
-					cfgSrcName, _ = getShellName(expandableContent)
+					// Bracket expandableContent contains ':' treating it as a config source, the name
+					// is everything before the delimiter so named config sources, e.g.: "file/secrets",
+					// can be referenced.
+					cfgSrcName = strings.Trim(strings.SplitN(expandableContent, string(configSourceNameDelimChar), 2)[0], " ")
 				}
 
 			default:
@@ -471,6 +494,55 @@ func (m *Manager) retrieveConfigSourceData(ctx context.Context, name, cfgSrcInvo
 	return retrieved, nil
 }
 
+// loadConfigSources creates the ConfigSource objects declared in the "config_sources"
+// section of the parser. The returned map is keyed by the full ID of the config source.
+func loadConfigSources(ctx context.Context, parser *configparser.Parser, factories configsource.Factories) (map[string]configsource.ConfigSource, error) {
+	configSources := make(map[string]configsource.ConfigSource)
+	if parser == nil || !parser.IsSet(configSourcesKey) {
+		return configSources, nil
+	}
+
+	srcsParser, err := parser.Sub(configSourcesKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s section: %w", configSourcesKey, err)
+	}
+
+	for key, value := range srcsParser.ToStringMap() {
+		id, err := config.NewIDFromString(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config source key %q: %w", key, err)
+		}
+
+		factory, ok := factories[id.Type()]
+		if !ok {
+			return nil, fmt.Errorf("unknown config source type %q for %v", id.Type(), id)
+		}
+
+		cfg := factory.CreateDefaultConfig()
+		cfg.SetIDName(id.Name())
+
+		// Environment variables can be used on the settings of config sources but
+		// config sources can't be used to configure other config sources.
+		settings := make(map[string]interface{})
+		if valueMap, ok := value.(map[string]interface{}); ok {
+			for k, v := range valueMap {
+				settings[k] = expandEnvVarsInValue(v)
+			}
+		}
+		if err = configparser.NewParserFromStringMap(settings).UnmarshalExact(cfg); err != nil {
+			return nil, fmt.Errorf("error reading config source configuration for %v: %w", id, err)
+		}
+
+		src, err := factory.CreateConfigSource(ctx, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create config source %v: %w", id, err)
+		}
+		configSources[id.String()] = src
+	}
+
+	return configSources, nil
+}
+
 func newErrUnknownConfigSource(cfgSrcName string) error {
 	return &errUnknownConfigSource{
 		fmt.Errorf(`config source %q not found if this was intended to be an environment variable use "${%s}" instead"`, cfgSrcName, cfgSrcName),
@@ -573,6 +645,34 @@ func expandEnvVars(s string) string {
 	})
 }
 
+// expandEnvVarsInValue expands environment variables in all strings of the given value.
+func expandEnvVarsInValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return expandEnvVars(v)
+	case []interface{}:
+		nslice := make([]interface{}, 0, len(v))
+		for _, vint := range v {
+			nslice = append(nslice, expandEnvVarsInValue(vint))
+		}
+		return nslice
+	case map[string]interface{}:
+		nmap := make(map[string]interface{}, len(v))
+		for k, vint := range v {
+			nmap[k] = expandEnvVarsInValue(vint)
+		}
+		return nmap
+	case map[interface{}]interface{}:
+		nmap := make(map[interface{}]interface{}, len(v))
+		for k, vint := range v {
+			nmap[k] = expandEnvVarsInValue(vint)
+		}
+		return nmap
+	default:
+		return v
+	}
+}
+
 // osExpandEnv replicate the internal behavior of os.ExpandEnv when handling env
 // vars updating the buffer accordingly.
 func osExpandEnv(buf []byte, name string, w int) []byte {
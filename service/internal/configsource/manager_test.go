@@ -25,13 +25,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configparser"
 	"go.opentelemetry.io/collector/config/experimental/configsource"
 )
 
 func TestConfigSourceManager_Simple(t *testing.T) {
 	ctx := context.Background()
-	manager, err := NewManager(nil)
+	manager, err := NewManager(context.Background(), nil, nil)
 	require.NoError(t, err)
 	manager.configSources = map[string]configsource.ConfigSource{
 		"tstcfgsrc": &testConfigSource{
@@ -73,6 +74,84 @@ func TestConfigSourceManager_Simple(t *testing.T) {
 	assert.ErrorIs(t, errWatcher, configsource.ErrSessionClosed)
 }
 
+func TestConfigSourceManager_ConfigSourcesSection(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, os.Setenv("CFGSRC_SECTION_VALUE", "from_env"))
+	defer os.Unsetenv("CFGSRC_SECTION_VALUE")
+
+	originalCfg := map[string]interface{}{
+		"config_sources": map[string]interface{}{
+			"tstcfgsrc": map[string]interface{}{
+				"values": map[string]interface{}{
+					"k": "default_value",
+				},
+			},
+			"tstcfgsrc/named": map[string]interface{}{
+				"values": map[string]interface{}{
+					"k": "$CFGSRC_SECTION_VALUE",
+				},
+			},
+		},
+		"top0": map[string]interface{}{
+			"default": "$tstcfgsrc:k",
+			"named":   "${tstcfgsrc/named:k}/suffix",
+		},
+	}
+	expectedCfg := map[string]interface{}{
+		"top0": map[string]interface{}{
+			"default": "default_value",
+			"named":   "from_env/suffix",
+		},
+	}
+
+	cp := configparser.NewParserFromStringMap(originalCfg)
+	factories := configsource.Factories{"tstcfgsrc": &testFactory{}}
+	manager, err := NewManager(ctx, cp, factories)
+	require.NoError(t, err)
+	assert.Len(t, manager.configSources, 2)
+
+	actualParser, err := manager.Resolve(ctx, cp)
+	require.NoError(t, err)
+	assert.Equal(t, expectedCfg, actualParser.ToStringMap())
+	assert.NoError(t, manager.Close(ctx))
+}
+
+func TestConfigSourceManager_ConfigSourcesSectionErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		srcCfg map[string]interface{}
+	}{
+		{
+			name:   "invalid_key",
+			srcCfg: map[string]interface{}{"tstcfgsrc/": nil},
+		},
+		{
+			name:   "unknown_type",
+			srcCfg: map[string]interface{}{"unknown": nil},
+		},
+		{
+			name: "unknown_setting",
+			srcCfg: map[string]interface{}{
+				"tstcfgsrc": map[string]interface{}{"unknown": 1},
+			},
+		},
+		{
+			name: "create_error",
+			srcCfg: map[string]interface{}{
+				"tstcfgsrc": map[string]interface{}{"fail_create": true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp := configparser.NewParserFromStringMap(map[string]interface{}{"config_sources": tt.srcCfg})
+			manager, err := NewManager(context.Background(), cp, configsource.Factories{"tstcfgsrc": &testFactory{}})
+			assert.Error(t, err)
+			assert.Nil(t, manager)
+		})
+	}
+}
+
 func TestConfigSourceManager_ResolveErrors(t *testing.T) {
 	ctx := context.Background()
 	testErr := errors.New("test error")
@@ -126,7 +205,7 @@ func TestConfigSourceManager_ResolveErrors(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			manager, err := NewManager(nil)
+			manager, err := NewManager(context.Background(), nil, nil)
 			require.NoError(t, err)
 			manager.configSources = tt.configSourceMap
 
@@ -140,7 +219,7 @@ func TestConfigSourceManager_ResolveErrors(t *testing.T) {
 
 func TestConfigSourceManager_ArraysAndMaps(t *testing.T) {
 	ctx := context.Background()
-	manager, err := NewManager(nil)
+	manager, err := NewManager(context.Background(), nil, nil)
 	require.NoError(t, err)
 	manager.configSources = map[string]configsource.ConfigSource{
 		"tstcfgsrc": &testConfigSource{
@@ -199,7 +278,7 @@ func TestConfigSourceManager_ParamsHandling(t *testing.T) {
 		return nil
 	}
 
-	manager, err := NewManager(nil)
+	manager, err := NewManager(context.Background(), nil, nil)
 	require.NoError(t, err)
 	manager.configSources = map[string]configsource.ConfigSource{
 		"tstcfgsrc": &tstCfgSrc,
@@ -221,7 +300,7 @@ func TestConfigSourceManager_ParamsHandling(t *testing.T) {
 
 func TestConfigSourceManager_WatchForUpdate(t *testing.T) {
 	ctx := context.Background()
-	manager, err := NewManager(nil)
+	manager, err := NewManager(context.Background(), nil, nil)
 	require.NoError(t, err)
 
 	watchForUpdateCh := make(chan error, 1)
@@ -265,7 +344,7 @@ func TestConfigSourceManager_WatchForUpdate(t *testing.T) {
 
 func TestConfigSourceManager_MultipleWatchForUpdate(t *testing.T) {
 	ctx := context.Background()
-	manager, err := NewManager(nil)
+	manager, err := NewManager(context.Background(), nil, nil)
 	require.NoError(t, err)
 
 	watchDoneCh := make(chan struct{})
@@ -344,7 +423,7 @@ func TestConfigSourceManager_EnvVarHandling(t *testing.T) {
 		return nil
 	}
 
-	manager, err := NewManager(nil)
+	manager, err := NewManager(context.Background(), nil, nil)
 	require.NoError(t, err)
 	manager.configSources = map[string]configsource.ConfigSource{
 		"tstcfgsrc": &tstCfgSrc,
@@ -366,7 +445,7 @@ func TestConfigSourceManager_EnvVarHandling(t *testing.T) {
 
 func TestManager_expandString(t *testing.T) {
 	ctx := context.Background()
-	csp, err := NewManager(nil)
+	csp, err := NewManager(context.Background(), nil, nil)
 	require.NoError(t, err)
 	csp.configSources = map[string]configsource.ConfigSource{
 		"tstcfgsrc": &testConfigSource{
@@ -624,6 +703,34 @@ func (t *testConfigSource) Close(context.Context) error {
 	return t.ErrOnClose
 }
 
+type testFactory struct{}
+
+type testFactoryConfig struct {
+	configsource.SourceSettings `mapstructure:",squash"`
+	Values                      map[string]interface{} `mapstructure:"values"`
+	FailCreate                  bool                   `mapstructure:"fail_create"`
+}
+
+func (f *testFactory) Type() config.Type {
+	return "tstcfgsrc"
+}
+
+func (f *testFactory) CreateDefaultConfig() configsource.ConfigSettings {
+	return &testFactoryConfig{SourceSettings: configsource.NewSourceSettings(config.NewID("tstcfgsrc"))}
+}
+
+func (f *testFactory) CreateConfigSource(_ context.Context, cfg configsource.ConfigSettings) (configsource.ConfigSource, error) {
+	tcfg := cfg.(*testFactoryConfig)
+	if tcfg.FailCreate {
+		return nil, errors.New("failed to create config source")
+	}
+	valueMap := make(map[string]valueEntry, len(tcfg.Values))
+	for k, v := range tcfg.Values {
+		valueMap[k] = valueEntry{Value: v}
+	}
+	return &testConfigSource{ValueMap: valueMap}, nil
+}
+
 type retrieved struct {
 	value interface{}
 }
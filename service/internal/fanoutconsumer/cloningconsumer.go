@@ -22,40 +22,69 @@ import (
 	"go.opentelemetry.io/collector/model/pdata"
 )
 
-// NewMetricsCloning wraps multiple metrics consumers in a single one and clones the data
-// before fanning out.
+// NewMetricsCloning wraps multiple metrics consumers in a single one and fans out the data
+// without letting the consumers modify the data seen by the others.
+//
+// If all the consumers mutate the data, each of them but the last one gets a clone of it.
+// Otherwise the data is marked as read-only and shared by the consumers not mutating it,
+// the ones mutating it get a copy of it made with pdata.Metrics.Mutable.
 func NewMetricsCloning(mcs []consumer.Metrics) consumer.Metrics {
 	if len(mcs) == 1 {
 		// Don't wrap if no need to do it.
 		return mcs[0]
 	}
-	return metricsCloningConsumer(mcs)
+	allMutate := true
+	mutates := make([]bool, len(mcs))
+	for i, mc := range mcs {
+		mutates[i] = mc.Capabilities().MutatesData
+		allMutate = allMutate && mutates[i]
+	}
+	return &metricsCloningConsumer{consumers: mcs, allMutate: allMutate, mutates: mutates}
 }
 
-type metricsCloningConsumer []consumer.Metrics
+type metricsCloningConsumer struct {
+	consumers []consumer.Metrics
+	allMutate bool
+	// mutates reports whether the consumer at the same index mutates the data.
+	mutates []bool
+}
 
 var _ consumer.Metrics = (*metricsCloningConsumer)(nil)
 
-func (mfc metricsCloningConsumer) Capabilities() consumer.Capabilities {
+func (c *metricsCloningConsumer) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
 
 // ConsumeMetrics exports the pdata.Metrics to all consumers wrapped by the current one.
-func (mfc metricsCloningConsumer) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+func (c *metricsCloningConsumer) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	if !c.allMutate {
+		md.MarkReadOnly()
+	}
+
 	var errs []error
 
 	// Fan out to first len-1 consumers.
-	for i := 0; i < len(mfc)-1; i++ {
-		// Create a clone of data. We need to clone because consumers may modify the data.
-		if err := mfc[i].ConsumeMetrics(ctx, md.Clone()); err != nil {
+	for i := 0; i < len(c.consumers)-1; i++ {
+		data := md
+		if c.allMutate {
+			// Create a clone of data. We need to clone because consumers modify the data.
+			data = md.Clone()
+		} else if c.mutates[i] {
+			data = md.Mutable()
+		}
+		if err := c.consumers[i].ConsumeMetrics(ctx, data); err != nil {
 			errs = append(errs, err)
 		}
 	}
 
-	if len(mfc) > 0 {
-		// Give the original data to the last consumer.
-		lastTc := mfc[len(mfc)-1]
-		if err := lastTc.ConsumeMetrics(ctx, md); err != nil {
+	if len(c.consumers) > 0 {
+		// Give the original data to the last consumer, unless it is shared read-only.
+		lastIndex := len(c.consumers) - 1
+		data := md
+		if !c.allMutate && c.mutates[lastIndex] {
+			data = md.Mutable()
+		}
+		if err := c.consumers[lastIndex].ConsumeMetrics(ctx, data); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -63,40 +92,69 @@ func (mfc metricsCloningConsumer) ConsumeMetrics(ctx context.Context, md pdata.M
 	return consumererror.Combine(errs)
 }
 
-// NewTracesCloning wraps multiple traces consumers in a single one and clones the data
-// before fanning out.
+// NewTracesCloning wraps multiple traces consumers in a single one and fans out the data
+// without letting the consumers modify the data seen by the others.
+//
+// If all the consumers mutate the data, each of them but the last one gets a clone of it.
+// Otherwise the data is marked as read-only and shared by the consumers not mutating it,
+// the ones mutating it get a copy of it made with pdata.Traces.Mutable.
 func NewTracesCloning(tcs []consumer.Traces) consumer.Traces {
 	if len(tcs) == 1 {
 		// Don't wrap if no need to do it.
 		return tcs[0]
 	}
-	return tracesCloningConsumer(tcs)
+	allMutate := true
+	mutates := make([]bool, len(tcs))
+	for i, tc := range tcs {
+		mutates[i] = tc.Capabilities().MutatesData
+		allMutate = allMutate && mutates[i]
+	}
+	return &tracesCloningConsumer{consumers: tcs, allMutate: allMutate, mutates: mutates}
 }
 
-type tracesCloningConsumer []consumer.Traces
+type tracesCloningConsumer struct {
+	consumers []consumer.Traces
+	allMutate bool
+	// mutates reports whether the consumer at the same index mutates the data.
+	mutates []bool
+}
 
 var _ consumer.Traces = (*tracesCloningConsumer)(nil)
 
-func (tfc tracesCloningConsumer) Capabilities() consumer.Capabilities {
+func (c *tracesCloningConsumer) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
 
 // ConsumeTraces exports the pdata.Traces to all consumers wrapped by the current one.
-func (tfc tracesCloningConsumer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+func (c *tracesCloningConsumer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if !c.allMutate {
+		td.MarkReadOnly()
+	}
+
 	var errs []error
 
 	// Fan out to first len-1 consumers.
-	for i := 0; i < len(tfc)-1; i++ {
-		// Create a clone of data. We need to clone because consumers may modify the data.
-		if err := tfc[i].ConsumeTraces(ctx, td.Clone()); err != nil {
+	for i := 0; i < len(c.consumers)-1; i++ {
+		data := td
+		if c.allMutate {
+			// Create a clone of data. We need to clone because consumers modify the data.
+			data = td.Clone()
+		} else if c.mutates[i] {
+			data = td.Mutable()
+		}
+		if err := c.consumers[i].ConsumeTraces(ctx, data); err != nil {
 			errs = append(errs, err)
 		}
 	}
 
-	if len(tfc) > 0 {
-		// Give the original data to the last consumer.
-		lastTc := tfc[len(tfc)-1]
-		if err := lastTc.ConsumeTraces(ctx, td); err != nil {
+	if len(c.consumers) > 0 {
+		// Give the original data to the last consumer, unless it is shared read-only.
+		lastIndex := len(c.consumers) - 1
+		data := td
+		if !c.allMutate && c.mutates[lastIndex] {
+			data = td.Mutable()
+		}
+		if err := c.consumers[lastIndex].ConsumeTraces(ctx, data); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -104,40 +162,69 @@ func (tfc tracesCloningConsumer) ConsumeTraces(ctx context.Context, td pdata.Tra
 	return consumererror.Combine(errs)
 }
 
-// NewLogsCloning wraps multiple trace consumers in a single one and clones the data
-// before fanning out.
+// NewLogsCloning wraps multiple logs consumers in a single one and fans out the data
+// without letting the consumers modify the data seen by the others.
+//
+// If all the consumers mutate the data, each of them but the last one gets a clone of it.
+// Otherwise the data is marked as read-only and shared by the consumers not mutating it,
+// the ones mutating it get a copy of it made with pdata.Logs.Mutable.
 func NewLogsCloning(lcs []consumer.Logs) consumer.Logs {
 	if len(lcs) == 1 {
 		// Don't wrap if no need to do it.
 		return lcs[0]
 	}
-	return logsCloningConsumer(lcs)
+	allMutate := true
+	mutates := make([]bool, len(lcs))
+	for i, lc := range lcs {
+		mutates[i] = lc.Capabilities().MutatesData
+		allMutate = allMutate && mutates[i]
+	}
+	return &logsCloningConsumer{consumers: lcs, allMutate: allMutate, mutates: mutates}
 }
 
-type logsCloningConsumer []consumer.Logs
+type logsCloningConsumer struct {
+	consumers []consumer.Logs
+	allMutate bool
+	// mutates reports whether the consumer at the same index mutates the data.
+	mutates []bool
+}
 
 var _ consumer.Logs = (*logsCloningConsumer)(nil)
 
-func (lfc logsCloningConsumer) Capabilities() consumer.Capabilities {
+func (c *logsCloningConsumer) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
 
 // ConsumeLogs exports the pdata.Logs to all consumers wrapped by the current one.
-func (lfc logsCloningConsumer) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+func (c *logsCloningConsumer) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	if !c.allMutate {
+		ld.MarkReadOnly()
+	}
+
 	var errs []error
 
 	// Fan out to first len-1 consumers.
-	for i := 0; i < len(lfc)-1; i++ {
-		// Create a clone of data. We need to clone because consumers may modify the data.
-		if err := lfc[i].ConsumeLogs(ctx, ld.Clone()); err != nil {
+	for i := 0; i < len(c.consumers)-1; i++ {
+		data := ld
+		if c.allMutate {
+			// Create a clone of data. We need to clone because consumers modify the data.
+			data = ld.Clone()
+		} else if c.mutates[i] {
+			data = ld.Mutable()
+		}
+		if err := c.consumers[i].ConsumeLogs(ctx, data); err != nil {
 			errs = append(errs, err)
 		}
 	}
 
-	if len(lfc) > 0 {
-		// Give the original data to the last consumer.
-		lastTc := lfc[len(lfc)-1]
-		if err := lastTc.ConsumeLogs(ctx, ld); err != nil {
+	if len(c.consumers) > 0 {
+		// Give the original data to the last consumer, unless it is shared read-only.
+		lastIndex := len(c.consumers) - 1
+		data := ld
+		if !c.allMutate && c.mutates[lastIndex] {
+			data = ld.Mutable()
+		}
+		if err := c.consumers[lastIndex].ConsumeLogs(ctx, data); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -19,12 +19,38 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/model/pdata"
 )
 
+type mutatingTracesSink struct {
+	consumertest.TracesSink
+}
+
+func (mts *mutatingTracesSink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+type mutatingMetricsSink struct {
+	consumertest.MetricsSink
+}
+
+func (mms *mutatingMetricsSink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+type mutatingLogsSink struct {
+	consumertest.LogsSink
+}
+
+func (mls *mutatingLogsSink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
 func TestTracesProcessorCloningNotMultiplexing(t *testing.T) {
 	nop := consumertest.NewNop()
 	tfc := NewTracesCloning([]consumer.Traces{nop})
@@ -34,7 +60,7 @@ func TestTracesProcessorCloningNotMultiplexing(t *testing.T) {
 func TestTracesProcessorCloningMultiplexing(t *testing.T) {
 	processors := make([]consumer.Traces, 3)
 	for i := range processors {
-		processors[i] = new(consumertest.TracesSink)
+		processors[i] = new(mutatingTracesSink)
 	}
 
 	tfc := NewTracesCloning(processors)
@@ -51,7 +77,7 @@ func TestTracesProcessorCloningMultiplexing(t *testing.T) {
 	}
 
 	for i, p := range processors {
-		m := p.(*consumertest.TracesSink)
+		m := p.(*mutatingTracesSink)
 		assert.Equal(t, wantSpanCount, m.SpanCount())
 		spanOrig := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
 		allTraces := m.AllTraces()
@@ -77,7 +103,7 @@ func TestMetricsProcessorCloningNotMultiplexing(t *testing.T) {
 func TestMetricsProcessorCloningMultiplexing(t *testing.T) {
 	processors := make([]consumer.Metrics, 3)
 	for i := range processors {
-		processors[i] = new(consumertest.MetricsSink)
+		processors[i] = new(mutatingMetricsSink)
 	}
 
 	mfc := NewMetricsCloning(processors)
@@ -94,7 +120,7 @@ func TestMetricsProcessorCloningMultiplexing(t *testing.T) {
 	}
 
 	for i, p := range processors {
-		m := p.(*consumertest.MetricsSink)
+		m := p.(*mutatingMetricsSink)
 		assert.Equal(t, wantDataPointCount, m.DataPointCount())
 		metricOrig := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
 		allMetrics := m.AllMetrics()
@@ -120,7 +146,7 @@ func TestLogsProcessorCloningNotMultiplexing(t *testing.T) {
 func TestLogsProcessorCloningMultiplexing(t *testing.T) {
 	processors := make([]consumer.Logs, 3)
 	for i := range processors {
-		processors[i] = new(consumertest.LogsSink)
+		processors[i] = new(mutatingLogsSink)
 	}
 
 	mfc := NewLogsCloning(processors)
@@ -137,7 +163,7 @@ func TestLogsProcessorCloningMultiplexing(t *testing.T) {
 	}
 
 	for i, p := range processors {
-		m := p.(*consumertest.LogsSink)
+		m := p.(*mutatingLogsSink)
 		assert.Equal(t, wantMetricsCount, m.LogRecordCount())
 		metricOrig := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
 		allLogs := m.AllLogs()
@@ -153,3 +179,75 @@ func TestLogsProcessorCloningMultiplexing(t *testing.T) {
 		assert.EqualValues(t, metricOrig, metricClone)
 	}
 }
+
+func TestTracesProcessorCloningSharedReadOnly(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	mutating := new(mutatingTracesSink)
+	tfc := NewTracesCloning([]consumer.Traces{sink, mutating})
+	td := testdata.GenerateTracesOneSpan()
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+
+	// The consumer not mutating the data gets the read-only data, the mutating one a copy of it.
+	assert.True(t, td.IsReadOnly())
+	assert.True(t, td.ResourceSpans().At(0).Resource() == sink.AllTraces()[0].ResourceSpans().At(0).Resource())
+	assert.False(t, td.ResourceSpans().At(0).Resource() == mutating.AllTraces()[0].ResourceSpans().At(0).Resource())
+	assert.False(t, mutating.AllTraces()[0].IsReadOnly())
+	assert.NotPanics(t, func() { mutating.AllTraces()[0].ResourceSpans().AppendEmpty() })
+	assert.Equal(t, 1, td.SpanCount())
+}
+
+// appendingTracesConsumer mutates the data it consumes without calling pdata.Traces.Mutable.
+type appendingTracesConsumer struct {
+	consumertest.TracesSink
+}
+
+func (atc *appendingTracesConsumer) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (atc *appendingTracesConsumer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	td.ResourceSpans().AppendEmpty()
+	return atc.TracesSink.ConsumeTraces(ctx, td)
+}
+
+func TestTracesProcessorCloningSharedReadOnlyMutatingConsumer(t *testing.T) {
+	for _, mutatingFirst := range []bool{true, false} {
+		sink := new(consumertest.TracesSink)
+		mutating := new(appendingTracesConsumer)
+		tcs := []consumer.Traces{sink, mutating}
+		if mutatingFirst {
+			tcs = []consumer.Traces{mutating, sink}
+		}
+		tfc := NewTracesCloning(tcs)
+		td := testdata.GenerateTracesOneSpan()
+		require.NotPanics(t, func() { require.NoError(t, tfc.ConsumeTraces(context.Background(), td)) })
+
+		assert.Equal(t, 1, td.ResourceSpans().Len())
+		assert.Equal(t, 1, sink.AllTraces()[0].ResourceSpans().Len())
+		assert.Equal(t, 2, mutating.AllTraces()[0].ResourceSpans().Len())
+	}
+}
+
+func TestMetricsProcessorCloningSharedReadOnly(t *testing.T) {
+	sinks := []consumer.Metrics{new(consumertest.MetricsSink), new(consumertest.MetricsSink)}
+	mfc := NewMetricsCloning(sinks)
+	md := testdata.GenerateMetricsOneMetric()
+	require.NoError(t, mfc.ConsumeMetrics(context.Background(), md))
+
+	assert.True(t, md.IsReadOnly())
+	for _, sink := range sinks {
+		assert.True(t, md.ResourceMetrics().At(0).Resource() == sink.(*consumertest.MetricsSink).AllMetrics()[0].ResourceMetrics().At(0).Resource())
+	}
+}
+
+func TestLogsProcessorCloningSharedReadOnly(t *testing.T) {
+	sinks := []consumer.Logs{new(consumertest.LogsSink), new(mutatingLogsSink)}
+	lfc := NewLogsCloning(sinks)
+	ld := testdata.GenerateLogsOneLogRecord()
+	require.NoError(t, lfc.ConsumeLogs(context.Background(), ld))
+
+	assert.True(t, ld.IsReadOnly())
+	assert.True(t, ld.ResourceLogs().At(0).Resource() == sinks[0].(*consumertest.LogsSink).AllLogs()[0].ResourceLogs().At(0).Resource())
+	assert.False(t, ld.ResourceLogs().At(0).Resource() == sinks[1].(*mutatingLogsSink).AllLogs()[0].ResourceLogs().At(0).Resource())
+	assert.False(t, sinks[1].(*mutatingLogsSink).AllLogs()[0].IsReadOnly())
+}
@@ -0,0 +1,353 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanoutconsumer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// defaultQueueSize is the number of requests queued for every best effort exporter
+// in config.FanoutModeAsync if the pipeline does not configure it.
+const defaultQueueSize = 100
+
+// ExportersTraces is a consumer.Traces fanning out to the exporters of a pipeline. It must be
+// started before and shut down after the processors of the pipeline.
+type ExportersTraces interface {
+	consumer.Traces
+	component.Component
+}
+
+// ExportersMetrics is a consumer.Metrics fanning out to the exporters of a pipeline. It must be
+// started before and shut down after the processors of the pipeline.
+type ExportersMetrics interface {
+	consumer.Metrics
+	component.Component
+}
+
+// ExportersLogs is a consumer.Logs fanning out to the exporters of a pipeline. It must be
+// started before and shut down after the processors of the pipeline.
+type ExportersLogs interface {
+	consumer.Logs
+	component.Component
+}
+
+// NewExportersTraces wraps the exporters of the pipeline, given in the same order as the
+// pipeline's Exporters, into a single consumer configured by the pipeline's Fanout.
+func NewExportersTraces(logger *zap.Logger, pipeline *config.Pipeline, tcs []consumer.Traces) ExportersTraces {
+	consumeFuncs := make([]consumeFunc, len(tcs))
+	for i, tc := range tcs {
+		tc := tc
+		// Exporters that mutate the data get their own copy, unless they are alone.
+		clone := tc.Capabilities().MutatesData && len(tcs) > 1
+		consumeFuncs[i] = func(ctx context.Context, data interface{}) error {
+			td := data.(pdata.Traces)
+			if clone {
+				td = td.Clone()
+			}
+			return tc.ConsumeTraces(ctx, td)
+		}
+	}
+	return &exportersTraces{newExportersFanout(logger, pipeline, consumeFuncs)}
+}
+
+type exportersTraces struct {
+	*exportersFanout
+}
+
+func (etc *exportersTraces) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	return etc.consume(ctx, td)
+}
+
+// NewExportersMetrics wraps the exporters of the pipeline, given in the same order as the
+// pipeline's Exporters, into a single consumer configured by the pipeline's Fanout.
+func NewExportersMetrics(logger *zap.Logger, pipeline *config.Pipeline, mcs []consumer.Metrics) ExportersMetrics {
+	consumeFuncs := make([]consumeFunc, len(mcs))
+	for i, mc := range mcs {
+		mc := mc
+		// Exporters that mutate the data get their own copy, unless they are alone.
+		clone := mc.Capabilities().MutatesData && len(mcs) > 1
+		consumeFuncs[i] = func(ctx context.Context, data interface{}) error {
+			md := data.(pdata.Metrics)
+			if clone {
+				md = md.Clone()
+			}
+			return mc.ConsumeMetrics(ctx, md)
+		}
+	}
+	return &exportersMetrics{newExportersFanout(logger, pipeline, consumeFuncs)}
+}
+
+type exportersMetrics struct {
+	*exportersFanout
+}
+
+func (emc *exportersMetrics) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	return emc.consume(ctx, md)
+}
+
+// NewExportersLogs wraps the exporters of the pipeline, given in the same order as the
+// pipeline's Exporters, into a single consumer configured by the pipeline's Fanout.
+func NewExportersLogs(logger *zap.Logger, pipeline *config.Pipeline, lcs []consumer.Logs) ExportersLogs {
+	consumeFuncs := make([]consumeFunc, len(lcs))
+	for i, lc := range lcs {
+		lc := lc
+		// Exporters that mutate the data get their own copy, unless they are alone.
+		clone := lc.Capabilities().MutatesData && len(lcs) > 1
+		consumeFuncs[i] = func(ctx context.Context, data interface{}) error {
+			ld := data.(pdata.Logs)
+			if clone {
+				ld = ld.Clone()
+			}
+			return lc.ConsumeLogs(ctx, ld)
+		}
+	}
+	return &exportersLogs{newExportersFanout(logger, pipeline, consumeFuncs)}
+}
+
+type exportersLogs struct {
+	*exportersFanout
+}
+
+func (elc *exportersLogs) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	return elc.consume(ctx, ld)
+}
+
+// readOnlyMarker is implemented by pdata.Traces, pdata.Metrics and pdata.Logs.
+type readOnlyMarker interface {
+	MarkReadOnly()
+}
+
+// consumeFunc sends the pipeline data, pdata.Traces, pdata.Metrics or pdata.Logs, to an exporter.
+type consumeFunc func(ctx context.Context, data interface{}) error
+
+// queuedRequest is the data queued for a best effort exporter.
+type queuedRequest struct {
+	ctx  context.Context
+	data interface{}
+}
+
+// branch is an exporter of the pipeline.
+type branch struct {
+	id         config.ComponentID
+	bestEffort bool
+	consume    consumeFunc
+	mutators   []tag.Mutator
+	// queue is only used for the best effort exporters in config.FanoutModeAsync.
+	queue chan queuedRequest
+}
+
+// send sends the data to the exporter and records the branch metrics.
+func (b *branch) send(ctx context.Context, data interface{}) error {
+	start := time.Now()
+	err := b.consume(ctx, data)
+	measurements := []stats.Measurement{obsmetrics.FanoutBranchLatency.M(float64(time.Since(start)) / float64(time.Millisecond))}
+	if err != nil {
+		measurements = append(measurements, obsmetrics.FanoutBranchErrors.M(1))
+	}
+	_ = stats.RecordWithTags(ctx, b.mutators, measurements...)
+	return err
+}
+
+// exportersFanout sends the data to the exporters of a pipeline, and implements the
+// behavior common to all the data types.
+type exportersFanout struct {
+	logger   *zap.Logger
+	async    bool
+	branches []*branch
+
+	// mu protects the queues from being used after they are closed.
+	mu         sync.RWMutex
+	stopped    bool
+	goroutines sync.WaitGroup
+}
+
+func newExportersFanout(logger *zap.Logger, pipeline *config.Pipeline, consumeFuncs []consumeFunc) *exportersFanout {
+	ef := &exportersFanout{
+		logger: logger,
+		async:  pipeline.Fanout.Mode == config.FanoutModeAsync,
+	}
+	queueSize := pipeline.Fanout.QueueSize
+	if queueSize == 0 {
+		queueSize = defaultQueueSize
+	}
+	for i, consume := range consumeFuncs {
+		id := pipeline.Exporters[i]
+		b := &branch{
+			id:         id,
+			bestEffort: pipeline.IsBestEffort(id),
+			consume:    consume,
+			mutators: []tag.Mutator{
+				tag.Upsert(obsmetrics.TagKeyPipeline, pipeline.Name, tag.WithTTL(tag.TTLNoPropagation)),
+				tag.Upsert(obsmetrics.TagKeyExporter, id.String(), tag.WithTTL(tag.TTLNoPropagation)),
+			},
+		}
+		if ef.async && b.bestEffort {
+			b.queue = make(chan queuedRequest, queueSize)
+		}
+		ef.branches = append(ef.branches, b)
+	}
+	return ef
+}
+
+func (ef *exportersFanout) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// Start starts consuming the queues of the best effort exporters.
+func (ef *exportersFanout) Start(context.Context, component.Host) error {
+	for _, b := range ef.branches {
+		if b.queue == nil {
+			continue
+		}
+		ef.goroutines.Add(1)
+		go func(b *branch) {
+			defer ef.goroutines.Done()
+			for req := range b.queue {
+				if err := b.send(req.ctx, req.data); err != nil {
+					ef.logger.Debug("Best effort exporter failed to consume data",
+						zap.Stringer("exporter", b.id), zap.Error(err))
+				}
+			}
+		}(b)
+	}
+	return nil
+}
+
+// Shutdown sends the data left in the queues of the best effort exporters.
+func (ef *exportersFanout) Shutdown(context.Context) error {
+	ef.mu.Lock()
+	if !ef.stopped {
+		ef.stopped = true
+		for _, b := range ef.branches {
+			if b.queue != nil {
+				close(b.queue)
+			}
+		}
+	}
+	ef.mu.Unlock()
+	ef.goroutines.Wait()
+	return nil
+}
+
+func (ef *exportersFanout) consume(ctx context.Context, data interface{}) error {
+	if ef.async {
+		return ef.consumeAsync(ctx, data)
+	}
+
+	var errs []error
+	for _, b := range ef.branches {
+		err := b.send(ctx, data)
+		if err == nil {
+			continue
+		}
+		if b.bestEffort {
+			ef.logger.Debug("Best effort exporter failed to consume data",
+				zap.Stringer("exporter", b.id), zap.Error(err))
+			continue
+		}
+		errs = append(errs, err)
+	}
+	return consumererror.Combine(errs)
+}
+
+// consumeAsync queues the data for the best effort exporters and sends it concurrently to the
+// required ones, it returns the errors of the required exporters only.
+func (ef *exportersFanout) consumeAsync(ctx context.Context, data interface{}) error {
+	// The exporters use the data concurrently, the ones mutating it get their own copy.
+	if len(ef.branches) > 1 {
+		data.(readOnlyMarker).MarkReadOnly()
+	}
+
+	var required []*branch
+	for _, b := range ef.branches {
+		if b.queue != nil {
+			ef.enqueue(ctx, b, data)
+		} else {
+			required = append(required, b)
+		}
+	}
+
+	if len(required) == 0 {
+		return nil
+	}
+
+	// Send to the last required exporter in the caller's goroutine.
+	errs := make([]error, len(required))
+	var wg sync.WaitGroup
+	for i := 0; i < len(required)-1; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = required[i].send(ctx, data)
+		}(i)
+	}
+	last := len(required) - 1
+	errs[last] = required[last].send(ctx, data)
+	wg.Wait()
+
+	var failed []error
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	return consumererror.Combine(failed)
+}
+
+// enqueue queues the data for a best effort exporter without blocking, the data is dropped
+// if the queue is full or the fanout is shut down.
+func (ef *exportersFanout) enqueue(ctx context.Context, b *branch, data interface{}) {
+	ef.mu.RLock()
+	defer ef.mu.RUnlock()
+	if !ef.stopped {
+		select {
+		// The receiver may cancel the context as soon as the data is queued.
+		case b.queue <- queuedRequest{ctx: noCancellationContext{Context: ctx}, data: data}:
+			return
+		default:
+		}
+	}
+	_ = stats.RecordWithTags(ctx, b.mutators, obsmetrics.FanoutBranchDropped.M(1))
+}
+
+// noCancellationContext keeps the values of a context, but not its deadline and cancellation.
+type noCancellationContext struct {
+	context.Context
+}
+
+func (noCancellationContext) Deadline() (deadline time.Time, ok bool) {
+	return
+}
+
+func (noCancellationContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (noCancellationContext) Err() error {
+	return nil
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanoutconsumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// blockingLogs is a consumer.Logs blocking until unblock is closed.
+type blockingLogs struct {
+	consumertest.LogsSink
+	unblock chan struct{}
+}
+
+func (bl *blockingLogs) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	<-bl.unblock
+	return bl.LogsSink.ConsumeLogs(ctx, ld)
+}
+
+// mutatingTraces is a consumer.Traces removing the spans of the data it consumes.
+type mutatingTraces struct {
+	consumertest.TracesSink
+}
+
+func (mt *mutatingTraces) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (mt *mutatingTraces) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if err := mt.TracesSink.ConsumeTraces(ctx, td.Clone()); err != nil {
+		return err
+	}
+	td.ResourceSpans().RemoveIf(func(pdata.ResourceSpans) bool { return true })
+	return nil
+}
+
+func newTestPipeline(mode config.FanoutMode, queueSize int, bestEffort ...config.ComponentID) *config.Pipeline {
+	return &config.Pipeline{
+		Name:      "test",
+		Exporters: []config.ComponentID{config.NewIDWithName("exp", "0"), config.NewIDWithName("exp", "1"), config.NewIDWithName("exp", "2")},
+		Fanout: config.FanoutSettings{
+			Mode:       mode,
+			BestEffort: bestEffort,
+			QueueSize:  queueSize,
+		},
+	}
+}
+
+func TestExportersTracesSync(t *testing.T) {
+	sink0 := new(consumertest.TracesSink)
+	sink2 := new(consumertest.TracesSink)
+	pipeline := newTestPipeline(config.FanoutModeSync, 0, config.NewIDWithName("exp", "1"))
+	etc := NewExportersTraces(zap.NewNop(), pipeline, []consumer.Traces{sink0, consumertest.NewErr(errors.New("my error")), sink2})
+	require.NoError(t, etc.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The error of the best effort exporter is not reported.
+	td := testdata.GenerateTracesOneSpan()
+	assert.NoError(t, etc.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, 1, sink0.SpanCount())
+	assert.Equal(t, 1, sink2.SpanCount())
+	assert.NoError(t, etc.Shutdown(context.Background()))
+
+	// The error of a required exporter is reported.
+	pipeline.Fanout.BestEffort = nil
+	etc = NewExportersTraces(zap.NewNop(), pipeline, []consumer.Traces{sink0, consumertest.NewErr(errors.New("my error")), sink2})
+	assert.EqualError(t, etc.ConsumeTraces(context.Background(), td), "my error")
+	assert.Equal(t, 2, sink2.SpanCount())
+}
+
+func TestExportersTracesMutatingExporter(t *testing.T) {
+	mutating := new(mutatingTraces)
+	sink := new(consumertest.TracesSink)
+	pipeline := newTestPipeline(config.FanoutModeSync, 0)
+	pipeline.Exporters = pipeline.Exporters[:2]
+	etc := NewExportersTraces(zap.NewNop(), pipeline, []consumer.Traces{mutating, sink})
+
+	td := testdata.GenerateTracesOneSpan()
+	assert.NoError(t, etc.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, 1, mutating.SpanCount())
+	assert.Equal(t, 1, sink.SpanCount())
+	assert.Equal(t, 1, td.SpanCount())
+}
+
+func TestExportersMetricsAsync(t *testing.T) {
+	sink0 := new(consumertest.MetricsSink)
+	sink2 := new(consumertest.MetricsSink)
+	pipeline := newTestPipeline(config.FanoutModeAsync, 0, config.NewIDWithName("exp", "2"))
+	emc := NewExportersMetrics(zap.NewNop(), pipeline, []consumer.Metrics{sink0, consumertest.NewErr(errors.New("my error")), sink2})
+	require.NoError(t, emc.Start(context.Background(), componenttest.NewNopHost()))
+
+	md := testdata.GenerateMetricsOneMetric()
+	assert.EqualError(t, emc.ConsumeMetrics(context.Background(), md), "my error")
+	assert.Equal(t, 1, len(sink0.AllMetrics()))
+
+	// The data queued for the best effort exporter is sent before the shutdown completes.
+	assert.NoError(t, emc.Shutdown(context.Background()))
+	assert.Equal(t, 1, len(sink2.AllMetrics()))
+}
+
+func TestExportersLogsAsyncBestEffortDoesNotBlock(t *testing.T) {
+	blocking := &blockingLogs{unblock: make(chan struct{})}
+	sink0 := new(consumertest.LogsSink)
+	sink1 := new(consumertest.LogsSink)
+	pipeline := newTestPipeline(config.FanoutModeAsync, 1, config.NewIDWithName("exp", "2"))
+	elc := NewExportersLogs(zap.NewNop(), pipeline, []consumer.Logs{sink0, sink1, blocking})
+	require.NoError(t, elc.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := testdata.GenerateLogsOneLogRecord()
+	ctx, cancel := context.WithCancel(context.Background())
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, elc.ConsumeLogs(ctx, ld))
+	}
+	// Cancelling the context of the receivers does not affect the queued data.
+	cancel()
+	assert.Equal(t, 5, sink0.LogRecordCount())
+	assert.Equal(t, 5, sink1.LogRecordCount())
+
+	close(blocking.unblock)
+	assert.NoError(t, elc.Shutdown(context.Background()))
+	// At most one request is being consumed and one is queued, the others are dropped.
+	assert.GreaterOrEqual(t, blocking.LogRecordCount(), 1)
+	assert.LessOrEqual(t, blocking.LogRecordCount(), 2)
+
+	// The data sent after the shutdown is dropped for the best effort exporters.
+	assert.NoError(t, elc.ConsumeLogs(context.Background(), ld))
+	assert.Equal(t, 6, sink0.LogRecordCount())
+	assert.LessOrEqual(t, blocking.LogRecordCount(), 2)
+}
+
+func TestExportersAsyncMarksDataReadOnly(t *testing.T) {
+	sink0 := new(consumertest.TracesSink)
+	sink1 := new(consumertest.TracesSink)
+	mutating := new(mutatingTraces)
+	pipeline := newTestPipeline(config.FanoutModeAsync, 0, config.NewIDWithName("exp", "1"))
+	etc := NewExportersTraces(zap.NewNop(), pipeline, []consumer.Traces{sink0, sink1, mutating})
+	require.NoError(t, etc.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := testdata.GenerateTracesOneSpan()
+	require.NoError(t, etc.ConsumeTraces(context.Background(), td))
+	require.NoError(t, etc.Shutdown(context.Background()))
+
+	assert.True(t, td.IsReadOnly())
+	require.Len(t, sink0.AllTraces(), 1)
+	assert.True(t, sink0.AllTraces()[0].IsReadOnly())
+	require.Len(t, sink1.AllTraces(), 1)
+	assert.True(t, sink1.AllTraces()[0].IsReadOnly())
+	// The mutating exporter got its own copy.
+	assert.Equal(t, 1, mutating.SpanCount())
+	assert.Equal(t, 1, td.SpanCount())
+}
+
+func TestExportersAsyncSingleExporterKeepsDataMutable(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	pipeline := &config.Pipeline{
+		Name:      "test",
+		Exporters: []config.ComponentID{config.NewIDWithName("exp", "0")},
+		Fanout:    config.FanoutSettings{Mode: config.FanoutModeAsync},
+	}
+	etc := NewExportersTraces(zap.NewNop(), pipeline, []consumer.Traces{sink})
+	require.NoError(t, etc.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := testdata.GenerateTracesOneSpan()
+	require.NoError(t, etc.ConsumeTraces(context.Background(), td))
+	require.NoError(t, etc.Shutdown(context.Background()))
+	assert.False(t, td.IsReadOnly())
+}
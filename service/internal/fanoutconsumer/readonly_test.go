@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanoutconsumer
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter/loggingexporter"
+	"go.opentelemetry.io/collector/exporter/prometheusexporter"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/testutil"
+)
+
+// The tests below fan out data to real exporters next to a sink, the data is then shared
+// read-only and any in place modification by the exporters panics.
+
+func readOnlyTestAttributes(attrs pdata.AttributeMap) {
+	attrs.InsertString("zone", "eu")
+	attrs.InsertString("host", "h1")
+	nested := pdata.NewAttributeValueMap()
+	nested.MapVal().InsertString("z", "1")
+	nested.MapVal().InsertString("a", "2")
+	attrs.Insert("labels", nested)
+}
+
+func TestMetricsCloningReadOnlyPrometheusExporter(t *testing.T) {
+	factory := prometheusexporter.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*prometheusexporter.Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.EnableOpenMetrics = true
+	exp, err := factory.CreateMetricsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, exp.Shutdown(context.Background())) }()
+
+	md := pdata.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("requests")
+	metric.SetDataType(pdata.MetricDataTypeSum)
+	metric.Sum().SetIsMonotonic(true)
+	metric.Sum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	dp := metric.Sum().DataPoints().AppendEmpty()
+	readOnlyTestAttributes(dp.Attributes())
+	dp.SetIntVal(10)
+	exemplar := dp.Exemplars().AppendEmpty()
+	exemplar.SetIntVal(3)
+	exemplar.FilteredAttributes().InsertString("user", "alice")
+	exemplar.FilteredAttributes().InsertString("client", "curl")
+
+	sink := new(consumertest.MetricsSink)
+	fc := NewMetricsCloning([]consumer.Metrics{exp, sink})
+	require.NoError(t, fc.ConsumeMetrics(context.Background(), md))
+	assert.True(t, md.IsReadOnly())
+	assert.Equal(t, 1, len(sink.AllMetrics()))
+
+	req, err := http.NewRequest(http.MethodGet, "http://"+cfg.Endpoint+"/metrics", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/openmetrics-text;version=0.0.1")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Contains(t, string(body), `# {client="curl",user="alice"} 3`)
+}
+
+func newLoggingExporterFactory() (component.ExporterFactory, *loggingexporter.Config) {
+	factory := loggingexporter.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*loggingexporter.Config)
+	cfg.LogLevel = "debug"
+	return factory, cfg
+}
+
+func TestLogsCloningReadOnlyLoggingExporter(t *testing.T) {
+	factory, cfg := newLoggingExporterFactory()
+	exp, err := factory.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	ld := pdata.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	readOnlyTestAttributes(lr.Attributes())
+
+	sink := new(consumertest.LogsSink)
+	fc := NewLogsCloning([]consumer.Logs{exp, sink})
+	require.NoError(t, fc.ConsumeLogs(context.Background(), ld))
+	assert.True(t, ld.IsReadOnly())
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestTracesCloningReadOnlyLoggingExporter(t *testing.T) {
+	factory, cfg := newLoggingExporterFactory()
+	exp, err := factory.CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	td := pdata.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	readOnlyTestAttributes(span.Attributes())
+
+	sink := new(consumertest.TracesSink)
+	fc := NewTracesCloning([]consumer.Traces{exp, sink})
+	require.NoError(t, fc.ConsumeTraces(context.Background(), td))
+	assert.True(t, td.IsReadOnly())
+	assert.Equal(t, 1, sink.SpanCount())
+}
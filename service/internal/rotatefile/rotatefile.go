@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rotatefile implements a file writer rotating the file once it reaches
+// a maximum size, used for the collector's own logs.
+package rotatefile
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Writer writes to a file, and renames the file to "<path>.1" once it reaches the
+// maximum size, shifting the previous backups to "<path>.2", "<path>.3" and so on.
+// It is safe for concurrent use.
+type Writer struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// Open opens, or creates, the file at path for appending. The file is rotated before
+// a write would make it larger than maxSize bytes, keeping at most maxBackups
+// rotated files, or all of them if maxBackups is zero.
+func Open(path string, maxSize int64, maxBackups int) (*Writer, error) {
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("invalid max size %d for file %q", maxSize, path)
+	}
+	w := &Writer{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write writes p to the file, rotating it first if needed.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return 0, os.ErrClosed
+	}
+	// An empty file is never rotated, so an entry larger than the maximum size
+	// still gets written.
+	if w.size > 0 && w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Sync commits the content of the file to stable storage.
+func (w *Writer) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return os.ErrClosed
+	}
+	return w.file.Sync()
+}
+
+// Close closes the file, the Writer cannot be used afterwards.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *Writer) open() error {
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	w.file = file
+	w.size = info.Size()
+	return nil
+}
+
+func (w *Writer) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	w.file = nil
+
+	// Find the first free backup index, bounded by maxBackups, and shift the backups
+	// before it by one, the last backup being overwritten when the limit is reached.
+	last := 1
+	for w.maxBackups == 0 || last < w.maxBackups {
+		if _, err := os.Stat(w.backup(last)); os.IsNotExist(err) {
+			break
+		}
+		last++
+	}
+	for i := last; i > 1; i-- {
+		if err := os.Rename(w.backup(i-1), w.backup(i)); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(w.path, w.backup(1)); err != nil {
+		return err
+	}
+	return w.open()
+}
+
+func (w *Writer) backup(i int) string {
+	return fmt.Sprintf("%s.%d", w.path, i)
+}
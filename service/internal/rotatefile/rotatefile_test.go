@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rotatefile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readFile(t *testing.T, path string) string {
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "otelcol.log")
+	require.NoError(t, os.WriteFile(path, []byte("old\n"), 0600))
+
+	w, err := Open(path, 10, 2)
+	require.NoError(t, err)
+
+	for _, line := range []string{"one\n", "two\n", "three\n", "four\n", "five\n", "six\n"} {
+		n, err := w.Write([]byte(line))
+		require.NoError(t, err)
+		assert.Equal(t, len(line), n)
+	}
+	require.NoError(t, w.Sync())
+	require.NoError(t, w.Close())
+
+	// The existing content counts toward the size, and only two backups are kept.
+	assert.Equal(t, "six\n", readFile(t, path))
+	assert.Equal(t, "four\nfive\n", readFile(t, path+".1"))
+	assert.Equal(t, "two\nthree\n", readFile(t, path+".2"))
+	assert.NoFileExists(t, path+".3")
+
+	_, err = w.Write([]byte("seven\n"))
+	assert.ErrorIs(t, err, os.ErrClosed)
+	assert.NoError(t, w.Close())
+}
+
+func TestWriterUnlimitedBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "otelcol.log")
+	w, err := Open(path, 1, 0)
+	require.NoError(t, err)
+
+	// Entries larger than the maximum size are written to their own file.
+	for _, line := range []string{"one\n", "two\n", "three\n"} {
+		_, err = w.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, "three\n", readFile(t, path))
+	assert.Equal(t, "two\n", readFile(t, path+".1"))
+	assert.Equal(t, "one\n", readFile(t, path+".2"))
+}
+
+func TestOpenInvalid(t *testing.T) {
+	_, err := Open(filepath.Join(t.TempDir(), "otelcol.log"), 0, 0)
+	assert.Error(t, err)
+
+	_, err = Open(filepath.Join(t.TempDir(), "missing", "otelcol.log"), 10, 0)
+	assert.Error(t, err)
+}
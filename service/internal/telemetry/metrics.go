@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"sort"
+
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/metric/metricexport"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// instrumentationLibraryName is the name of the instrumentation library of the
+// collector's own metrics and traces.
+const instrumentationLibraryName = "go.opentelemetry.io/collector"
+
+// metricsExporter is a metricexport.Exporter pushing the OpenCensus metrics of the
+// collector to a consumer.
+type metricsExporter struct {
+	resourceAttrs map[string]string
+	next          consumer.Metrics
+	logger        *zap.Logger
+}
+
+var _ metricexport.Exporter = (*metricsExporter)(nil)
+
+// NewMetricsExporter returns a metricexport.Exporter converting the OpenCensus metrics
+// to pdata.Metrics, with a resource having the given attributes, and pushing them to
+// the next consumer. The export errors are logged.
+func NewMetricsExporter(resourceAttrs map[string]string, next consumer.Metrics, logger *zap.Logger) metricexport.Exporter {
+	return &metricsExporter{
+		resourceAttrs: resourceAttrs,
+		next:          next,
+		logger:        logger,
+	}
+}
+
+func (me *metricsExporter) ExportMetrics(ctx context.Context, metrics []*metricdata.Metric) error {
+	md := MetricsToPdata(me.resourceAttrs, metrics)
+	if md.MetricCount() == 0 {
+		return nil
+	}
+	err := me.next.ConsumeMetrics(ctx, md)
+	if err != nil {
+		me.logger.Warn("Failed to export the collector's own metrics", zap.Error(err))
+	}
+	return err
+}
+
+// MetricsToPdata converts the OpenCensus metrics to pdata.Metrics with a resource having
+// the given attributes. The gauges become pdata gauges, the cumulatives become monotonic
+// sums, and the distributions become cumulative histograms.
+func MetricsToPdata(resourceAttrs map[string]string, metrics []*metricdata.Metric) pdata.Metrics {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	fillResource(rm.Resource(), resourceAttrs)
+	ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName(instrumentationLibraryName)
+
+	for _, metric := range metrics {
+		if metric == nil {
+			continue
+		}
+		ocMetricToPdata(metric, ilm.Metrics())
+	}
+	return md
+}
+
+func ocMetricToPdata(metric *metricdata.Metric, dest pdata.MetricSlice) {
+	desc := metric.Descriptor
+	m := pdata.NewMetric()
+	m.SetName(desc.Name)
+	m.SetDescription(desc.Description)
+	m.SetUnit(string(desc.Unit))
+
+	switch desc.Type {
+	case metricdata.TypeGaugeInt64, metricdata.TypeGaugeFloat64:
+		m.SetDataType(pdata.MetricDataTypeGauge)
+		dps := m.Gauge().DataPoints()
+		forEachPoint(metric, func(ts *metricdata.TimeSeries, point metricdata.Point) {
+			fillNumberDataPoint(dps.AppendEmpty(), desc.LabelKeys, ts, point, false)
+		})
+	case metricdata.TypeCumulativeInt64, metricdata.TypeCumulativeFloat64:
+		m.SetDataType(pdata.MetricDataTypeSum)
+		m.Sum().SetIsMonotonic(true)
+		m.Sum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		dps := m.Sum().DataPoints()
+		forEachPoint(metric, func(ts *metricdata.TimeSeries, point metricdata.Point) {
+			fillNumberDataPoint(dps.AppendEmpty(), desc.LabelKeys, ts, point, true)
+		})
+	case metricdata.TypeGaugeDistribution, metricdata.TypeCumulativeDistribution:
+		m.SetDataType(pdata.MetricDataTypeHistogram)
+		m.Histogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		dps := m.Histogram().DataPoints()
+		forEachPoint(metric, func(ts *metricdata.TimeSeries, point metricdata.Point) {
+			if dist, ok := point.Value.(*metricdata.Distribution); ok {
+				fillHistogramDataPoint(dps.AppendEmpty(), desc.LabelKeys, ts, point, dist)
+			}
+		})
+	case metricdata.TypeSummary:
+		m.SetDataType(pdata.MetricDataTypeSummary)
+		dps := m.Summary().DataPoints()
+		forEachPoint(metric, func(ts *metricdata.TimeSeries, point metricdata.Point) {
+			if summary, ok := point.Value.(*metricdata.Summary); ok {
+				fillSummaryDataPoint(dps.AppendEmpty(), desc.LabelKeys, ts, point, summary)
+			}
+		})
+	default:
+		return
+	}
+	m.CopyTo(dest.AppendEmpty())
+}
+
+func forEachPoint(metric *metricdata.Metric, fn func(*metricdata.TimeSeries, metricdata.Point)) {
+	for _, ts := range metric.TimeSeries {
+		if ts == nil {
+			continue
+		}
+		for _, point := range ts.Points {
+			fn(ts, point)
+		}
+	}
+}
+
+func fillNumberDataPoint(dp pdata.NumberDataPoint, keys []metricdata.LabelKey, ts *metricdata.TimeSeries, point metricdata.Point, cumulative bool) {
+	fillLabels(dp.Attributes(), keys, ts.LabelValues)
+	if cumulative {
+		dp.SetStartTimestamp(pdata.TimestampFromTime(ts.StartTime))
+	}
+	dp.SetTimestamp(pdata.TimestampFromTime(point.Time))
+	switch v := point.Value.(type) {
+	case int64:
+		dp.SetIntVal(v)
+	case float64:
+		dp.SetDoubleVal(v)
+	}
+}
+
+func fillHistogramDataPoint(dp pdata.HistogramDataPoint, keys []metricdata.LabelKey, ts *metricdata.TimeSeries, point metricdata.Point, dist *metricdata.Distribution) {
+	fillLabels(dp.Attributes(), keys, ts.LabelValues)
+	dp.SetStartTimestamp(pdata.TimestampFromTime(ts.StartTime))
+	dp.SetTimestamp(pdata.TimestampFromTime(point.Time))
+	dp.SetCount(uint64(dist.Count))
+	dp.SetSum(dist.Sum)
+	if dist.BucketOptions != nil {
+		dp.SetExplicitBounds(dist.BucketOptions.Bounds)
+	}
+	counts := make([]uint64, 0, len(dist.Buckets))
+	for _, bucket := range dist.Buckets {
+		counts = append(counts, uint64(bucket.Count))
+	}
+	dp.SetBucketCounts(counts)
+}
+
+func fillSummaryDataPoint(dp pdata.SummaryDataPoint, keys []metricdata.LabelKey, ts *metricdata.TimeSeries, point metricdata.Point, summary *metricdata.Summary) {
+	fillLabels(dp.Attributes(), keys, ts.LabelValues)
+	dp.SetStartTimestamp(pdata.TimestampFromTime(ts.StartTime))
+	dp.SetTimestamp(pdata.TimestampFromTime(point.Time))
+	if summary.HasCountAndSum {
+		dp.SetCount(uint64(summary.Count))
+		dp.SetSum(summary.Sum)
+	}
+	percentiles := make([]float64, 0, len(summary.Snapshot.Percentiles))
+	for p := range summary.Snapshot.Percentiles {
+		percentiles = append(percentiles, p)
+	}
+	sort.Float64s(percentiles)
+	for _, p := range percentiles {
+		qv := dp.QuantileValues().AppendEmpty()
+		qv.SetQuantile(p / 100)
+		qv.SetValue(summary.Snapshot.Percentiles[p])
+	}
+}
+
+func fillLabels(dest pdata.AttributeMap, keys []metricdata.LabelKey, values []metricdata.LabelValue) {
+	for i, key := range keys {
+		if i < len(values) && values[i].Present {
+			dest.InsertString(key.Key, values[i].Value)
+		}
+	}
+}
+
+func fillResource(resource pdata.Resource, attrs map[string]string) {
+	for k, v := range attrs {
+		resource.Attributes().InsertString(k, v)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric/metricdata"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestMetricsToPdata(t *testing.T) {
+	start := time.Unix(100, 0)
+	now := time.Unix(200, 0)
+	keys := []metricdata.LabelKey{{Key: "exporter"}, {Key: "missing"}}
+	values := []metricdata.LabelValue{metricdata.NewLabelValue("otlp"), {}}
+	metrics := []*metricdata.Metric{
+		{
+			Descriptor: metricdata.Descriptor{Name: "exporter/sent_spans", Description: "sent", Unit: metricdata.UnitDimensionless, Type: metricdata.TypeCumulativeInt64, LabelKeys: keys},
+			TimeSeries: []*metricdata.TimeSeries{{LabelValues: values, StartTime: start, Points: []metricdata.Point{metricdata.NewInt64Point(now, 42)}}},
+		},
+		{
+			Descriptor: metricdata.Descriptor{Name: "process/cpu_seconds", Unit: metricdata.UnitDimensionless, Type: metricdata.TypeGaugeFloat64},
+			TimeSeries: []*metricdata.TimeSeries{{StartTime: start, Points: []metricdata.Point{metricdata.NewFloat64Point(now, 1.5)}}},
+		},
+		{
+			Descriptor: metricdata.Descriptor{Name: "batch_send_size", Type: metricdata.TypeCumulativeDistribution},
+			TimeSeries: []*metricdata.TimeSeries{{StartTime: start, Points: []metricdata.Point{metricdata.NewDistributionPoint(now, &metricdata.Distribution{
+				Count:         3,
+				Sum:           30,
+				BucketOptions: &metricdata.BucketOptions{Bounds: []float64{10, 20}},
+				Buckets:       []metricdata.Bucket{{Count: 1}, {Count: 1}, {Count: 1}},
+			})}}},
+		},
+		{
+			Descriptor: metricdata.Descriptor{Name: "latency", Type: metricdata.TypeSummary},
+			TimeSeries: []*metricdata.TimeSeries{{StartTime: start, Points: []metricdata.Point{metricdata.NewSummaryPoint(now, &metricdata.Summary{
+				Count:          2,
+				Sum:            5,
+				HasCountAndSum: true,
+				Snapshot:       metricdata.Snapshot{Percentiles: map[float64]float64{99: 4, 50: 1}},
+			})}}},
+		},
+		nil,
+	}
+
+	md := MetricsToPdata(map[string]string{"service.name": "otelcol"}, metrics)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rm := md.ResourceMetrics().At(0)
+	name, ok := rm.Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "otelcol", name.StringVal())
+	ilm := rm.InstrumentationLibraryMetrics().At(0)
+	assert.Equal(t, instrumentationLibraryName, ilm.InstrumentationLibrary().Name())
+	require.Equal(t, 4, ilm.Metrics().Len())
+
+	sum := ilm.Metrics().At(0)
+	assert.Equal(t, "exporter/sent_spans", sum.Name())
+	assert.Equal(t, "sent", sum.Description())
+	assert.Equal(t, "1", sum.Unit())
+	require.Equal(t, pdata.MetricDataTypeSum, sum.DataType())
+	assert.True(t, sum.Sum().IsMonotonic())
+	assert.Equal(t, pdata.AggregationTemporalityCumulative, sum.Sum().AggregationTemporality())
+	dp := sum.Sum().DataPoints().At(0)
+	assert.Equal(t, int64(42), dp.IntVal())
+	assert.Equal(t, pdata.TimestampFromTime(start), dp.StartTimestamp())
+	assert.Equal(t, pdata.TimestampFromTime(now), dp.Timestamp())
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{"exporter": pdata.NewAttributeValueString("otlp")}), dp.Attributes())
+
+	gauge := ilm.Metrics().At(1)
+	require.Equal(t, pdata.MetricDataTypeGauge, gauge.DataType())
+	assert.Equal(t, 1.5, gauge.Gauge().DataPoints().At(0).DoubleVal())
+	assert.Equal(t, pdata.Timestamp(0), gauge.Gauge().DataPoints().At(0).StartTimestamp())
+
+	hist := ilm.Metrics().At(2)
+	require.Equal(t, pdata.MetricDataTypeHistogram, hist.DataType())
+	hdp := hist.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(3), hdp.Count())
+	assert.Equal(t, 30.0, hdp.Sum())
+	assert.Equal(t, []float64{10, 20}, hdp.ExplicitBounds())
+	assert.Equal(t, []uint64{1, 1, 1}, hdp.BucketCounts())
+
+	summary := ilm.Metrics().At(3)
+	require.Equal(t, pdata.MetricDataTypeSummary, summary.DataType())
+	sdp := summary.Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(2), sdp.Count())
+	require.Equal(t, 2, sdp.QuantileValues().Len())
+	assert.Equal(t, 0.5, sdp.QuantileValues().At(0).Quantile())
+	assert.Equal(t, 0.99, sdp.QuantileValues().At(1).Quantile())
+	assert.Equal(t, 4.0, sdp.QuantileValues().At(1).Value())
+}
+
+func TestMetricsExporter(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	exp := NewMetricsExporter(nil, sink, zap.NewNop())
+
+	// Nothing is pushed when there are no metrics.
+	require.NoError(t, exp.ExportMetrics(context.Background(), nil))
+	assert.Empty(t, sink.AllMetrics())
+
+	metrics := []*metricdata.Metric{{
+		Descriptor: metricdata.Descriptor{Name: "m", Type: metricdata.TypeGaugeInt64},
+		TimeSeries: []*metricdata.TimeSeries{{Points: []metricdata.Point{metricdata.NewInt64Point(time.Now(), 1)}}},
+	}}
+	require.NoError(t, exp.ExportMetrics(context.Background(), metrics))
+	assert.Equal(t, 1, sink.DataPointCount())
+
+	exp = NewMetricsExporter(nil, consumertest.NewErr(errors.New("failed")), zap.NewNop())
+	assert.EqualError(t, exp.ExportMetrics(context.Background(), metrics), "failed")
+}
@@ -0,0 +1,277 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+const (
+	// defaultSpansBatchSize is the number of ended spans pushed at once.
+	defaultSpansBatchSize = 512
+
+	// defaultSpansQueueSize is the number of ended spans kept while waiting to be
+	// pushed, the spans ending while the queue is full are dropped.
+	defaultSpansQueueSize = 2048
+
+	// defaultSpansInterval is the interval the ended spans are pushed at.
+	defaultSpansInterval = 5 * time.Second
+)
+
+// SpanProcessor is a sdktrace.SpanProcessor pushing the spans recorded by the collector
+// to a consumer. Unlike the batch span processor of the SDK, it also pushes the spans
+// that are recorded but not sampled, which are most of the collector's spans.
+type SpanProcessor struct {
+	resourceAttrs map[string]string
+	next          consumer.Traces
+	logger        *zap.Logger
+
+	mu    sync.Mutex
+	spans []sdktrace.ReadOnlySpan
+
+	flushCh      chan struct{}
+	shutdownCh   chan struct{}
+	done         chan struct{}
+	shutdownOnce sync.Once
+}
+
+var _ sdktrace.SpanProcessor = (*SpanProcessor)(nil)
+
+// NewSpanProcessor returns a SpanProcessor converting the ended spans to pdata.Traces,
+// with a resource having the given attributes, and pushing them to the next consumer
+// in batches. The push errors are logged.
+func NewSpanProcessor(resourceAttrs map[string]string, next consumer.Traces, logger *zap.Logger) *SpanProcessor {
+	sp := &SpanProcessor{
+		resourceAttrs: resourceAttrs,
+		next:          next,
+		logger:        logger,
+		flushCh:       make(chan struct{}, 1),
+		shutdownCh:    make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go sp.run()
+	return sp
+}
+
+// OnStart does nothing.
+func (sp *SpanProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+// OnEnd queues the span to be pushed.
+func (sp *SpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	if len(sp.spans) >= defaultSpansQueueSize {
+		return
+	}
+	sp.spans = append(sp.spans, s)
+	if len(sp.spans) >= defaultSpansBatchSize {
+		select {
+		case sp.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// ForceFlush pushes the queued spans.
+func (sp *SpanProcessor) ForceFlush(ctx context.Context) error {
+	return sp.flush(ctx)
+}
+
+// Shutdown stops pushing the spans periodically and pushes the queued spans.
+func (sp *SpanProcessor) Shutdown(ctx context.Context) error {
+	sp.shutdownOnce.Do(func() {
+		close(sp.shutdownCh)
+	})
+	<-sp.done
+	return sp.flush(ctx)
+}
+
+func (sp *SpanProcessor) run() {
+	defer close(sp.done)
+
+	ticker := time.NewTicker(defaultSpansInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-sp.flushCh:
+		case <-sp.shutdownCh:
+			return
+		}
+		_ = sp.flush(context.Background())
+	}
+}
+
+func (sp *SpanProcessor) flush(ctx context.Context) error {
+	for {
+		sp.mu.Lock()
+		n := len(sp.spans)
+		if n > defaultSpansBatchSize {
+			n = defaultSpansBatchSize
+		}
+		batch := sp.spans[:n]
+		sp.spans = sp.spans[n:]
+		sp.mu.Unlock()
+
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := sp.next.ConsumeTraces(ctx, SpansToPdata(sp.resourceAttrs, batch)); err != nil {
+			sp.logger.Warn("Failed to export the collector's own spans", zap.Error(err))
+			return err
+		}
+	}
+}
+
+// SpansToPdata converts the spans of the OpenTelemetry SDK to pdata.Traces with a
+// resource having the given attributes.
+func SpansToPdata(resourceAttrs map[string]string, spans []sdktrace.ReadOnlySpan) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	fillResource(rs.Resource(), resourceAttrs)
+
+	// Group the spans by instrumentation library, which is the name of the tracer.
+	ilss := make(map[string]pdata.InstrumentationLibrarySpans)
+	for _, s := range spans {
+		lib := s.InstrumentationLibrary()
+		ils, ok := ilss[lib.Name]
+		if !ok {
+			ils = rs.InstrumentationLibrarySpans().AppendEmpty()
+			ils.InstrumentationLibrary().SetName(lib.Name)
+			ils.InstrumentationLibrary().SetVersion(lib.Version)
+			ilss[lib.Name] = ils
+		}
+		spanToPdata(s, ils.Spans().AppendEmpty())
+	}
+	return td
+}
+
+func spanToPdata(s sdktrace.ReadOnlySpan, dest pdata.Span) {
+	sc := s.SpanContext()
+	dest.SetTraceID(pdata.NewTraceID(sc.TraceID()))
+	dest.SetSpanID(pdata.NewSpanID(sc.SpanID()))
+	dest.SetTraceState(pdata.TraceState(sc.TraceState().String()))
+	if parent := s.Parent(); parent.IsValid() {
+		dest.SetParentSpanID(pdata.NewSpanID(parent.SpanID()))
+	}
+	dest.SetName(s.Name())
+	dest.SetKind(spanKindToPdata(s.SpanKind()))
+	dest.SetStartTimestamp(pdata.TimestampFromTime(s.StartTime()))
+	dest.SetEndTimestamp(pdata.TimestampFromTime(s.EndTime()))
+	fillAttributes(dest.Attributes(), s.Attributes())
+	dest.SetDroppedAttributesCount(uint32(s.DroppedAttributes()))
+
+	for _, event := range s.Events() {
+		e := dest.Events().AppendEmpty()
+		e.SetName(event.Name)
+		e.SetTimestamp(pdata.TimestampFromTime(event.Time))
+		fillAttributes(e.Attributes(), event.Attributes)
+		e.SetDroppedAttributesCount(uint32(event.DroppedAttributeCount))
+	}
+	dest.SetDroppedEventsCount(uint32(s.DroppedEvents()))
+
+	for _, link := range s.Links() {
+		l := dest.Links().AppendEmpty()
+		l.SetTraceID(pdata.NewTraceID(link.SpanContext.TraceID()))
+		l.SetSpanID(pdata.NewSpanID(link.SpanContext.SpanID()))
+		l.SetTraceState(pdata.TraceState(link.SpanContext.TraceState().String()))
+		fillAttributes(l.Attributes(), link.Attributes)
+		l.SetDroppedAttributesCount(uint32(link.DroppedAttributeCount))
+	}
+	dest.SetDroppedLinksCount(uint32(s.DroppedLinks()))
+
+	status := s.Status()
+	switch status.Code {
+	case codes.Ok:
+		dest.Status().SetCode(pdata.StatusCodeOk)
+	case codes.Error:
+		dest.Status().SetCode(pdata.StatusCodeError)
+		dest.Status().SetMessage(status.Description)
+	}
+}
+
+func spanKindToPdata(kind trace.SpanKind) pdata.SpanKind {
+	switch kind {
+	case trace.SpanKindInternal:
+		return pdata.SpanKindInternal
+	case trace.SpanKindServer:
+		return pdata.SpanKindServer
+	case trace.SpanKindClient:
+		return pdata.SpanKindClient
+	case trace.SpanKindProducer:
+		return pdata.SpanKindProducer
+	case trace.SpanKindConsumer:
+		return pdata.SpanKindConsumer
+	}
+	return pdata.SpanKindUnspecified
+}
+
+func fillAttributes(dest pdata.AttributeMap, attrs []attribute.KeyValue) {
+	for _, kv := range attrs {
+		key := string(kv.Key)
+		switch kv.Value.Type() {
+		case attribute.BOOL:
+			dest.UpsertBool(key, kv.Value.AsBool())
+		case attribute.INT64:
+			dest.UpsertInt(key, kv.Value.AsInt64())
+		case attribute.FLOAT64:
+			dest.UpsertDouble(key, kv.Value.AsFloat64())
+		case attribute.STRING:
+			dest.UpsertString(key, kv.Value.AsString())
+		case attribute.ARRAY:
+			dest.Upsert(key, arrayToPdata(kv.Value.AsArray()))
+		default:
+			dest.UpsertString(key, kv.Value.Emit())
+		}
+	}
+}
+
+// arrayToPdata converts the array of an attribute, which is an array of bool, int64,
+// float64 or string.
+func arrayToPdata(array interface{}) pdata.AttributeValue {
+	av := pdata.NewAttributeValueArray()
+	v := reflect.ValueOf(array)
+	if v.Kind() != reflect.Array && v.Kind() != reflect.Slice {
+		return av
+	}
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		dest := av.ArrayVal().AppendEmpty()
+		switch elem.Kind() {
+		case reflect.Bool:
+			dest.SetBoolVal(elem.Bool())
+		case reflect.Int, reflect.Int64:
+			dest.SetIntVal(elem.Int())
+		case reflect.Float64:
+			dest.SetDoubleVal(elem.Float())
+		case reflect.String:
+			dest.SetStringVal(elem.String())
+		}
+	}
+	return av
+}
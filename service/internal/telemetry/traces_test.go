@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestSpanProcessor(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	sp := NewSpanProcessor(map[string]string{"service.name": "otelcol"}, sink, zap.NewNop())
+
+	// The spans are recorded but not sampled, like most of the collector's spans.
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(recordOnlySampler{}),
+		sdktrace.WithSpanProcessor(sp))
+	tracer := tp.Tracer("receiver/otlp")
+
+	ctx, parent := tracer.Start(context.Background(), "parent", trace.WithSpanKind(trace.SpanKindServer))
+	_, child := tracer.Start(ctx, "child", trace.WithAttributes(
+		attribute.String("str", "value"),
+		attribute.Int64("int", 1),
+		attribute.Bool("bool", true),
+		attribute.Float64("float", 1.5),
+		attribute.Array("array", []string{"a", "b"})))
+	child.AddEvent("event", trace.WithAttributes(attribute.String("key", "value")))
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+	parent.End()
+
+	require.NoError(t, tp.ForceFlush(context.Background()))
+	require.Equal(t, 2, sink.SpanCount())
+
+	rs := sink.AllTraces()[0].ResourceSpans().At(0)
+	name, ok := rs.Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "otelcol", name.StringVal())
+	ils := rs.InstrumentationLibrarySpans().At(0)
+	assert.Equal(t, "receiver/otlp", ils.InstrumentationLibrary().Name())
+
+	childSpan := ils.Spans().At(0)
+	parentSpan := ils.Spans().At(1)
+	assert.Equal(t, "child", childSpan.Name())
+	assert.Equal(t, pdata.NewTraceID(parent.SpanContext().TraceID()), childSpan.TraceID())
+	assert.Equal(t, pdata.NewSpanID(child.SpanContext().SpanID()), childSpan.SpanID())
+	assert.Equal(t, parentSpan.SpanID(), childSpan.ParentSpanID())
+	assert.Equal(t, pdata.SpanKindInternal, childSpan.Kind())
+	assert.Equal(t, pdata.StatusCodeError, childSpan.Status().Code())
+	assert.Equal(t, "failed", childSpan.Status().Message())
+	assert.Equal(t, 5, childSpan.Attributes().Len())
+	str, _ := childSpan.Attributes().Get("str")
+	assert.Equal(t, "value", str.StringVal())
+	arr, _ := childSpan.Attributes().Get("array")
+	require.Equal(t, pdata.AttributeValueTypeArray, arr.Type())
+	require.Equal(t, 2, arr.ArrayVal().Len())
+	assert.Equal(t, "b", arr.ArrayVal().At(1).StringVal())
+	require.Equal(t, 1, childSpan.Events().Len())
+	assert.Equal(t, "event", childSpan.Events().At(0).Name())
+	assert.True(t, childSpan.StartTimestamp() <= childSpan.EndTimestamp())
+
+	assert.Equal(t, "parent", parentSpan.Name())
+	assert.Equal(t, pdata.SpanKindServer, parentSpan.Kind())
+	assert.True(t, parentSpan.ParentSpanID().IsEmpty())
+
+	// The spans ended before the shutdown are pushed.
+	_, span := tracer.Start(context.Background(), "last")
+	span.End()
+	require.NoError(t, tp.Shutdown(context.Background()))
+	assert.Equal(t, 3, sink.SpanCount())
+}
+
+func TestSpanProcessorBatches(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	sp := NewSpanProcessor(nil, sink, zap.NewNop())
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(recordOnlySampler{}), sdktrace.WithSpanProcessor(sp))
+	tracer := tp.Tracer("test")
+
+	for i := 0; i < defaultSpansQueueSize+10; i++ {
+		_, span := tracer.Start(context.Background(), "span")
+		span.End()
+	}
+	require.NoError(t, tp.Shutdown(context.Background()))
+
+	// The spans are pushed in batches, and the spans ending while the queue is full are dropped.
+	assert.LessOrEqual(t, sink.SpanCount(), defaultSpansQueueSize+10)
+	assert.GreaterOrEqual(t, sink.SpanCount(), defaultSpansQueueSize)
+	for _, td := range sink.AllTraces() {
+		assert.LessOrEqual(t, td.SpanCount(), defaultSpansBatchSize)
+	}
+}
+
+func TestSpanProcessorError(t *testing.T) {
+	sp := NewSpanProcessor(nil, consumertest.NewErr(errors.New("failed")), zap.NewNop())
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sp))
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.End()
+	assert.EqualError(t, sp.ForceFlush(context.Background()), "failed")
+	assert.NoError(t, sp.Shutdown(context.Background()))
+}
+
+type recordOnlySampler struct{}
+
+func (recordOnlySampler) ShouldSample(sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	return sdktrace.SamplingResult{Decision: sdktrace.RecordOnly}
+}
+
+func (recordOnlySampler) Description() string {
+	return "record only"
+}
@@ -17,9 +17,14 @@ package service
 import (
 	"flag"
 	"fmt"
+	"sort"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/internal/rotatefile"
 )
 
 const (
@@ -46,26 +51,149 @@ func loggerFlags(flags *flag.FlagSet) {
 	loggerFormatPtr = flags.String(logFormatCfg, "console", "Format of logs to use (json, console)")
 }
 
-func newLogger(options []zap.Option) (*zap.Logger, error) {
+// newLogger creates the collector's own logger from the command line flags, overridden
+// by the non-zero settings of the service::telemetry::logs configuration section. The
+// returned function closes the files the logger writes to.
+func newLogger(cfg config.ServiceTelemetryLogs, options []zap.Option) (*zap.Logger, func(), error) {
 	var conf zap.Config
 
 	// Use logger profile if set on command line before falling back
 	// to default based on build type.
-	switch *loggerProfilePtr {
+	profile := *loggerProfilePtr
+	if cfg.Development {
+		profile = "dev"
+	}
+	switch profile {
 	case "dev":
 		conf = zap.NewDevelopmentConfig()
 	case "prod":
 		conf = zap.NewProductionConfig()
 	default:
-		return nil, fmt.Errorf("invalid value %s for %s flag", *loggerProfilePtr, logProfileCfg)
+		return nil, nil, fmt.Errorf("invalid value %s for %s flag", *loggerProfilePtr, logProfileCfg)
 	}
 
 	conf.Encoding = *loggerFormatPtr
+	if cfg.Encoding != "" {
+		conf.Encoding = cfg.Encoding
+	}
 	if conf.Encoding == "console" {
 		// Human-readable timestamps for console format of logs.
 		conf.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	}
 
-	conf.Level.SetLevel(*loggerLevelPtr)
-	return conf.Build(options...)
+	level := *loggerLevelPtr
+	if cfg.Level != "" {
+		if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+			return nil, nil, err
+		}
+	}
+	conf.Level.SetLevel(level)
+
+	if len(cfg.OutputPaths) > 0 {
+		conf.OutputPaths = cfg.OutputPaths
+	}
+	if len(cfg.ErrorOutputPaths) > 0 {
+		conf.ErrorOutputPaths = cfg.ErrorOutputPaths
+	}
+	if cfg.Sampling != nil {
+		conf.Sampling = &zap.SamplingConfig{
+			Initial:    cfg.Sampling.Initial,
+			Thereafter: cfg.Sampling.Thereafter,
+		}
+	}
+	conf.InitialFields = cfg.InitialFields
+
+	return buildLogger(conf, cfg.Rotation, options)
+}
+
+// buildLogger does what zap.Config.Build does, except that the files of the output paths
+// are rotated if rotation is set, and that the files can be closed.
+func buildLogger(conf zap.Config, rotation *config.ServiceTelemetryLogsRotation, options []zap.Option) (*zap.Logger, func(), error) {
+	var enc zapcore.Encoder
+	switch conf.Encoding {
+	case "json":
+		enc = zapcore.NewJSONEncoder(conf.EncoderConfig)
+	case "console":
+		enc = zapcore.NewConsoleEncoder(conf.EncoderConfig)
+	default:
+		return nil, nil, fmt.Errorf("invalid value %s for %s flag", conf.Encoding, logFormatCfg)
+	}
+
+	sink, closeSink, err := openOutputs(conf.OutputPaths, rotation)
+	if err != nil {
+		return nil, nil, err
+	}
+	errSink, closeErrSink, err := zap.Open(conf.ErrorOutputPaths...)
+	if err != nil {
+		closeSink()
+		return nil, nil, err
+	}
+
+	core := zapcore.NewCore(enc, sink, conf.Level)
+	if conf.Sampling != nil {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, conf.Sampling.Initial, conf.Sampling.Thereafter)
+	}
+
+	opts := []zap.Option{zap.ErrorOutput(errSink), zap.AddCaller()}
+	stackLevel := zapcore.ErrorLevel
+	if conf.Development {
+		opts = append(opts, zap.Development())
+		stackLevel = zapcore.WarnLevel
+	}
+	opts = append(opts, zap.AddStacktrace(stackLevel))
+
+	if len(conf.InitialFields) > 0 {
+		keys := make([]string, 0, len(conf.InitialFields))
+		for k := range conf.InitialFields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fields := make([]zap.Field, 0, len(keys))
+		for _, k := range keys {
+			fields = append(fields, zap.Any(k, conf.InitialFields[k]))
+		}
+		opts = append(opts, zap.Fields(fields...))
+	}
+
+	closeAll := func() {
+		closeSink()
+		closeErrSink()
+	}
+	return zap.New(core, append(opts, options...)...), closeAll, nil
+}
+
+// openOutputs opens the output paths, the files being rotated if rotation is set.
+func openOutputs(paths []string, rotation *config.ServiceTelemetryLogsRotation) (zapcore.WriteSyncer, func(), error) {
+	if rotation == nil {
+		return zap.Open(paths...)
+	}
+
+	var syncers []zapcore.WriteSyncer
+	var closers []func()
+	closeAll := func() {
+		for _, c := range closers {
+			c()
+		}
+	}
+	for _, path := range paths {
+		if path == "stdout" || path == "stderr" {
+			ws, closeFn, err := zap.Open(path)
+			if err != nil {
+				closeAll()
+				return nil, nil, err
+			}
+			syncers = append(syncers, ws)
+			closers = append(closers, closeFn)
+			continue
+		}
+
+		w, err := rotatefile.Open(path, int64(rotation.MaxSizeMiB)<<20, rotation.MaxBackups)
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+		syncers = append(syncers, w)
+		closers = append(closers, func() { _ = w.Close() })
+	}
+	return zapcore.NewMultiWriteSyncer(syncers...), closeAll, nil
 }
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+func readLogEntries(t *testing.T, path string) []map[string]interface{} {
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		if line == "" {
+			continue
+		}
+		entry := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestNewLoggerFromConfig(t *testing.T) {
+	loggerFlags(new(flag.FlagSet))
+	path := filepath.Join(t.TempDir(), "otelcol.log")
+
+	logger, closeLogger, err := newLogger(config.ServiceTelemetryLogs{
+		Level:         "warn",
+		Encoding:      "json",
+		OutputPaths:   []string{path},
+		InitialFields: map[string]interface{}{"service": "otelcol"},
+	}, nil)
+	require.NoError(t, err)
+
+	logger.Info("dropped")
+	logger.Warn("kept", zap.String("key", "value"))
+	require.NoError(t, logger.Sync())
+	closeLogger()
+
+	entries := readLogEntries(t, path)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "warn", entries[0]["level"])
+	assert.Equal(t, "kept", entries[0]["msg"])
+	assert.Equal(t, "value", entries[0]["key"])
+	assert.Equal(t, "otelcol", entries[0]["service"])
+}
+
+func TestNewLoggerDefaultsToFlags(t *testing.T) {
+	loggerFlags(new(flag.FlagSet))
+	*loggerLevelPtr = zapcore.DebugLevel
+	*loggerFormatPtr = "json"
+
+	logger, closeLogger, err := newLogger(config.ServiceTelemetryLogs{}, nil)
+	require.NoError(t, err)
+	defer closeLogger()
+	assert.True(t, logger.Core().Enabled(zapcore.DebugLevel))
+
+	*loggerProfilePtr = "unknown"
+	_, _, err = newLogger(config.ServiceTelemetryLogs{}, nil)
+	assert.Error(t, err)
+
+	// The development setting overrides the profile given on the command line.
+	logger, closeLogger, err = newLogger(config.ServiceTelemetryLogs{Development: true}, nil)
+	require.NoError(t, err)
+	defer closeLogger()
+	assert.Panics(t, func() { logger.DPanic("panics in development mode") })
+}
+
+func TestNewLoggerRotation(t *testing.T) {
+	loggerFlags(new(flag.FlagSet))
+	path := filepath.Join(t.TempDir(), "otelcol.log")
+
+	logger, closeLogger, err := newLogger(config.ServiceTelemetryLogs{
+		Encoding:    "json",
+		OutputPaths: []string{path},
+		Rotation:    &config.ServiceTelemetryLogsRotation{MaxSizeMiB: 1, MaxBackups: 1},
+		Sampling:    &config.ServiceTelemetryLogsSampling{Initial: 10000, Thereafter: 1},
+	}, nil)
+	require.NoError(t, err)
+
+	msg := strings.Repeat("x", 1024)
+	for i := 0; i < 2048; i++ {
+		logger.Info(msg)
+	}
+	closeLogger()
+
+	assert.FileExists(t, path+".1")
+	assert.NoFileExists(t, path+".2")
+	info, err := os.Stat(path + ".1")
+	require.NoError(t, err)
+	assert.LessOrEqual(t, info.Size(), int64(1<<20))
+}
+
+func TestNewLoggerInvalidOutput(t *testing.T) {
+	loggerFlags(new(flag.FlagSet))
+	path := filepath.Join(t.TempDir(), "missing", "otelcol.log")
+
+	_, _, err := newLogger(config.ServiceTelemetryLogs{OutputPaths: []string{path}}, nil)
+	assert.Error(t, err)
+
+	_, _, err = newLogger(config.ServiceTelemetryLogs{
+		OutputPaths: []string{path},
+		Rotation:    &config.ServiceTelemetryLogsRotation{MaxSizeMiB: 1},
+	}, nil)
+	assert.Error(t, err)
+}
+
+func TestCollector_applyLogsConfig(t *testing.T) {
+	loggerFlags(new(flag.FlagSet))
+	path := filepath.Join(t.TempDir(), "otelcol.log")
+
+	col := &Collector{logger: zap.NewNop(), closeLogger: func() {}}
+	logsCfg := config.ServiceTelemetryLogs{Encoding: "json", OutputPaths: []string{path}}
+	require.NoError(t, col.applyLogsConfig(logsCfg))
+	logger := col.logger
+	col.logger.Info("applied")
+
+	// The logger is kept when the configuration does not change.
+	require.NoError(t, col.applyLogsConfig(config.ServiceTelemetryLogs{Encoding: "json", OutputPaths: []string{path}}))
+	assert.Same(t, logger, col.logger)
+
+	// The logger is kept when the new configuration is invalid.
+	assert.Error(t, col.applyLogsConfig(config.ServiceTelemetryLogs{Level: "verbose"}))
+	assert.Same(t, logger, col.logger)
+
+	require.NoError(t, col.applyLogsConfig(config.ServiceTelemetryLogs{}))
+	assert.NotSame(t, logger, col.logger)
+
+	entries := readLogEntries(t, path)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "applied", entries[0]["msg"])
+	assert.Equal(t, "Switching to the logger of the logs configuration", entries[1]["msg"])
+}
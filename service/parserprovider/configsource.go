@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package parserprovider
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/collector/config/configparser"
+	"go.opentelemetry.io/collector/config/experimental/configsource"
+	"go.opentelemetry.io/collector/config/experimental/configsource/envconfigsource"
+	"go.opentelemetry.io/collector/config/experimental/configsource/fileconfigsource"
+	"go.opentelemetry.io/collector/config/experimental/configsource/includeconfigsource"
+	configsourcemanager "go.opentelemetry.io/collector/service/internal/configsource"
+)
+
+// configSourcesKey is the key of the section used to declare config sources.
+const configSourcesKey = "config_sources"
+
+type configSourceParserProvider struct {
+	pp        ParserProvider
+	factories configsource.Factories
+
+	mu      sync.Mutex
+	manager *configsourcemanager.Manager
+	closeCh chan struct{}
+}
+
+var _ Watchable = (*configSourceParserProvider)(nil)
+var _ Closeable = (*configSourceParserProvider)(nil)
+
+// NewConfigSource returns a new ParserProvider that resolves the config sources referenced
+// in the configuration given by the ParserProvider pp. The config sources are declared in
+// the "config_sources" section of the configuration and created using the given factories.
+// Updates on any of the values retrieved from config sources are reported via WatchForUpdate.
+// Configurations without a "config_sources" section are returned unchanged, leaving the
+// expansion of environment variables to the configuration unmarshaler.
+func NewConfigSource(pp ParserProvider, factories configsource.Factories) ParserProvider {
+	return &configSourceParserProvider{
+		pp:        pp,
+		factories: factories,
+	}
+}
+
+// DefaultConfigSourceFactories returns the factories of the config sources built into the collector.
+func DefaultConfigSourceFactories() configsource.Factories {
+	factories := configsource.Factories{}
+	for _, f := range []configsource.Factory{
+		envconfigsource.NewFactory(),
+		fileconfigsource.NewFactory(),
+		includeconfigsource.NewFactory(),
+	} {
+		factories[f.Type()] = f
+	}
+	return factories
+}
+
+func (csp *configSourceParserProvider) Get() (*configparser.Parser, error) {
+	cp, err := csp.pp.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	var manager *configsourcemanager.Manager
+	if cp.IsSet(configSourcesKey) {
+		ctx := context.Background()
+		if manager, err = configsourcemanager.NewManager(ctx, cp, csp.factories); err != nil {
+			return nil, err
+		}
+		if cp, err = manager.Resolve(ctx, cp); err != nil {
+			_ = manager.Close(ctx)
+			return nil, err
+		}
+	}
+
+	csp.mu.Lock()
+	defer csp.mu.Unlock()
+	csp.manager = manager
+	csp.closeCh = make(chan struct{})
+	return cp, nil
+}
+
+func (csp *configSourceParserProvider) WatchForUpdate() error {
+	csp.mu.Lock()
+	manager, closeCh := csp.manager, csp.closeCh
+	csp.mu.Unlock()
+
+	if manager != nil {
+		return manager.WatchForUpdate()
+	}
+
+	// No config sources are in use, there is nothing to watch until the provider is closed.
+	if closeCh != nil {
+		<-closeCh
+	}
+	return configsource.ErrSessionClosed
+}
+
+func (csp *configSourceParserProvider) Close(ctx context.Context) error {
+	csp.mu.Lock()
+	manager, closeCh := csp.manager, csp.closeCh
+	csp.manager, csp.closeCh = nil, nil
+	csp.mu.Unlock()
+
+	if closeCh != nil {
+		close(closeCh)
+	}
+	if manager != nil {
+		return manager.Close(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package parserprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configparser"
+	"go.opentelemetry.io/collector/config/experimental/configsource"
+)
+
+type fileParserProvider struct {
+	fileName string
+}
+
+func (fpp *fileParserProvider) Get() (*configparser.Parser, error) {
+	return configparser.NewParserFromFile(fpp.fileName)
+}
+
+func TestConfigSource_NoConfigSources(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, ioutil.WriteFile(fileName, []byte("exporters:\n  otlp:\n    endpoint: $HOST:4317\n"), 0600))
+
+	pp := NewConfigSource(&fileParserProvider{fileName: fileName}, DefaultConfigSourceFactories())
+	cp, err := pp.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "$HOST:4317", cp.Get("exporters::otlp::endpoint"))
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- pp.(Watchable).WatchForUpdate()
+	}()
+	require.NoError(t, pp.(Closeable).Close(context.Background()))
+	assert.True(t, errors.Is(<-errCh, configsource.ErrSessionClosed))
+}
+
+func TestConfigSource_Resolve(t *testing.T) {
+	require.NoError(t, os.Setenv("CONFIGSOURCE_TEST_ENDPOINT", "localhost:4317"))
+	defer os.Unsetenv("CONFIGSOURCE_TEST_ENDPOINT")
+
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "api-key")
+	require.NoError(t, ioutil.WriteFile(secretFile, []byte("s3cr3t"), 0600))
+	fragmentFile := filepath.Join(dir, "attributes.yaml")
+	require.NoError(t, ioutil.WriteFile(fragmentFile, []byte("actions:\n  - key: region\n    value: us-west-1\n    action: insert\n"), 0600))
+
+	cfg := strings.Join([]string{
+		"config_sources:",
+		"  env:",
+		"  file:",
+		"  include:",
+		"    watch_files: false",
+		"exporters:",
+		"  otlp:",
+		"    endpoint: $env:CONFIGSOURCE_TEST_ENDPOINT",
+		"    headers:",
+		fmt.Sprintf("      api-key: $file:%s", secretFile),
+		"processors:",
+		fmt.Sprintf("  attributes: $include:%s", fragmentFile),
+	}, "\n")
+	fileName := filepath.Join(dir, "config.yaml")
+	require.NoError(t, ioutil.WriteFile(fileName, []byte(cfg), 0600))
+
+	pp := NewConfigSource(&fileParserProvider{fileName: fileName}, DefaultConfigSourceFactories())
+	cp, err := pp.Get()
+	require.NoError(t, err)
+	assert.False(t, cp.IsSet(configSourcesKey))
+	assert.Equal(t, "localhost:4317", cp.Get("exporters::otlp::endpoint"))
+	assert.Equal(t, "s3cr3t", cp.Get("exporters::otlp::headers::api-key"))
+	processors, err := cp.Sub("processors")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"attributes": map[string]interface{}{
+			"actions": []interface{}{
+				map[string]interface{}{"key": "region", "value": "us-west-1", "action": "insert"},
+			},
+		},
+	}, processors.ToStringMap())
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- pp.(Watchable).WatchForUpdate()
+	}()
+	require.NoError(t, ioutil.WriteFile(secretFile, []byte("n3w"), 0600))
+	assert.True(t, errors.Is(<-errCh, configsource.ErrValueUpdated))
+
+	// Reload the configuration as the collector does after an update.
+	require.NoError(t, pp.(Closeable).Close(context.Background()))
+	cp, err = pp.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "n3w", cp.Get("exporters::otlp::headers::api-key"))
+	require.NoError(t, pp.(Closeable).Close(context.Background()))
+}
+
+func TestConfigSource_Errors(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, ioutil.WriteFile(fileName, []byte("config_sources:\n  unknown:\n"), 0600))
+	pp := NewConfigSource(&fileParserProvider{fileName: fileName}, DefaultConfigSourceFactories())
+	_, err := pp.Get()
+	assert.Error(t, err)
+
+	require.NoError(t, ioutil.WriteFile(fileName, []byte("config_sources:\n  env:\nkey: $env:CONFIGSOURCE_TEST_NOT_DEFINED\n"), 0600))
+	_, err = pp.Get()
+	assert.Error(t, err)
+
+	pp = NewConfigSource(&fileParserProvider{fileName: filepath.Join(t.TempDir(), "not_found.yaml")}, DefaultConfigSourceFactories())
+	_, err = pp.Get()
+	assert.Error(t, err)
+}
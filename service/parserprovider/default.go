@@ -15,8 +15,9 @@
 package parserprovider
 
 // Default is the default ParserProvider and it creates configuration from a file
-// defined by the --config command line flag and overwrites properties from --set
-// command line flag (if the flag is present).
+// defined by the --config command line flag, overwrites properties from --set
+// command line flag (if the flag is present) and resolves the references to the
+// built-in config sources declared in the "config_sources" section.
 func Default() ParserProvider {
-	return NewSetFlag(NewFile())
+	return NewConfigSource(NewSetFlag(NewFile()), DefaultConfigSourceFactories())
 }
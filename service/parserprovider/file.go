@@ -4,41 +4,93 @@
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
 //
-//      http://www.apache.org/licenses/LICENSE-2.0
+//       http://www.apache.org/licenses/LICENSE-2.0
 //
 // Unless required by applicable law or agreed to in writing, software
 // distributed under the License is distributed on an "AS IS" BASIS,
 // WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
 // See the License for the specific language governing permissions and
 // limitations under the License.
-
 package parserprovider
 
 import (
 	"errors"
 	"fmt"
+	"os"
+	"strings"
 
 	"go.opentelemetry.io/collector/config/configparser"
 )
 
+const (
+	fileSchemePrefix = "file:"
+	envSchemePrefix  = "env:"
+	yamlSchemePrefix = "yaml:"
+)
+
 type fileProvider struct{}
 
-// NewFile returns a new ParserProvider that reads the configuration from a file configured
-// via the --config command line flag.
+// NewFile returns a new ParserProvider that reads the configuration from the locations configured
+// via the --config command line flag. The flag can be repeated, the configurations are deep-merged
+// in the order of the flags, see configparser.Parser.Merge for the merge semantics.
+//
+// A location is either a path to a file or an URI with one of the following schemes:
+//   - "file:" followed by the path to a YAML file, e.g.: "file:/etc/otelcol/config.yaml";
+//   - "env:" followed by the name of an environment variable holding YAML, e.g.: "env:OTELCOL_CONFIG";
+//   - "yaml:" followed by inline YAML, keys can use "::" to refer to nested keys, e.g.:
+//     "yaml:processors::batch::timeout: 2s".
 func NewFile() ParserProvider {
 	return &fileProvider{}
 }
 
 func (fl *fileProvider) Get() (*configparser.Parser, error) {
-	fileName := getConfigFlag()
-	if fileName == "" {
+	locations := getConfigFlag()
+	if len(locations) == 0 {
 		return nil, errors.New("config file not specified")
 	}
 
-	cp, err := configparser.NewParserFromFile(fileName)
-	if err != nil {
-		return nil, fmt.Errorf("error loading config file %q: %v", fileName, err)
+	cp := configparser.NewParser()
+	for _, location := range locations {
+		overlay, err := loadLocation(location)
+		if err != nil {
+			return nil, err
+		}
+		if err = cp.Merge(overlay); err != nil {
+			return nil, fmt.Errorf("error merging config %q: %w", location, err)
+		}
 	}
 
 	return cp, nil
 }
+
+// loadLocation loads the configuration from the given location.
+func loadLocation(location string) (*configparser.Parser, error) {
+	switch {
+	case strings.HasPrefix(location, envSchemePrefix):
+		envVar := location[len(envSchemePrefix):]
+		value, ok := os.LookupEnv(envVar)
+		if !ok {
+			return nil, fmt.Errorf("error loading config %q: environment variable %q is not defined", location, envVar)
+		}
+		cp, err := configparser.NewParserFromBuffer(strings.NewReader(value))
+		if err != nil {
+			return nil, fmt.Errorf("error loading config %q: %v", location, err)
+		}
+		return cp, nil
+
+	case strings.HasPrefix(location, yamlSchemePrefix):
+		cp, err := configparser.NewParserFromBuffer(strings.NewReader(location[len(yamlSchemePrefix):]))
+		if err != nil {
+			return nil, fmt.Errorf("error loading config %q: %v", location, err)
+		}
+		return cp, nil
+
+	default:
+		fileName := strings.TrimPrefix(location, fileSchemePrefix)
+		cp, err := configparser.NewParserFromFile(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("error loading config file %q: %v", fileName, err)
+		}
+		return cp, nil
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package parserprovider
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile(t *testing.T) {
+	require.NoError(t, os.Setenv("OTELCOL_TEST_CONFIG_OVERLAY", "exporters:\n  logging:\n    loglevel: debug\n"))
+	defer os.Unsetenv("OTELCOL_TEST_CONFIG_OVERLAY")
+
+	flags := new(flag.FlagSet)
+	Flags(flags)
+	require.NoError(t, flags.Parse([]string{
+		"--config=testdata/otelcol-config.yaml",
+		"--config=file:testdata/otelcol-config-overlay.yaml",
+		"--config=env:OTELCOL_TEST_CONFIG_OVERLAY",
+		"--config=yaml:processors::batch::send_batch_size: 512",
+	}))
+
+	cp, err := NewFile().Get()
+	require.NoError(t, err)
+
+	// Maps are merged.
+	assert.Equal(t, "locahost:55678", cp.Get("exporters::opencensus::endpoint"))
+	assert.Equal(t, "5s", cp.Get("processors::batch::timeout"))
+	assert.Equal(t, 512, cp.Get("processors::batch::send_batch_size"))
+	// Later configs have precedence.
+	assert.Equal(t, "debug", cp.Get("exporters::logging::loglevel"))
+	// Lists are replaced.
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"key": "environment", "value": "production", "action": "insert"},
+	}, cp.Get("processors::attributes::actions"))
+}
+
+func TestFile_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		errMsg string
+	}{
+		{
+			name:   "not_specified",
+			errMsg: "config file not specified",
+		},
+		{
+			name:   "file_not_found",
+			args:   []string{"--config=file:testdata/not_found.yaml"},
+			errMsg: "error loading config file \"testdata/not_found.yaml\"",
+		},
+		{
+			name:   "env_not_defined",
+			args:   []string{"--config=env:OTELCOL_TEST_NOT_DEFINED"},
+			errMsg: "environment variable \"OTELCOL_TEST_NOT_DEFINED\" is not defined",
+		},
+		{
+			name:   "invalid_yaml",
+			args:   []string{"--config=yaml:[invalid"},
+			errMsg: "error loading config \"yaml:[invalid\"",
+		},
+		{
+			name:   "conflict",
+			args:   []string{"--config=testdata/otelcol-config.yaml", "--config=testdata/otelcol-config-conflict.yaml"},
+			errMsg: "error merging config \"testdata/otelcol-config-conflict.yaml\": cannot merge key \"processors\": a map conflicts with a scalar value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := new(flag.FlagSet)
+			Flags(flags)
+			require.NoError(t, flags.Parse(tt.args))
+
+			_, err := NewFile().Get()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
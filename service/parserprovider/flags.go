@@ -25,7 +25,7 @@ const (
 )
 
 var (
-	configFlag *string
+	configFlag *stringArrayValue
 	setFlag    *stringArrayValue
 )
 
@@ -44,7 +44,12 @@ func (s *stringArrayValue) String() string {
 
 // Flags adds flags related to basic configuration's parser loader to the flags.
 func Flags(flags *flag.FlagSet) {
-	configFlag = flags.String(configFlagName, "", "Path to the config file")
+	configFlag = new(stringArrayValue)
+	flags.Var(configFlag, configFlagName,
+		"Locations of the config, the flag can be repeated and the configs are deep-merged in order, maps are merged"+
+			" and any other value is replaced by the later config. A location is a file path or an URI with one of the"+
+			" schemes \"file:\" (file path), \"env:\" (environment variable holding YAML) or \"yaml:\" (inline YAML)"+
+			" e.g. --config=base.yaml --config=env:OTELCOL_OVERLAY --config=\"yaml:processors::batch::timeout: 2s\"")
 	setFlag = new(stringArrayValue)
 	flags.Var(setFlag, setFlagName,
 		"Set arbitrary component config property. The component has to be defined in the config file and the flag"+
@@ -52,8 +57,8 @@ func Flags(flags *flag.FlagSet) {
 			" (first) array property can be set e.g. -set=processors.attributes.actions.key=some_key. Example --set=processors.batch.timeout=2s")
 }
 
-func getConfigFlag() string {
-	return *configFlag
+func getConfigFlag() []string {
+	return configFlag.values
 }
 
 func getSetFlag() []string {
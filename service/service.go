@@ -64,6 +64,8 @@ func newService(set *svcSettings) (*service, error) {
 	}
 
 	if err := srv.buildPipelines(); err != nil {
+		// Release the components already built, none of them was started.
+		_ = srv.Shutdown(context.Background())
 		return nil, fmt.Errorf("cannot build pipelines: %w", err)
 	}
 
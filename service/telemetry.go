@@ -15,47 +15,105 @@
 package service
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"strings"
+	"time"
 	"unicode"
 
 	"contrib.go.opencensus.io/exporter/prometheus"
 	"github.com/google/uuid"
+	"go.opencensus.io/metric/metricexport"
 	"go.opencensus.io/stats/view"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/jaegerexporter"
 	"go.opentelemetry.io/collector/internal/collector/telemetry"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/processor/batchprocessor"
 	"go.opentelemetry.io/collector/receiver/kafkareceiver"
+	"go.opentelemetry.io/collector/service/internal/fanoutconsumer"
 	telemetry2 "go.opentelemetry.io/collector/service/internal/telemetry"
 	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
 )
 
+// defaultMetricsInterval is the interval the metrics are pushed to the telemetry exporters at.
+const defaultMetricsInterval = 10 * time.Second
+
 // collectorTelemetry is collector's own telemetry.
 var collectorTelemetry collectorTelemetryExporter = &colTelemetry{}
 
 type collectorTelemetryExporter interface {
-	init(asyncErrorChannel chan<- error, ballastSizeBytes uint64, logger *zap.Logger) error
+	init(set telemetrySettings) error
 	shutdown() error
 }
 
+// telemetrySettings holds what the collector's own telemetry is initialized from.
+type telemetrySettings struct {
+	asyncErrorChannel chan<- error
+	ballastSizeBytes  uint64
+	logger            *zap.Logger
+	buildInfo         component.BuildInfo
+	config            *config.Config
+	factories         map[config.Type]component.ExporterFactory
+	// tracerProvider is the provider of the collector's own spans, the spans are pushed
+	// to the telemetry exporters if it is a *sdktrace.TracerProvider.
+	tracerProvider trace.TracerProvider
+	// host is the host the telemetry exporters are started with.
+	host component.Host
+}
+
 type colTelemetry struct {
-	views  []*view.View
-	server *http.Server
+	views        []*view.View
+	processViews *telemetry2.ProcessMetricsViews
+	promExporter *prometheus.Exporter
+	server       *http.Server
+
+	metricsReader  *metricexport.IntervalReader
+	tracerProvider *sdktrace.TracerProvider
+	spanProcessor  *telemetry2.SpanProcessor
+	exporters      []component.Exporter
 }
 
-func (tel *colTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes uint64, logger *zap.Logger) error {
+func (tel *colTelemetry) init(set telemetrySettings) (err error) {
+	defer func() {
+		// Do not leave the telemetry exporters running.
+		if err != nil {
+			_ = tel.shutdown()
+		}
+	}()
+
+	telCfg := set.config.Service.Telemetry
 	level := configtelemetry.GetMetricsLevelFlagValue()
 	metricsAddr := telemetry.GetMetricsAddr()
+	if telCfg.Metrics.Address != nil {
+		metricsAddr = *telCfg.Metrics.Address
+	}
 
-	if level == configtelemetry.LevelNone || metricsAddr == "" {
+	var instanceID string
+	if telemetry.GetAddInstanceID() {
+		instanceUUID, _ := uuid.NewRandom()
+		instanceID = instanceUUID.String()
+	}
+	resourceAttrs := telemetryResource(set.buildInfo, instanceID, telCfg.Resource)
+
+	if err = tel.initTraces(set, resourceAttrs); err != nil {
+		return err
+	}
+
+	if level == configtelemetry.LevelNone || (metricsAddr == "" && len(telCfg.Metrics.Exporters) == 0) {
 		return nil
 	}
 
-	processMetricsViews, err := telemetry2.NewProcessMetricsViews(ballastSizeBytes)
+	processMetricsViews, err := telemetry2.NewProcessMetricsViews(set.ballastSizeBytes)
 	if err != nil {
 		return err
 	}
@@ -74,16 +132,20 @@ func (tel *colTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes u
 	}
 
 	processMetricsViews.StartCollection()
+	tel.processViews = processMetricsViews
+
+	if err = tel.initMetricsExporters(set, resourceAttrs); err != nil {
+		return err
+	}
+
+	if metricsAddr == "" {
+		return nil
+	}
 
-	// Until we can use a generic metrics exporter, default to Prometheus.
 	opts := prometheus.Options{
 		Namespace: telemetry.GetMetricsPrefix(),
 	}
-
-	var instanceID string
-	if telemetry.GetAddInstanceID() {
-		instanceUUID, _ := uuid.NewRandom()
-		instanceID = instanceUUID.String()
+	if instanceID != "" {
 		opts.ConstLabels = map[string]string{
 			sanitizePrometheusKey(conventions.AttributeServiceInstanceID): instanceID,
 		}
@@ -95,8 +157,9 @@ func (tel *colTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes u
 	}
 
 	view.RegisterExporter(pe)
+	tel.promExporter = pe
 
-	logger.Info(
+	set.logger.Info(
 		"Serving Prometheus metrics",
 		zap.String("address", metricsAddr),
 		zap.Int8("level", int8(level)), // TODO: make it human friendly
@@ -106,29 +169,179 @@ func (tel *colTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes u
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", pe)
 
-	tel.server = &http.Server{
+	server := &http.Server{
 		Addr:    metricsAddr,
 		Handler: mux,
 	}
+	tel.server = server
 
 	go func() {
-		serveErr := tel.server.ListenAndServe()
+		serveErr := server.ListenAndServe()
 		if serveErr != nil && serveErr != http.ErrServerClosed {
-			asyncErrorChannel <- serveErr
+			set.asyncErrorChannel <- serveErr
 		}
 	}()
 
 	return nil
 }
 
+// initMetricsExporters pushes the metrics to the telemetry metrics exporters.
+func (tel *colTelemetry) initMetricsExporters(set telemetrySettings, resourceAttrs map[string]string) error {
+	metricsCfg := set.config.Service.Telemetry.Metrics
+	if len(metricsCfg.Exporters) == 0 {
+		return nil
+	}
+
+	var consumers []consumer.Metrics
+	for _, id := range metricsCfg.Exporters {
+		exp, err := tel.startExporter(set, id, config.MetricsDataType)
+		if err != nil {
+			return err
+		}
+		consumers = append(consumers, exp.(component.MetricsExporter))
+	}
+
+	reader, err := metricexport.NewIntervalReader(
+		metricexport.NewReader(),
+		telemetry2.NewMetricsExporter(resourceAttrs, fanoutconsumer.NewMetrics(consumers), set.logger))
+	if err != nil {
+		return err
+	}
+	reader.ReportingInterval = metricsCfg.Interval
+	if reader.ReportingInterval == 0 {
+		reader.ReportingInterval = defaultMetricsInterval
+	}
+	if err = reader.Start(); err != nil {
+		return err
+	}
+	tel.metricsReader = reader
+
+	set.logger.Info("Pushing the collector's own metrics", zap.Strings("exporters", idsToStrings(metricsCfg.Exporters)))
+	return nil
+}
+
+// initTraces pushes the spans recorded by the collector to the telemetry traces exporters.
+func (tel *colTelemetry) initTraces(set telemetrySettings, resourceAttrs map[string]string) error {
+	tracesCfg := set.config.Service.Telemetry.Traces
+	if len(tracesCfg.Exporters) == 0 {
+		return nil
+	}
+	tp, ok := set.tracerProvider.(*sdktrace.TracerProvider)
+	if !ok {
+		return fmt.Errorf("cannot push the collector's own spans recorded by a %T", set.tracerProvider)
+	}
+
+	var consumers []consumer.Traces
+	for _, id := range tracesCfg.Exporters {
+		exp, err := tel.startExporter(set, id, config.TracesDataType)
+		if err != nil {
+			return err
+		}
+		consumers = append(consumers, exp.(component.TracesExporter))
+	}
+
+	tel.spanProcessor = telemetry2.NewSpanProcessor(resourceAttrs, fanoutconsumer.NewTraces(consumers), set.logger)
+	tel.tracerProvider = tp
+	tp.RegisterSpanProcessor(tel.spanProcessor)
+
+	set.logger.Info("Pushing the collector's own spans", zap.Strings("exporters", idsToStrings(tracesCfg.Exporters)))
+	return nil
+}
+
+// startExporter creates and starts an exporter of the collector's own telemetry. The
+// exporter does not record spans, otherwise pushing spans would record more spans.
+func (tel *colTelemetry) startExporter(set telemetrySettings, id config.ComponentID, dataType config.DataType) (component.Exporter, error) {
+	factory, ok := set.factories[id.Type()]
+	if !ok || factory == nil {
+		return nil, fmt.Errorf("exporter factory not found for type: %s", id.Type())
+	}
+	expSet := component.ExporterCreateSettings{
+		Logger:         set.logger.With(zap.String("kind", "telemetry_exporter"), zap.String("name", id.String())),
+		TracerProvider: trace.NewNoopTracerProvider(),
+		BuildInfo:      set.buildInfo,
+	}
+
+	var exp component.Exporter
+	var err error
+	switch dataType {
+	case config.MetricsDataType:
+		exp, err = factory.CreateMetricsExporter(context.Background(), expSet, set.config.Exporters[id])
+	case config.TracesDataType:
+		exp, err = factory.CreateTracesExporter(context.Background(), expSet, set.config.Exporters[id])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error creating telemetry exporter %q for data type %q: %w", id, dataType, err)
+	}
+	if err = exp.Start(context.Background(), set.host); err != nil {
+		return nil, fmt.Errorf("error starting telemetry exporter %q: %w", id, err)
+	}
+	tel.exporters = append(tel.exporters, exp)
+	return exp, nil
+}
+
 func (tel *colTelemetry) shutdown() error {
+	var errs []error
+
+	// Push the last metrics and spans before shutting down the exporters.
+	if tel.metricsReader != nil {
+		tel.metricsReader.Stop()
+		tel.metricsReader.Flush()
+		tel.metricsReader = nil
+	}
+	if tel.spanProcessor != nil {
+		tel.tracerProvider.UnregisterSpanProcessor(tel.spanProcessor)
+		tel.spanProcessor = nil
+	}
+	for _, exp := range tel.exporters {
+		if err := exp.Shutdown(context.Background()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	tel.exporters = nil
+
 	view.Unregister(tel.views...)
+	tel.views = nil
+	if tel.processViews != nil {
+		tel.processViews.StopCollection()
+		tel.processViews = nil
+	}
+	if tel.promExporter != nil {
+		view.UnregisterExporter(tel.promExporter)
+		tel.promExporter = nil
+	}
 
 	if tel.server != nil {
-		return tel.server.Close()
+		if err := tel.server.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		tel.server = nil
 	}
 
-	return nil
+	return consumererror.Combine(errs)
+}
+
+// telemetryResource returns the attributes of the resource of the collector's own
+// metrics and traces, the configured attributes overriding the service ones.
+func telemetryResource(buildInfo component.BuildInfo, instanceID string, attrs map[string]string) map[string]string {
+	resourceAttrs := map[string]string{
+		conventions.AttributeServiceName:    buildInfo.Command,
+		conventions.AttributeServiceVersion: buildInfo.Version,
+	}
+	if instanceID != "" {
+		resourceAttrs[conventions.AttributeServiceInstanceID] = instanceID
+	}
+	for k, v := range attrs {
+		resourceAttrs[k] = v
+	}
+	return resourceAttrs
+}
+
+func idsToStrings(ids []config.ComponentID) []string {
+	ret := make([]string, 0, len(ids))
+	for _, id := range ids {
+		ret = append(ret, id.String())
+	}
+	return ret
 }
 
 func sanitizePrometheusKey(str string) string {
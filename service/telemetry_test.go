@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/internal/collector/telemetry"
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/translator/conventions/v1.5.0"
+)
+
+// newSinkExporterFactory returns the factory of an exporter pushing to the sinks.
+func newSinkExporterFactory(metricsSink *consumertest.MetricsSink, tracesSink *consumertest.TracesSink) component.ExporterFactory {
+	return exporterhelper.NewFactory(
+		"sink",
+		func() config.Exporter {
+			cfg := config.NewExporterSettings(config.NewID("sink"))
+			return &cfg
+		},
+		exporterhelper.WithMetrics(func(_ context.Context, set component.ExporterCreateSettings, cfg config.Exporter) (component.MetricsExporter, error) {
+			return exporterhelper.NewMetricsExporter(cfg, set, metricsSink.ConsumeMetrics)
+		}),
+		exporterhelper.WithTraces(func(_ context.Context, set component.ExporterCreateSettings, cfg config.Exporter) (component.TracesExporter, error) {
+			return exporterhelper.NewTracesExporter(cfg, set, tracesSink.ConsumeTraces)
+		}))
+}
+
+func TestTelemetryPushesToExporters(t *testing.T) {
+	telemetry.Flags(new(flag.FlagSet))
+
+	metricsSink := new(consumertest.MetricsSink)
+	tracesSink := new(consumertest.TracesSink)
+	factory := newSinkExporterFactory(metricsSink, tracesSink)
+
+	noAddress := ""
+	id := config.NewID("sink")
+	cfg := &config.Config{
+		Exporters: config.Exporters{id: factory.CreateDefaultConfig()},
+		Service: config.Service{
+			Telemetry: config.ServiceTelemetry{
+				Metrics: config.ServiceTelemetryMetrics{
+					Address:   &noAddress,
+					Exporters: []config.ComponentID{id},
+					Interval:  time.Second,
+				},
+				Traces: config.ServiceTelemetryTraces{
+					Exporters: []config.ComponentID{id},
+				},
+				Resource: map[string]string{"deployment.environment": "test"},
+			},
+		},
+	}
+	tp := sdktrace.NewTracerProvider()
+
+	tel := &colTelemetry{}
+	require.NoError(t, tel.init(telemetrySettings{
+		asyncErrorChannel: make(chan error),
+		logger:            zap.NewNop(),
+		buildInfo:         component.BuildInfo{Command: "otelcol", Version: "v1.2.3"},
+		config:            cfg,
+		factories:         map[config.Type]component.ExporterFactory{factory.Type(): factory},
+		tracerProvider:    tp,
+		host:              componenttest.NewNopHost(),
+	}))
+
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.End()
+
+	assert.Eventually(t, func() bool {
+		return len(metricsSink.AllMetrics()) > 0
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, tel.shutdown())
+
+	// The spans are pushed on shutdown at the latest.
+	require.Equal(t, 1, tracesSink.SpanCount())
+
+	for _, resource := range []pdata.Resource{
+		metricsSink.AllMetrics()[0].ResourceMetrics().At(0).Resource(),
+		tracesSink.AllTraces()[0].ResourceSpans().At(0).Resource(),
+	} {
+		attrs := resource.Attributes()
+		for k, v := range map[string]string{
+			conventions.AttributeServiceName:    "otelcol",
+			conventions.AttributeServiceVersion: "v1.2.3",
+			"deployment.environment":            "test",
+		} {
+			got, ok := attrs.Get(k)
+			require.True(t, ok, k)
+			assert.Equal(t, v, got.StringVal())
+		}
+		_, ok := attrs.Get(conventions.AttributeServiceInstanceID)
+		assert.True(t, ok)
+	}
+}
+
+func TestTelemetryInitErrors(t *testing.T) {
+	telemetry.Flags(new(flag.FlagSet))
+
+	factory := newSinkExporterFactory(new(consumertest.MetricsSink), new(consumertest.TracesSink))
+	id := config.NewID("sink")
+	noAddress := ""
+	cfg := &config.Config{
+		Exporters: config.Exporters{id: factory.CreateDefaultConfig()},
+		Service: config.Service{
+			Telemetry: config.ServiceTelemetry{
+				Metrics: config.ServiceTelemetryMetrics{Address: &noAddress},
+				Traces:  config.ServiceTelemetryTraces{Exporters: []config.ComponentID{id}},
+			},
+		},
+	}
+	set := telemetrySettings{
+		logger:         zap.NewNop(),
+		config:         cfg,
+		factories:      map[config.Type]component.ExporterFactory{},
+		tracerProvider: sdktrace.NewTracerProvider(),
+		host:           componenttest.NewNopHost(),
+	}
+
+	tel := &colTelemetry{}
+	assert.EqualError(t, tel.init(set), "exporter factory not found for type: sink")
+
+	set.factories = map[config.Type]component.ExporterFactory{factory.Type(): factory}
+	set.tracerProvider = trace.NewNoopTracerProvider()
+	assert.Error(t, tel.init(set))
+	assert.Empty(t, tel.exporters)
+}
@@ -61,6 +61,7 @@ func testWithTracingGoldenDataset(
 	dataProvider := testbed.NewGoldenDataProvider(
 		"../../../internal/goldendataset/testdata/generated_pict_pairs_traces.txt",
 		"../../../internal/goldendataset/testdata/generated_pict_pairs_spans.txt",
+		"",
 		"")
 	factories, err := defaultcomponents.Components()
 	require.NoError(t, err, "default components resulted in: %v", err)
@@ -154,12 +154,28 @@ func ConstructMetricsSender(t *testing.T, receiver string) testbed.MetricDataSen
 	return sender
 }
 
+// ConstructLogsSender creates a testbed logs sender from the passed-in logs sender identifier.
+func ConstructLogsSender(t *testing.T, receiver string) testbed.LogDataSender {
+	var sender testbed.LogDataSender
+	switch receiver {
+	case "otlp":
+		sender = testbed.NewOTLPLogsDataSender(testbed.DefaultHost, testbed.GetAvailablePort(t))
+	case "otlphttp":
+		sender = testbed.NewOTLPHTTPLogsDataSender(testbed.DefaultHost, testbed.GetAvailablePort(t))
+	default:
+		t.Errorf("unknown receiver type: %s", receiver)
+	}
+	return sender
+}
+
 // ConstructReceiver creates a testbed receiver from the passed-in recevier identifier.
 func ConstructReceiver(t *testing.T, exporter string) testbed.DataReceiver {
 	var receiver testbed.DataReceiver
 	switch exporter {
 	case "otlp":
 		receiver = testbed.NewOTLPDataReceiver(testbed.GetAvailablePort(t))
+	case "otlphttp":
+		receiver = testbed.NewOTLPHTTPDataReceiver(testbed.GetAvailablePort(t))
 	case "opencensus":
 		receiver = testbed.NewOCDataReceiver(testbed.GetAvailablePort(t))
 	case "jaeger":
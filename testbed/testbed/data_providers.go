@@ -190,15 +190,21 @@ type goldenDataProvider struct {
 	metricPairsFile  string
 	metricsGenerated []pdata.Metrics
 	metricsIndex     int
+
+	logPairsFile  string
+	logsGenerated []pdata.Logs
+	logsIndex     int
 }
 
 // NewGoldenDataProvider creates a new instance of goldenDataProvider which generates test data based
-// on the pairwise combinations specified in the tracePairsFile and spanPairsFile input variables.
-func NewGoldenDataProvider(tracePairsFile string, spanPairsFile string, metricPairsFile string) DataProvider {
+// on the pairwise combinations specified in the tracePairsFile, spanPairsFile, metricPairsFile and
+// logPairsFile input variables.
+func NewGoldenDataProvider(tracePairsFile string, spanPairsFile string, metricPairsFile string, logPairsFile string) DataProvider {
 	return &goldenDataProvider{
 		tracePairsFile:  tracePairsFile,
 		spanPairsFile:   spanPairsFile,
 		metricPairsFile: metricPairsFile,
+		logPairsFile:    logPairsFile,
 	}
 }
 
@@ -242,7 +248,21 @@ func (dp *goldenDataProvider) GenerateMetrics() (pdata.Metrics, bool) {
 }
 
 func (dp *goldenDataProvider) GenerateLogs() (pdata.Logs, bool) {
-	return pdata.NewLogs(), true
+	if dp.logsGenerated == nil {
+		var err error
+		dp.logsGenerated, err = goldendataset.GenerateLogs(dp.logPairsFile)
+		if err != nil {
+			log.Printf("cannot generate logs: %s", err)
+			dp.logsGenerated = nil
+		}
+	}
+	if dp.logsIndex >= len(dp.logsGenerated) {
+		return pdata.NewLogs(), true
+	}
+	ld := dp.logsGenerated[dp.logsIndex]
+	dp.logsIndex++
+	dp.dataItemsGenerated.Add(uint64(ld.LogRecordCount()))
+	return ld, false
 }
 
 // FileDataProvider in an implementation of the DataProvider for use in performance tests.
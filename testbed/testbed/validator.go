@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/model/pdata/pdatatest"
 )
 
 // TestCaseValidator defines the interface for validating and reporting test results.
@@ -99,7 +100,10 @@ func (v *CorrectnessTestValidator) Validate(tc *TestCase) {
 	if len(tc.MockBackend.ReceivedTraces) > 0 {
 		v.assertSentRecdTracingDataEqual(tc.MockBackend.ReceivedTraces)
 	}
-	assert.EqualValues(tc.t, 0, len(v.assertionFailures), "There are span data mismatches.")
+	if len(tc.MockBackend.ReceivedLogs) > 0 {
+		v.assertSentRecdLogsDataEqual(tc.MockBackend.ReceivedLogs)
+	}
+	assert.EqualValues(tc.t, 0, len(v.assertionFailures), "There are span or log data mismatches.")
 }
 
 func (v *CorrectnessTestValidator) RecordResults(tc *TestCase) {
@@ -146,6 +150,39 @@ func (v *CorrectnessTestValidator) assertSentRecdTracingDataEqual(tracesList []p
 	}
 }
 
+func (v *CorrectnessTestValidator) assertSentRecdLogsDataEqual(logsList []pdata.Logs) {
+	logsMap := make(map[string]pdata.Logs)
+	// TODO: Remove this hack, and add a way to retrieve all sent data.
+	if val, ok := v.dataProvider.(*goldenDataProvider); ok {
+		populateLogsMap(logsMap, val.logsGenerated)
+	}
+
+	recdLogsMap := make(map[string]pdata.Logs)
+	populateLogsMap(recdLogsMap, logsList)
+	for name, recdLogs := range recdLogsMap {
+		sentLogs, ok := logsMap[name]
+		if !ok {
+			v.assertionFailures = append(v.assertionFailures, &TraceAssertionFailure{
+				typeName:      "LogRecord",
+				dataComboName: name,
+				fieldPath:     "Name",
+				expectedValue: nil,
+				actualValue:   name,
+			})
+			continue
+		}
+		for _, diff := range pdatatest.DiffLogs(sentLogs, recdLogs, pdatatest.IgnoreAttributeOrder()) {
+			v.assertionFailures = append(v.assertionFailures, &TraceAssertionFailure{
+				typeName:      "LogRecord",
+				dataComboName: name,
+				fieldPath:     diff.Path,
+				expectedValue: diff.Expected,
+				actualValue:   diff.Actual,
+			})
+		}
+	}
+}
+
 func (v *CorrectnessTestValidator) diffSpan(sentSpan pdata.Span, recdSpan pdata.Span) {
 	v.diffSpanTraceID(sentSpan, recdSpan)
 	v.diffSpanSpanID(sentSpan, recdSpan)
@@ -529,6 +566,29 @@ func populateSpansMap(spansMap map[string]pdata.Span, tds []pdata.Traces) {
 	}
 }
 
+// populateLogsMap splits the given logs into one pdata.Logs per log record, holding the record
+// together with its resource and instrumentation library, keyed by the log record name.
+func populateLogsMap(logsMap map[string]pdata.Logs, lds []pdata.Logs) {
+	for _, ld := range lds {
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			ills := rls.At(i).InstrumentationLibraryLogs()
+			for j := 0; j < ills.Len(); j++ {
+				logs := ills.At(j).Logs()
+				for k := 0; k < logs.Len(); k++ {
+					single := pdata.NewLogs()
+					rl := single.ResourceLogs().AppendEmpty()
+					rls.At(i).Resource().CopyTo(rl.Resource())
+					ill := rl.InstrumentationLibraryLogs().AppendEmpty()
+					ills.At(j).InstrumentationLibrary().CopyTo(ill.InstrumentationLibrary())
+					logs.At(k).CopyTo(ill.Logs().AppendEmpty())
+					logsMap[logs.At(k).Name()] = single
+				}
+			}
+		}
+	}
+}
+
 func traceIDAndSpanIDToString(traceID pdata.TraceID, spanID pdata.SpanID) string {
 	return fmt.Sprintf("%s-%s", traceID.HexString(), spanID.HexString())
 }